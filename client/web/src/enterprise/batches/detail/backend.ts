@@ -40,6 +40,8 @@ import {
     ReenqueueChangesetsVariables,
     MergeChangesetsResult,
     MergeChangesetsVariables,
+    MergeChangesetsWhenReadyResult,
+    MergeChangesetsWhenReadyVariables,
     CloseChangesetsResult,
     CloseChangesetsVariables,
     PublishChangesetsResult,
@@ -770,6 +772,24 @@ export async function mergeChangesets(
     dataOrThrowErrors(result)
 }
 
+export async function mergeChangesetsWhenReady(
+    batchChange: Scalars['ID'],
+    changesets: Scalars['ID'][],
+    squash: boolean
+): Promise<void> {
+    const result = await requestGraphQL<MergeChangesetsWhenReadyResult, MergeChangesetsWhenReadyVariables>(
+        gql`
+            mutation MergeChangesetsWhenReady($batchChange: ID!, $changesets: [ID!]!, $squash: Boolean!) {
+                mergeChangesetsWhenReady(batchChange: $batchChange, changesets: $changesets, squash: $squash) {
+                    id
+                }
+            }
+        `,
+        { batchChange, changesets, squash }
+    ).toPromise()
+    dataOrThrowErrors(result)
+}
+
 export async function closeChangesets(batchChange: Scalars['ID'], changesets: Scalars['ID'][]): Promise<void> {
     const result = await requestGraphQL<CloseChangesetsResult, CloseChangesetsVariables>(
         gql`
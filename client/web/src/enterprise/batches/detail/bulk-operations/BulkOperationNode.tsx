@@ -35,6 +35,11 @@ const OPERATION_TITLES: Record<BulkOperationType, JSX.Element> = {
             <Icon aria-hidden={true} className="text-muted" svgPath={mdiSourceBranch} /> Merge changesets
         </>
     ),
+    MERGE_WHEN_READY: (
+        <>
+            <Icon aria-hidden={true} className="text-muted" svgPath={mdiSourceBranch} /> Merge changesets when ready
+        </>
+    ),
     CLOSE: (
         <>
             <Icon aria-hidden={true} className="text-danger" svgPath={mdiSourceBranch} /> Close changesets
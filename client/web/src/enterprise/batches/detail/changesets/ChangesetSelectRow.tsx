@@ -110,6 +110,26 @@ const AVAILABLE_ACTIONS: Record<BulkOperationType, ChangesetListAction> = {
             )
         },
     },
+    [BulkOperationType.MERGE_WHEN_READY]: {
+        type: 'merge_when_ready',
+        experimental: true,
+        buttonLabel: 'Merge changesets when ready',
+        dropdownTitle: 'Merge changesets when ready',
+        dropdownDescription:
+            'Merge all selected changesets once their checks have passed and they have been approved. Merges are rate limited per code host and paused while the base branch checks are failing.',
+        onTrigger: (batchChangeID, changesetIDs, onDone, onCancel) => {
+            eventLogger.log('batch_change_details:bulk_action_merge_when_ready:clicked')
+            return (
+                <MergeChangesetsModal
+                    batchChangeID={batchChangeID}
+                    changesetIDs={changesetIDs}
+                    afterCreate={onDone}
+                    onCancel={onCancel}
+                    whenReady={true}
+                />
+            )
+        },
+    },
     [BulkOperationType.CLOSE]: {
         type: 'close',
         buttonLabel: 'Close changesets',
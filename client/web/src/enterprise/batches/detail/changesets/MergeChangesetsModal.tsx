@@ -7,16 +7,26 @@ import { Button, Checkbox, Modal, H3, Text } from '@sourcegraph/wildcard'
 
 import { LoaderButton } from '../../../../components/LoaderButton'
 import { Scalars } from '../../../../graphql-operations'
-import { mergeChangesets as _mergeChangesets } from '../backend'
+import {
+    mergeChangesets as _mergeChangesets,
+    mergeChangesetsWhenReady as _mergeChangesetsWhenReady,
+} from '../backend'
 
 export interface MergeChangesetsModalProps {
     onCancel: () => void
     afterCreate: () => void
     batchChangeID: Scalars['ID']
     changesetIDs: Scalars['ID'][]
+    /**
+     * Whether to merge the changesets once their checks have passed and they
+     * have been approved, instead of right away.
+     */
+    whenReady?: boolean
 
     /** For testing only. */
     mergeChangesets?: typeof _mergeChangesets
+    /** For testing only. */
+    mergeChangesetsWhenReady?: typeof _mergeChangesetsWhenReady
 }
 
 export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildren<MergeChangesetsModalProps>> = ({
@@ -24,7 +34,9 @@ export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildr
     afterCreate,
     batchChangeID,
     changesetIDs,
+    whenReady = false,
     mergeChangesets = _mergeChangesets,
+    mergeChangesetsWhenReady = _mergeChangesetsWhenReady,
 }) => {
     const [isLoading, setIsLoading] = useState<boolean | Error>(false)
     const [squash, setSquash] = useState<boolean>(false)
@@ -32,12 +44,16 @@ export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildr
     const onSubmit = useCallback<React.FormEventHandler>(async () => {
         setIsLoading(true)
         try {
-            await mergeChangesets(batchChangeID, changesetIDs, squash)
+            if (whenReady) {
+                await mergeChangesetsWhenReady(batchChangeID, changesetIDs, squash)
+            } else {
+                await mergeChangesets(batchChangeID, changesetIDs, squash)
+            }
             afterCreate()
         } catch (error) {
             setIsLoading(asError(error))
         }
-    }, [changesetIDs, mergeChangesets, batchChangeID, squash, afterCreate])
+    }, [changesetIDs, whenReady, mergeChangesets, mergeChangesetsWhenReady, batchChangeID, squash, afterCreate])
 
     const onToggleSquash = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
         setSquash(event.target.checked)
@@ -45,8 +61,19 @@ export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildr
 
     return (
         <Modal onDismiss={onCancel} aria-labelledby={MODAL_LABEL_ID}>
-            <H3 id={MODAL_LABEL_ID}>Merge changesets</H3>
-            <Text className="mb-4">Are you sure you want to attempt to merge all the selected changesets?</Text>
+            <H3 id={MODAL_LABEL_ID}>{whenReady ? 'Merge changesets when ready' : 'Merge changesets'}</H3>
+            <Text className="mb-4">
+                {whenReady
+                    ? 'Are you sure you want to merge all the selected changesets once their checks have passed and they have been approved?'
+                    : 'Are you sure you want to attempt to merge all the selected changesets?'}
+            </Text>
+            {whenReady && (
+                <Text className="mb-4">
+                    Merges are rate limited per code host. On GitHub, they are also paused while the checks on the base
+                    branch of a merged changeset are failing; other code hosts don't report the state of a branch's
+                    checks.
+                </Text>
+            )}
             <Form>
                 <div className="form-group">
                     <Checkbox
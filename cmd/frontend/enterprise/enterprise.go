@@ -23,6 +23,9 @@ type Services struct {
 	BatchesChangesFileGetHandler    http.Handler
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
+	InsightsExportHandler           http.Handler
+	InsightsMetricsHandler          http.Handler
+	InsightsImportHandler           http.Handler
 	NewCodeIntelUploadHandler       NewCodeIntelUploadHandler
 	NewExecutorProxyHandler         NewExecutorProxyHandler
 	NewGitHubAppSetupHandler        NewGitHubAppSetupHandler
@@ -66,6 +69,9 @@ func DefaultServices() Services {
 		BatchesChangesFileGetHandler:    makeNotFoundHandler("batches file get handler"),
 		BatchesChangesFileExistsHandler: makeNotFoundHandler("batches file exists handler"),
 		BatchesChangesFileUploadHandler: makeNotFoundHandler("batches file upload handler"),
+		InsightsExportHandler:           makeNotFoundHandler("insights export handler"),
+		InsightsMetricsHandler:          makeNotFoundHandler("insights metrics handler"),
+		InsightsImportHandler:           makeNotFoundHandler("insights import handler"),
 		NewCodeIntelUploadHandler:       func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		NewExecutorProxyHandler:         func() http.Handler { return makeNotFoundHandler("executor proxy") },
 		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
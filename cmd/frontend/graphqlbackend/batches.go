@@ -220,6 +220,11 @@ type MergeChangesetsArgs struct {
 	Squash bool
 }
 
+type MergeChangesetsWhenReadyArgs struct {
+	BulkOperationBaseArgs
+	Squash bool
+}
+
 type CloseChangesetsArgs struct {
 	BulkOperationBaseArgs
 }
@@ -229,6 +234,55 @@ type PublishChangesetsArgs struct {
 	Draft bool
 }
 
+type CreateBatchChangeTemplateArgs struct {
+	Namespace   graphql.ID
+	Name        string
+	Description string
+	Template    string
+	InputSchema string
+}
+
+type UpdateBatchChangeTemplateArgs struct {
+	ID          graphql.ID
+	Name        *string
+	Description *string
+	Template    *string
+	InputSchema *string
+}
+
+type DeleteBatchChangeTemplateArgs struct {
+	Template graphql.ID
+}
+
+type CreateBatchSpecFromTemplateArgs struct {
+	Template    graphql.ID
+	Inputs      *JSONValue
+	Namespace   graphql.ID
+	BatchChange *graphql.ID
+}
+
+type SetBatchChangeSLAArgs struct {
+	BatchChange     graphql.ID
+	TargetDate      DateTime
+	NotifyEmail     bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+type DeleteBatchChangeSLAArgs struct {
+	BatchChange graphql.ID
+}
+
+type ListBatchChangeSnapshotsArgs struct {
+	From *DateTime
+}
+
+type ListBatchChangeTemplatesArgs struct {
+	Namespace *graphql.ID
+	First     int32
+	After     *string
+}
+
 type ResolveWorkspacesForBatchSpecArgs struct {
 	BatchSpec string
 }
@@ -277,12 +331,21 @@ type BatchChangesResolver interface {
 	CreateChangesetComments(ctx context.Context, args *CreateChangesetCommentsArgs) (BulkOperationResolver, error)
 	ReenqueueChangesets(ctx context.Context, args *ReenqueueChangesetsArgs) (BulkOperationResolver, error)
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
+	MergeChangesetsWhenReady(ctx context.Context, args *MergeChangesetsWhenReadyArgs) (BulkOperationResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
 
+	CreateBatchChangeTemplate(ctx context.Context, args *CreateBatchChangeTemplateArgs) (BatchChangeTemplateResolver, error)
+	UpdateBatchChangeTemplate(ctx context.Context, args *UpdateBatchChangeTemplateArgs) (BatchChangeTemplateResolver, error)
+	DeleteBatchChangeTemplate(ctx context.Context, args *DeleteBatchChangeTemplateArgs) (*EmptyResponse, error)
+	CreateBatchSpecFromTemplate(ctx context.Context, args *CreateBatchSpecFromTemplateArgs) (BatchSpecResolver, error)
+	SetBatchChangeSLA(ctx context.Context, args *SetBatchChangeSLAArgs) (BatchChangeSLAResolver, error)
+	DeleteBatchChangeSLA(ctx context.Context, args *DeleteBatchChangeSLAArgs) (*EmptyResponse, error)
+
 	// Queries
 	BatchChange(ctx context.Context, args *BatchChangeArgs) (BatchChangeResolver, error)
 	BatchChanges(cx context.Context, args *ListBatchChangesArgs) (BatchChangesConnectionResolver, error)
+	BatchChangeTemplates(ctx context.Context, args *ListBatchChangeTemplatesArgs) (BatchChangeTemplateConnectionResolver, error)
 
 	BatchChangesCodeHosts(ctx context.Context, args *ListBatchChangesCodeHostsArgs) (BatchChangesCodeHostConnectionResolver, error)
 	RepoChangesetsStats(ctx context.Context, repo *graphql.ID) (RepoChangesetsStatsResolver, error)
@@ -300,6 +363,26 @@ type BatchChangesResolver interface {
 	NodeResolvers() map[string]NodeByIDFunc
 }
 
+type BatchChangeTemplateResolver interface {
+	ID() graphql.ID
+	Name() string
+	Description() string
+	Namespace(ctx context.Context) (*NamespaceResolver, error)
+	Creator(ctx context.Context) (*UserResolver, error)
+	Template() string
+	InputSchema() string
+	InputNames() []string
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+	ViewerCanAdminister(ctx context.Context) (bool, error)
+}
+
+type BatchChangeTemplateConnectionResolver interface {
+	Nodes(ctx context.Context) ([]BatchChangeTemplateResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
 type BulkOperationConnectionResolver interface {
 	TotalCount(ctx context.Context) (int32, error)
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
@@ -653,6 +736,41 @@ type BatchChangeResolver interface {
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	Snapshots(ctx context.Context, args *ListBatchChangeSnapshotsArgs) ([]BatchChangeSnapshotResolver, error)
+	SLA(ctx context.Context) (BatchChangeSLAResolver, error)
+	Rollout(ctx context.Context) (BatchChangeRolloutResolver, error)
+}
+
+type BatchChangeRolloutResolver interface {
+	Stage() int32
+	StageCount() int32
+	ReleasedCount() int32
+	SucceededCount() int32
+	FailedCount() int32
+	Halted() bool
+}
+
+type BatchChangeSnapshotResolver interface {
+	Date() DateTime
+	Total() int32
+	Open() int32
+	Draft() int32
+	Merged() int32
+	Closed() int32
+	ReviewLatencySeconds() *int32
+}
+
+type BatchChangeSLAResolver interface {
+	TargetDate() DateTime
+	ExpectedProgress() float64
+	BehindSchedule(ctx context.Context) (bool, error)
+	NotifyEmail() bool
+	SlackWebhookURL(ctx context.Context) (*string, error)
+	WebhookURL(ctx context.Context) (*string, error)
+	AlertedAt() *DateTime
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
 }
 
 type BatchChangesConnectionResolver interface {
@@ -772,6 +890,7 @@ type ExternalChangesetResolver interface {
 	ReviewState(context.Context) *string
 	// CheckState returns a value of type *btypes.ChangesetCheckState.
 	CheckState() *string
+	Conflicting() bool
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
@@ -48,6 +48,7 @@ type AutoindexingServiceResolver interface {
 
 type UploadsServiceResolver interface {
 	CommitGraph(ctx context.Context, id graphql.ID) (uploadsgraphql.CodeIntelligenceCommitGraphResolver, error)
+	StorageQuotaEvictionPreview(ctx context.Context, id graphql.ID) (*[]uploadsgraphql.LSIFUploadStorageQuotaEvictionResolver, error)
 	LSIFUploadByID(ctx context.Context, id graphql.ID) (sharedresolvers.LSIFUploadResolver, error)
 	LSIFUploads(ctx context.Context, args *uploadsgraphql.LSIFUploadsQueryArgs) (sharedresolvers.LSIFUploadConnectionResolver, error)
 	LSIFUploadsByRepo(ctx context.Context, args *uploadsgraphql.LSIFRepositoryUploadsQueryArgs) (sharedresolvers.LSIFUploadConnectionResolver, error)
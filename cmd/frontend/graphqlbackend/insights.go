@@ -36,6 +36,9 @@ type InsightsResolver interface {
 
 	DeleteInsightView(ctx context.Context, args *DeleteInsightViewArgs) (*EmptyResponse, error)
 
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
+
 	// Admin Management
 	UpdateInsightSeries(ctx context.Context, args *UpdateInsightSeriesArgs) (InsightSeriesMetadataPayloadResolver, error)
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
@@ -198,6 +201,7 @@ type InsightViewResolver interface {
 	AppliedSeriesDisplayOptions(ctx context.Context) (InsightViewSeriesDisplayOptionsResolver, error)
 	Dashboards(ctx context.Context, args *InsightsDashboardsArgs) InsightsDashboardConnectionResolver
 	SeriesCount(ctx context.Context) (*int32, error)
+	Alerts(ctx context.Context) ([]InsightSeriesAlertResolver, error)
 }
 
 type InsightDataSeriesDefinition interface {
@@ -228,6 +232,7 @@ type SearchInsightDataSeriesDefinitionResolver interface {
 	GeneratedFromCaptureGroups() (bool, error)
 	IsCalculated() (bool, error)
 	GroupBy() (*string, error)
+	IsDerived() (bool, error)
 }
 
 type InsightPresentation interface {
@@ -404,6 +409,7 @@ type LineChartSearchInsightDataSeriesInput struct {
 	Options                    LineChartDataSeriesOptionsInput
 	GeneratedFromCaptureGroups *bool
 	GroupBy                    *string
+	Derived                    *bool
 }
 
 type LineChartDataSeriesOptionsInput struct {
@@ -445,6 +451,37 @@ type DeleteInsightViewArgs struct {
 	Id graphql.ID
 }
 
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	InsightViewId   graphql.ID
+	SeriesId        string
+	Condition       string
+	Threshold       float64
+	NotifyEmail     *bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Condition() string
+	Threshold() float64
+	NotifyEmail() bool
+	SlackWebhookURL() *string
+	WebhookURL() *string
+	Firing() bool
+	LastAlertedAt() *DateTime
+	CreatedAt() DateTime
+}
+
 type SearchInsightLivePreviewSeriesResolver interface {
 	Points(ctx context.Context) ([]InsightsDataPointResolver, error)
 	Label(ctx context.Context) (string, error)
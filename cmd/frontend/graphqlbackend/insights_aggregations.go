@@ -60,4 +60,5 @@ type AggregationsArgs struct {
 	Mode            *string `json:"mode"` //enum
 	Limit           int32   `json:"limit"`
 	ExtendedTimeout bool    `json:"extendedTimeout"`
+	RepoMetadataKey *string `json:"repoMetadataKey"`
 }
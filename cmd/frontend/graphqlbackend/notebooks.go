@@ -67,6 +67,8 @@ type NotebookBlockResolver interface {
 	ToFileBlock() (FileBlockResolver, bool)
 	ToSymbolBlock() (SymbolBlockResolver, bool)
 	ToComputeBlock() (ComputeBlockResolver, bool)
+	ToInsightBlock() (InsightBlockResolver, bool)
+	ToDiffPreviewBlock() (DiffPreviewBlockResolver, bool)
 }
 
 type MarkdownBlockResolver interface {
@@ -111,6 +113,35 @@ type ComputeBlockResolver interface {
 	ComputeInput() string
 }
 
+type InsightBlockResolver interface {
+	ID() string
+	InsightInput() InsightBlockInputResolver
+}
+
+type InsightBlockInputResolver interface {
+	SeriesID() *string
+	Query() *string
+	TimeRange() InsightBlockTimeRangeResolver
+}
+
+type InsightBlockTimeRangeResolver interface {
+	Unit() string
+	Value() int32
+}
+
+type DiffPreviewBlockResolver interface {
+	ID() string
+	DiffPreviewInput() DiffPreviewBlockInputResolver
+}
+
+type DiffPreviewBlockInputResolver interface {
+	Query() string
+	MatchPattern() string
+	RewritePattern() string
+	PatternType() string
+	ComputeQuery() string
+}
+
 type FileBlockLineRangeResolver interface {
 	StartLine() int32
 	EndLine() int32
@@ -119,11 +150,13 @@ type FileBlockLineRangeResolver interface {
 type NotebookBlockType string
 
 const (
-	NotebookMarkdownBlockType NotebookBlockType = "MARKDOWN"
-	NotebookQueryBlockType    NotebookBlockType = "QUERY"
-	NotebookFileBlockType     NotebookBlockType = "FILE"
-	NotebookSymbolBlockType   NotebookBlockType = "SYMBOL"
-	NotebookComputeBlockType  NotebookBlockType = "COMPUTE"
+	NotebookMarkdownBlockType    NotebookBlockType = "MARKDOWN"
+	NotebookQueryBlockType       NotebookBlockType = "QUERY"
+	NotebookFileBlockType        NotebookBlockType = "FILE"
+	NotebookSymbolBlockType      NotebookBlockType = "SYMBOL"
+	NotebookComputeBlockType     NotebookBlockType = "COMPUTE"
+	NotebookInsightBlockType     NotebookBlockType = "INSIGHT"
+	NotebookDiffPreviewBlockType NotebookBlockType = "DIFF_PREVIEW"
 )
 
 type CreateNotebookInputArgs struct {
@@ -147,13 +180,15 @@ type NotebookInputArgs struct {
 }
 
 type CreateNotebookBlockInputArgs struct {
-	ID            string                  `json:"id"`
-	Type          NotebookBlockType       `json:"type"`
-	MarkdownInput *string                 `json:"markdownInput"`
-	QueryInput    *string                 `json:"queryInput"`
-	FileInput     *CreateFileBlockInput   `json:"fileInput"`
-	SymbolInput   *CreateSymbolBlockInput `json:"symbolInput"`
-	ComputeInput  *string                 `json:"computeInput"`
+	ID               string                       `json:"id"`
+	Type             NotebookBlockType            `json:"type"`
+	MarkdownInput    *string                      `json:"markdownInput"`
+	QueryInput       *string                      `json:"queryInput"`
+	FileInput        *CreateFileBlockInput        `json:"fileInput"`
+	SymbolInput      *CreateSymbolBlockInput      `json:"symbolInput"`
+	ComputeInput     *string                      `json:"computeInput"`
+	InsightInput     *CreateInsightBlockInput     `json:"insightInput"`
+	DiffPreviewInput *CreateDiffPreviewBlockInput `json:"diffPreviewInput"`
 }
 
 type CreateFileBlockInput struct {
@@ -173,6 +208,24 @@ type CreateSymbolBlockInput struct {
 	SymbolKind          string  `json:"symbolKind"`
 }
 
+type CreateInsightBlockInput struct {
+	SeriesID  *string                           `json:"seriesId"`
+	Query     *string                           `json:"query"`
+	TimeRange *CreateInsightBlockTimeRangeInput `json:"timeRange"`
+}
+
+type CreateInsightBlockTimeRangeInput struct {
+	Unit  string `json:"unit"`
+	Value int32  `json:"value"`
+}
+
+type CreateDiffPreviewBlockInput struct {
+	Query          string `json:"query"`
+	MatchPattern   string `json:"matchPattern"`
+	RewritePattern string `json:"rewritePattern"`
+	PatternType    string `json:"patternType"`
+}
+
 type CreateFileBlockLineRangeInput struct {
 	StartLine int32 `json:"startLine"`
 	EndLine   int32 `json:"endLine"`
@@ -386,6 +386,10 @@ func (r *RepositoryResolver) CodeIntelligenceCommitGraph(ctx context.Context) (u
 	return EnterpriseResolvers.codeIntelResolver.CommitGraph(ctx, r.ID())
 }
 
+func (r *RepositoryResolver) CodeIntelligenceStorageQuotaEvictionPreview(ctx context.Context) (*[]uploads.LSIFUploadStorageQuotaEvictionResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.StorageQuotaEvictionPreview(ctx, r.ID())
+}
+
 func (r *RepositoryResolver) CodeIntelSummary(ctx context.Context) (sharedresolvers.CodeIntelRepositorySummaryResolver, error) {
 	return EnterpriseResolvers.codeIntelResolver.RepositorySummary(ctx, r.ID())
 }
@@ -347,6 +347,9 @@ func makeExternalAPI(db database.DB, schema *graphql.Schema, enterprise enterpri
 			BatchesChangesFileGetHandler:    enterprise.BatchesChangesFileGetHandler,
 			BatchesChangesFileExistsHandler: enterprise.BatchesChangesFileExistsHandler,
 			BatchesChangesFileUploadHandler: enterprise.BatchesChangesFileUploadHandler,
+			InsightsExportHandler:           enterprise.InsightsExportHandler,
+			InsightsMetricsHandler:          enterprise.InsightsMetricsHandler,
+			InsightsImportHandler:           enterprise.InsightsImportHandler,
 			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
 			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
 		},
@@ -24,6 +24,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/handlerutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/releasecache"
 	apirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/router"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/scim"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/webhookhandlers"
 	frontendsearch "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	registry "github.com/sourcegraph/sourcegraph/cmd/frontend/registry/api"
@@ -50,6 +51,9 @@ type Handlers struct {
 	BatchesChangesFileGetHandler    http.Handler
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
+	InsightsExportHandler           http.Handler
+	InsightsMetricsHandler          http.Handler
+	InsightsImportHandler           http.Handler
 	NewCodeIntelUploadHandler       enterprise.NewCodeIntelUploadHandler
 	NewComputeStreamHandler         enterprise.NewComputeStreamHandler
 }
@@ -104,9 +108,23 @@ func NewHandler(
 	m.Get(apirouter.BatchesFileGet).Handler(trace.Route(handlers.BatchesChangesFileGetHandler))
 	m.Get(apirouter.BatchesFileExists).Handler(trace.Route(handlers.BatchesChangesFileExistsHandler))
 	m.Get(apirouter.BatchesFileUpload).Handler(trace.Route(handlers.BatchesChangesFileUploadHandler))
+	m.Get(apirouter.InsightsExport).Handler(trace.Route(handlers.InsightsExportHandler))
+	m.Get(apirouter.InsightsMetrics).Handler(trace.Route(handlers.InsightsMetricsHandler))
+	m.Get(apirouter.InsightsImport).Handler(trace.Route(handlers.InsightsImportHandler))
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(true)))
 	m.Get(apirouter.ComputeStream).Handler(trace.Route(handlers.NewComputeStreamHandler()))
 
+	scimHandler := scim.NewHandler(logger.Scoped("scim", "SCIM provisioning API"), db)
+	for _, route := range []string{
+		apirouter.SCIMUsers,
+		apirouter.SCIMUser,
+		apirouter.SCIMGroups,
+		apirouter.SCIMGroup,
+		apirouter.SCIMServiceProviderConfig,
+	} {
+		m.Get(route).Handler(trace.Route(scimHandler))
+	}
+
 	ghSync := repos.GitHubWebhookHandler{}
 	ghSync.Register(&gh)
 
@@ -0,0 +1,37 @@
+package scim
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// filter is an equality filter on a single attribute, such as `userName eq "alice"`.
+//
+// This is the subset of the SCIM filter grammar (RFC 7644 section 3.4.2.2) that identity
+// providers use to look up existing resources before provisioning them.
+type filter struct {
+	// attribute is the lowercased attribute name, since attribute names are case-insensitive.
+	attribute string
+	value     string
+}
+
+var filterPattern = lazyregexp.New(`(?i)^\s*([a-z][\w.:]*)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// parseFilter parses the filter query parameter of a list request. It returns nil if s is
+// empty.
+func parseFilter(s string) (*filter, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	m := filterPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, badRequest(errTypeInvalidFilter, "unsupported filter %q: only `<attribute> eq \"<value>\"` is supported", s)
+	}
+	value, err := strconv.Unquote(m[2])
+	if err != nil {
+		return nil, badRequest(errTypeInvalidFilter, "invalid filter value %s", m[2])
+	}
+	return &filter{attribute: strings.ToLower(m[1]), value: value}, nil
+}
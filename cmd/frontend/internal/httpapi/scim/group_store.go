@@ -0,0 +1,78 @@
+package scim
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// groupStore records which organizations were created through SCIM. Only these organizations
+// can be read or changed through SCIM.
+type groupStore interface {
+	// Add records that the organization was created through SCIM.
+	Add(ctx context.Context, orgID int32) error
+	// Exists returns true if the organization was created through SCIM.
+	Exists(ctx context.Context, orgID int32) (bool, error)
+	// Count returns the number of organizations created through SCIM that are not deleted.
+	Count(ctx context.Context) (int, error)
+	// List returns the IDs of the organizations created through SCIM that are not deleted,
+	// ordered by ID.
+	List(ctx context.Context, limitOffset *database.LimitOffset) ([]int32, error)
+}
+
+type dbGroupStore struct {
+	*basestore.Store
+}
+
+func newGroupStore(db database.DB) groupStore {
+	return &dbGroupStore{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+func (s *dbGroupStore) Add(ctx context.Context, orgID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(addGroupQuery, orgID))
+}
+
+const addGroupQuery = `
+-- source: cmd/frontend/internal/httpapi/scim/group_store.go:Add
+INSERT INTO scim_groups (org_id) VALUES (%s) ON CONFLICT DO NOTHING
+`
+
+func (s *dbGroupStore) Exists(ctx context.Context, orgID int32) (bool, error) {
+	exists, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(groupExistsQuery, orgID)))
+	return exists, err
+}
+
+const groupExistsQuery = `
+-- source: cmd/frontend/internal/httpapi/scim/group_store.go:Exists
+SELECT EXISTS (SELECT 1 FROM scim_groups WHERE org_id = %s)
+`
+
+func (s *dbGroupStore) Count(ctx context.Context) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(countGroupsQuery)))
+	return count, err
+}
+
+const countGroupsQuery = `
+-- source: cmd/frontend/internal/httpapi/scim/group_store.go:Count
+SELECT COUNT(*)
+FROM scim_groups g
+JOIN orgs o ON o.id = g.org_id
+WHERE o.deleted_at IS NULL
+`
+
+func (s *dbGroupStore) List(ctx context.Context, limitOffset *database.LimitOffset) ([]int32, error) {
+	return basestore.ScanInt32s(s.Query(ctx, sqlf.Sprintf(listGroupsQuery, limitOffset.SQL())))
+}
+
+const listGroupsQuery = `
+-- source: cmd/frontend/internal/httpapi/scim/group_store.go:List
+SELECT g.org_id
+FROM scim_groups g
+JOIN orgs o ON o.id = g.org_id
+WHERE o.deleted_at IS NULL
+ORDER BY g.org_id
+%s
+`
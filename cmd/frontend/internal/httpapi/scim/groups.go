@@ -0,0 +1,479 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// groupResource is the SCIM representation of an organization. Its id is the organization's
+// database ID and its displayName is the organization's display name. The organization name is
+// derived from the displayName when the group is created and never changes afterwards.
+type groupResource struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	DisplayName string   `json:"displayName"`
+	Members     []member `json:"members,omitempty"`
+	Meta        *meta    `json:"meta,omitempty"`
+}
+
+// member references a user by its SCIM id.
+type member struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+func (h *handler) toGroupResource(ctx context.Context, org *types.Org, withMembers bool) (*groupResource, error) {
+	res := &groupResource{
+		Schemas:     []string{schemaGroup},
+		ID:          strconv.Itoa(int(org.ID)),
+		DisplayName: org.Name,
+		Meta: &meta{
+			ResourceType: "Group",
+			Created:      org.CreatedAt.UTC().Format(time.RFC3339),
+			LastModified: org.UpdatedAt.UTC().Format(time.RFC3339),
+			Location:     resourceLocation("Groups", org.ID),
+		},
+	}
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		res.DisplayName = *org.DisplayName
+	}
+	if !withMembers {
+		return res, nil
+	}
+	memberships, err := h.db.OrgMembers().GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		res.Members = append(res.Members, member{
+			Value: strconv.Itoa(int(m.UserID)),
+			Ref:   resourceLocation("Users", m.UserID),
+		})
+	}
+	return res, nil
+}
+
+// includeMembers reports whether the client wants the members of groups in the response. Azure
+// AD excludes them to avoid transferring large groups.
+func includeMembers(r *http.Request) bool {
+	return !strings.Contains(strings.ToLower(r.URL.Query().Get("excludedAttributes")), "members")
+}
+
+func (h *handler) listGroups(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	p := parsePagination(r)
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return err
+	}
+
+	// Only organizations created through SCIM are listed, so that identity providers never
+	// match and take over organizations created by other means.
+	var orgs []*types.Org
+	var total int
+	if f != nil {
+		if f.attribute != "displayname" {
+			return badRequest(errTypeInvalidFilter, "filtering groups by %q is not supported", f.attribute)
+		}
+		org, err := h.findOrg(ctx, f.value)
+		if err != nil && !errcode.IsNotFound(err) {
+			return err
+		}
+		if org != nil {
+			total = 1
+			if p.startIndex == 1 && p.count > 0 {
+				orgs = append(orgs, org)
+			}
+		}
+	} else {
+		if total, err = h.groups.Count(ctx); err != nil {
+			return err
+		}
+		ids, err := h.groups.List(ctx, p.limitOffset())
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			org, err := h.db.Orgs().GetByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			orgs = append(orgs, org)
+		}
+	}
+
+	resources := make([]any, 0, len(orgs))
+	for _, org := range orgs {
+		res, err := h.toGroupResource(ctx, org, includeMembers(r))
+		if err != nil {
+			return err
+		}
+		resources = append(resources, res)
+	}
+	return writeList(w, p, total, resources)
+}
+
+// findOrg returns the organization created through SCIM for the group with the given
+// displayName, or nil if there is none.
+func (h *handler) findOrg(ctx context.Context, displayName string) (*types.Org, error) {
+	orgName, err := auth.NormalizeUsername(displayName)
+	if err != nil {
+		return nil, nil
+	}
+	org, err := h.db.Orgs().GetByName(ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+	if managed, err := h.groups.Exists(ctx, org.ID); err != nil || !managed {
+		return nil, err
+	}
+	return org, nil
+}
+
+// managedOrg returns the organization with the given ID.
+//
+// 🚨 SECURITY: Organizations that were not created through SCIM are reported as not found, so
+// that the holder of the SCIM token cannot read or change organizations created by other means.
+func (h *handler) managedOrg(ctx context.Context, id int32) (*types.Org, error) {
+	org, err := h.db.Orgs().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	managed, err := h.groups.Exists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !managed {
+		return nil, &scimError{status: http.StatusNotFound, detail: "organization not created through SCIM"}
+	}
+	return org, nil
+}
+
+func (h *handler) getGroup(w http.ResponseWriter, r *http.Request) error {
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	org, err := h.managedOrg(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	res, err := h.toGroupResource(r.Context(), org, includeMembers(r))
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) createGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	var req groupResource
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	if req.DisplayName == "" {
+		return badRequest(errTypeInvalidValue, "displayName is required")
+	}
+	orgName, err := auth.NormalizeUsername(req.DisplayName)
+	if err != nil {
+		return badRequest(errTypeInvalidValue, "%s", err)
+	}
+	memberIDs, err := parseMembers(req.Members)
+	if err != nil {
+		return err
+	}
+
+	if existing, err := h.db.Orgs().GetByName(ctx, orgName); err == nil {
+		return &scimError{
+			status:   http.StatusConflict,
+			scimType: errTypeUniqueness,
+			detail:   "organization " + existing.Name + " already exists",
+		}
+	} else if !errcode.IsNotFound(err) {
+		return err
+	}
+
+	org, err := h.db.Orgs().Create(ctx, orgName, &req.DisplayName)
+	if err != nil {
+		return err
+	}
+	if err := h.groups.Add(ctx, org.ID); err != nil {
+		// The organization could not be managed through SCIM, so it would block retries.
+		if deleteErr := h.db.Orgs().Delete(ctx, org.ID); deleteErr != nil {
+			err = errors.Append(err, deleteErr)
+		}
+		return err
+	}
+	if err := h.addMembers(ctx, org.ID, memberIDs); err != nil {
+		return err
+	}
+
+	res, err := h.toGroupResource(ctx, org, true)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Location", res.Meta.Location)
+	return writeJSON(w, http.StatusCreated, res)
+}
+
+func (h *handler) replaceGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	org, err := h.managedOrg(ctx, id)
+	if err != nil {
+		return err
+	}
+	var req groupResource
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	memberIDs, err := parseMembers(req.Members)
+	if err != nil {
+		return err
+	}
+
+	if req.DisplayName != "" {
+		if org, err = h.db.Orgs().Update(ctx, org.ID, &req.DisplayName); err != nil {
+			return err
+		}
+	}
+	if err := h.replaceMembers(ctx, org.ID, memberIDs); err != nil {
+		return err
+	}
+
+	res, err := h.toGroupResource(ctx, org, true)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, res)
+}
+
+// memberFilterPath matches the path of a PATCH operation that removes a single member, such as
+// `members[value eq "42"]`.
+var memberFilterPath = lazyregexp.New(`(?i)^members\[value\s+eq\s+"(\d+)"\]$`)
+
+func (h *handler) patchGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	org, err := h.managedOrg(ctx, id)
+	if err != nil {
+		return err
+	}
+	var req patchRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+
+	for _, op := range req.Operations {
+		if err := h.applyGroupOperation(ctx, org, op); err != nil {
+			return err
+		}
+	}
+	// The response would contain all members of the group, which can be large, so we use the
+	// No Content response allowed by RFC 7644 section 3.5.2.
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (h *handler) applyGroupOperation(ctx context.Context, org *types.Org, op patchOperation) error {
+	switch strings.ToLower(op.Op) {
+	case "add", "replace":
+		values, err := op.values()
+		if err != nil {
+			return err
+		}
+		for path, value := range values {
+			switch strings.ToLower(path) {
+			case "displayname":
+				var displayName string
+				if err := json.Unmarshal(value, &displayName); err != nil || displayName == "" {
+					return badRequest(errTypeInvalidValue, "displayName must be a non-empty string")
+				}
+				if _, err := h.db.Orgs().Update(ctx, org.ID, &displayName); err != nil {
+					return err
+				}
+			case "members":
+				memberIDs, err := unmarshalMembers(value)
+				if err != nil {
+					return err
+				}
+				if strings.EqualFold(op.Op, "add") {
+					err = h.addMembers(ctx, org.ID, memberIDs)
+				} else {
+					err = h.replaceMembers(ctx, org.ID, memberIDs)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+
+	case "remove":
+		if m := memberFilterPath.FindStringSubmatch(op.Path); m != nil {
+			userID, err := parseUserID(m[1])
+			if err != nil {
+				return err
+			}
+			return h.removeMembers(ctx, org.ID, []int32{userID})
+		}
+		if !strings.EqualFold(op.Path, "members") {
+			return badRequest(errTypeInvalidValue, "removing %q from groups is not supported", op.Path)
+		}
+		if len(op.Value) == 0 {
+			return h.replaceMembers(ctx, org.ID, nil)
+		}
+		memberIDs, err := unmarshalMembers(op.Value)
+		if err != nil {
+			return err
+		}
+		return h.removeMembers(ctx, org.ID, memberIDs)
+
+	default:
+		return badRequest(errTypeInvalidValue, "unsupported patch operation %q on groups", op.Op)
+	}
+}
+
+func (h *handler) deleteGroup(w http.ResponseWriter, r *http.Request) error {
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	if _, err := h.managedOrg(r.Context(), id); err != nil {
+		return err
+	}
+	if err := h.db.Orgs().Delete(r.Context(), id); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func unmarshalMembers(value json.RawMessage) ([]int32, error) {
+	var members []member
+	if err := json.Unmarshal(value, &members); err != nil {
+		return nil, badRequest(errTypeInvalidValue, "members must be a list of member objects")
+	}
+	return parseMembers(members)
+}
+
+func parseMembers(members []member) ([]int32, error) {
+	ids := make([]int32, 0, len(members))
+	for _, m := range members {
+		id, err := parseUserID(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func parseUserID(value string) (int32, error) {
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, badRequest(errTypeInvalidValue, "invalid member %q", value)
+	}
+	return int32(id), nil
+}
+
+// checkManagedMember returns an error if the user must not be added to or removed from an
+// organization through SCIM.
+//
+// 🚨 SECURITY: Only users provisioned through SCIM that are not site admins are managed, so
+// that the holder of the SCIM token cannot change the organizations of other accounts.
+func (h *handler) checkManagedMember(ctx context.Context, userID int32) error {
+	user, err := h.db.Users().GetByID(ctx, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return badRequest(errTypeInvalidValue, "member %d does not exist", userID)
+		}
+		return err
+	}
+	acct, err := h.scimAccount(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if acct == nil {
+		return badRequest(errTypeInvalidValue, "member %d was not provisioned through SCIM", userID)
+	}
+	return checkModifiable(user)
+}
+
+func (h *handler) addMembers(ctx context.Context, orgID int32, userIDs []int32) error {
+	for _, userID := range userIDs {
+		_, err := h.db.OrgMembers().GetByOrgIDAndUserID(ctx, orgID, userID)
+		if err == nil {
+			continue
+		}
+		if !errcode.IsNotFound(err) {
+			return err
+		}
+		if err := h.checkManagedMember(ctx, userID); err != nil {
+			return err
+		}
+		if _, err := h.db.OrgMembers().Create(ctx, orgID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *handler) removeMembers(ctx context.Context, orgID int32, userIDs []int32) error {
+	for _, userID := range userIDs {
+		if err := h.checkManagedMember(ctx, userID); err != nil {
+			return err
+		}
+		if err := h.db.OrgMembers().Remove(ctx, orgID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceMembers makes userIDs the exact set of members of the organization that are managed
+// through SCIM. Other members, such as site admins, are kept.
+func (h *handler) replaceMembers(ctx context.Context, orgID int32, userIDs []int32) error {
+	memberships, err := h.db.OrgMembers().GetByOrgID(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	want := make(map[int32]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		want[id] = struct{}{}
+	}
+	for _, m := range memberships {
+		if _, ok := want[m.UserID]; ok {
+			continue
+		}
+		if err := h.checkManagedMember(ctx, m.UserID); err != nil {
+			var e *scimError
+			if errors.As(err, &e) {
+				continue
+			}
+			return err
+		}
+		if err := h.db.OrgMembers().Remove(ctx, orgID, m.UserID); err != nil {
+			return err
+		}
+	}
+	return h.addMembers(ctx, orgID, userIDs)
+}
@@ -0,0 +1,291 @@
+// Package scim implements a SCIM 2.0 (RFC 7643 and RFC 7644) provisioning API, which lets an
+// identity provider such as Okta or Azure AD create, update and deactivate users, and manage
+// organizations as groups.
+package scim
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	apirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/router"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	contentType = "application/scim+json"
+
+	// maxResults is the maximum number of resources returned in a single list response.
+	maxResults = 100
+)
+
+// Error types defined in RFC 7644 section 3.12.
+const (
+	errTypeInvalidFilter = "invalidFilter"
+	errTypeInvalidValue  = "invalidValue"
+	errTypeInvalidSyntax = "invalidSyntax"
+	errTypeUniqueness    = "uniqueness"
+)
+
+// NewHandler returns the handler serving all SCIM routes of the API router.
+//
+// 🚨 SECURITY: Requests are authenticated by the `scim.authToken` site configuration value and
+// are then handled as the internal actor, so the handler MUST reject every request that does
+// not present the token.
+func NewHandler(logger log.Logger, db database.DB) http.Handler {
+	return &handler{logger: logger, db: db, groups: newGroupStore(db)}
+}
+
+type handler struct {
+	logger log.Logger
+	db     database.DB
+	groups groupStore
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !conf.SCIMEnabled() {
+		writeError(w, &scimError{status: http.StatusNotFound, detail: "SCIM provisioning is not enabled"})
+		return
+	}
+	// 🚨 SECURITY: Only the identity provider holding the SCIM token may provision users.
+	if !authenticated(r) {
+		writeError(w, &scimError{status: http.StatusUnauthorized, detail: "invalid SCIM token"})
+		return
+	}
+	r = r.WithContext(actor.WithInternalActor(r.Context()))
+
+	var err error
+	switch mux.CurrentRoute(r).GetName() {
+	case apirouter.SCIMUsers:
+		if r.Method == http.MethodPost {
+			err = h.createUser(w, r)
+		} else {
+			err = h.listUsers(w, r)
+		}
+	case apirouter.SCIMUser:
+		switch r.Method {
+		case http.MethodGet:
+			err = h.getUser(w, r)
+		case http.MethodPut:
+			err = h.replaceUser(w, r)
+		case http.MethodPatch:
+			err = h.patchUser(w, r)
+		case http.MethodDelete:
+			err = h.deleteUser(w, r)
+		}
+	case apirouter.SCIMGroups:
+		if r.Method == http.MethodPost {
+			err = h.createGroup(w, r)
+		} else {
+			err = h.listGroups(w, r)
+		}
+	case apirouter.SCIMGroup:
+		switch r.Method {
+		case http.MethodGet:
+			err = h.getGroup(w, r)
+		case http.MethodPut:
+			err = h.replaceGroup(w, r)
+		case http.MethodPatch:
+			err = h.patchGroup(w, r)
+		case http.MethodDelete:
+			err = h.deleteGroup(w, r)
+		}
+	case apirouter.SCIMServiceProviderConfig:
+		err = writeJSON(w, http.StatusOK, serviceProviderConfig)
+	default:
+		err = &scimError{status: http.StatusNotFound, detail: "no route"}
+	}
+	if err != nil {
+		h.handleError(w, r, err)
+	}
+}
+
+// authenticated reports whether the request carries the configured SCIM token as a bearer
+// token.
+func authenticated(r *http.Request) bool {
+	token := conf.Get().ScimAuthToken
+	if token == "" {
+		return false
+	}
+	scheme, value, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(value)), []byte(token)) == 1
+}
+
+// scimError is an error that is reported to the client using the SCIM error response format.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.detail
+}
+
+func badRequest(scimType, format string, args ...any) error {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+func (h *handler) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	var e *scimError
+	switch {
+	case errors.As(err, &e):
+	case errcode.IsNotFound(err):
+		e = &scimError{status: http.StatusNotFound, detail: "resource not found"}
+	case database.IsUsernameExists(err), database.IsEmailExists(err):
+		e = &scimError{status: http.StatusConflict, scimType: errTypeUniqueness, detail: err.Error()}
+	default:
+		h.logger.Error("SCIM request failed", log.String("method", r.Method), log.String("path", r.URL.Path), log.Error(err))
+		e = &scimError{status: http.StatusInternalServerError, detail: "internal error"}
+	}
+	writeError(w, e)
+}
+
+func writeError(w http.ResponseWriter, e *scimError) {
+	_ = writeJSON(w, e.status, struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail,omitempty"`
+	}{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(e.status),
+		ScimType: e.scimType,
+		Detail:   e.detail,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
+
+func readJSON(r *http.Request, v any) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return badRequest(errTypeInvalidSyntax, "invalid request body: %s", err)
+	}
+	return nil
+}
+
+// resourceID parses the {id} route variable, which is the database ID of a user or an
+// organization.
+func resourceID(r *http.Request) (int32, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		return 0, &scimError{status: http.StatusNotFound, detail: "resource not found"}
+	}
+	return int32(id), nil
+}
+
+func resourceLocation(resourceType string, id int32) string {
+	return strings.TrimSuffix(conf.ExternalURL(), "/") + "/.api/scim/v2/" + resourceType + "/" + strconv.Itoa(int(id))
+}
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// pagination holds the 1-based startIndex and the count query parameters of a list request.
+type pagination struct {
+	startIndex int
+	count      int
+}
+
+func parsePagination(r *http.Request) pagination {
+	p := pagination{startIndex: 1, count: maxResults}
+	if v, err := strconv.Atoi(r.URL.Query().Get("startIndex")); err == nil && v > 1 {
+		p.startIndex = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil && v >= 0 && v < maxResults {
+		p.count = v
+	}
+	return p
+}
+
+func (p pagination) limitOffset() *database.LimitOffset {
+	return &database.LimitOffset{Limit: p.count, Offset: p.startIndex - 1}
+}
+
+func writeList(w http.ResponseWriter, p pagination, total int, resources []any) error {
+	if resources == nil {
+		resources = []any{}
+	}
+	return writeJSON(w, http.StatusOK, &listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   p.startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// values returns the attribute values the operation sets. An operation without a path carries
+// an object of attribute values, as sent by Okta.
+func (op patchOperation) values() (map[string]json.RawMessage, error) {
+	if op.Path != "" {
+		return map[string]json.RawMessage{op.Path: op.Value}, nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &values); err != nil {
+		return nil, badRequest(errTypeInvalidValue, "patch operation without a path requires an object value")
+	}
+	return values, nil
+}
+
+var serviceProviderConfig = map[string]any{
+	"schemas":        []string{schemaServiceProviderConfig},
+	"patch":          map[string]bool{"supported": true},
+	"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+	"filter":         map[string]any{"supported": true, "maxResults": maxResults},
+	"changePassword": map[string]bool{"supported": false},
+	"sort":           map[string]bool{"supported": false},
+	"etag":           map[string]bool{"supported": false},
+	"authenticationSchemes": []map[string]any{{
+		"type":        "oauthbearertoken",
+		"name":        "OAuth Bearer Token",
+		"description": "Authentication using the scim.authToken site configuration value as a bearer token",
+		"primary":     true,
+	}},
+}
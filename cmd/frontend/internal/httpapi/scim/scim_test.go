@@ -0,0 +1,579 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/router"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testToken = "0123456789abcdefghijklmnop"
+
+func newTestServer(t *testing.T, db database.DB, groups groupStore) http.Handler {
+	t.Helper()
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExternalURL:   "https://sourcegraph.example.com",
+		ScimAuthToken: testToken,
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	m := apirouter.New(mux.NewRouter().PathPrefix("/.api/").Subrouter())
+	h := NewHandler(logtest.Scoped(t), db)
+	if groups != nil {
+		h.(*handler).groups = groups
+	}
+	for _, route := range []string{apirouter.SCIMUsers, apirouter.SCIMUser, apirouter.SCIMGroups, apirouter.SCIMGroup, apirouter.SCIMServiceProviderConfig} {
+		m.Get(route).Handler(h)
+	}
+	return m
+}
+
+func do(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, "/.api/scim/v2"+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// fakeGroupStore records the organizations created through SCIM in memory.
+type fakeGroupStore map[int32]struct{}
+
+func (s fakeGroupStore) Add(_ context.Context, orgID int32) error {
+	s[orgID] = struct{}{}
+	return nil
+}
+
+func (s fakeGroupStore) Exists(_ context.Context, orgID int32) (bool, error) {
+	_, ok := s[orgID]
+	return ok, nil
+}
+
+func (s fakeGroupStore) Count(_ context.Context) (int, error) {
+	return len(s), nil
+}
+
+func (s fakeGroupStore) List(_ context.Context, _ *database.LimitOffset) ([]int32, error) {
+	ids := make([]int32, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func TestAuthentication(t *testing.T) {
+	h := newTestServer(t, database.NewMockDB(), nil)
+
+	for name, tc := range map[string]struct {
+		authorization string
+		want          int
+	}{
+		"missing token": {authorization: "", want: http.StatusUnauthorized},
+		"wrong token":   {authorization: "Bearer wrong", want: http.StatusUnauthorized},
+		"wrong scheme":  {authorization: "token " + testToken, want: http.StatusUnauthorized},
+		"valid token":   {authorization: "Bearer " + testToken, want: http.StatusOK},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/.api/scim/v2/ServiceProviderConfig", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tc.want, rec.Code)
+			assert.Equal(t, contentType, rec.Header().Get("Content-Type"))
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		conf.Mock(&conf.Unified{})
+		rec := do(t, h, http.MethodGet, "/ServiceProviderConfig", "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestParseFilter(t *testing.T) {
+	for input, want := range map[string]*filter{
+		``:                               nil,
+		`userName eq "alice"`:            {attribute: "username", value: "alice"},
+		`emails.value EQ "a@b.com"`:      {attribute: "emails.value", value: "a@b.com"},
+		`displayName eq "Dev \"Ops\""`:   {attribute: "displayname", value: `Dev "Ops"`},
+		` displayName   eq  "Platform" `: {attribute: "displayname", value: "Platform"},
+	} {
+		got, err := parseFilter(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{
+		`userName sw "al"`,
+		`userName eq "alice" and active eq true`,
+		`userName eq alice`,
+	} {
+		_, err := parseFilter(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	alice := &types.User{ID: 1, Username: "alice", DisplayName: "Alice"}
+	admin := &types.User{ID: 3, Username: "admin", SiteAdmin: true}
+	carol := &types.User{ID: 4, Username: "carol"}
+	// dave signed in through SAML and erin signed up before SCIM was enabled.
+	dave := &types.User{ID: 5, Username: "dave"}
+	erin := &types.User{ID: 6, Username: "erin"}
+	root := &types.User{ID: 7, Username: "root", SiteAdmin: true}
+	all := []*types.User{alice, admin, carol, dave, erin, root}
+
+	scimAccount := func(userID int32, accountID, externalID string) *extsvc.Account {
+		return &extsvc.Account{
+			UserID: userID,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: accountServiceType,
+				ServiceID:   accountServiceID,
+				AccountID:   accountID,
+			},
+			AccountData: extsvc.AccountData{
+				Data: extsvc.NewUnencryptedData(json.RawMessage(`{"externalId":"` + externalID + `"}`)),
+			},
+		}
+	}
+	// carol was not provisioned through SCIM.
+	accounts := map[int32]*extsvc.Account{
+		alice.ID: scimAccount(alice.ID, "00u1", "00u1"),
+		admin.ID: scimAccount(admin.ID, "admin", ""),
+	}
+
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		for _, u := range all {
+			if u.ID == id {
+				return u, nil
+			}
+		}
+		if id == 2 {
+			return &types.User{ID: 2, Username: "bob", DisplayName: "Bob Smith"}, nil
+		}
+		return nil, database.NewUserNotFoundError(id)
+	})
+	users.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, username string) (*types.User, error) {
+		for _, u := range all {
+			if u.Username == username {
+				return u, nil
+			}
+		}
+		return nil, database.NewUserNotFoundError(0)
+	})
+	users.GetByVerifiedEmailFunc.SetDefaultHook(func(_ context.Context, address string) (*types.User, error) {
+		for _, u := range []*types.User{alice, erin, root} {
+			if address == u.Username+"@example.com" {
+				return u, nil
+			}
+		}
+		return nil, database.NewUserNotFoundError(0)
+	})
+	emails := database.NewMockUserEmailsStore()
+	emails.ListByUserFunc.SetDefaultReturn([]*database.UserEmail{{UserID: 1, Email: "alice@example.com", Primary: true}}, nil)
+
+	externalAccounts := database.NewMockUserExternalAccountsStore()
+	externalAccounts.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		if opts.ServiceType == "saml" && opts.AccountIDLike == "00u5" {
+			return []*extsvc.Account{{UserID: dave.ID, AccountSpec: extsvc.AccountSpec{ServiceType: "saml", AccountID: "00u5"}}}, nil
+		}
+		if opts.ServiceType != accountServiceType || opts.ServiceID != accountServiceID {
+			return nil, nil
+		}
+		var accts []*extsvc.Account
+		for _, id := range []int32{1, 2, 3, 4, 5, 6, 7} {
+			acct, ok := accounts[id]
+			if !ok || (opts.UserID != 0 && opts.UserID != id) || (opts.AccountIDLike != "" && opts.AccountIDLike != acct.AccountID) {
+				continue
+			}
+			accts = append(accts, acct)
+		}
+		return accts, nil
+	})
+	externalAccounts.CreateUserAndSaveFunc.SetDefaultHook(func(_ context.Context, u database.NewUser, spec extsvc.AccountSpec, data extsvc.AccountData) (int32, error) {
+		accounts[2] = &extsvc.Account{UserID: 2, AccountSpec: spec, AccountData: data}
+		return 2, nil
+	})
+	externalAccounts.AssociateUserAndSaveFunc.SetDefaultHook(func(_ context.Context, userID int32, spec extsvc.AccountSpec, data extsvc.AccountData) error {
+		accounts[userID] = &extsvc.Account{UserID: userID, AccountSpec: spec, AccountData: data}
+		return nil
+	})
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserEmailsFunc.SetDefaultReturn(emails)
+	db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
+	h := newTestServer(t, db, nil)
+
+	t.Run("create", func(t *testing.T) {
+		rec := do(t, h, http.MethodPost, "/Users", `{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+			"userName": "bob@example.com",
+			"externalId": "00u2",
+			"name": {"givenName": "Bob", "familyName": "Smith"},
+			"emails": [{"value": "bob@example.com", "type": "work", "primary": true}],
+			"active": true
+		}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, "https://sourcegraph.example.com/.api/scim/v2/Users/2", rec.Header().Get("Location"))
+		assert.Contains(t, rec.Body.String(), `"externalId":"00u2"`)
+
+		call := externalAccounts.CreateUserAndSaveFunc.History()[0]
+		assert.Equal(t, database.NewUser{
+			Username:        "bob",
+			DisplayName:     "Bob Smith",
+			Email:           "bob@example.com",
+			EmailIsVerified: true,
+		}, call.Arg1)
+		assert.Equal(t, extsvc.AccountSpec{ServiceType: accountServiceType, ServiceID: accountServiceID, AccountID: "00u2"}, call.Arg2)
+	})
+
+	t.Run("list with filter", func(t *testing.T) {
+		rec := do(t, h, http.MethodGet, `/Users?filter=userName+eq+"alice@example.com"`, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var resp struct {
+			TotalResults int
+			Resources    []userResource
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Equal(t, 1, resp.TotalResults)
+		assert.Equal(t, "1", resp.Resources[0].ID)
+		assert.Equal(t, "alice", resp.Resources[0].UserName)
+		assert.Equal(t, "00u1", resp.Resources[0].ExternalID)
+		assert.Equal(t, []email{{Value: "alice@example.com", Type: "work", Primary: true}}, resp.Resources[0].Emails)
+	})
+
+	t.Run("list by externalId", func(t *testing.T) {
+		rec := do(t, h, http.MethodGet, `/Users?filter=externalId+eq+"00u1"`, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"totalResults":1`)
+		assert.Contains(t, rec.Body.String(), `"userName":"alice"`)
+	})
+
+	t.Run("list without match", func(t *testing.T) {
+		rec := do(t, h, http.MethodGet, `/Users?filter=userName+eq+"nobody"`, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"totalResults":0`)
+		assert.Contains(t, rec.Body.String(), `"Resources":[]`)
+	})
+
+	t.Run("list does not match users not provisioned through SCIM", func(t *testing.T) {
+		rec := do(t, h, http.MethodGet, `/Users?filter=userName+eq+"carol"`, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"totalResults":0`)
+	})
+
+	t.Run("unsupported filter", func(t *testing.T) {
+		rec := do(t, h, http.MethodGet, `/Users?filter=title+eq+"CEO"`, "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), errTypeInvalidFilter)
+	})
+
+	t.Run("get unknown user", func(t *testing.T) {
+		rec := do(t, h, http.MethodGet, "/Users/42", "")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("user not provisioned through SCIM", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			rec := do(t, h, method, "/Users/4", `{"userName": "mallory", "emails": [{"value": "mallory@evil.com", "primary": true}]}`)
+			assert.Equal(t, http.StatusNotFound, rec.Code, method)
+		}
+	})
+
+	t.Run("site admins cannot be changed", func(t *testing.T) {
+		rec := do(t, h, http.MethodPatch, "/Users/3", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "mallory@evil.com"}]
+		}`)
+		assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+		rec = do(t, h, http.MethodDelete, "/Users/3", "")
+		assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+		assert.Empty(t, emails.AddFunc.History())
+		assert.Empty(t, users.DeleteFunc.History())
+	})
+
+	t.Run("patch display name", func(t *testing.T) {
+		rec := do(t, h, http.MethodPatch, "/Users/1", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "replace", "value": {"displayName": "Alice A."}}]
+		}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		history := users.UpdateFunc.History()
+		require.Len(t, history, 1)
+		assert.Equal(t, int32(1), history[0].Arg1)
+		assert.Equal(t, "Alice A.", *history[0].Arg2.DisplayName)
+		assert.Empty(t, history[0].Arg2.Username)
+	})
+
+	t.Run("deactivate", func(t *testing.T) {
+		// Azure AD sends booleans as strings.
+		rec := do(t, h, http.MethodPatch, "/Users/1", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "Replace", "path": "active", "value": "False"}]
+		}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"active":false`)
+
+		history := users.DeleteFunc.History()
+		require.Len(t, history, 1)
+		assert.Equal(t, int32(1), history[0].Arg1)
+	})
+
+	t.Run("create takes over user by SSO externalId", func(t *testing.T) {
+		associated := len(externalAccounts.AssociateUserAndSaveFunc.History())
+		rec := do(t, h, http.MethodPost, "/Users", `{
+			"userName": "dave.jones@example.com",
+			"externalId": "00u5",
+			"displayName": "Dave Jones",
+			"emails": [{"value": "dave@example.com", "primary": true}]
+		}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, "https://sourcegraph.example.com/.api/scim/v2/Users/5", rec.Header().Get("Location"))
+
+		history := externalAccounts.AssociateUserAndSaveFunc.History()
+		require.Len(t, history, associated+1)
+		call := history[associated]
+		assert.Equal(t, dave.ID, call.Arg1)
+		assert.Equal(t, extsvc.AccountSpec{ServiceType: accountServiceType, ServiceID: accountServiceID, AccountID: "00u5"}, call.Arg2)
+		assert.Len(t, externalAccounts.CreateUserAndSaveFunc.History(), 1)
+
+		// The existing username is kept.
+		updates := users.UpdateFunc.History()
+		update := updates[len(updates)-1]
+		assert.Equal(t, dave.ID, update.Arg1)
+		assert.Empty(t, update.Arg2.Username)
+		assert.Equal(t, "Dave Jones", *update.Arg2.DisplayName)
+	})
+
+	t.Run("create takes over user by verified email", func(t *testing.T) {
+		associated := len(externalAccounts.AssociateUserAndSaveFunc.History())
+		rec := do(t, h, http.MethodPost, "/Users", `{
+			"userName": "erin@example.com",
+			"externalId": "00u6",
+			"emails": [{"value": "erin@example.com", "primary": true}]
+		}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, "https://sourcegraph.example.com/.api/scim/v2/Users/6", rec.Header().Get("Location"))
+		assert.Contains(t, rec.Body.String(), `"externalId":"00u6"`)
+
+		history := externalAccounts.AssociateUserAndSaveFunc.History()
+		require.Len(t, history, associated+1)
+		assert.Equal(t, erin.ID, history[associated].Arg1)
+		assert.Len(t, externalAccounts.CreateUserAndSaveFunc.History(), 1)
+
+		// erin is now managed through SCIM.
+		rec = do(t, h, http.MethodGet, "/Users/6", "")
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	})
+
+	t.Run("create does not take over site admins", func(t *testing.T) {
+		associated := len(externalAccounts.AssociateUserAndSaveFunc.History())
+		rec := do(t, h, http.MethodPost, "/Users", `{
+			"userName": "root",
+			"externalId": "00u7",
+			"emails": [{"value": "root@example.com", "primary": true}]
+		}`)
+		assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+		assert.Len(t, externalAccounts.AssociateUserAndSaveFunc.History(), associated)
+	})
+
+	t.Run("create user already provisioned through SCIM", func(t *testing.T) {
+		associated := len(externalAccounts.AssociateUserAndSaveFunc.History())
+		rec := do(t, h, http.MethodPost, "/Users", `{
+			"userName": "alice",
+			"externalId": "00u9",
+			"emails": [{"value": "alice@example.com", "primary": true}]
+		}`)
+		assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), errTypeUniqueness)
+		assert.Len(t, externalAccounts.AssociateUserAndSaveFunc.History(), associated)
+	})
+}
+
+func TestGroups(t *testing.T) {
+	displayName := "Platform Team"
+	org := &types.Org{ID: 10, Name: "Platform-Team", DisplayName: &displayName}
+	// other was not created through SCIM.
+	other := &types.Org{ID: 11, Name: "other"}
+
+	// Users 1 and 2 were provisioned through SCIM, as was the site admin 3. carol was not.
+	provisioned := map[int32]bool{1: true, 2: true, 3: true}
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		switch id {
+		case 1, 2:
+			return &types.User{ID: id}, nil
+		case 3:
+			return &types.User{ID: id, Username: "admin", SiteAdmin: true}, nil
+		case 4:
+			return &types.User{ID: id, Username: "carol"}, nil
+		}
+		return nil, database.NewUserNotFoundError(id)
+	})
+	externalAccounts := database.NewMockUserExternalAccountsStore()
+	externalAccounts.ListFunc.SetDefaultHook(func(_ context.Context, opts database.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		if !provisioned[opts.UserID] || opts.ServiceType != accountServiceType {
+			return nil, nil
+		}
+		return []*extsvc.Account{{
+			UserID:      opts.UserID,
+			AccountSpec: extsvc.AccountSpec{ServiceType: accountServiceType, ServiceID: accountServiceID},
+		}}, nil
+	})
+
+	orgs := database.NewMockOrgStore()
+	orgs.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.Org, error) {
+		switch id {
+		case org.ID:
+			return org, nil
+		case other.ID:
+			return other, nil
+		}
+		return nil, &database.OrgNotFoundError{}
+	})
+	orgs.GetByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Org, error) {
+		if name == other.Name {
+			return other, nil
+		}
+		return nil, &database.OrgNotFoundError{}
+	})
+	orgs.CreateFunc.SetDefaultReturn(org, nil)
+	orgs.UpdateFunc.SetDefaultReturn(org, nil)
+
+	members := database.NewMockOrgMemberStore()
+	members.GetByOrgIDAndUserIDFunc.SetDefaultReturn(nil, &database.ErrOrgMemberNotFound{})
+	members.GetByOrgIDFunc.SetDefaultReturn([]*types.OrgMembership{{OrgID: 10, UserID: 1}, {OrgID: 10, UserID: 3}, {OrgID: 10, UserID: 4}}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
+	db.OrgsFunc.SetDefaultReturn(orgs)
+	db.OrgMembersFunc.SetDefaultReturn(members)
+	groups := fakeGroupStore{}
+	h := newTestServer(t, db, groups)
+
+	t.Run("create", func(t *testing.T) {
+		rec := do(t, h, http.MethodPost, "/Groups", `{
+			"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+			"displayName": "Platform Team",
+			"members": [{"value": "1"}]
+		}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		assert.Equal(t, "Platform-Team", orgs.CreateFunc.History()[0].Arg1)
+		assert.Contains(t, groups, org.ID)
+
+		var res groupResource
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, "10", res.ID)
+		assert.Equal(t, "Platform Team", res.DisplayName)
+		assert.Contains(t, res.Members, member{Value: "1", Ref: "https://sourcegraph.example.com/.api/scim/v2/Users/1"})
+		assert.Equal(t, int32(1), members.CreateFunc.History()[0].Arg2)
+	})
+
+	t.Run("create with unknown member", func(t *testing.T) {
+		rec := do(t, h, http.MethodPost, "/Groups", `{"displayName": "Unknown", "members": [{"value": "42"}]}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("list only organizations created through SCIM", func(t *testing.T) {
+		rec := do(t, h, http.MethodGet, "/Groups", "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"totalResults":1`)
+		assert.Contains(t, rec.Body.String(), `"id":"10"`)
+
+		rec = do(t, h, http.MethodGet, `/Groups?filter=displayName+eq+"other"`, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"totalResults":0`)
+	})
+
+	t.Run("organization not created through SCIM", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			rec := do(t, h, method, "/Groups/11", `{"displayName": "other", "members": []}`)
+			assert.Equal(t, http.StatusNotFound, rec.Code, method)
+		}
+		assert.Empty(t, orgs.DeleteFunc.History())
+		assert.Empty(t, members.RemoveFunc.History())
+	})
+
+	t.Run("members not provisioned through SCIM", func(t *testing.T) {
+		for _, op := range []string{"add", "remove"} {
+			rec := do(t, h, http.MethodPatch, "/Groups/10", `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "`+op+`", "path": "members", "value": [{"value": "4"}]}]
+			}`)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, op)
+		}
+		assert.Len(t, members.CreateFunc.History(), 1)
+		assert.Empty(t, members.RemoveFunc.History())
+	})
+
+	t.Run("site admins cannot be added or removed", func(t *testing.T) {
+		for _, op := range []string{"add", "remove"} {
+			rec := do(t, h, http.MethodPatch, "/Groups/10", `{
+				"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+				"Operations": [{"op": "`+op+`", "path": "members", "value": [{"value": "3"}]}]
+			}`)
+			assert.Equal(t, http.StatusForbidden, rec.Code, op)
+		}
+		assert.Len(t, members.CreateFunc.History(), 1)
+		assert.Empty(t, members.RemoveFunc.History())
+	})
+
+	t.Run("patch members", func(t *testing.T) {
+		rec := do(t, h, http.MethodPatch, "/Groups/10", `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [
+				{"op": "add", "path": "members", "value": [{"value": "2"}]},
+				{"op": "remove", "path": "members[value eq \"1\"]"}
+			]
+		}`)
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+		created := members.CreateFunc.History()
+		assert.Equal(t, int32(2), created[len(created)-1].Arg2)
+		removed := members.RemoveFunc.History()
+		require.Len(t, removed, 1)
+		assert.Equal(t, int32(10), removed[0].Arg1)
+		assert.Equal(t, int32(1), removed[0].Arg2)
+	})
+
+	t.Run("replace members keeps members not managed through SCIM", func(t *testing.T) {
+		rec := do(t, h, http.MethodPut, "/Groups/10", `{"displayName": "Platform Team", "members": [{"value": "2"}]}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		// Only user 1 is removed: the site admin 3 and carol are kept.
+		removed := members.RemoveFunc.History()
+		require.Len(t, removed, 2)
+		assert.Equal(t, int32(1), removed[1].Arg2)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		rec := do(t, h, http.MethodDelete, "/Groups/10", "")
+		require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+		assert.Equal(t, int32(10), orgs.DeleteFunc.History()[0].Arg1)
+	})
+}
@@ -0,0 +1,695 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Users provisioned through SCIM have an external account of this service type and ID. Only
+// these users can be read or changed through SCIM.
+const (
+	accountServiceType = "scim"
+	accountServiceID   = "scim"
+)
+
+// ssoServiceTypes are the service types of the external accounts created by signing in through
+// an SSO provider. Identity providers use the subject of these accounts as the externalId of
+// the user, which lets SCIM take over the users that signed in before being provisioned.
+var ssoServiceTypes = []string{"openidconnect", "saml"}
+
+// accountData is the data stored in the SCIM external account of a user.
+type accountData struct {
+	ExternalID string `json:"externalId,omitempty"`
+}
+
+// userResource is the SCIM representation of a user. Its id is the user's database ID and its
+// userName is the Sourcegraph username.
+type userResource struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id,omitempty"`
+	ExternalID  string   `json:"externalId,omitempty"`
+	UserName    string   `json:"userName"`
+	Name        *name    `json:"name,omitempty"`
+	DisplayName string   `json:"displayName,omitempty"`
+	Emails      []email  `json:"emails,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+	Meta        *meta    `json:"meta,omitempty"`
+}
+
+type name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// displayName returns the display name of the user, falling back to the name components when
+// no displayName is given.
+func (u *userResource) displayName() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name == nil {
+		return ""
+	}
+	if u.Name.Formatted != "" {
+		return u.Name.Formatted
+	}
+	return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+}
+
+// primaryEmail returns the email marked as primary, or the first email if none is.
+func (u *userResource) primaryEmail() string {
+	for _, e := range u.Emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+	return ""
+}
+
+// setAttribute sets the attribute at path, as given by a PATCH operation. Attributes that
+// Sourcegraph does not store, such as phone numbers or enterprise extension attributes, are
+// ignored, since identity providers send them regardless of what the service provider
+// supports.
+func (u *userResource) setAttribute(path string, value json.RawMessage) error {
+	var err error
+	switch lower := strings.ToLower(path); {
+	case lower == "active":
+		var active bool
+		active, err = unmarshalBool(value)
+		u.Active = &active
+	case lower == "username":
+		err = json.Unmarshal(value, &u.UserName)
+	case lower == "displayname":
+		err = json.Unmarshal(value, &u.DisplayName)
+	case lower == "externalid":
+		err = json.Unmarshal(value, &u.ExternalID)
+	case lower == "name":
+		err = json.Unmarshal(value, &u.Name)
+	case strings.HasPrefix(lower, "name."):
+		if u.Name == nil {
+			u.Name = &name{}
+		}
+		switch strings.TrimPrefix(lower, "name.") {
+		case "formatted":
+			err = json.Unmarshal(value, &u.Name.Formatted)
+		case "givenname":
+			err = json.Unmarshal(value, &u.Name.GivenName)
+		case "familyname":
+			err = json.Unmarshal(value, &u.Name.FamilyName)
+		}
+	case lower == "emails":
+		err = json.Unmarshal(value, &u.Emails)
+	case strings.HasPrefix(lower, "emails[") && strings.HasSuffix(lower, "].value"):
+		// Azure AD addresses the work email as `emails[type eq "work"].value`.
+		var v string
+		if err = json.Unmarshal(value, &v); err == nil {
+			u.Emails = []email{{Value: v, Primary: true}}
+		}
+	}
+	if err != nil {
+		return badRequest(errTypeInvalidValue, "invalid value for %q: %s", path, err)
+	}
+	return nil
+}
+
+// unmarshalBool unmarshals a JSON boolean. Azure AD sends booleans as the strings "True" and
+// "False" in PATCH operations.
+func unmarshalBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(strings.ToLower(s))
+}
+
+// scimAccount returns the SCIM external account of the user, or nil if the user was not
+// provisioned through SCIM.
+func (h *handler) scimAccount(ctx context.Context, userID int32) (*extsvc.Account, error) {
+	accts, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		UserID:      userID,
+		ServiceType: accountServiceType,
+		ServiceID:   accountServiceID,
+		LimitOffset: &database.LimitOffset{Limit: 1},
+	})
+	if err != nil || len(accts) == 0 {
+		return nil, err
+	}
+	return accts[0], nil
+}
+
+// managedUser returns the user with the given ID and its SCIM external account.
+//
+// 🚨 SECURITY: Users that were not provisioned through SCIM are reported as not found, so that
+// the holder of the SCIM token cannot read or take over accounts created by other means.
+func (h *handler) managedUser(ctx context.Context, id int32) (*types.User, *extsvc.Account, error) {
+	user, err := h.db.Users().GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	acct, err := h.scimAccount(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if acct == nil {
+		return nil, nil, &scimError{status: http.StatusNotFound, detail: "user not provisioned through SCIM"}
+	}
+	return user, acct, nil
+}
+
+// checkModifiable returns an error if the user must not be changed through SCIM.
+//
+// 🚨 SECURITY: Site admins are never changed through SCIM, since changing their email or
+// username would let the holder of the SCIM token take over the site.
+func checkModifiable(user *types.User) error {
+	if user.SiteAdmin {
+		return &scimError{status: http.StatusForbidden, detail: "site admins cannot be changed through SCIM"}
+	}
+	return nil
+}
+
+func (h *handler) toUserResource(ctx context.Context, user *types.User, acct *extsvc.Account, active bool) (*userResource, error) {
+	emails, err := h.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: user.ID})
+	if err != nil {
+		return nil, err
+	}
+	var data accountData
+	if acct.Data != nil {
+		if err := encryption.DecryptJSON(ctx, acct.Data, &data); err != nil {
+			return nil, err
+		}
+	}
+	res := &userResource{
+		Schemas:     []string{schemaUser},
+		ID:          strconv.Itoa(int(user.ID)),
+		ExternalID:  data.ExternalID,
+		UserName:    user.Username,
+		DisplayName: user.DisplayName,
+		Active:      &active,
+		Meta: &meta{
+			ResourceType: "User",
+			Created:      user.CreatedAt.UTC().Format(time.RFC3339),
+			LastModified: user.UpdatedAt.UTC().Format(time.RFC3339),
+			Location:     resourceLocation("Users", user.ID),
+		},
+	}
+	if user.DisplayName != "" {
+		res.Name = &name{Formatted: user.DisplayName}
+	}
+	for _, e := range emails {
+		res.Emails = append(res.Emails, email{Value: e.Email, Type: "work", Primary: e.Primary})
+	}
+	return res, nil
+}
+
+func (h *handler) listUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	p := parsePagination(r)
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return err
+	}
+
+	// Only users provisioned through SCIM are listed, so that identity providers never match
+	// and take over accounts created by other means.
+	var accts []*extsvc.Account
+	var total int
+	if f != nil {
+		acct, err := h.findAccount(ctx, f)
+		if err != nil && !errcode.IsNotFound(err) {
+			return err
+		}
+		if acct != nil {
+			total = 1
+			if p.startIndex == 1 && p.count > 0 {
+				accts = append(accts, acct)
+			}
+		}
+	} else {
+		opts := database.ExternalAccountsListOptions{ServiceType: accountServiceType, ServiceID: accountServiceID}
+		if total, err = h.db.UserExternalAccounts().Count(ctx, opts); err != nil {
+			return err
+		}
+		opts.LimitOffset = p.limitOffset()
+		if accts, err = h.db.UserExternalAccounts().List(ctx, opts); err != nil {
+			return err
+		}
+	}
+
+	resources := make([]any, 0, len(accts))
+	for _, acct := range accts {
+		user, err := h.db.Users().GetByID(ctx, acct.UserID)
+		if err != nil {
+			return err
+		}
+		res, err := h.toUserResource(ctx, user, acct, true)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, res)
+	}
+	return writeList(w, p, total, resources)
+}
+
+// findAccount returns the SCIM external account of the user matching f, or nil if no user
+// provisioned through SCIM matches.
+func (h *handler) findAccount(ctx context.Context, f *filter) (*extsvc.Account, error) {
+	var user *types.User
+	var err error
+	switch f.attribute {
+	case "username":
+		username, err := auth.NormalizeUsername(f.value)
+		if err != nil {
+			return nil, nil
+		}
+		user, err = h.db.Users().GetByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+	case "emails.value", "emails":
+		user, err = h.db.Users().GetByVerifiedEmail(ctx, f.value)
+		if err != nil {
+			return nil, err
+		}
+	case "externalid":
+		return h.findAccountByExternalID(ctx, f.value)
+	default:
+		return nil, badRequest(errTypeInvalidFilter, "filtering users by %q is not supported", f.attribute)
+	}
+	return h.scimAccount(ctx, user.ID)
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (h *handler) findAccountByExternalID(ctx context.Context, externalID string) (*extsvc.Account, error) {
+	accts, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		ServiceType:   accountServiceType,
+		ServiceID:     accountServiceID,
+		AccountIDLike: likeEscaper.Replace(externalID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The account ID is the username for users provisioned without an externalId, so the
+	// externalId stored in the account data must be checked as well.
+	for _, acct := range accts {
+		var data accountData
+		if acct.Data == nil {
+			continue
+		}
+		if err := encryption.DecryptJSON(ctx, acct.Data, &data); err != nil {
+			return nil, err
+		}
+		if data.ExternalID == externalID {
+			return acct, nil
+		}
+	}
+	return nil, nil
+}
+
+func (h *handler) getUser(w http.ResponseWriter, r *http.Request) error {
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	user, acct, err := h.managedUser(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	res, err := h.toUserResource(r.Context(), user, acct, true)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) createUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	var req userResource
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	if req.UserName == "" {
+		return badRequest(errTypeInvalidValue, "userName is required")
+	}
+	if req.Active != nil && !*req.Active {
+		return badRequest(errTypeInvalidValue, "cannot provision an inactive user")
+	}
+	username, err := auth.NormalizeUsername(req.UserName)
+	if err != nil {
+		return badRequest(errTypeInvalidValue, "%s", err)
+	}
+
+	// The account ID identifies the user in the identity provider. The username is used for
+	// identity providers that do not send an externalId.
+	spec := extsvc.AccountSpec{
+		ServiceType: accountServiceType,
+		ServiceID:   accountServiceID,
+		AccountID:   req.ExternalID,
+	}
+	if spec.AccountID == "" {
+		spec.AccountID = username
+	}
+	data, err := marshalAccountData(req.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	primaryEmail := req.primaryEmail()
+	existing, err := h.existingUser(ctx, req.ExternalID, primaryEmail)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return h.takeOverUser(w, r, existing, spec, data, &req)
+	}
+
+	userID, err := h.db.UserExternalAccounts().CreateUserAndSave(ctx, database.NewUser{
+		Username:    username,
+		DisplayName: req.displayName(),
+		Email:       primaryEmail,
+		// 🚨 SECURITY: The identity provider is trusted to have verified the email address.
+		EmailIsVerified: primaryEmail != "",
+	}, spec, data)
+	if err != nil {
+		return err
+	}
+
+	user, acct, err := h.managedUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	res, err := h.toUserResource(ctx, user, acct, true)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Location", res.Meta.Location)
+	return writeJSON(w, http.StatusCreated, res)
+}
+
+// existingUser returns the user created by other means that the identity provider provisions,
+// or nil if there is none. The user is matched by the subject of its SSO external account,
+// then by its verified email address.
+func (h *handler) existingUser(ctx context.Context, externalID, address string) (*types.User, error) {
+	if externalID != "" {
+		var userID int32
+		for _, serviceType := range ssoServiceTypes {
+			accts, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+				ServiceType:   serviceType,
+				AccountIDLike: likeEscaper.Replace(externalID),
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, acct := range accts {
+				if userID != 0 && userID != acct.UserID {
+					return nil, &scimError{
+						status:   http.StatusConflict,
+						scimType: errTypeUniqueness,
+						detail:   "externalId matches the SSO accounts of several users",
+					}
+				}
+				userID = acct.UserID
+			}
+		}
+		if userID != 0 {
+			return h.db.Users().GetByID(ctx, userID)
+		}
+	}
+	if address != "" {
+		user, err := h.db.Users().GetByVerifiedEmail(ctx, address)
+		if err == nil || !errcode.IsNotFound(err) {
+			return user, err
+		}
+	}
+	return nil, nil
+}
+
+// takeOverUser provisions an existing user by linking it to a SCIM external account, so that
+// it is managed through SCIM like the users SCIM creates. The display name and primary email
+// of req are applied to the user, but its username is kept: it may have been chosen by the
+// user, and the normalized userName may be taken by another user.
+//
+// 🚨 SECURITY: Site admins are never taken over, and users that are already provisioned
+// through SCIM are reported as duplicates.
+func (h *handler) takeOverUser(w http.ResponseWriter, r *http.Request, user *types.User, spec extsvc.AccountSpec, data extsvc.AccountData, req *userResource) error {
+	ctx := r.Context()
+	if err := checkModifiable(user); err != nil {
+		return err
+	}
+	acct, err := h.scimAccount(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if acct != nil {
+		return &scimError{
+			status:   http.StatusConflict,
+			scimType: errTypeUniqueness,
+			detail:   "user " + user.Username + " is already provisioned through SCIM",
+		}
+	}
+	// Another user provisioned with the same identity would make the association fail.
+	taken, err := h.db.UserExternalAccounts().List(ctx, database.ExternalAccountsListOptions{
+		ServiceType:   accountServiceType,
+		ServiceID:     accountServiceID,
+		AccountIDLike: likeEscaper.Replace(spec.AccountID),
+		LimitOffset:   &database.LimitOffset{Limit: 1},
+	})
+	if err != nil {
+		return err
+	}
+	if len(taken) > 0 {
+		return &scimError{
+			status:   http.StatusConflict,
+			scimType: errTypeUniqueness,
+			detail:   "another user is already provisioned with this identity",
+		}
+	}
+	if err := h.db.UserExternalAccounts().AssociateUserAndSave(ctx, user.ID, spec, data); err != nil {
+		return err
+	}
+	attrs := *req
+	attrs.UserName = ""
+	if err := h.applyUserAttributes(ctx, user, &attrs); err != nil {
+		return err
+	}
+
+	user, acct, err = h.managedUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	res, err := h.toUserResource(ctx, user, acct, true)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Location", res.Meta.Location)
+	return writeJSON(w, http.StatusCreated, res)
+}
+
+func marshalAccountData(externalID string) (extsvc.AccountData, error) {
+	raw, err := json.Marshal(accountData{ExternalID: externalID})
+	if err != nil {
+		return extsvc.AccountData{}, err
+	}
+	return extsvc.AccountData{Data: extsvc.NewUnencryptedData(raw)}, nil
+}
+
+func (h *handler) replaceUser(w http.ResponseWriter, r *http.Request) error {
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	user, acct, err := h.managedUser(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	var req userResource
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	return h.updateUser(w, r, user, acct, &req)
+}
+
+func (h *handler) patchUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	user, acct, err := h.managedUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	var req patchRequest
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+
+	res, err := h.toUserResource(ctx, user, acct, true)
+	if err != nil {
+		return err
+	}
+	for _, op := range req.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			values, err := op.values()
+			if err != nil {
+				return err
+			}
+			for path, value := range values {
+				if err := res.setAttribute(path, value); err != nil {
+					return err
+				}
+			}
+		default:
+			return badRequest(errTypeInvalidValue, "unsupported patch operation %q on users", op.Op)
+		}
+	}
+	return h.updateUser(w, r, user, acct, res)
+}
+
+// updateUser applies the attributes of req to user and writes the updated resource. Setting
+// active to false deactivates the user by deleting it, which signs it out everywhere.
+// Deactivated users cannot be reactivated through SCIM, since they no longer exist.
+func (h *handler) updateUser(w http.ResponseWriter, r *http.Request, user *types.User, acct *extsvc.Account, req *userResource) error {
+	ctx := r.Context()
+	if err := checkModifiable(user); err != nil {
+		return err
+	}
+	if req.Active != nil && !*req.Active {
+		res, err := h.toUserResource(ctx, user, acct, false)
+		if err != nil {
+			return err
+		}
+		if err := h.db.Users().Delete(ctx, user.ID); err != nil {
+			return err
+		}
+		return writeJSON(w, http.StatusOK, res)
+	}
+
+	if err := h.applyUserAttributes(ctx, user, req); err != nil {
+		return err
+	}
+	if req.ExternalID != "" {
+		data, err := marshalAccountData(req.ExternalID)
+		if err != nil {
+			return err
+		}
+		if err := h.db.UserExternalAccounts().AssociateUserAndSave(ctx, user.ID, acct.AccountSpec, data); err != nil {
+			return err
+		}
+	}
+
+	user, acct, err := h.managedUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	res, err := h.toUserResource(ctx, user, acct, true)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, res)
+}
+
+// applyUserAttributes updates the username, display name and primary email of user to the ones
+// given in req. Attributes that are not given or are unchanged are left alone.
+func (h *handler) applyUserAttributes(ctx context.Context, user *types.User, req *userResource) error {
+	var update database.UserUpdate
+	if req.UserName != "" {
+		username, err := auth.NormalizeUsername(req.UserName)
+		if err != nil {
+			return badRequest(errTypeInvalidValue, "%s", err)
+		}
+		if username != user.Username {
+			update.Username = username
+		}
+	}
+	if displayName := req.displayName(); displayName != "" && displayName != user.DisplayName {
+		update.DisplayName = &displayName
+	}
+	if update.Username != "" || update.DisplayName != nil {
+		if err := h.db.Users().Update(ctx, user.ID, update); err != nil {
+			return err
+		}
+	}
+	if primaryEmail := req.primaryEmail(); primaryEmail != "" {
+		return h.setPrimaryEmail(ctx, user.ID, primaryEmail)
+	}
+	return nil
+}
+
+// setPrimaryEmail makes address the verified primary email of the user, adding it if the user
+// does not have it yet. Other email addresses of the user are kept.
+func (h *handler) setPrimaryEmail(ctx context.Context, userID int32, address string) error {
+	emails, err := h.db.UserEmails().ListByUser(ctx, database.UserEmailsListOptions{UserID: userID})
+	if err != nil {
+		return err
+	}
+	var existing *database.UserEmail
+	for _, e := range emails {
+		if strings.EqualFold(e.Email, address) {
+			existing = e
+			break
+		}
+	}
+	if existing == nil {
+		if err := h.db.UserEmails().Add(ctx, userID, address, nil); err != nil {
+			return err
+		}
+		existing = &database.UserEmail{Email: address}
+	}
+	if existing.VerifiedAt == nil {
+		// 🚨 SECURITY: The identity provider is trusted to have verified the email address.
+		if err := h.db.UserEmails().SetVerified(ctx, userID, existing.Email, true); err != nil {
+			return err
+		}
+	}
+	if !existing.Primary {
+		return h.db.UserEmails().SetPrimaryEmail(ctx, userID, existing.Email)
+	}
+	return nil
+}
+
+func (h *handler) deleteUser(w http.ResponseWriter, r *http.Request) error {
+	id, err := resourceID(r)
+	if err != nil {
+		return err
+	}
+	user, _, err := h.managedUser(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if err := checkModifiable(user); err != nil {
+		return err
+	}
+	if err := h.db.Users().Delete(r.Context(), id); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -16,7 +16,8 @@ func (s *Server) handleListGitolite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	defaultGitolite.listRepos(r.Context(), r.URL.Query().Get("gitolite"), w)
+	q := r.URL.Query()
+	defaultGitolite.listRepos(r.Context(), q.Get("gitolite"), q.Get("user"), w)
 }
 
 var defaultGitolite = gitoliteFetcher{client: gitoliteClient{}}
@@ -27,17 +28,24 @@ type gitoliteFetcher struct {
 
 type gitoliteRepoLister interface {
 	ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error)
+	ListUserRepos(ctx context.Context, host, user string) ([]*gitolite.Repo, error)
 }
 
-// listRepos lists the repos of a Gitolite server reachable at the address in gitoliteHost
-func (g gitoliteFetcher) listRepos(ctx context.Context, gitoliteHost string, w http.ResponseWriter) {
+// listRepos lists the repos of a Gitolite server reachable at the address in gitoliteHost. If
+// user is not empty, only the repos the Gitolite user has read access to are listed.
+func (g gitoliteFetcher) listRepos(ctx context.Context, gitoliteHost, user string, w http.ResponseWriter) {
 	var (
 		repos = []*gitolite.Repo{}
 		err   error
 	)
 
 	if gitoliteHost != "" || !security.ValidateRemoteAddr(gitoliteHost) {
-		if repos, err = g.client.ListRepos(ctx, gitoliteHost); err != nil {
+		if user != "" {
+			repos, err = g.client.ListUserRepos(ctx, gitoliteHost, user)
+		} else {
+			repos, err = g.client.ListRepos(ctx, gitoliteHost)
+		}
+		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -54,3 +62,7 @@ type gitoliteClient struct{}
 func (c gitoliteClient) ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error) {
 	return gitolite.NewClient(host).ListRepos(ctx)
 }
+
+func (c gitoliteClient) ListUserRepos(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+	return gitolite.NewClient(host).ListUserRepos(ctx, user)
+}
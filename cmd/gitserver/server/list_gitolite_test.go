@@ -20,8 +20,10 @@ import (
 func Test_Gitolite_listRepos(t *testing.T) {
 	tests := []struct {
 		listRepos       map[string][]*gitolite.Repo
+		listUserRepos   map[string][]*gitolite.Repo
 		configs         []*schema.GitoliteConnection
 		gitoliteHost    string
+		user            string
 		expResponseCode int
 		expResponseBody string
 	}{
@@ -41,6 +43,34 @@ func Test_Gitolite_listRepos(t *testing.T) {
 			expResponseCode: 200,
 			expResponseBody: `[{"Name":"myrepo","URL":"git@gitolite.example.com:myrepo"}]` + "\n",
 		},
+		{
+			listRepos: map[string][]*gitolite.Repo{
+				"git@gitolite.example.com": {
+					{Name: "myrepo", URL: "git@gitolite.example.com:myrepo"},
+					{Name: "secret", URL: "git@gitolite.example.com:secret"},
+				},
+			},
+			listUserRepos: map[string][]*gitolite.Repo{
+				"alice": {
+					{Name: "myrepo", URL: "git@gitolite.example.com:myrepo"},
+				},
+			},
+			configs: []*schema.GitoliteConnection{
+				{
+					Host:   "git@gitolite.example.com",
+					Prefix: "gitolite.example.com/",
+					Authorization: &schema.GitoliteAuthorization{
+						IdentityProvider: schema.GitoliteIdentityProvider{
+							Username: &schema.GitoliteUsernameIdentity{Type: "username"},
+						},
+					},
+				},
+			},
+			gitoliteHost:    "git@gitolite.example.com",
+			user:            "alice",
+			expResponseCode: 200,
+			expResponseBody: `[{"Name":"myrepo","URL":"git@gitolite.example.com:myrepo"}]` + "\n",
+		},
 	}
 
 	for _, test := range tests {
@@ -50,10 +80,13 @@ func Test_Gitolite_listRepos(t *testing.T) {
 					ListRepos_: func(ctx context.Context, host string) ([]*gitolite.Repo, error) {
 						return test.listRepos[host], nil
 					},
+					ListUserRepos_: func(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+						return test.listUserRepos[user], nil
+					},
 				},
 			}
 			w := httptest.NewRecorder()
-			g.listRepos(context.Background(), test.gitoliteHost, w)
+			g.listRepos(context.Background(), test.gitoliteHost, test.user, w)
 			resp := w.Result()
 			respBody, err := io.ReadAll(resp.Body)
 			if err != nil {
@@ -124,9 +157,14 @@ func TestCheckSSRFHeader(t *testing.T) {
 }
 
 type stubGitoliteClient struct {
-	ListRepos_ func(ctx context.Context, host string) ([]*gitolite.Repo, error)
+	ListRepos_     func(ctx context.Context, host string) ([]*gitolite.Repo, error)
+	ListUserRepos_ func(ctx context.Context, host, user string) ([]*gitolite.Repo, error)
 }
 
 func (c stubGitoliteClient) ListRepos(ctx context.Context, host string) ([]*gitolite.Repo, error) {
 	return c.ListRepos_(ctx, host)
 }
+
+func (c stubGitoliteClient) ListUserRepos(ctx context.Context, host, user string) ([]*gitolite.Repo, error) {
+	return c.ListUserRepos_(ctx, host, user)
+}
@@ -37,6 +37,9 @@ type Server struct {
 	Scheduler             interface {
 		UpdateOnce(id api.RepoID, name api.RepoName)
 		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
+		// OnRepoChanged registers a function called each time an update
+		// fetches new commits for a repository.
+		OnRepoChanged(f repos.RepoChangedFunc)
 	}
 	ChangesetSyncRegistry batches.ChangesetSyncRegistry
 	RateLimitSyncer       interface {
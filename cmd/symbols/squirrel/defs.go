@@ -0,0 +1,149 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// findLocalDef finds the definition of the identifier at the given node by walking up the scopes
+// described by the language's localsQuery. The root of the file is treated as the outermost scope.
+// Returns nil if the identifier is not defined in any enclosing scope.
+func (squirrel *SquirrelService) findLocalDef(node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	query := node.LangSpec.localsQuery
+	if query == "" {
+		return nil, nil
+	}
+
+	root := swapNode(node, getRoot(node.Node))
+
+	// Collect scopes
+	scopes := map[NodeId]map[SymbolName]*sitter.Node{
+		nodeId(root.Node): {},
+	}
+	err = forEachCapture(query, root, func(nameToNode map[string]Node) {
+		if scope, ok := nameToNode["scope"]; ok {
+			scopes[nodeId(scope.Node)] = map[SymbolName]*sitter.Node{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect defs into their nearest scope, keeping the first def of each name
+	err = forEachCapture(query, root, func(nameToNode map[string]Node) {
+		for captureName, def := range nameToNode {
+			if !strings.HasPrefix(captureName, "definition") {
+				continue
+			}
+			for cur := def.Node; cur != nil; cur = cur.Parent() {
+				scope, ok := scopes[nodeId(cur)]
+				if !ok {
+					continue
+				}
+				// The name of a scope (e.g. function f() { ... }) is visible in the enclosing scope
+				if name := cur.ChildByFieldName("name"); name != nil && nodeId(name) == nodeId(def.Node) {
+					continue
+				}
+				symbolName := SymbolName(def.Content(def.Contents))
+				if _, ok := scope[symbolName]; !ok {
+					scope[symbolName] = def.Node
+				}
+				break
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk up the tree looking for the nearest scope that defines the identifier
+	ident := SymbolName(node.Content(node.Contents))
+	for cur := node.Node; cur != nil; cur = cur.Parent() {
+		scope, ok := scopes[nodeId(cur)]
+		if !ok {
+			continue
+		}
+		if def, ok := scope[ident]; ok {
+			return swapNodePtr(node, def), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findTopLevelDef finds the top-level symbol named ident in the file that contains the given node
+// using the language's topLevelSymbolsQuery.
+func findTopLevelDef(node Node, ident string) (*Node, error) {
+	query := node.LangSpec.topLevelSymbolsQuery
+	if query == "" {
+		return nil, nil
+	}
+
+	captures, err := allCaptures(query, swapNode(node, getRoot(node.Node)))
+	if err != nil {
+		return nil, err
+	}
+	for _, capture := range captures {
+		if capture.Content(capture.Contents) == ident {
+			return swapNodePtr(node, capture.Node), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findFileInAncestors looks for a file with the given name in the directory of the given path and
+// all of its parent directories, returning the path to the first one that exists.
+func (squirrel *SquirrelService) findFileInAncestors(ctx context.Context, repoCommitPath types.RepoCommitPath, name string) (string, []byte, bool) {
+	for dir := filepath.Dir(repoCommitPath.Path); ; dir = filepath.Dir(dir) {
+		path := filepath.Join(dir, name)
+		contents, err := squirrel.readFile(ctx, types.RepoCommitPath{
+			Repo:   repoCommitPath.Repo,
+			Commit: repoCommitPath.Commit,
+			Path:   path,
+		})
+		if err == nil {
+			return path, contents, true
+		}
+		if dir == "." || dir == "/" {
+			return "", nil, false
+		}
+	}
+}
+
+// dirNode returns a node that refers to a directory rather than a location in a file.
+func dirNode(other Node, dir string) *Node {
+	return &Node{
+		RepoCommitPath: types.RepoCommitPath{
+			Repo:   other.RepoCommitPath.Repo,
+			Commit: other.RepoCommitPath.Commit,
+			Path:   dir,
+		},
+		Node:     nil,
+		Contents: other.Contents,
+		LangSpec: other.LangSpec,
+	}
+}
+
+// filesInDirPattern returns an include pattern for symbol searches that matches files directly inside
+// the given directory with one of the given extensions.
+func filesInDirPattern(dir string, exts ...string) string {
+	quotedExts := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		quotedExts = append(quotedExts, regexp.QuoteMeta(ext))
+	}
+	prefix := "^"
+	if dir != "." && dir != "" {
+		prefix = fmt.Sprintf("^%s/", regexp.QuoteMeta(dir))
+	}
+	return fmt.Sprintf("%s[^/]+\\.(%s)$", prefix, strings.Join(quotedExts, "|"))
+}
@@ -0,0 +1,102 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+)
+
+var cppSourceExtensions = []string{"c", "cc", "cpp", "cxx", "c++", "h", "hh", "hpp", "hxx", "h++"}
+
+// getDefCpp finds definitions in C and C++ files.
+func (squirrel *SquirrelService) getDefCpp(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "namespace_identifier":
+		ident := node.Content(node.Contents)
+
+		// Check the enclosing scopes first
+		if node.Type() == "identifier" {
+			found, err := squirrel.findLocalDef(node)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+
+		return squirrel.getDefInIncludesCpp(ctx, node, ident)
+
+	case "field_identifier":
+		// Member accesses (x.f) require type information, but methods and fields declared in a
+		// class body can be found through the symbols table.
+		parent := node.Parent()
+		if parent != nil && parent.Type() == "field_expression" {
+			squirrel.breadcrumb(node, "getDefCpp: field access on a value is not supported")
+			return nil, nil
+		}
+		return squirrel.getDefInIncludesCpp(ctx, node, node.Content(node.Contents))
+
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInIncludesCpp finds the top-level symbol named ident in the current file, the files it
+// includes, and finally the other files in the same directory.
+func (squirrel *SquirrelService) getDefInIncludesCpp(ctx context.Context, node Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(ident), lazyNodeStringer(&ret))()
+
+	// Check the current file first (faster) before running symbol searches (slower)
+	found, err := findTopLevelDef(node, ident)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// Check the included files. #include "foo/bar.h" is resolved relative to the current directory
+	// and then to any include directory in the repository, e.g. include/foo/bar.h
+	includes, err := allCaptures(`(preproc_include path: (string_literal) @path)`, swapNode(node, getRoot(node.Node)))
+	if err != nil {
+		return nil, err
+	}
+	for _, include := range includes {
+		includePath := strings.Trim(include.Content(include.Contents), `"`)
+		relative := filepath.Join(filepath.Dir(node.RepoCommitPath.Path), includePath)
+
+		for _, pattern := range []string{
+			fmt.Sprintf("^%s$", regexp.QuoteMeta(relative)),
+			fmt.Sprintf("(^|/)%s$", regexp.QuoteMeta(filepath.Clean(includePath))),
+		} {
+			found, err := squirrel.symbolSearchOne(
+				ctx,
+				node.RepoCommitPath.Repo,
+				node.RepoCommitPath.Commit,
+				[]string{pattern},
+				ident,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+	}
+
+	// Check the rest of the directory, e.g. the foo.c next to foo.h
+	return squirrel.symbolSearchOne(
+		ctx,
+		node.RepoCommitPath.Repo,
+		node.RepoCommitPath.Commit,
+		[]string{filesInDirPattern(filepath.Dir(node.RepoCommitPath.Path), cppSourceExtensions...)},
+		ident,
+	)
+}
@@ -0,0 +1,212 @@
+package squirrel
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (squirrel *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier":
+		ident := node.Content(node.Contents)
+
+		// Check the enclosing scopes first
+		found, err := squirrel.findLocalDef(node)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Check if it's the name of an imported package
+		imports, err := squirrel.getImportsGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if dir, ok := imports[ident]; ok {
+			if dir == "" {
+				squirrel.breadcrumb(node, "getDefGo: package is not in this repository")
+				return nil, nil
+			}
+			return dirNode(node, dir), nil
+		}
+
+		// Check the rest of the package
+		return squirrel.getDefInPackageGo(ctx, node, filepath.Dir(node.RepoCommitPath.Path), ident)
+
+	case "field_identifier":
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "selector_expression" {
+			// Method declarations, struct fields, and the like
+			return squirrel.getDefInPackageGo(ctx, node, filepath.Dir(node.RepoCommitPath.Path), node.Content(node.Contents))
+		}
+
+		// Check for a reference to a symbol in an imported package, e.g. fmt.Println
+		operand := parent.ChildByFieldName("operand")
+		if operand == nil || operand.Type() != "identifier" {
+			return nil, nil
+		}
+		operandNode := swapNode(node, operand)
+		local, err := squirrel.findLocalDef(operandNode)
+		if err != nil {
+			return nil, err
+		}
+		if local != nil {
+			// The operand is a variable, and we don't know its type
+			squirrel.breadcrumb(node, "getDefGo: field access on a local variable is not supported")
+			return nil, nil
+		}
+		imports, err := squirrel.getImportsGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		dir, ok := imports[operand.Content(node.Contents)]
+		if !ok {
+			return squirrel.getDefInPackageGo(ctx, node, filepath.Dir(node.RepoCommitPath.Path), node.Content(node.Contents))
+		}
+		if dir == "" {
+			squirrel.breadcrumb(node, "getDefGo: package is not in this repository")
+			return nil, nil
+		}
+		return squirrel.getDefInPackageGo(ctx, node, dir, node.Content(node.Contents))
+
+	case "package_identifier":
+		imports, err := squirrel.getImportsGo(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		dir, ok := imports[node.Content(node.Contents)]
+		if !ok || dir == "" {
+			return nil, nil
+		}
+		return dirNode(node, dir), nil
+
+	case "interpreted_string_literal":
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "import_spec" {
+			return nil, nil
+		}
+		importPath, err := strconv.Unquote(node.Content(node.Contents))
+		if err != nil {
+			return nil, nil
+		}
+		dir := squirrel.getModuleGo(ctx, node.RepoCommitPath).importPathToDir(importPath)
+		if dir == "" {
+			return nil, nil
+		}
+		return dirNode(node, dir), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInPackageGo finds the top-level symbol named ident in the package in the given directory.
+func (squirrel *SquirrelService) getDefInPackageGo(ctx context.Context, node Node, dir string, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(node, &Tuple{String(dir), String(ident)}, lazyNodeStringer(&ret))()
+
+	// Check the current file first (faster) before running a symbol search (slower)
+	if dir == filepath.Dir(node.RepoCommitPath.Path) {
+		found, err := findTopLevelDef(node, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return squirrel.symbolSearchOne(
+		ctx,
+		node.RepoCommitPath.Repo,
+		node.RepoCommitPath.Commit,
+		[]string{filesInDirPattern(dir, "go")},
+		ident,
+	)
+}
+
+// getImportsGo returns a mapping from package name to the directory of the imported package for
+// the file containing the node. The directory is empty for packages outside of the repository.
+func (squirrel *SquirrelService) getImportsGo(ctx context.Context, node Node) (map[string]string, error) {
+	module := squirrel.getModuleGo(ctx, node.RepoCommitPath)
+
+	query := `
+		(import_spec name: (package_identifier)? @name path: (interpreted_string_literal) @path)
+	`
+
+	imports := map[string]string{}
+	err := forEachCapture(query, swapNode(node, getRoot(node.Node)), func(nameToNode map[string]Node) {
+		pathNode, ok := nameToNode["path"]
+		if !ok {
+			return
+		}
+		importPath, err := strconv.Unquote(pathNode.Content(pathNode.Contents))
+		if err != nil {
+			return
+		}
+
+		// Without an explicit name, assume the package name matches the last path component
+		name := path.Base(importPath)
+		if nameNode, ok := nameToNode["name"]; ok {
+			name = nameNode.Content(nameNode.Contents)
+		}
+
+		imports[name] = module.importPathToDir(importPath)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// moduleGo is the Go module that contains a file.
+type moduleGo struct {
+	// dir is the directory containing the go.mod file.
+	dir string
+	// path is the module path, e.g. github.com/foo/bar
+	path string
+}
+
+var goModuleRegex = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+
+// getModuleGo finds the Go module that contains the given file by looking for the nearest go.mod
+// file. Returns nil if the file is not in a module.
+func (squirrel *SquirrelService) getModuleGo(ctx context.Context, repoCommitPath types.RepoCommitPath) *moduleGo {
+	goModPath, contents, ok := squirrel.findFileInAncestors(ctx, repoCommitPath, "go.mod")
+	if !ok {
+		return nil
+	}
+
+	matches := goModuleRegex.FindSubmatch(contents)
+	if matches == nil {
+		return nil
+	}
+
+	return &moduleGo{dir: filepath.Dir(goModPath), path: string(matches[1])}
+}
+
+// importPathToDir converts an import path to a directory in the repository. Returns an empty string
+// if the package is not in the module.
+func (module *moduleGo) importPathToDir(importPath string) string {
+	if module == nil {
+		return ""
+	}
+	if importPath == module.path {
+		return module.dir
+	}
+	if !strings.HasPrefix(importPath, module.path+"/") {
+		return ""
+	}
+	return filepath.Join(module.dir, strings.TrimPrefix(importPath, module.path+"/"))
+}
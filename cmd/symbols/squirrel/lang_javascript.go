@@ -0,0 +1,184 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+)
+
+// getDefJavaScript finds definitions in JavaScript and TypeScript files.
+func (squirrel *SquirrelService) getDefJavaScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "shorthand_property_identifier":
+		ident := node.Content(node.Contents)
+
+		// Check for the original name in an aliased import, e.g. import { foo as bar } from './baz'
+		if parent := node.Parent(); parent != nil && parent.Type() == "import_specifier" {
+			alias := parent.ChildByFieldName("alias")
+			if alias != nil && nodeId(alias) != nodeId(node.Node) {
+				for cur := parent; cur != nil; cur = cur.Parent() {
+					if cur.Type() != "import_statement" {
+						continue
+					}
+					for _, child := range children(cur) {
+						if child.Type() == "string" {
+							return squirrel.getDefInModuleJavaScript(ctx, node, strings.Trim(child.Content(node.Contents), "\"'`"), ident)
+						}
+					}
+					return nil, nil
+				}
+			}
+		}
+
+		// Check the enclosing scopes
+		found, err := squirrel.findLocalDef(node)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Check the top-level declarations in this file
+		found, err = findTopLevelDef(node, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Check the imports
+		imports, err := getImportsJavaScript(node)
+		if err != nil {
+			return nil, err
+		}
+		imp, ok := imports[ident]
+		if !ok {
+			return nil, nil
+		}
+		if imp.name != "" {
+			found, err := squirrel.getDefInModuleJavaScript(ctx, node, imp.source, imp.name)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+
+		// Fall back to the local name bound by the import
+		return imp.binding, nil
+
+	case "property_identifier":
+		// Check for a reference to a symbol in a namespace import, e.g. ns.foo
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "member_expression" {
+			return nil, nil
+		}
+		object := parent.ChildByFieldName("object")
+		if object == nil || object.Type() != "identifier" {
+			return nil, nil
+		}
+		imports, err := getImportsJavaScript(node)
+		if err != nil {
+			return nil, err
+		}
+		imp, ok := imports[object.Content(node.Contents)]
+		if !ok || !imp.namespace {
+			squirrel.breadcrumb(node, "getDefJavaScript: property access on a value is not supported")
+			return nil, nil
+		}
+		return squirrel.getDefInModuleJavaScript(ctx, node, imp.source, node.Content(node.Contents))
+
+	default:
+		return nil, nil
+	}
+}
+
+// importJavaScript is a name bound by an import statement.
+type importJavaScript struct {
+	// source is the module specifier, e.g. ./foo
+	source string
+	// name is the name of the imported symbol in the source module, or empty for default imports.
+	name string
+	// namespace is true for `import * as ns from ...`
+	namespace bool
+	// binding is the identifier that the import binds in the current file.
+	binding *Node
+}
+
+// getImportsJavaScript returns a mapping from local name to import for the file containing the node.
+func getImportsJavaScript(node Node) (map[string]importJavaScript, error) {
+	query := `
+		(import_statement (import_clause (identifier) @default) source: (string) @source)
+		(import_statement (import_clause (namespace_import (identifier) @namespace)) source: (string) @source)
+		(import_statement (import_clause (named_imports (import_specifier name: (identifier) @name alias: (identifier)? @alias))) source: (string) @source)
+	`
+
+	imports := map[string]importJavaScript{}
+	err := forEachCapture(query, swapNode(node, getRoot(node.Node)), func(nameToNode map[string]Node) {
+		sourceNode, ok := nameToNode["source"]
+		if !ok {
+			return
+		}
+		source := strings.Trim(sourceNode.Content(sourceNode.Contents), "\"'`")
+
+		if binding, ok := nameToNode["default"]; ok {
+			imports[binding.Content(binding.Contents)] = importJavaScript{source: source, binding: swapNodePtr(node, binding.Node)}
+		}
+		if binding, ok := nameToNode["namespace"]; ok {
+			imports[binding.Content(binding.Contents)] = importJavaScript{source: source, namespace: true, binding: swapNodePtr(node, binding.Node)}
+		}
+		if name, ok := nameToNode["name"]; ok {
+			binding := name
+			if alias, ok := nameToNode["alias"]; ok {
+				binding = alias
+			}
+			imports[binding.Content(binding.Contents)] = importJavaScript{
+				source:  source,
+				name:    name.Content(name.Contents),
+				binding: swapNodePtr(node, binding.Node),
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+var javaScriptModuleExtensions = []string{"ts", "tsx", "d.ts", "js", "jsx", "mjs", "cjs"}
+
+// getDefInModuleJavaScript finds the top-level symbol named ident in the module imported from the
+// given source. Only relative imports are resolved because packages don't live in the repository.
+func (squirrel *SquirrelService) getDefInModuleJavaScript(ctx context.Context, node Node, source string, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(node, &Tuple{String(source), String(ident)}, lazyNodeStringer(&ret))()
+
+	if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		squirrel.breadcrumb(node, "getDefInModuleJavaScript: only relative imports are supported")
+		return nil, nil
+	}
+
+	// ./foo can refer to ./foo.ts, ./foo/index.ts, ./foo.js, and the like
+	modulePath := filepath.Join(filepath.Dir(node.RepoCommitPath.Path), source)
+	exts := []string{}
+	for _, ext := range javaScriptModuleExtensions {
+		exts = append(exts, regexp.QuoteMeta(ext))
+	}
+	pattern := fmt.Sprintf("^%s((/index)?\\.(%s))?$", regexp.QuoteMeta(modulePath), strings.Join(exts, "|"))
+
+	return squirrel.symbolSearchOne(
+		ctx,
+		node.RepoCommitPath.Repo,
+		node.RepoCommitPath.Commit,
+		[]string{pattern},
+		ident,
+	)
+}
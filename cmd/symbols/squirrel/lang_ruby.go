@@ -0,0 +1,129 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+)
+
+func (squirrel *SquirrelService) getDefRuby(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		ident := node.Content(node.Contents)
+
+		// Method calls on a receiver (x.foo) require type information
+		parent := node.Parent()
+		if parent != nil && parent.Type() == "call" {
+			receiver := parent.ChildByFieldName("receiver")
+			method := parent.ChildByFieldName("method")
+			if receiver != nil && method != nil && nodeId(method) == nodeId(node.Node) && receiver.Type() != "self" {
+				squirrel.breadcrumb(node, "getDefRuby: method calls on a receiver are not supported")
+				return nil, nil
+			}
+		}
+
+		// Check the enclosing scopes first
+		found, err := squirrel.findLocalDef(node)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Then methods defined in this file and the files it requires
+		return squirrel.getDefInRequiresRuby(ctx, node, ident)
+
+	case "constant":
+		ident := node.Content(node.Contents)
+
+		found, err := squirrel.getDefInRequiresRuby(ctx, node, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Constants are global and are often autoloaded (e.g. in Rails) without a require, so
+		// fall back to searching the whole repository.
+		return squirrel.symbolSearchOne(
+			ctx,
+			node.RepoCommitPath.Repo,
+			node.RepoCommitPath.Commit,
+			[]string{`\.(rb|rake)$`},
+			ident,
+		)
+
+	default:
+		return nil, nil
+	}
+}
+
+// getDefInRequiresRuby finds the top-level symbol named ident in the current file and the files
+// loaded with require_relative.
+func (squirrel *SquirrelService) getDefInRequiresRuby(ctx context.Context, node Node, ident string) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(ident), lazyNodeStringer(&ret))()
+
+	// Check the current file first (faster) before running symbol searches (slower)
+	found, err := findTopLevelDef(node, ident)
+	if err != nil {
+		return nil, err
+	}
+	if found != nil {
+		return found, nil
+	}
+
+	// Collect the paths loaded with require_relative
+	query := `
+		(call
+			method: (identifier) @method
+			arguments: (argument_list . (string (string_content) @path))
+		)
+	`
+	paths := []string{}
+	seen := map[string]struct{}{}
+	err = forEachCapture(query, swapNode(node, getRoot(node.Node)), func(nameToNode map[string]Node) {
+		method, ok := nameToNode["method"]
+		if !ok || method.Content(method.Contents) != "require_relative" {
+			return
+		}
+		path, ok := nameToNode["path"]
+		if !ok {
+			return
+		}
+		if _, ok := seen[path.Content(path.Contents)]; ok {
+			return
+		}
+		seen[path.Content(path.Contents)] = struct{}{}
+		paths = append(paths, path.Content(path.Contents))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		path = strings.TrimSuffix(filepath.Join(filepath.Dir(node.RepoCommitPath.Path), path), ".rb")
+
+		found, err := squirrel.symbolSearchOne(
+			ctx,
+			node.RepoCommitPath.Repo,
+			node.RepoCommitPath.Commit,
+			[]string{fmt.Sprintf(`^%s\.rb$`, regexp.QuoteMeta(path))},
+			ident,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
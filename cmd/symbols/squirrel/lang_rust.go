@@ -0,0 +1,249 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+func (squirrel *SquirrelService) getDefRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier":
+		ident := node.Content(node.Contents)
+
+		// Check for a path like foo::bar::Baz
+		parent := node.Parent()
+		if parent != nil && (parent.Type() == "scoped_identifier" || parent.Type() == "scoped_type_identifier") {
+			name := parent.ChildByFieldName("name")
+			if name != nil && nodeId(name) == nodeId(node.Node) {
+				// Paths inside of use declarations are absolute (or relative to the current module)
+				if isInUseDeclarationRust(parent) {
+					return squirrel.getDefInPathRust(ctx, node, getPathRust(swapNode(node, parent)))
+				}
+
+				uses, err := getUsesRust(node)
+				if err != nil {
+					return nil, err
+				}
+				return squirrel.getDefInPathRust(ctx, node, expandPathRust(getPathRust(swapNode(node, parent)), uses))
+			}
+		}
+
+		// Check the enclosing scopes
+		found, err := squirrel.findLocalDef(node)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Check the items in this file
+		found, err = findTopLevelDef(node, ident)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+
+		// Check the use declarations
+		uses, err := getUsesRust(node)
+		if err != nil {
+			return nil, err
+		}
+		if path, ok := uses.bindings[ident]; ok {
+			return squirrel.getDefInPathRust(ctx, node, path)
+		}
+
+		// Check the modules imported with a wildcard
+		for _, module := range uses.wildcards {
+			found, err := squirrel.getDefInPathRust(ctx, node, append(append([]string{}, module...), ident))
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+		}
+
+		return nil, nil
+
+	case "field_identifier":
+		squirrel.breadcrumb(node, "getDefRust: field access requires type information")
+		return nil, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// usesRust are the names brought into scope by the use declarations in a file.
+type usesRust struct {
+	// bindings maps a name to its full path, e.g. Baz -> [crate foo bar Baz]
+	bindings map[string][]string
+	// wildcards are the paths of modules imported with use foo::*;
+	wildcards [][]string
+}
+
+// getUsesRust collects the use declarations in the file containing the node.
+func getUsesRust(node Node) (usesRust, error) {
+	uses := usesRust{bindings: map[string][]string{}}
+
+	declarations, err := allCaptures(`(use_declaration argument: (_) @argument)`, swapNode(node, getRoot(node.Node)))
+	if err != nil {
+		return uses, err
+	}
+
+	var visit func(prefix []string, tree *sitter.Node)
+	visit = func(prefix []string, tree *sitter.Node) {
+		switch tree.Type() {
+		case "identifier", "crate", "super", "self", "scoped_identifier":
+			path := append(append([]string{}, prefix...), getPathRust(swapNode(node, tree))...)
+			name := path[len(path)-1]
+			if name == "self" && len(path) > 1 {
+				// use foo::{self} brings foo into scope
+				path = path[:len(path)-1]
+				name = path[len(path)-1]
+			}
+			uses.bindings[name] = path
+		case "use_as_clause":
+			path := tree.ChildByFieldName("path")
+			alias := tree.ChildByFieldName("alias")
+			if path == nil || alias == nil {
+				return
+			}
+			uses.bindings[alias.Content(node.Contents)] = append(append([]string{}, prefix...), getPathRust(swapNode(node, path))...)
+		case "use_wildcard":
+			for _, child := range children(tree) {
+				uses.wildcards = append(uses.wildcards, append(append([]string{}, prefix...), getPathRust(swapNode(node, child))...))
+			}
+		case "scoped_use_list":
+			newPrefix := prefix
+			if path := tree.ChildByFieldName("path"); path != nil {
+				newPrefix = append(append([]string{}, prefix...), getPathRust(swapNode(node, path))...)
+			}
+			if list := tree.ChildByFieldName("list"); list != nil {
+				visit(newPrefix, list)
+			}
+		case "use_list":
+			for _, child := range children(tree) {
+				visit(prefix, child)
+			}
+		}
+	}
+	for _, declaration := range declarations {
+		visit(nil, declaration.Node)
+	}
+
+	return uses, nil
+}
+
+// getPathRust returns the components of a path like crate::foo::Bar.
+func getPathRust(node Node) []string {
+	switch node.Type() {
+	case "scoped_identifier", "scoped_type_identifier":
+		path := []string{}
+		if prefix := node.ChildByFieldName("path"); prefix != nil {
+			path = append(path, getPathRust(swapNode(node, prefix))...)
+		}
+		if name := node.ChildByFieldName("name"); name != nil {
+			path = append(path, name.Content(node.Contents))
+		}
+		return path
+	default:
+		return []string{node.Content(node.Contents)}
+	}
+}
+
+// expandPathRust replaces the first component of a path with its full path if it was brought into
+// scope by a use declaration, e.g. bar::Baz -> crate::foo::bar::Baz given use crate::foo::bar;
+func expandPathRust(path []string, uses usesRust) []string {
+	if len(path) == 0 {
+		return path
+	}
+	full, ok := uses.bindings[path[0]]
+	if !ok {
+		return path
+	}
+	return append(append([]string{}, full...), path[1:]...)
+}
+
+func isInUseDeclarationRust(node *sitter.Node) bool {
+	for cur := node; cur != nil; cur = cur.Parent() {
+		if cur.Type() == "use_declaration" {
+			return true
+		}
+	}
+	return false
+}
+
+// getDefInPathRust finds the item at the given path, e.g. [crate foo Bar] finds Bar in the module
+// crate::foo.
+func (squirrel *SquirrelService) getDefInPathRust(ctx context.Context, node Node, path []string) (ret *Node, err error) {
+	defer squirrel.onCall(node, String(strings.Join(path, "::")), lazyNodeStringer(&ret))()
+
+	if len(path) < 2 {
+		return nil, nil
+	}
+
+	moduleDir, ok := squirrel.getModuleDirRust(ctx, node, path[:len(path)-1])
+	if !ok {
+		squirrel.breadcrumb(node, "getDefInPathRust: module is not in this crate")
+		return nil, nil
+	}
+
+	// The items of the module foo live in foo.rs or foo/mod.rs, and the crate root lives in
+	// src/lib.rs or src/main.rs
+	quoted := regexp.QuoteMeta(moduleDir)
+	pattern := fmt.Sprintf(`^(%s\.rs|%s/(mod|lib|main)\.rs)$`, quoted, quoted)
+
+	return squirrel.symbolSearchOne(
+		ctx,
+		node.RepoCommitPath.Repo,
+		node.RepoCommitPath.Commit,
+		[]string{pattern},
+		path[len(path)-1],
+	)
+}
+
+// getModuleDirRust returns the directory that contains the submodules of the module at the given
+// path, e.g. [crate foo bar] -> src/foo/bar.
+func (squirrel *SquirrelService) getModuleDirRust(ctx context.Context, node Node, path []string) (string, bool) {
+	// The directory for the submodules of the current file
+	current := filepath.Dir(node.RepoCommitPath.Path)
+	switch filepath.Base(node.RepoCommitPath.Path) {
+	case "mod.rs", "lib.rs", "main.rs":
+	default:
+		current = filepath.Join(current, strings.TrimSuffix(filepath.Base(node.RepoCommitPath.Path), ".rs"))
+	}
+
+	dir := current
+	for i, component := range path {
+		switch component {
+		case "crate":
+			if i != 0 {
+				return "", false
+			}
+			cargoToml, _, ok := squirrel.findFileInAncestors(ctx, node.RepoCommitPath, "Cargo.toml")
+			if !ok {
+				return "", false
+			}
+			dir = filepath.Join(filepath.Dir(cargoToml), "src")
+		case "self":
+			// Stay in the same module
+		case "super":
+			dir = filepath.Dir(dir)
+		default:
+			dir = filepath.Join(dir, component)
+		}
+	}
+
+	return dir, true
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/smacker/go-tree-sitter/javascript"
 	"github.com/smacker/go-tree-sitter/python"
 	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
 	"github.com/smacker/go-tree-sitter/typescript/tsx"
 )
 
@@ -124,6 +125,13 @@ var langToLangSpec = map[string]LangSpec{
 (short_var_declaration left: (expression_list (identifier) @definition)) ; x, y := ...
 (range_clause          left: (expression_list (identifier) @definition)) ; for i := range ... { ... }
 (receive_statement     left: (expression_list (identifier) @definition)) ; case x := <-ch: ...
+`,
+		topLevelSymbolsQuery: `
+(source_file (function_declaration name: (identifier) @symbol))
+(source_file (method_declaration name: (field_identifier) @symbol))
+(source_file (type_declaration (type_spec name: (type_identifier) @symbol)))
+(source_file (var_declaration (var_spec name: (identifier) @symbol)))
+(source_file (const_declaration (const_spec name: (identifier) @symbol)))
 `,
 	},
 	"csharp": {
@@ -193,6 +201,18 @@ var langToLangSpec = map[string]LangSpec{
 (arrow_function parameter: (identifier) @definition)                                   ; x => ...
 (for_in_statement left: (identifier) @definition)                                      ; for (const x of xs) ...
 (catch_clause parameter: (identifier) @definition)                                     ; catch (e) ...
+`,
+		topLevelSymbolsQuery: `
+(program                                (function_declaration           name: (identifier) @symbol))
+(program (export_statement declaration: (function_declaration           name: (identifier) @symbol)))
+(program                                (generator_function_declaration name: (identifier) @symbol))
+(program (export_statement declaration: (generator_function_declaration name: (identifier) @symbol)))
+(program                                (class_declaration              name: (identifier) @symbol))
+(program (export_statement declaration: (class_declaration              name: (identifier) @symbol)))
+(program                                (lexical_declaration  (variable_declarator name: (identifier) @symbol)))
+(program (export_statement declaration: (lexical_declaration  (variable_declarator name: (identifier) @symbol))))
+(program                                (variable_declaration (variable_declarator name: (identifier) @symbol)))
+(program (export_statement declaration: (variable_declaration (variable_declarator name: (identifier) @symbol))))
 `,
 	},
 	"typescript": {
@@ -227,6 +247,24 @@ var langToLangSpec = map[string]LangSpec{
 (arrow_function parameter: (identifier) @definition)            ; x => ...
 (for_in_statement left: (identifier) @definition)               ; for (const x of xs) ...
 (catch_clause parameter: (identifier) @definition)              ; catch (e) ...
+`,
+		topLevelSymbolsQuery: `
+(program                                (function_declaration           name: (identifier) @symbol))
+(program (export_statement declaration: (function_declaration           name: (identifier) @symbol)))
+(program                                (generator_function_declaration name: (identifier) @symbol))
+(program (export_statement declaration: (generator_function_declaration name: (identifier) @symbol)))
+(program                                (class_declaration              name: (type_identifier) @symbol))
+(program (export_statement declaration: (class_declaration              name: (type_identifier) @symbol)))
+(program                                (lexical_declaration  (variable_declarator name: (identifier) @symbol)))
+(program (export_statement declaration: (lexical_declaration  (variable_declarator name: (identifier) @symbol))))
+(program                                (variable_declaration (variable_declarator name: (identifier) @symbol)))
+(program (export_statement declaration: (variable_declaration (variable_declarator name: (identifier) @symbol))))
+(program                                (interface_declaration          name: (type_identifier) @symbol))
+(program (export_statement declaration: (interface_declaration          name: (type_identifier) @symbol)))
+(program                                (type_alias_declaration         name: (type_identifier) @symbol))
+(program (export_statement declaration: (type_alias_declaration         name: (type_identifier) @symbol)))
+(program                                (enum_declaration               name: (identifier) @symbol))
+(program (export_statement declaration: (enum_declaration               name: (identifier) @symbol)))
 `,
 	},
 	"cpp": {
@@ -253,6 +291,25 @@ var langToLangSpec = map[string]LangSpec{
 (parameter_declaration          declarator: (pointer_declarator   (identifier) @definition)) ; [](int* x) { ... }
 (optional_parameter_declaration declarator: (identifier) @definition)                        ; [](auto x = 5) { ... }
 (for_range_loop declarator: (identifier) @definition)									     ; for (int x : xs) ...
+`,
+		topLevelSymbolsQuery: `
+(function_definition declarator: (function_declarator declarator: (identifier) @symbol))
+(function_definition declarator: (pointer_declarator declarator: (function_declarator declarator: (identifier) @symbol)))
+(function_definition declarator: (function_declarator declarator: (qualified_identifier name: (identifier) @symbol)))
+(translation_unit (declaration declarator: (function_declarator declarator: (identifier) @symbol)))
+(translation_unit (declaration declarator: (init_declarator declarator: (identifier) @symbol)))
+(translation_unit (declaration declarator: (identifier) @symbol))
+(field_declaration declarator: (function_declarator declarator: (field_identifier) @symbol))
+(class_specifier     name: (type_identifier) @symbol body: (field_declaration_list))
+(struct_specifier    name: (type_identifier) @symbol body: (field_declaration_list))
+(union_specifier     name: (type_identifier) @symbol body: (field_declaration_list))
+(enum_specifier      name: (type_identifier) @symbol body: (enumerator_list))
+(enumerator          name: (identifier) @symbol)
+(type_definition     declarator: (type_identifier) @symbol)
+(alias_declaration   name: (type_identifier) @symbol)
+(namespace_definition name: (identifier) @symbol)
+(preproc_def          name: (identifier) @symbol)
+(preproc_function_def name: (identifier) @symbol)
 `,
 	},
 	"ruby": {
@@ -277,6 +334,59 @@ var langToLangSpec = map[string]LangSpec{
 (assignment           left: (identifier) @definition)    ; x = ...
 (left_assignment_list (identifier) @definition)          ; x, y = ...
 (for                  pattern: (identifier) @definition) ; for i in 1..5 ...
+`,
+		topLevelSymbolsQuery: `
+(class            name: (constant) @symbol)
+(class            name: (scope_resolution name: (constant) @symbol))
+(module           name: (constant) @symbol)
+(module           name: (scope_resolution name: (constant) @symbol))
+(method           name: (_) @symbol)
+(singleton_method name: (_) @symbol)
+(assignment       left: (constant) @symbol)
+`,
+	},
+	"rust": {
+		name:     "rust",
+		language: rust.GetLanguage(),
+		commentStyle: CommentStyle{
+			nodeTypes:     []string{"line_comment", "block_comment"},
+			stripRegex:    regexp.MustCompile(`^//[/!]?|^\s*\*/?|^/\*[*!]?|\*/$`),
+			ignoreRegex:   javaStyleIgnoreRegex,
+			codeFenceName: "rust",
+			skipNodeTypes: []string{"attribute_item"},
+		},
+		localsQuery: `
+(block)                 @scope ; { ... }
+(function_item)         @scope ; fn f() { ... }
+(closure_expression)    @scope ; |x| ...
+(for_expression)        @scope ; for x in xs { ... }
+(if_let_expression)     @scope ; if let Some(x) = ... { ... }
+(while_let_expression)  @scope ; while let Some(x) = ... { ... }
+(match_arm)             @scope ; Some(x) => ...
+
+(parameter          pattern: (identifier) @definition)                      ; fn f(x: i32) { ... }
+(closure_parameters (identifier) @definition)                               ; |x| ...
+(let_declaration    pattern: (identifier) @definition)                      ; let x = ...;
+(for_expression     pattern: (identifier) @definition)                      ; for x in xs { ... }
+(tuple_pattern      (identifier) @definition)                               ; let (x, y) = ...;
+(reference_pattern  (identifier) @definition)                               ; let &x = ...;
+(tuple_struct_pattern type: (_) (identifier) @definition)                   ; Some(x) => ...
+(field_pattern      name: (shorthand_field_identifier) @definition)         ; let S { x } = ...;
+(field_pattern      pattern: (identifier) @definition)                      ; let S { x: y } = ...;
+`,
+		topLevelSymbolsQuery: `
+(source_file      (function_item name: (identifier) @symbol))
+(declaration_list (function_item name: (identifier) @symbol))
+(function_signature_item name: (identifier) @symbol)
+(struct_item      name: (type_identifier) @symbol)
+(enum_item        name: (type_identifier) @symbol)
+(union_item       name: (type_identifier) @symbol)
+(trait_item       name: (type_identifier) @symbol)
+(type_item        name: (type_identifier) @symbol)
+(const_item       name: (identifier) @symbol)
+(static_item      name: (identifier) @symbol)
+(mod_item         name: (identifier) @symbol)
+(macro_definition name: (identifier) @symbol)
 `,
 	},
 	"starlark": {
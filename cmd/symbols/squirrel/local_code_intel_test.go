@@ -334,6 +334,43 @@ def f(p1, p2 = 3, *p3, **p4)
 		puts e
 	end
 end
+`}, {
+		path: "test.rs",
+		contents: `
+//   v f.p def
+//   v f.p ref
+fn f(p: i32) {
+	//  v f.x def
+	//  v f.x ref
+	//      v f.p ref
+	let x = p;
+
+	//   v f.a def
+	//   v f.a ref
+	//      v f.b def
+	//      v f.b ref
+	//            v f.x ref
+	let (a, b) = (x, 1);
+
+	//  v f.i def
+	//  v f.i ref
+	//          v f.a ref
+	for i in 0..a {
+		//    v f.i ref
+		print(i);
+	}
+
+	//         v f.b ref
+	//                   v f.y def
+	//                   v f.y ref
+	//                         v f.y ref
+	match Some(b) { Some(y) => y, None => 0 };
+
+	//       v f.q def
+	//       v f.q ref
+	//          v f.q ref
+	let c = |q| q; // < "c" f.c def < "c" f.c ref
+}
 `},
 	}
 
@@ -146,13 +146,17 @@ func (squirrel *SquirrelService) getDef(ctx context.Context, node Node) (*Node,
 		return squirrel.getDefStarlark(ctx, node)
 	case "python":
 		return squirrel.getDefPython(ctx, node)
-	// case "go":
+	case "go":
+		return squirrel.getDefGo(ctx, node)
+	case "javascript", "typescript":
+		return squirrel.getDefJavaScript(ctx, node)
+	case "cpp":
+		return squirrel.getDefCpp(ctx, node)
+	case "rust":
+		return squirrel.getDefRust(ctx, node)
+	case "ruby":
+		return squirrel.getDefRuby(ctx, node)
 	// case "csharp":
-	// case "python":
-	// case "javascript":
-	// case "typescript":
-	// case "cpp":
-	// case "ruby":
 	default:
 		// Language not implemented yet
 		return nil, nil
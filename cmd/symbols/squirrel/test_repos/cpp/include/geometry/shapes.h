@@ -0,0 +1,12 @@
+#pragma once
+
+// The area of a circle.
+//
+//     vvvvvvvvvv cpp.circleArea def
+double circleArea(double radius);
+
+//    vvvvv cpp.Point def
+class Point {
+public:
+    int x;
+};
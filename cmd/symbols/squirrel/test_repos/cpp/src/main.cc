@@ -0,0 +1,20 @@
+#include <cstdio>
+#include "geometry/shapes.h"
+
+//  vvvvvv cpp.helper def
+int helper(int n) { return n; }
+
+int main() {
+    Point p; // < "Point" cpp.Point ref
+    //   vvvvv cpp.total def
+    auto total = 0;
+    //       v cpp.main.i def
+    for (int i = 0; i < 10; i++) {
+        //       vvvvvvvvvv cpp.circleArea ref
+        //                  v cpp.main.i ref
+        total += circleArea(i);
+    }
+    //     vvvvvv cpp.helper ref
+    //            vvvvv cpp.total ref
+    return helper(total);
+}
@@ -0,0 +1,7 @@
+#include "geometry/shapes.h"
+
+//                       vvvvvv cpp.circleArea.radius def
+double circleArea(double radius) {
+    //     vvvvvv cpp.circleArea.radius ref
+    return radius * radius * 3.14;
+}
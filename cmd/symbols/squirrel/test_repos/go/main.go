@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	//  v sub path
+	"example.com/squirrel/sub"
+	//          v sub path
+	renamed "example.com/squirrel/sub"
+)
+
+type Greeting string // < "Greeting" go.Greeting def
+
+func answer() int { return 42 } // < "answer" go.answer def
+
+func main() {
+	number := answer() // < "number" go.main.number def < "answer" go.answer ref
+
+	greeting := Greeting("hi") // < "Greeting" go.Greeting ref
+
+	//          vvv sub path
+	//              vvvvvv go.sub.Helper ref
+	//                     vvvvvv go.main.number ref
+	//                                      vvvvvv go.sub.Helper ref
+	fmt.Println(sub.Helper(number), renamed.Helper(number))
+
+	_ = fromOther + len(greeting) // < "fromOther" go.fromOther ref
+}
+
+func f(number int) int { // < "number" go.f.number def
+	//     vvvvvv go.f.number ref
+	return number
+}
@@ -0,0 +1,3 @@
+package main
+
+var fromOther = 5 // < "fromOther" go.fromOther def
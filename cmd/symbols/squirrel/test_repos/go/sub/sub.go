@@ -0,0 +1,6 @@
+package sub
+
+// Helper doubles the input.
+func Helper(x int) int { // < "Helper" go.sub.Helper def
+	return x * 2
+}
@@ -0,0 +1,19 @@
+//       vvvvv js.Queue ref
+import { Queue } from './queue.js'
+
+//       vvvvvv js.create def
+function create() {
+    //       vvvvv js.inner def
+    function inner() {
+        //         vvvvv js.Queue ref
+        return new Queue()
+    }
+    //     vvvvv js.inner ref
+    return inner()
+}
+
+//    vvvvv js.queue def
+const queue = create()
+//       vvvvvv js.create ref
+//               vvvvv js.queue ref
+export { create, queue }
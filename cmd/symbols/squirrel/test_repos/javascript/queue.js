@@ -0,0 +1,6 @@
+// A first-in first-out queue.
+//
+//           vvvvv js.Queue def
+export class Queue {
+    push(item) {}
+}
@@ -0,0 +1,16 @@
+# Greets people.
+#
+#     vvvvvvv rb.Greeter def
+class Greeter
+  #   vvvv rb.Greeter.name def
+  def name
+    @name
+  end
+end
+
+#   vvvvvvvvvvv rb.format_name def
+#               vvvv rb.format_name.name def
+def format_name(name)
+  #   vvvv rb.format_name.name ref
+  "<#{name}>"
+end
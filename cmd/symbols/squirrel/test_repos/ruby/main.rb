@@ -0,0 +1,14 @@
+require_relative 'lib/greeter'
+
+#   vvvvv rb.shout def
+#         vvvv rb.shout.text def
+def shout(text)
+  #    vvvv rb.shout.text ref
+  puts text.upcase
+end
+
+#         vvvvvvv rb.Greeter ref
+greeter = Greeter.new('world') # < "greeter" rb.greeter def
+#                 vvvvvvv rb.greeter ref
+#     vvvvvvvvvvv rb.format_name ref
+shout(format_name(greeter.name)) # < "shout" rb.shout ref
@@ -0,0 +1,25 @@
+//  vvvvvv rs.shapes def
+mod shapes;
+
+//         vvvvvv rs.shapes ref
+//                         vvvvvv rs.Circle ref
+use crate::shapes::circle::Circle;
+//                 vvvvvv rs.circle ref
+use crate::shapes::circle;
+
+/// Computes the total area.
+///
+//     vvvvvvvvvv rs.total_area def
+pub fn total_area(radii: &[f64]) -> f64 {
+    //      vvvvv rs.total_area.total def
+    let mut total = 0.0;
+    //  v rs.total_area.r def
+    for r in radii {
+        //      vvvvvv rs.Circle ref
+        //                        v rs.total_area.r ref
+        let c = Circle { radius: *r };
+        //                          vvvv rs.circle.unit ref
+        total += c.area() + circle::unit().area();
+    }
+    total // < "total" rs.total_area.total ref
+}
@@ -0,0 +1,15 @@
+//         vvvvvv rs.Circle def
+pub struct Circle {
+    pub radius: f64,
+}
+
+//     vvvv rs.circle.unit def
+pub fn unit() -> Circle {
+    Circle { radius: 1.0 } // < "Circle" rs.Circle ref
+}
+
+impl Circle {
+    pub fn area(&self) -> f64 {
+        self.radius * self.radius * 3.14
+    }
+}
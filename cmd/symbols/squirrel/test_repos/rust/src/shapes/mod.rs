@@ -0,0 +1,2 @@
+//      vvvvvv rs.circle def
+pub mod circle;
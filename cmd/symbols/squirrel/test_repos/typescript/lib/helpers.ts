@@ -0,0 +1,7 @@
+//              vvvvvv ts.lib.helpers.helper def
+export function helper(...args: unknown[]): void {}
+
+//           vvvvvvv ts.lib.Counter def
+export class Counter {
+    private count = 0
+}
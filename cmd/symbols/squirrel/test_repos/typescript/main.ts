@@ -0,0 +1,26 @@
+//       vvvvvv ts.lib.helpers.helper ref
+//               vvvvvvv ts.lib.Counter ref
+import { helper, Counter as Renamed } from './lib/helpers'
+//          vvvvv ts.utils def
+import * as utils from './utils'
+
+// A greeting.
+//
+//              vvvvvvvv ts.Greeting def
+//                       vvvv ts.Greeting.name def
+export function Greeting(name: string): string {
+    //    vvvvvvvv ts.Greeting.greeting def
+    const greeting = 'Hello'
+    //     vvvvvvvv ts.Greeting.greeting ref
+    //                vvvv ts.Greeting.name ref
+    return greeting + name
+}
+
+//            vvvvvvv ts.lib.Counter ref
+const c = new Renamed()
+
+// vvvvvv ts.lib.helpers.helper ref
+//        vvvvvvvv ts.Greeting ref
+//                  vvvvv ts.utils ref
+//                        vvvvvvv ts.utils.VERSION ref
+   helper(Greeting, utils.VERSION, c)
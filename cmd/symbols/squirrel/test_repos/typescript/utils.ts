@@ -0,0 +1,2 @@
+//           vvvvvvv ts.utils.VERSION def
+export const VERSION = '1.0'
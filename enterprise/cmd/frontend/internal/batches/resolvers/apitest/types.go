@@ -384,6 +384,36 @@ type BulkOperation struct {
 	FinishedAt string
 }
 
+type BatchChangeTemplate struct {
+	ID                  string
+	Name                string
+	Template            string
+	InputSchema         string
+	InputNames          []string
+	Namespace           UserOrg
+	ViewerCanAdminister bool
+}
+
+type BatchChangeSLA struct {
+	TargetDate       string
+	ExpectedProgress float64
+	BehindSchedule   bool
+	NotifyEmail      bool
+	SlackWebhookURL  *string
+	WebhookURL       *string
+	AlertedAt        *string
+}
+
+type BatchChangeSnapshot struct {
+	Date                 string
+	Total                int32
+	Open                 int32
+	Draft                int32
+	Merged               int32
+	Closed               int32
+	ReviewLatencySeconds *int32
+}
+
 type ChangesetJobError struct {
 	Changeset *Changeset
 	Error     *string
@@ -347,3 +347,52 @@ func (r *batchChangeResolver) BatchSpecs(
 
 	return &batchSpecConnectionResolver{store: r.store, opts: opts}, nil
 }
+
+func (r *batchChangeResolver) Snapshots(ctx context.Context, args *graphqlbackend.ListBatchChangeSnapshotsArgs) ([]graphqlbackend.BatchChangeSnapshotResolver, error) {
+	opts := store.ListBatchChangeSnapshotsOpts{BatchChangeID: r.batchChange.ID}
+	if args.From != nil {
+		opts.From = args.From.Time
+	}
+
+	snapshots, err := r.store.ListBatchChangeSnapshots(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeSnapshotResolver, 0, len(snapshots))
+	for _, s := range snapshots {
+		resolvers = append(resolvers, &batchChangeSnapshotResolver{snapshot: s})
+	}
+	return resolvers, nil
+}
+
+func (r *batchChangeResolver) SLA(ctx context.Context) (graphqlbackend.BatchChangeSLAResolver, error) {
+	sla, err := r.store.GetBatchChangeSLA(ctx, r.batchChange.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchChangeSLAResolver{store: r.store, batchChange: r.batchChange, sla: sla}, nil
+}
+
+func (r *batchChangeResolver) Rollout(ctx context.Context) (graphqlbackend.BatchChangeRolloutResolver, error) {
+	batchSpec, err := r.computeBatchSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if batchSpec.Spec == nil || batchSpec.Spec.Rollout == nil {
+		return nil, nil
+	}
+
+	cs, _, err := r.store.ListChangesets(ctx, store.ListChangesetsOpts{OwnedByBatchChangeID: r.batchChange.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeRolloutResolver{
+		rollout: batchSpec.Spec.Rollout,
+		status:  state.CalcRolloutStatus(batchSpec.Spec.Rollout, cs),
+	}, nil
+}
@@ -0,0 +1,26 @@
+package resolvers
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+var _ graphqlbackend.BatchChangeRolloutResolver = &batchChangeRolloutResolver{}
+
+type batchChangeRolloutResolver struct {
+	rollout *batcheslib.Rollout
+	status  *state.RolloutStatus
+}
+
+func (r *batchChangeRolloutResolver) Stage() int32 { return int32(r.status.Stage) }
+
+func (r *batchChangeRolloutResolver) StageCount() int32 {
+	// The final stage that releases the remaining changesets is implicit.
+	return int32(len(r.rollout.Stages) + 1)
+}
+
+func (r *batchChangeRolloutResolver) ReleasedCount() int32  { return int32(r.status.Released) }
+func (r *batchChangeRolloutResolver) SucceededCount() int32 { return int32(r.status.Succeeded) }
+func (r *batchChangeRolloutResolver) FailedCount() int32    { return int32(r.status.Failed) }
+func (r *batchChangeRolloutResolver) Halted() bool          { return r.status.Halted }
@@ -0,0 +1,93 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+var _ graphqlbackend.BatchChangeSLAResolver = &batchChangeSLAResolver{}
+
+type batchChangeSLAResolver struct {
+	store       *store.Store
+	batchChange *btypes.BatchChange
+	sla         *btypes.BatchChangeSLA
+}
+
+func (r *batchChangeSLAResolver) TargetDate() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.sla.TargetDate}
+}
+
+func (r *batchChangeSLAResolver) ExpectedProgress() float64 {
+	return r.sla.ExpectedProgress(r.store.Clock()())
+}
+
+func (r *batchChangeSLAResolver) BehindSchedule(ctx context.Context) (bool, error) {
+	now := r.store.Clock()()
+	// Only the snapshot of the current day is recent enough to tell.
+	snapshots, err := r.store.ListBatchChangeSnapshots(ctx, store.ListBatchChangeSnapshotsOpts{
+		BatchChangeID: r.batchChange.ID,
+		From:          now,
+	})
+	if err != nil || len(snapshots) == 0 {
+		return false, err
+	}
+	return r.sla.BehindSchedule(now, snapshots[len(snapshots)-1]), nil
+}
+
+func (r *batchChangeSLAResolver) NotifyEmail() bool {
+	return r.sla.NotifyEmail
+}
+
+func (r *batchChangeSLAResolver) SlackWebhookURL(ctx context.Context) (*string, error) {
+	return r.secret(ctx, r.sla.SlackWebhookURL)
+}
+
+func (r *batchChangeSLAResolver) WebhookURL(ctx context.Context) (*string, error) {
+	return r.secret(ctx, r.sla.WebhookURL)
+}
+
+// secret returns the given value only to the users who can set the SLA,
+// because webhook URLs usually embed the credentials needed to post to them.
+func (r *batchChangeSLAResolver) secret(ctx context.Context, value string) (*string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	// 🚨 SECURITY: Only the creator of the batch change and site admins can
+	// see the webhook URLs.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.store.DatabaseDB(), r.batchChange.CreatorID); err != nil {
+		return nil, nil
+	}
+	return &value, nil
+}
+
+func (r *batchChangeSLAResolver) AlertedAt() *graphqlbackend.DateTime {
+	if r.sla.AlertedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.sla.AlertedAt}
+}
+
+func (r *batchChangeSLAResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.sla.CreatorID == 0 {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.sla.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchChangeSLAResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.sla.CreatedAt}
+}
+
+func (r *batchChangeSLAResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.sla.UpdatedAt}
+}
@@ -0,0 +1,139 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers/apitest"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestBatchChangeSLA(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	userID := bt.CreateTestUser(t, db, false).ID
+	otherUserID := bt.CreateTestUser(t, db, false).ID
+	actorCtx := actor.WithActor(ctx, actor.FromUser(userID))
+	otherActorCtx := actor.WithActor(ctx, actor.FromUser(otherUserID))
+
+	bstore := store.New(db, &observation.TestContext, nil)
+
+	batchChange := bt.CreateBatchChange(t, ctx, bstore, "sla", userID, 0)
+	batchChangeAPIID := string(marshalBatchChangeID(batchChange.ID))
+
+	r := &Resolver{store: bstore}
+	s, err := newSchema(db, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetDate := batchChange.CreatedAt.Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+	input := map[string]any{
+		"batchChange":     batchChangeAPIID,
+		"targetDate":      targetDate.Format(time.RFC3339),
+		"notifyEmail":     true,
+		"slackWebhookURL": "https://hooks.slack.com/services/secret",
+	}
+
+	t.Run("set without access", func(t *testing.T) {
+		var response struct{ SetBatchChangeSLA apitest.BatchChangeSLA }
+		errs := apitest.Exec(otherActorCtx, t, s, input, &response, mutationSetBatchChangeSLA)
+		assert.NotEmpty(t, errs)
+	})
+
+	var set struct{ SetBatchChangeSLA apitest.BatchChangeSLA }
+	apitest.MustExec(actorCtx, t, s, input, &set, mutationSetBatchChangeSLA)
+	assert.Equal(t, targetDate.Format(time.RFC3339), set.SetBatchChangeSLA.TargetDate)
+	assert.True(t, set.SetBatchChangeSLA.NotifyEmail)
+	require.NotNil(t, set.SetBatchChangeSLA.SlackWebhookURL)
+	assert.Nil(t, set.SetBatchChangeSLA.WebhookURL)
+
+	require.NoError(t, bstore.UpsertBatchChangeSnapshot(ctx, &btypes.BatchChangeSnapshot{
+		BatchChangeID: batchChange.ID,
+		Total:         4,
+		Merged:        1,
+		Open:          3,
+		ReviewLatency: time.Hour,
+	}))
+
+	var query struct {
+		Node struct {
+			SLA       *apitest.BatchChangeSLA
+			Snapshots []apitest.BatchChangeSnapshot
+		}
+	}
+
+	t.Run("query", func(t *testing.T) {
+		apitest.MustExec(actorCtx, t, s, map[string]any{"batchChange": batchChangeAPIID}, &query, queryBatchChangeSLA)
+		require.NotNil(t, query.Node.SLA)
+		assert.NotNil(t, query.Node.SLA.SlackWebhookURL)
+		require.Len(t, query.Node.Snapshots, 1)
+		assert.Equal(t, int32(4), query.Node.Snapshots[0].Total)
+		require.NotNil(t, query.Node.Snapshots[0].ReviewLatencySeconds)
+		assert.Equal(t, int32(3600), *query.Node.Snapshots[0].ReviewLatencySeconds)
+	})
+
+	t.Run("webhook URLs are hidden from other users", func(t *testing.T) {
+		apitest.MustExec(otherActorCtx, t, s, map[string]any{"batchChange": batchChangeAPIID}, &query, queryBatchChangeSLA)
+		require.NotNil(t, query.Node.SLA)
+		assert.Nil(t, query.Node.SLA.SlackWebhookURL)
+	})
+
+	var deleted struct{ DeleteBatchChangeSLA apitest.EmptyResponse }
+	apitest.MustExec(actorCtx, t, s, map[string]any{"batchChange": batchChangeAPIID}, &deleted, mutationDeleteBatchChangeSLA)
+
+	_, err = bstore.GetBatchChangeSLA(ctx, batchChange.ID)
+	assert.ErrorIs(t, err, store.ErrNoResults)
+}
+
+const fragmentBatchChangeSLA = `
+fragment sla on BatchChangeSLA {
+	targetDate
+	expectedProgress
+	behindSchedule
+	notifyEmail
+	slackWebhookURL
+	webhookURL
+	alertedAt
+}
+`
+
+const mutationSetBatchChangeSLA = fragmentBatchChangeSLA + `
+mutation($batchChange: ID!, $targetDate: DateTime!, $notifyEmail: Boolean, $slackWebhookURL: String) {
+	setBatchChangeSLA(batchChange: $batchChange, targetDate: $targetDate, notifyEmail: $notifyEmail, slackWebhookURL: $slackWebhookURL) { ...sla }
+}
+`
+
+const queryBatchChangeSLA = fragmentBatchChangeSLA + `
+query($batchChange: ID!) {
+	node(id: $batchChange) {
+		... on BatchChange {
+			sla { ...sla }
+			snapshots { date total open draft merged closed reviewLatencySeconds }
+		}
+	}
+}
+`
+
+const mutationDeleteBatchChangeSLA = `
+mutation($batchChange: ID!) {
+	deleteBatchChangeSLA(batchChange: $batchChange) { alwaysNil }
+}
+`
@@ -0,0 +1,31 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+var _ graphqlbackend.BatchChangeSnapshotResolver = &batchChangeSnapshotResolver{}
+
+type batchChangeSnapshotResolver struct {
+	snapshot *btypes.BatchChangeSnapshot
+}
+
+func (r *batchChangeSnapshotResolver) Date() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.snapshot.Date}
+}
+func (r *batchChangeSnapshotResolver) Total() int32  { return r.snapshot.Total }
+func (r *batchChangeSnapshotResolver) Open() int32   { return r.snapshot.Open }
+func (r *batchChangeSnapshotResolver) Draft() int32  { return r.snapshot.Draft }
+func (r *batchChangeSnapshotResolver) Merged() int32 { return r.snapshot.Merged }
+func (r *batchChangeSnapshotResolver) Closed() int32 { return r.snapshot.Closed }
+
+func (r *batchChangeSnapshotResolver) ReviewLatencySeconds() *int32 {
+	if r.snapshot.ReviewLatency == 0 {
+		return nil
+	}
+	seconds := int32(r.snapshot.ReviewLatency / time.Second)
+	return &seconds
+}
@@ -0,0 +1,101 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const batchChangeTemplateIDKind = "BatchChangeTemplate"
+
+func marshalBatchChangeTemplateID(id int64) graphql.ID {
+	return relay.MarshalID(batchChangeTemplateIDKind, id)
+}
+
+func unmarshalBatchChangeTemplateID(id graphql.ID) (templateID int64, err error) {
+	err = relay.UnmarshalSpec(id, &templateID)
+	return
+}
+
+var _ graphqlbackend.BatchChangeTemplateResolver = &batchChangeTemplateResolver{}
+
+type batchChangeTemplateResolver struct {
+	store    *store.Store
+	template *btypes.BatchChangeTemplate
+}
+
+func (r *batchChangeTemplateResolver) ID() graphql.ID {
+	return marshalBatchChangeTemplateID(r.template.ID)
+}
+
+func (r *batchChangeTemplateResolver) Name() string {
+	return r.template.Name
+}
+
+func (r *batchChangeTemplateResolver) Description() string {
+	return r.template.Description
+}
+
+func (r *batchChangeTemplateResolver) Namespace(ctx context.Context) (*graphqlbackend.NamespaceResolver, error) {
+	var (
+		err error
+		n   = &graphqlbackend.NamespaceResolver{}
+	)
+
+	if r.template.NamespaceUserID != 0 {
+		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.template.NamespaceUserID)
+	} else {
+		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.store.DatabaseDB(), r.template.NamespaceOrgID)
+	}
+
+	if errcode.IsNotFound(err) {
+		return nil, errors.New("namespace of batch change template has been deleted")
+	}
+
+	return n, err
+}
+
+func (r *batchChangeTemplateResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.template.CreatorID == 0 {
+		return nil, nil
+	}
+
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.template.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchChangeTemplateResolver) Template() string {
+	return r.template.RawTemplate
+}
+
+func (r *batchChangeTemplateResolver) InputSchema() string {
+	return r.template.InputSchema
+}
+
+func (r *batchChangeTemplateResolver) InputNames() []string {
+	return r.template.InputNames()
+}
+
+func (r *batchChangeTemplateResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.CreatedAt}
+}
+
+func (r *batchChangeTemplateResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.UpdatedAt}
+}
+
+func (r *batchChangeTemplateResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
+	svc := service.New(r.store)
+	return svc.CanAdministerInNamespace(ctx, r.template.NamespaceUserID, r.template.NamespaceOrgID)
+}
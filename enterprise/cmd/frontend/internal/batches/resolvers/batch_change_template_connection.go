@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+var _ graphqlbackend.BatchChangeTemplateConnectionResolver = &batchChangeTemplateConnectionResolver{}
+
+type batchChangeTemplateConnectionResolver struct {
+	store *store.Store
+	opts  store.ListBatchChangeTemplatesOpts
+
+	// cache results because they are used by multiple fields
+	once      sync.Once
+	templates []*btypes.BatchChangeTemplate
+	next      int64
+	err       error
+}
+
+func (r *batchChangeTemplateConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchChangeTemplateResolver, error) {
+	nodes, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.BatchChangeTemplateResolver, 0, len(nodes))
+	for _, t := range nodes {
+		resolvers = append(resolvers, &batchChangeTemplateResolver{store: r.store, template: t})
+	}
+	return resolvers, nil
+}
+
+func (r *batchChangeTemplateConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountBatchChangeTemplates(ctx, store.CountBatchChangeTemplatesOpts{
+		NamespaceUserID: r.opts.NamespaceUserID,
+		NamespaceOrgID:  r.opts.NamespaceOrgID,
+	})
+	return int32(count), err
+}
+
+func (r *batchChangeTemplateConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *batchChangeTemplateConnectionResolver) compute(ctx context.Context) ([]*btypes.BatchChangeTemplate, int64, error) {
+	r.once.Do(func() {
+		r.templates, r.next, r.err = r.store.ListBatchChangeTemplates(ctx, r.opts)
+	})
+	return r.templates, r.next, r.err
+}
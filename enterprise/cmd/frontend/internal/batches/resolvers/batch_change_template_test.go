@@ -0,0 +1,148 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers/apitest"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestBatchChangeTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+
+	userID := bt.CreateTestUser(t, db, true).ID
+	userAPIID := string(graphqlbackend.MarshalUserID(userID))
+	actorCtx := actor.WithActor(ctx, actor.FromUser(userID))
+
+	bstore := store.New(db, &observation.TestContext, nil)
+
+	r := &Resolver{store: bstore}
+	s, err := newSchema(db, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var created struct{ CreateBatchChangeTemplate apitest.BatchChangeTemplate }
+	apitest.MustExec(actorCtx, t, s, map[string]any{
+		"namespace":   userAPIID,
+		"name":        "hello",
+		"template":    "name: hello-${{ inputs.name }}\n",
+		"inputSchema": `{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`,
+	}, &created, mutationCreateBatchChangeTemplate)
+
+	template := created.CreateBatchChangeTemplate
+	assert.Equal(t, "hello", template.Name)
+	assert.Equal(t, []string{"name"}, template.InputNames)
+	assert.Equal(t, userAPIID, template.Namespace.ID)
+	assert.True(t, template.ViewerCanAdminister)
+
+	var listed struct {
+		BatchChangeTemplates struct {
+			TotalCount int
+			Nodes      []apitest.BatchChangeTemplate
+		}
+	}
+	apitest.MustExec(actorCtx, t, s, map[string]any{}, &listed, queryBatchChangeTemplates)
+	assert.Equal(t, 1, listed.BatchChangeTemplates.TotalCount)
+	require.Len(t, listed.BatchChangeTemplates.Nodes, 1)
+	assert.Equal(t, template.ID, listed.BatchChangeTemplates.Nodes[0].ID)
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		var response struct{ CreateBatchSpecFromTemplate apitest.BatchSpec }
+		errs := apitest.Exec(actorCtx, t, s, map[string]any{
+			"template":  template.ID,
+			"namespace": userAPIID,
+			"inputs":    map[string]any{"name": 42},
+		}, &response, mutationCreateBatchSpecFromTemplate)
+		assert.NotEmpty(t, errs)
+	})
+
+	t.Run("valid inputs", func(t *testing.T) {
+		var response struct{ CreateBatchSpecFromTemplate apitest.BatchSpec }
+		apitest.MustExec(actorCtx, t, s, map[string]any{
+			"template":  template.ID,
+			"namespace": userAPIID,
+			"inputs":    map[string]any{"name": "world"},
+		}, &response, mutationCreateBatchSpecFromTemplate)
+		assert.Equal(t, "name: hello-world\n", response.CreateBatchSpecFromTemplate.OriginalInput)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		var response struct{ UpdateBatchChangeTemplate apitest.BatchChangeTemplate }
+		apitest.MustExec(actorCtx, t, s, map[string]any{
+			"id":       template.ID,
+			"template": "name: hello-${{ inputs.name }}\ndescription: ${{ inputs.name }}\n",
+		}, &response, mutationUpdateBatchChangeTemplate)
+		assert.Equal(t, "hello", response.UpdateBatchChangeTemplate.Name)
+		assert.Equal(t, []string{"name"}, response.UpdateBatchChangeTemplate.InputNames)
+		assert.Equal(t, "name: hello-${{ inputs.name }}\ndescription: ${{ inputs.name }}\n", response.UpdateBatchChangeTemplate.Template)
+	})
+
+	var deleted struct{ DeleteBatchChangeTemplate apitest.EmptyResponse }
+	apitest.MustExec(actorCtx, t, s, map[string]any{"template": template.ID}, &deleted, mutationDeleteBatchChangeTemplate)
+
+	templateID, err := unmarshalBatchChangeTemplateID(graphql.ID(template.ID))
+	require.NoError(t, err)
+	_, err = bstore.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: templateID})
+	assert.ErrorIs(t, err, store.ErrNoResults)
+}
+
+const fragmentBatchChangeTemplate = `
+fragment t on BatchChangeTemplate {
+	id
+	name
+	template
+	inputSchema
+	inputNames
+	namespace { ... on User { id } ... on Org { id } }
+	viewerCanAdminister
+}
+`
+
+const mutationCreateBatchChangeTemplate = fragmentBatchChangeTemplate + `
+mutation($namespace: ID!, $name: String!, $template: String!, $inputSchema: String!) {
+	createBatchChangeTemplate(namespace: $namespace, name: $name, template: $template, inputSchema: $inputSchema) { ...t }
+}
+`
+
+const queryBatchChangeTemplates = fragmentBatchChangeTemplate + `
+query {
+	batchChangeTemplates { totalCount nodes { ...t } }
+}
+`
+
+const mutationCreateBatchSpecFromTemplate = `
+mutation($template: ID!, $namespace: ID!, $inputs: JSONValue) {
+	createBatchSpecFromTemplate(template: $template, namespace: $namespace, inputs: $inputs) { id originalInput }
+}
+`
+
+const mutationUpdateBatchChangeTemplate = fragmentBatchChangeTemplate + `
+mutation($id: ID!, $template: String) {
+	updateBatchChangeTemplate(id: $id, template: $template) { ...t }
+}
+`
+
+const mutationDeleteBatchChangeTemplate = `
+mutation($template: ID!) {
+	deleteBatchChangeTemplate(template: $template) { alwaysNil }
+}
+`
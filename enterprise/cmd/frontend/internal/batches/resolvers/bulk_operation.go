@@ -117,6 +117,8 @@ func changesetJobTypeToBulkOperationType(t btypes.ChangesetJobType) (string, err
 		return "REENQUEUE", nil
 	case btypes.ChangesetJobTypeMerge:
 		return "MERGE", nil
+	case btypes.ChangesetJobTypeMergeWhenReady:
+		return "MERGE_WHEN_READY", nil
 	case btypes.ChangesetJobTypeClose:
 		return "CLOSE", nil
 	case btypes.ChangesetJobTypePublish:
@@ -126,9 +126,37 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 		batchSpecWorkspaceIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.batchSpecWorkspaceByID(ctx, id)
 		},
+		batchChangeTemplateIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.batchChangeTemplateByID(ctx, id)
+		},
 	}
 }
 
+func (r *Resolver) batchChangeTemplateByID(ctx context.Context, id graphql.ID) (graphqlbackend.BatchChangeTemplateResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchChangeTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, nil
+	}
+
+	template, err := r.store.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: templateID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &batchChangeTemplateResolver{store: r.store, template: template}, nil
+}
+
 func (r *Resolver) changesetByID(ctx context.Context, id graphql.ID) (graphqlbackend.ChangesetResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
@@ -1171,7 +1199,10 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else if externalServiceType == extsvc.TypeBitbucketCloud {
+	} else if externalServiceType == extsvc.TypeBitbucketCloud || externalServiceType == extsvc.TypeGerrit || externalServiceType == extsvc.TypeAWSCodeCommit {
+		if username == nil {
+			return nil, errors.Newf("a username is required for %s credentials", externalServiceType)
+		}
 		a = &extsvcauth.BasicAuthWithSSH{
 			BasicAuth:  extsvcauth.BasicAuth{Username: *username, Password: credential},
 			PrivateKey: keypair.PrivateKey,
@@ -1406,6 +1437,43 @@ func (r *Resolver) MergeChangesets(ctx context.Context, args *graphqlbackend.Mer
 	return r.bulkOperationByIDString(ctx, bulkGroupID)
 }
 
+func (r *Resolver) MergeChangesetsWhenReady(ctx context.Context, args *graphqlbackend.MergeChangesetsWhenReadyArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.MergeChangesetsWhenReady", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, changesetIDs, err := unmarshalBulkOperationBaseArgs(args.BulkOperationBaseArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
+	svc := service.New(r.store)
+	published := btypes.ChangesetPublicationStatePublished
+	bulkGroupID, err := svc.CreateChangesetJobs(
+		ctx,
+		batchChangeID,
+		changesetIDs,
+		btypes.ChangesetJobTypeMergeWhenReady,
+		&btypes.ChangesetJobMergeWhenReadyPayload{Squash: args.Squash},
+		store.ListChangesetsOpts{
+			PublicationState: &published,
+			ReconcilerStates: []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+			ExternalStates:   []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.bulkOperationByIDString(ctx, bulkGroupID)
+}
+
 func (r *Resolver) CloseChangesets(ctx context.Context, args *graphqlbackend.CloseChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.CloseChangesets", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
 	defer func() {
@@ -1475,6 +1543,264 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 	return r.bulkOperationByIDString(ctx, bulkGroupID)
 }
 
+func (r *Resolver) CreateBatchChangeTemplate(ctx context.Context, args *graphqlbackend.CreateBatchChangeTemplateArgs) (_ graphqlbackend.BatchChangeTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchChangeTemplate", fmt.Sprintf("Namespace: %+v, Name: %q", args.Namespace, args.Name))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesCreateAccess(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	var uid, oid int32
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Namespace, &uid, &oid); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: CreateBatchChangeTemplate checks whether current user has
+	// access to the namespace.
+	svc := service.New(r.store)
+	template, err := svc.CreateBatchChangeTemplate(ctx, service.CreateBatchChangeTemplateOpts{
+		NamespaceUserID: uid,
+		NamespaceOrgID:  oid,
+		Name:            args.Name,
+		Description:     args.Description,
+		RawTemplate:     args.Template,
+		InputSchema:     args.InputSchema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeTemplateResolver{store: r.store, template: template}, nil
+}
+
+func (r *Resolver) UpdateBatchChangeTemplate(ctx context.Context, args *graphqlbackend.UpdateBatchChangeTemplateArgs) (_ graphqlbackend.BatchChangeTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.UpdateBatchChangeTemplate", fmt.Sprintf("Template: %q", args.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchChangeTemplateID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: UpdateBatchChangeTemplate checks whether current user has
+	// access to the namespace of the template.
+	svc := service.New(r.store)
+	template, err := svc.UpdateBatchChangeTemplate(ctx, service.UpdateBatchChangeTemplateOpts{
+		ID:          templateID,
+		Name:        args.Name,
+		Description: args.Description,
+		RawTemplate: args.Template,
+		InputSchema: args.InputSchema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeTemplateResolver{store: r.store, template: template}, nil
+}
+
+func (r *Resolver) DeleteBatchChangeTemplate(ctx context.Context, args *graphqlbackend.DeleteBatchChangeTemplateArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChangeTemplate", fmt.Sprintf("Template: %q", args.Template))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchChangeTemplateID(args.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: DeleteBatchChangeTemplate checks whether current user has
+	// access to the namespace of the template.
+	svc := service.New(r.store)
+	if err := svc.DeleteBatchChangeTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) CreateBatchSpecFromTemplate(ctx context.Context, args *graphqlbackend.CreateBatchSpecFromTemplateArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchSpecFromTemplate", fmt.Sprintf("Template: %q, Namespace: %+v", args.Template, args.Namespace))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesCreateAccess(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchChangeTemplateID(args.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	var inputs map[string]any
+	if args.Inputs != nil && args.Inputs.Value != nil {
+		var ok bool
+		if inputs, ok = args.Inputs.Value.(map[string]any); !ok {
+			return nil, errors.New("inputs must be a JSON object")
+		}
+	}
+
+	var uid, oid int32
+	if err := graphqlbackend.UnmarshalNamespaceID(args.Namespace, &uid, &oid); err != nil {
+		return nil, err
+	}
+
+	var bid int64
+	if args.BatchChange != nil {
+		if bid, err = unmarshalBatchChangeID(*args.BatchChange); err != nil {
+			return nil, err
+		}
+	}
+
+	// 🚨 SECURITY: CreateBatchSpecFromTemplate checks whether current user
+	// has access to the namespace and the batch change.
+	svc := service.New(r.store)
+	batchSpec, err := svc.CreateBatchSpecFromTemplate(ctx, service.CreateBatchSpecFromTemplateOpts{
+		TemplateID:      templateID,
+		Inputs:          inputs,
+		NamespaceUserID: uid,
+		NamespaceOrgID:  oid,
+		BatchChange:     bid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
+}
+
+func (r *Resolver) SetBatchChangeSLA(ctx context.Context, args *graphqlbackend.SetBatchChangeSLAArgs) (_ graphqlbackend.BatchChangeSLAResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.SetBatchChangeSLA", fmt.Sprintf("BatchChange: %q, TargetDate: %s", args.BatchChange, args.TargetDate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	opts := service.SetBatchChangeSLAOpts{
+		BatchChangeID: batchChangeID,
+		TargetDate:    args.TargetDate.Time,
+		NotifyEmail:   args.NotifyEmail,
+	}
+	if args.SlackWebhookURL != nil {
+		opts.SlackWebhookURL = *args.SlackWebhookURL
+	}
+	if args.WebhookURL != nil {
+		opts.WebhookURL = *args.WebhookURL
+	}
+
+	// 🚨 SECURITY: SetBatchChangeSLA checks whether current user is authorized.
+	svc := service.New(r.store)
+	sla, err := svc.SetBatchChangeSLA(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	batchChange, err := r.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchChangeSLAResolver{store: r.store, batchChange: batchChange, sla: sla}, nil
+}
+
+func (r *Resolver) DeleteBatchChangeSLA(ctx context.Context, args *graphqlbackend.DeleteBatchChangeSLAArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChangeSLA", fmt.Sprintf("BatchChange: %q", args.BatchChange))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, err := unmarshalBatchChangeID(args.BatchChange)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchChangeID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: DeleteBatchChangeSLA checks whether current user is authorized.
+	svc := service.New(r.store)
+	if err := svc.DeleteBatchChangeSLA(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) BatchChangeTemplates(ctx context.Context, args *graphqlbackend.ListBatchChangeTemplatesArgs) (_ graphqlbackend.BatchChangeTemplateConnectionResolver, err error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts := store.ListBatchChangeTemplatesOpts{
+		LimitOpts: store.LimitOpts{Limit: int(args.First)},
+	}
+	if args.After != nil {
+		cursor, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = cursor
+	}
+
+	if args.Namespace != nil {
+		if err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &batchChangeTemplateConnectionResolver{store: r.store, opts: opts}, nil
+}
+
 func (r *Resolver) BatchSpecs(ctx context.Context, args *graphqlbackend.ListBatchSpecArgs) (_ graphqlbackend.BatchSpecConnectionResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.BatchSpecs", fmt.Sprintf("First: %d, After: %v", args.First, args.After))
 	defer func() {
@@ -64,6 +64,7 @@ func TestNullIDResilience(t *testing.T) {
 		marshalBatchChangesCredentialID(0, true),
 		marshalBulkOperationID(""),
 		marshalBatchSpecWorkspaceID(0),
+		marshalBatchChangeTemplateID(0),
 	}
 
 	for _, id := range ids {
@@ -105,6 +106,9 @@ func TestNullIDResilience(t *testing.T) {
 		fmt.Sprintf(`mutation { replaceBatchSpecInput(previousSpec: %q, batchSpec: "name: testing") { id } }`, marshalBatchSpecRandID("")),
 		fmt.Sprintf(`mutation { retryBatchSpecWorkspaceExecution(batchSpecWorkspaces: [%q]) { alwaysNil } }`, marshalBatchSpecWorkspaceID(0)),
 		fmt.Sprintf(`mutation { retryBatchSpecExecution(batchSpec: %q) { id } }`, marshalBatchSpecRandID("")),
+		fmt.Sprintf(`mutation { deleteBatchChangeTemplate(template: %q) { alwaysNil } }`, marshalBatchChangeTemplateID(0)),
+		fmt.Sprintf(`mutation { setBatchChangeSLA(batchChange: %q, targetDate: "2030-01-01T00:00:00Z") { targetDate } }`, marshalBatchChangeID(0)),
+		fmt.Sprintf(`mutation { deleteBatchChangeSLA(batchChange: %q) { alwaysNil } }`, marshalBatchChangeID(0)),
 	}
 
 	for _, m := range mutations {
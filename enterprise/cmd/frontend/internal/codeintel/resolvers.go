@@ -111,6 +111,11 @@ func (r *Resolver) CommitGraph(ctx context.Context, id graphql.ID) (_ uploadsgra
 	return r.uploadsRootResolver.CommitGraph(ctx, id)
 }
 
+// 🚨 SECURITY: Only entrypoint is within the repository resolver so the user is already authenticated
+func (r *Resolver) StorageQuotaEvictionPreview(ctx context.Context, id graphql.ID) (_ *[]uploadsgraphql.LSIFUploadStorageQuotaEvictionResolver, err error) {
+	return r.uploadsRootResolver.StorageQuotaEvictionPreview(ctx, id)
+}
+
 // 🚨 SECURITY: Only site admins may queue auto-index jobs
 func (r *Resolver) QueueAutoIndexJobsForRepo(ctx context.Context, args *autoindexinggraphql.QueueAutoIndexJobsForRepoArgs) (_ []sharedresolvers.LSIFIndexResolver, err error) {
 	return r.autoIndexingRootResolver.QueueAutoIndexJobsForRepo(ctx, args)
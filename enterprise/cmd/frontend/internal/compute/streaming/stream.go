@@ -102,7 +102,18 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	matchesBuf := streamhttp.NewJSONArrayBuf(32*1024, func(data []byte) error {
 		return eventWriter.EventBytes("results", data)
 	})
+	// Commands that aggregate results send a table of all the results seen
+	// so far instead of the results themselves, limited to the top display
+	// groups.
+	var aggregator *compute.Aggregator
+	if _, ok := computeQuery.Command.(*compute.Aggregate); ok {
+		aggregator = compute.NewAggregator(args.Display)
+	}
+
 	matchesFlush := func() {
+		if aggregator != nil && aggregator.Dirty() {
+			_ = matchesBuf.Append(aggregator.Table())
+		}
 		if err := matchesBuf.Flush(); err != nil {
 			// EOF
 			return
@@ -124,11 +135,15 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		progress.Stats.Update(&event.Stats)
 
 		for _, result := range event.Results {
+			if aggregator != nil {
+				aggregator.Add(result)
+				continue
+			}
 			_ = matchesBuf.Append(result)
 		}
 
 		// Instantly send results if we have not sent any yet.
-		if first && matchesBuf.Len() > 0 {
+		if first && (matchesBuf.Len() > 0 || (aggregator != nil && aggregator.Dirty())) {
 			first = false
 			matchesFlush()
 		}
@@ -203,6 +218,7 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.New("no query found")
 	}
 
+	// TODO: display only limits the groups of aggregated results so far.
 	display := get("display", "-1") // TODO(rvantonder): Currently unused; implement a limit for compute results.
 	var err error
 	if a.Display, err = strconv.Atoi(display); err != nil {
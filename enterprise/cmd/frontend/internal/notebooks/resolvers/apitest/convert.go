@@ -1,6 +1,8 @@
 package apitest
 
 import (
+	"strings"
+
 	"github.com/graph-gophers/graphql-go"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -32,6 +34,24 @@ func BlockToAPIResponse(block notebooks.NotebookBlock) NotebookBlock {
 		}}
 	case notebooks.NotebookComputeBlockType:
 		return NotebookBlock{Typename: "ComputeBlock", ID: block.ID, ComputeInput: block.ComputeInput.Value}
+	case notebooks.NotebookInsightBlockType:
+		var timeRange *InsightTimeRange
+		if block.InsightInput.TimeRange != nil {
+			timeRange = &InsightTimeRange{Unit: block.InsightInput.TimeRange.Unit, Value: block.InsightInput.TimeRange.Value}
+		}
+		return NotebookBlock{Typename: "InsightBlock", ID: block.ID, InsightInput: InsightInput{
+			SeriesID:  block.InsightInput.SeriesID,
+			Query:     block.InsightInput.Query,
+			TimeRange: timeRange,
+		}}
+	case notebooks.NotebookDiffPreviewBlockType:
+		return NotebookBlock{Typename: "DiffPreviewBlock", ID: block.ID, DiffPreviewInput: DiffPreviewInput{
+			Query:          block.DiffPreviewInput.Query,
+			MatchPattern:   block.DiffPreviewInput.MatchPattern,
+			RewritePattern: block.DiffPreviewInput.RewritePattern,
+			PatternType:    strings.ToUpper(string(block.DiffPreviewInput.PatternType)),
+			ComputeQuery:   block.DiffPreviewInput.ComputeQuery(),
+		}}
 	}
 	panic("unknown block type")
 }
@@ -79,6 +99,23 @@ func BlockToAPIInput(block notebooks.NotebookBlock) graphqlbackend.CreateNoteboo
 		}}
 	case notebooks.NotebookComputeBlockType:
 		return graphqlbackend.CreateNotebookBlockInputArgs{ID: block.ID, Type: graphqlbackend.NotebookComputeBlockType, ComputeInput: &block.ComputeInput.Value}
+	case notebooks.NotebookInsightBlockType:
+		var timeRange *graphqlbackend.CreateInsightBlockTimeRangeInput
+		if block.InsightInput.TimeRange != nil {
+			timeRange = &graphqlbackend.CreateInsightBlockTimeRangeInput{Unit: block.InsightInput.TimeRange.Unit, Value: block.InsightInput.TimeRange.Value}
+		}
+		return graphqlbackend.CreateNotebookBlockInputArgs{ID: block.ID, Type: graphqlbackend.NotebookInsightBlockType, InsightInput: &graphqlbackend.CreateInsightBlockInput{
+			SeriesID:  block.InsightInput.SeriesID,
+			Query:     block.InsightInput.Query,
+			TimeRange: timeRange,
+		}}
+	case notebooks.NotebookDiffPreviewBlockType:
+		return graphqlbackend.CreateNotebookBlockInputArgs{ID: block.ID, Type: graphqlbackend.NotebookDiffPreviewBlockType, DiffPreviewInput: &graphqlbackend.CreateDiffPreviewBlockInput{
+			Query:          block.DiffPreviewInput.Query,
+			MatchPattern:   block.DiffPreviewInput.MatchPattern,
+			RewritePattern: block.DiffPreviewInput.RewritePattern,
+			PatternType:    strings.ToUpper(string(block.DiffPreviewInput.PatternType)),
+		}}
 	}
 	panic("unknown block type")
 }
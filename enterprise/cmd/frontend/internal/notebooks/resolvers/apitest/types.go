@@ -17,13 +17,34 @@ type NotebookUser struct {
 }
 
 type NotebookBlock struct {
-	Typename      string `json:"__typename"`
-	ID            string
-	MarkdownInput string
-	QueryInput    string
-	FileInput     FileInput
-	SymbolInput   SymbolInput
-	ComputeInput  string
+	Typename         string `json:"__typename"`
+	ID               string
+	MarkdownInput    string
+	QueryInput       string
+	FileInput        FileInput
+	SymbolInput      SymbolInput
+	ComputeInput     string
+	InsightInput     InsightInput
+	DiffPreviewInput DiffPreviewInput
+}
+
+type InsightInput struct {
+	SeriesID  *string `json:"seriesId"`
+	Query     *string
+	TimeRange *InsightTimeRange
+}
+
+type InsightTimeRange struct {
+	Unit  string
+	Value int32
+}
+
+type DiffPreviewInput struct {
+	Query          string
+	MatchPattern   string
+	RewritePattern string
+	PatternType    string
+	ComputeQuery   string
 }
 
 type FileInput struct {
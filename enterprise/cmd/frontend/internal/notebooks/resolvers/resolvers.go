@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -67,6 +68,13 @@ func convertLineRangeInput(inputLineRage *graphqlbackend.CreateFileBlockLineRang
 	return &notebooks.LineRange{StartLine: inputLineRage.StartLine, EndLine: inputLineRage.EndLine}
 }
 
+func convertTimeRangeInput(inputTimeRange *graphqlbackend.CreateInsightBlockTimeRangeInput) *notebooks.InsightTimeRange {
+	if inputTimeRange == nil {
+		return nil
+	}
+	return &notebooks.InsightTimeRange{Unit: inputTimeRange.Unit, Value: inputTimeRange.Value}
+}
+
 func convertNotebookBlockInput(inputBlock graphqlbackend.CreateNotebookBlockInputArgs) (*notebooks.NotebookBlock, error) {
 	block := &notebooks.NotebookBlock{ID: inputBlock.ID}
 	switch inputBlock.Type {
@@ -113,6 +121,27 @@ func convertNotebookBlockInput(inputBlock graphqlbackend.CreateNotebookBlockInpu
 		}
 		block.Type = notebooks.NotebookComputeBlockType
 		block.ComputeInput = &notebooks.NotebookComputeBlockInput{Value: *inputBlock.ComputeInput}
+	case graphqlbackend.NotebookInsightBlockType:
+		if inputBlock.InsightInput == nil {
+			return nil, errors.Errorf("insight block with id %s is missing input", inputBlock.ID)
+		}
+		block.Type = notebooks.NotebookInsightBlockType
+		block.InsightInput = &notebooks.NotebookInsightBlockInput{
+			SeriesID:  inputBlock.InsightInput.SeriesID,
+			Query:     inputBlock.InsightInput.Query,
+			TimeRange: convertTimeRangeInput(inputBlock.InsightInput.TimeRange),
+		}
+	case graphqlbackend.NotebookDiffPreviewBlockType:
+		if inputBlock.DiffPreviewInput == nil {
+			return nil, errors.Errorf("diff preview block with id %s is missing input", inputBlock.ID)
+		}
+		block.Type = notebooks.NotebookDiffPreviewBlockType
+		block.DiffPreviewInput = &notebooks.NotebookDiffPreviewBlockInput{
+			Query:          inputBlock.DiffPreviewInput.Query,
+			MatchPattern:   inputBlock.DiffPreviewInput.MatchPattern,
+			RewritePattern: inputBlock.DiffPreviewInput.RewritePattern,
+			PatternType:    notebooks.DiffPreviewPatternType(strings.ToLower(inputBlock.DiffPreviewInput.PatternType)),
+		}
 	default:
 		return nil, errors.Newf("invalid block type: %s", inputBlock.Type)
 	}
@@ -525,6 +554,20 @@ func (r *notebookBlockResolver) ToComputeBlock() (graphqlbackend.ComputeBlockRes
 	return nil, false
 }
 
+func (r *notebookBlockResolver) ToInsightBlock() (graphqlbackend.InsightBlockResolver, bool) {
+	if r.block.Type == notebooks.NotebookInsightBlockType {
+		return &insightBlockResolver{r.block}, true
+	}
+	return nil, false
+}
+
+func (r *notebookBlockResolver) ToDiffPreviewBlock() (graphqlbackend.DiffPreviewBlockResolver, bool) {
+	if r.block.Type == notebooks.NotebookDiffPreviewBlockType {
+		return &diffPreviewBlockResolver{r.block}, true
+	}
+	return nil, false
+}
+
 type markdownBlockResolver struct {
 	// block.type == NotebookMarkdownBlockType
 	block notebooks.NotebookBlock
@@ -656,3 +699,84 @@ func (r *computeBlockResolver) ID() string {
 func (r *computeBlockResolver) ComputeInput() string {
 	return r.block.ComputeInput.Value
 }
+
+type insightBlockResolver struct {
+	// block.type == NotebookInsightBlockType
+	block notebooks.NotebookBlock
+}
+
+func (r *insightBlockResolver) ID() string {
+	return r.block.ID
+}
+
+func (r *insightBlockResolver) InsightInput() graphqlbackend.InsightBlockInputResolver {
+	return &insightBlockInputResolver{*r.block.InsightInput}
+}
+
+type insightBlockInputResolver struct {
+	input notebooks.NotebookInsightBlockInput
+}
+
+func (r *insightBlockInputResolver) SeriesID() *string {
+	return r.input.SeriesID
+}
+
+func (r *insightBlockInputResolver) Query() *string {
+	return r.input.Query
+}
+
+func (r *insightBlockInputResolver) TimeRange() graphqlbackend.InsightBlockTimeRangeResolver {
+	if r.input.TimeRange == nil {
+		return nil
+	}
+	return &insightBlockTimeRangeResolver{*r.input.TimeRange}
+}
+
+type insightBlockTimeRangeResolver struct {
+	timeRange notebooks.InsightTimeRange
+}
+
+func (r *insightBlockTimeRangeResolver) Unit() string {
+	return r.timeRange.Unit
+}
+
+func (r *insightBlockTimeRangeResolver) Value() int32 {
+	return r.timeRange.Value
+}
+
+type diffPreviewBlockResolver struct {
+	// block.type == NotebookDiffPreviewBlockType
+	block notebooks.NotebookBlock
+}
+
+func (r *diffPreviewBlockResolver) ID() string {
+	return r.block.ID
+}
+
+func (r *diffPreviewBlockResolver) DiffPreviewInput() graphqlbackend.DiffPreviewBlockInputResolver {
+	return &diffPreviewBlockInputResolver{*r.block.DiffPreviewInput}
+}
+
+type diffPreviewBlockInputResolver struct {
+	input notebooks.NotebookDiffPreviewBlockInput
+}
+
+func (r *diffPreviewBlockInputResolver) Query() string {
+	return r.input.Query
+}
+
+func (r *diffPreviewBlockInputResolver) MatchPattern() string {
+	return r.input.MatchPattern
+}
+
+func (r *diffPreviewBlockInputResolver) RewritePattern() string {
+	return r.input.RewritePattern
+}
+
+func (r *diffPreviewBlockInputResolver) PatternType() string {
+	return strings.ToUpper(string(r.input.PatternType))
+}
+
+func (r *diffPreviewBlockInputResolver) ComputeQuery() string {
+	return r.input.ComputeQuery()
+}
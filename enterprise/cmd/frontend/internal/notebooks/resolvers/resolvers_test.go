@@ -78,6 +78,29 @@ const notebookFields = `
 			id
 			computeInput
 		}
+		... on InsightBlock {
+			__typename
+			id
+			insightInput {
+				seriesId
+				query
+				timeRange {
+					unit
+					value
+				}
+			}
+		}
+		... on DiffPreviewBlock {
+			__typename
+			id
+			diffPreviewInput {
+				query
+				matchPattern
+				rewritePattern
+				patternType
+				computeQuery
+			}
+		}
 	}
 `
 
@@ -132,6 +155,7 @@ mutation DeleteNotebook($id: ID!) {
 
 func notebookFixture(creatorID int32, namespaceUserID int32, namespaceOrgID int32, public bool) *notebooks.Notebook {
 	revision := "deadbeef"
+	insightQuery := "errors.Wrap"
 	blocks := notebooks.NotebookBlocks{
 		{ID: "1", Type: notebooks.NotebookQueryBlockType, QueryInput: &notebooks.NotebookQueryBlockInput{Text: "repo:a b"}},
 		{ID: "2", Type: notebooks.NotebookMarkdownBlockType, MarkdownInput: &notebooks.NotebookMarkdownBlockInput{Text: "# Title"}},
@@ -153,6 +177,16 @@ func notebookFixture(creatorID int32, namespaceUserID int32, namespaceOrgID int3
 		{ID: "5", Type: notebooks.NotebookComputeBlockType, ComputeInput: &notebooks.NotebookComputeBlockInput{
 			Value: "github.com/sourcegraph/sourcegraph"},
 		},
+		{ID: "6", Type: notebooks.NotebookInsightBlockType, InsightInput: &notebooks.NotebookInsightBlockInput{
+			Query:     &insightQuery,
+			TimeRange: &notebooks.InsightTimeRange{Unit: "MONTH", Value: 6},
+		}},
+		{ID: "7", Type: notebooks.NotebookDiffPreviewBlockType, DiffPreviewInput: &notebooks.NotebookDiffPreviewBlockInput{
+			Query:          "repo:sourcegraph lang:go",
+			MatchPattern:   "errors.Wrap(:[err], :[msg])",
+			RewritePattern: "errors.Wrapf(:[err], :[msg])",
+			PatternType:    notebooks.DiffPreviewStructuralPatternType,
+		}},
 	}
 	return &notebooks.Notebook{Title: "Notebook Title", Blocks: blocks, Public: public, CreatorUserID: creatorID, UpdaterUserID: creatorID, NamespaceUserID: namespaceUserID, NamespaceOrgID: namespaceOrgID}
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/repo-updater/internal/authz"
 	frontendAuthz "github.com/sourcegraph/sourcegraph/enterprise/internal/authz"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/repochange"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	ossAuthz "github.com/sourcegraph/sourcegraph/internal/authz"
@@ -62,6 +63,12 @@ func enterpriseInit(
 	go startBackgroundPermsSync(ctx, permsSyncer, db)
 	if server != nil {
 		server.PermsSyncer = permsSyncer
+
+		// Event-driven code monitors are run when the scheduler fetches new
+		// commits for a repository they can match.
+		codeMonitorTrigger := repochange.NewTrigger(logger, edb.NewEnterpriseDB(db).CodeMonitors())
+		go codeMonitorTrigger.Start()
+		server.Scheduler.OnRepoChanged(codeMonitorTrigger.HandleRepoChanged)
 	}
 
 	return map[string]debugserver.Dumper{
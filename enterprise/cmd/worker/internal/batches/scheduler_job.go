@@ -6,9 +6,12 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/burndown"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/rebaser"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/scheduler"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 )
 
@@ -36,6 +39,8 @@ func (j *schedulerJob) Routines(_ context.Context, logger log.Logger) ([]gorouti
 
 	routines := []goroutine.BackgroundRoutine{
 		scheduler.NewScheduler(workCtx, bstore),
+		rebaser.NewRebaser(workCtx, logger.Scoped("rebaser", "enqueues rebases of outdated changesets"), bstore, gitserver.NewClient(bstore.DatabaseDB())),
+		burndown.NewSnapshotter(workCtx, logger.Scoped("burndown", "records batch change snapshots and sends SLA alerts"), bstore, burndown.NewAlerter(bstore.DatabaseDB())),
 	}
 
 	return routines, nil
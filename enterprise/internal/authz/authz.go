@@ -11,9 +11,11 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gitlab"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gitolite"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -64,6 +66,8 @@ func ProvidersFromConfig(
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
 			extsvc.KindPerforce,
+			extsvc.KindBitbucketCloud,
+			extsvc.KindGitolite,
 		},
 		LimitOffset: &database.LimitOffset{
 			Limit: 500, // The number is randomly chosen
@@ -75,6 +79,8 @@ func ProvidersFromConfig(
 		gitLabConns          []*types.GitLabConnection
 		bitbucketServerConns []*types.BitbucketServerConnection
 		perforceConns        []*types.PerforceConnection
+		bitbucketCloudConns  []*types.BitbucketCloudConnection
+		gitoliteConns        []*types.GitoliteConnection
 	)
 	for {
 		svcs, err := store.List(ctx, opt)
@@ -124,6 +130,16 @@ func ProvidersFromConfig(
 					URN:                svc.URN(),
 					PerforceConnection: c,
 				})
+			case *schema.BitbucketCloudConnection:
+				bitbucketCloudConns = append(bitbucketCloudConns, &types.BitbucketCloudConnection{
+					URN:                      svc.URN(),
+					BitbucketCloudConnection: c,
+				})
+			case *schema.GitoliteConnection:
+				gitoliteConns = append(gitoliteConns, &types.GitoliteConnection{
+					URN:                svc.URN(),
+					GitoliteConnection: c,
+				})
 			default:
 				log15.Error("ProvidersFromConfig", "error", errors.Errorf("unexpected connection type: %T", cfg))
 				continue
@@ -173,6 +189,22 @@ func ProvidersFromConfig(
 		invalidConnections = append(invalidConnections, pfInvalidConnections...)
 	}
 
+	if len(bitbucketCloudConns) > 0 {
+		bbcloudProviders, bbcloudProblems, bbcloudWarnings, bbcloudInvalidConnections := bitbucketcloud.NewAuthzProviders(bitbucketCloudConns)
+		providers = append(providers, bbcloudProviders...)
+		seriousProblems = append(seriousProblems, bbcloudProblems...)
+		warnings = append(warnings, bbcloudWarnings...)
+		invalidConnections = append(invalidConnections, bbcloudInvalidConnections...)
+	}
+
+	if len(gitoliteConns) > 0 {
+		gitoliteProviders, gitoliteProblems, gitoliteWarnings, gitoliteInvalidConnections := gitolite.NewAuthzProviders(gitoliteConns)
+		providers = append(providers, gitoliteProviders...)
+		seriousProblems = append(seriousProblems, gitoliteProblems...)
+		warnings = append(warnings, gitoliteWarnings...)
+		invalidConnections = append(invalidConnections, gitoliteInvalidConnections...)
+	}
+
 	// 🚨 SECURITY: Warn the admin when both code host authz provider and the permissions user mapping are configured.
 	if cfg.SiteConfig().PermissionsUserMapping != nil &&
 		cfg.SiteConfig().PermissionsUserMapping.Enabled {
@@ -274,6 +306,24 @@ func ProviderFromExternalService(
 			},
 			db,
 		)
+	case *schema.BitbucketCloudConnection:
+		providers, problems, _, _ = bitbucketcloud.NewAuthzProviders(
+			[]*types.BitbucketCloudConnection{
+				{
+					URN:                      svc.URN(),
+					BitbucketCloudConnection: c,
+				},
+			},
+		)
+	case *schema.GitoliteConnection:
+		providers, problems, _, _ = gitolite.NewAuthzProviders(
+			[]*types.GitoliteConnection{
+				{
+					URN:                svc.URN(),
+					GitoliteConnection: c,
+				},
+			},
+		)
 	default:
 		return nil, errors.Errorf("unsupported connection type %T", cfg)
 	}
@@ -85,6 +85,8 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 		cfg                          conf.Unified
 		gitlabConnections            []*schema.GitLabConnection
 		bitbucketServerConnections   []*schema.BitbucketServerConnection
+		bitbucketCloudConnections    []*schema.BitbucketCloudConnection
+		gitoliteConnections          []*schema.GitoliteConnection
 		expAuthzAllowAccessByDefault bool
 		expAuthzProviders            func(*testing.T, []authz.Provider)
 		expSeriousProblems           []string
@@ -396,6 +398,60 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			description: "Bitbucket Cloud connection with authz enabled",
+			cfg:         conf.Unified{},
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{
+						IdentityProvider: schema.BitbucketCloudIdentityProvider{
+							Username: &schema.BitbucketCloudUsernameIdentity{Type: "username"},
+						},
+					},
+					Url:         "https://bitbucket.org",
+					Username:    "admin",
+					AppPassword: "secret",
+				},
+				{
+					Url:         "https://bitbucket.org",
+					Username:    "other",
+					AppPassword: "secret",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				if len(have) != 1 {
+					t.Fatalf("want 1 provider but got %d", len(have))
+				}
+				if have[0].ServiceType() != extsvc.TypeBitbucketCloud {
+					t.Fatalf("no Bitbucket Cloud authz provider returned")
+				}
+			},
+		},
+		{
+			description: "Gitolite connection with authz enabled",
+			cfg:         conf.Unified{},
+			gitoliteConnections: []*schema.GitoliteConnection{
+				{
+					Authorization: &schema.GitoliteAuthorization{
+						IdentityProvider: schema.GitoliteIdentityProvider{
+							Username: &schema.GitoliteUsernameIdentity{Type: "username"},
+						},
+					},
+					Host:   "git@gitolite.example.com",
+					Prefix: "gitolite.example.com/",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				if len(have) != 1 {
+					t.Fatalf("want 1 provider but got %d", len(have))
+				}
+				if have[0].ServiceType() != extsvc.TypeGitolite || have[0].ServiceID() != "git@gitolite.example.com" {
+					t.Fatalf("no Gitolite authz provider returned")
+				}
+			},
+		},
 
 		// For Sourcegraph authz provider
 		{
@@ -489,6 +545,20 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbs)),
 							})
 						}
+					case extsvc.KindBitbucketCloud:
+						for _, bbc := range test.bitbucketCloudConnections {
+							svcs = append(svcs, &types.ExternalService{
+								Kind:   kind,
+								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(bbc)),
+							})
+						}
+					case extsvc.KindGitolite:
+						for _, gl := range test.gitoliteConnections {
+							svcs = append(svcs, &types.ExternalService{
+								Kind:   kind,
+								Config: extsvc.NewUnencryptedConfig(mustMarshalJSONString(gl)),
+							})
+						}
 					case extsvc.KindGitHub, extsvc.KindPerforce:
 					default:
 						return nil, errors.Errorf("unexpected kind: %s", kind)
@@ -0,0 +1,226 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// client is the subset of the Bitbucket Cloud API client used by the Provider.
+type client interface {
+	CurrentUser(ctx context.Context) (*bitbucketcloud.User, error)
+	WorkspaceMember(ctx context.Context, workspace, nickname string) (*bitbucketcloud.Account, error)
+	RepositoryPermissions(ctx context.Context, workspace, slug string) ([]*bitbucketcloud.RepositoryPermission, error)
+	UserRepositoryPermissions(ctx context.Context, workspace, userUUID string) ([]*bitbucketcloud.RepositoryPermission, error)
+}
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the workspace permissions API of Bitbucket Cloud.
+type Provider struct {
+	urn      string
+	client   client
+	codeHost *extsvc.CodeHost
+
+	// workspaces are the workspaces whose repositories are mirrored by the
+	// connection, i.e. the configured user's own workspace and its teams.
+	workspaces []string
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authorization provider for the given
+// connection. It assumes usernames of Sourcegraph accounts match 1-1 with the
+// nicknames of Bitbucket Cloud accounts, so it must only be used when the
+// connection opted in to the username identity provider.
+func NewProvider(conn *types.BitbucketCloudConnection) (*Provider, error) {
+	baseURL, err := url.Parse(conn.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing Bitbucket Cloud URL")
+	}
+	cli, err := bitbucketcloud.NewClient(conn.URN, conn.BitbucketCloudConnection, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(conn.Teams)+1)
+	var workspaces []string
+	for _, w := range append([]string{conn.Username}, conn.Teams...) {
+		if _, ok := seen[w]; ok || w == "" {
+			continue
+		}
+		seen[w] = struct{}{}
+		workspaces = append(workspaces, w)
+	}
+
+	return &Provider{
+		urn:        conn.URN,
+		client:     cli,
+		codeHost:   extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+		workspaces: workspaces,
+	}, nil
+}
+
+// ValidateConnection validates that the Provider has access to the Bitbucket
+// Cloud API and that the configured user is allowed to read the repository
+// permissions of all configured workspaces, which requires it to be an
+// administrator of them.
+func (p *Provider) ValidateConnection(ctx context.Context) []string {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	user, err := p.client.CurrentUser(ctx)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var warnings []string
+	for _, workspace := range p.workspaces {
+		if _, err := p.client.UserRepositoryPermissions(ctx, workspace, user.UUID); err != nil {
+			warnings = append(warnings, errors.Wrapf(err, "reading repository permissions of workspace %q", workspace).Error())
+		}
+	}
+	return warnings
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Bitbucket Cloud instance
+// this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "bitbucketCloud".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount returns the Bitbucket Cloud account of the given user. An account
+// the user already has on this code host is returned as is. Otherwise it is the
+// member of the configured workspaces whose nickname is the username of the
+// user, or nil if no such member exists.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, current []*extsvc.Account, _ []string) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	for _, acct := range current {
+		if extsvc.IsHostOfAccount(p.codeHost, acct) {
+			return acct, nil
+		}
+	}
+
+	for _, workspace := range p.workspaces {
+		member, err := p.client.WorkspaceMember(ctx, workspace, user.Username)
+		if err != nil {
+			return nil, errors.Wrapf(err, "looking up member %q of workspace %q", user.Username, workspace)
+		}
+		if member == nil {
+			continue
+		}
+
+		accountData, err := json.Marshal(member)
+		if err != nil {
+			return nil, err
+		}
+		return &extsvc.Account{
+			UserID: user.ID,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: p.codeHost.ServiceType,
+				ServiceID:   p.codeHost.ServiceID,
+				AccountID:   member.UUID,
+			},
+			AccountData: extsvc.AccountData{
+				Data: extsvc.NewUnencryptedData(accountData),
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID, namely the UUID of the repository.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-get
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var user bitbucketcloud.Account
+	if err := encryption.DecryptJSON(ctx, account.Data, &user); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling account data")
+	}
+
+	perms := &authz.ExternalUserPermissions{}
+	for _, workspace := range p.workspaces {
+		repoPerms, err := p.client.UserRepositoryPermissions(ctx, workspace, user.UUID)
+		if err != nil {
+			return perms, errors.Wrapf(err, "listing repository permissions in workspace %q", workspace)
+		}
+		for _, perm := range repoPerms {
+			if perm.Repository != nil {
+				perms.Exacts = append(perms.Exacts, extsvc.RepoID(perm.Repository.UUID))
+			}
+		}
+	}
+	return perms, nil
+}
+
+// FetchUserPermsByToken is the same as FetchUserPerms, but it only requires a
+// token.
+func (p *Provider) FetchUserPermsByToken(ctx context.Context, token string, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "bitbucketcloud.FetchUserPermsByToken"}
+}
+
+// FetchRepoPerms returns a list of user IDs (on code host) who have read access to
+// the given repo on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID, namely the UUID of the user.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, errors.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	// The URI of a Bitbucket Cloud repository is always "<host>/<workspace>/<slug>".
+	parts := strings.Split(repo.URI, "/")
+	if len(parts) != 3 {
+		return nil, errors.Errorf("unexpected Bitbucket Cloud repository URI %q", repo.URI)
+	}
+
+	repoPerms, err := p.client.RepositoryPermissions(ctx, parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]extsvc.AccountID, 0, len(repoPerms))
+	for _, perm := range repoPerms {
+		if perm.User != nil {
+			userIDs = append(userIDs, extsvc.AccountID(perm.User.UUID))
+		}
+	}
+	return userIDs, nil
+}
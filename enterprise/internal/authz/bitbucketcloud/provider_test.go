@@ -0,0 +1,232 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type mockClient struct {
+	currentUser               func(ctx context.Context) (*bitbucketcloud.User, error)
+	workspaceMember           func(ctx context.Context, workspace, nickname string) (*bitbucketcloud.Account, error)
+	repositoryPermissions     func(ctx context.Context, workspace, slug string) ([]*bitbucketcloud.RepositoryPermission, error)
+	userRepositoryPermissions func(ctx context.Context, workspace, userUUID string) ([]*bitbucketcloud.RepositoryPermission, error)
+}
+
+func (m *mockClient) CurrentUser(ctx context.Context) (*bitbucketcloud.User, error) {
+	return m.currentUser(ctx)
+}
+
+func (m *mockClient) WorkspaceMember(ctx context.Context, workspace, nickname string) (*bitbucketcloud.Account, error) {
+	return m.workspaceMember(ctx, workspace, nickname)
+}
+
+func (m *mockClient) RepositoryPermissions(ctx context.Context, workspace, slug string) ([]*bitbucketcloud.RepositoryPermission, error) {
+	return m.repositoryPermissions(ctx, workspace, slug)
+}
+
+func (m *mockClient) UserRepositoryPermissions(ctx context.Context, workspace, userUUID string) ([]*bitbucketcloud.RepositoryPermission, error) {
+	return m.userRepositoryPermissions(ctx, workspace, userUUID)
+}
+
+func newTestProvider(t *testing.T, cli client) *Provider {
+	t.Helper()
+
+	p, err := NewProvider(&types.BitbucketCloudConnection{
+		URN: "extsvc:bitbucketcloud:1",
+		BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+			Url:         "https://bitbucket.org",
+			Username:    "admin",
+			AppPassword: "secret",
+			Teams:       []string{"sourcegraph", "admin"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.client = cli
+	return p
+}
+
+func TestNewProvider(t *testing.T) {
+	p := newTestProvider(t, nil)
+
+	if diff := cmp.Diff([]string{"admin", "sourcegraph"}, p.workspaces); diff != "" {
+		t.Fatalf("workspaces mismatch (-want +got):\n%s", diff)
+	}
+	if want := "https://bitbucket.org/"; p.ServiceID() != want {
+		t.Fatalf("ServiceID: want %q but got %q", want, p.ServiceID())
+	}
+	if want := extsvc.TypeBitbucketCloud; p.ServiceType() != want {
+		t.Fatalf("ServiceType: want %q but got %q", want, p.ServiceType())
+	}
+}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t, &mockClient{
+		workspaceMember: func(ctx context.Context, workspace, nickname string) (*bitbucketcloud.Account, error) {
+			if workspace == "sourcegraph" && nickname == "bob" {
+				return &bitbucketcloud.Account{Nickname: "bob", UUID: "{2}"}, nil
+			}
+			return nil, nil
+		},
+	})
+
+	t.Run("existing account", func(t *testing.T) {
+		existing := &extsvc.Account{
+			UserID: 42,
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+				AccountID:   "{3}",
+			},
+		}
+		acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "bob"}, []*extsvc.Account{existing}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acct != existing {
+			t.Fatalf("want existing account but got %+v", acct)
+		}
+	})
+
+	t.Run("member of a workspace", func(t *testing.T) {
+		acct, err := p.FetchAccount(context.Background(), &types.User{ID: 42, Username: "bob"}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acct == nil {
+			t.Fatal("account was nil")
+		}
+		want := extsvc.AccountSpec{
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+			AccountID:   "{2}",
+		}
+		if diff := cmp.Diff(want, acct.AccountSpec); diff != "" {
+			t.Fatalf("AccountSpec mismatch (-want +got):\n%s", diff)
+		}
+		if acct.UserID != 42 {
+			t.Fatalf("UserID: want 42 but got %d", acct.UserID)
+		}
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		acct, err := p.FetchAccount(context.Background(), &types.User{ID: 43, Username: "mallory"}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acct != nil {
+			t.Fatalf("want nil account but got %+v", acct)
+		}
+	})
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := newTestProvider(t, &mockClient{
+		userRepositoryPermissions: func(ctx context.Context, workspace, userUUID string) ([]*bitbucketcloud.RepositoryPermission, error) {
+			if userUUID != "{1}" {
+				return nil, errors.Errorf("unexpected user %q", userUUID)
+			}
+			switch workspace {
+			case "admin":
+				return nil, nil
+			case "sourcegraph":
+				return []*bitbucketcloud.RepositoryPermission{
+					{Permission: "read", Repository: &bitbucketcloud.Repo{UUID: "{r1}"}},
+					{Permission: "admin", Repository: &bitbucketcloud.Repo{UUID: "{r2}"}},
+				}, nil
+			}
+			return nil, errors.Errorf("unexpected workspace %q", workspace)
+		},
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+			},
+			AccountData: extsvc.AccountData{Data: extsvc.NewUnencryptedData([]byte(`{}`))},
+		}, authz.FetchPermsOptions{})
+		if err == nil {
+			t.Fatal("want error but got nil")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		perms, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+				AccountID:   "{1}",
+			},
+			AccountData: extsvc.AccountData{Data: extsvc.NewUnencryptedData([]byte(`{"uuid": "{1}", "nickname": "alice"}`))},
+		}, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &authz.ExternalUserPermissions{Exacts: []extsvc.RepoID{"{r1}", "{r2}"}}
+		if diff := cmp.Diff(want, perms); diff != "" {
+			t.Fatalf("permissions mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t, &mockClient{
+		repositoryPermissions: func(ctx context.Context, workspace, slug string) ([]*bitbucketcloud.RepositoryPermission, error) {
+			if workspace != "sourcegraph" || slug != "private" {
+				return nil, errors.Errorf("unexpected repository %s/%s", workspace, slug)
+			}
+			return []*bitbucketcloud.RepositoryPermission{
+				{Permission: "read", User: &bitbucketcloud.Account{UUID: "{1}"}},
+				{Permission: "write", User: &bitbucketcloud.Account{UUID: "{2}"}},
+			}, nil
+		},
+	})
+
+	userIDs, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+		URI: "bitbucket.org/sourcegraph/private",
+		ExternalRepoSpec: api.ExternalRepoSpec{
+			ID:          "{r1}",
+			ServiceType: extsvc.TypeBitbucketCloud,
+			ServiceID:   "https://bitbucket.org/",
+		},
+	}, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]extsvc.AccountID{"{1}", "{2}"}, userIDs); diff != "" {
+		t.Fatalf("user IDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProvider_ValidateConnection(t *testing.T) {
+	p := newTestProvider(t, &mockClient{
+		currentUser: func(ctx context.Context) (*bitbucketcloud.User, error) {
+			return &bitbucketcloud.User{Account: bitbucketcloud.Account{UUID: "{0}"}}, nil
+		},
+		userRepositoryPermissions: func(ctx context.Context, workspace, userUUID string) ([]*bitbucketcloud.RepositoryPermission, error) {
+			if workspace == "sourcegraph" {
+				return nil, errors.New("403 Forbidden")
+			}
+			return nil, nil
+		},
+	})
+
+	warnings := p.ValidateConnection(context.Background())
+	want := []string{`reading repository permissions of workspace "sourcegraph": 403 Forbidden`}
+	if diff := cmp.Diff(want, warnings); diff != "" {
+		t.Fatalf("warnings mismatch (-want +got):\n%s", diff)
+	}
+}
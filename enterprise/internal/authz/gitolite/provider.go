@@ -0,0 +1,143 @@
+// Package gitolite contains an authorization provider for Gitolite.
+package gitolite
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// lister lists the repositories of a Gitolite server.
+type lister interface {
+	ListRepos(ctx context.Context, gitoliteHost string) ([]*gitolite.Repo, error)
+	ListUserRepos(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error)
+}
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined by the `info` command of a Gitolite server run on behalf of each user.
+type Provider struct {
+	urn      string
+	host     string
+	lister   lister
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Gitolite authorization provider for the Gitolite server at host.
+// It assumes usernames of Sourcegraph accounts match 1-1 with Gitolite user names, so it
+// must only be used when the connection opted in to the username identity provider.
+func NewProvider(urn, host string, l lister) *Provider {
+	return &Provider{
+		urn:    urn,
+		host:   host,
+		lister: l,
+		codeHost: &extsvc.CodeHost{
+			ServiceID:   gitolite.ServiceID(host),
+			ServiceType: extsvc.TypeGitolite,
+		},
+	}
+}
+
+// ValidateConnection validates that the Gitolite server is reachable and that
+// the `info` command can be run on behalf of other users, which requires the
+// `sudo` command to be enabled and Sourcegraph's SSH key to belong to an
+// administrator.
+func (p *Provider) ValidateConnection(ctx context.Context) []string {
+	if _, err := p.lister.ListRepos(ctx, p.host); err != nil {
+		return []string{errors.Wrap(err, "listing repositories").Error()}
+	}
+	// The gitolite-admin user has no special meaning to the `sudo` command, it
+	// only needs to be a syntactically valid user name.
+	if _, err := p.lister.ListUserRepos(ctx, p.host, "gitolite-admin"); err != nil {
+		return []string{errors.Wrap(err, "running the info command on behalf of another user, make sure the sudo command is enabled").Error()}
+	}
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the host of the Gitolite server this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "gitolite".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount returns the Gitolite account of the given user. Gitolite has no
+// API to look up users, so the user name of the account is the username of the
+// user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, _ []string) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(gitolite.AccountData{Username: user.Username})
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   user.Username,
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID, namely the name of the repository in Gitolite.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	user, err := gitolite.GetExternalAccountData(ctx, &account.AccountData)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting external account data")
+	} else if user == nil {
+		return nil, errors.New("no user found in the external account data")
+	}
+
+	repos, err := p.lister.ListUserRepos(ctx, p.host, user.Username)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing user repositories")
+	}
+
+	extIDs := make([]extsvc.RepoID, 0, len(repos))
+	for _, repo := range repos {
+		extIDs = append(extIDs, extsvc.RepoID(repo.Name))
+	}
+	return &authz.ExternalUserPermissions{
+		Exacts: extIDs,
+	}, nil
+}
+
+// FetchUserPermsByToken is the same as FetchUserPerms, but it only requires a
+// token.
+func (p *Provider) FetchUserPermsByToken(ctx context.Context, token string, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "gitolite.FetchUserPermsByToken"}
+}
+
+// FetchRepoPerms is unimplemented because Gitolite cannot list the users who have
+// access to a repository. Repository permissions are only synced from the users'
+// side.
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "gitolite.FetchRepoPerms"}
+}
@@ -0,0 +1,108 @@
+package gitolite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const testHost = "git@gitolite.example.com"
+
+type mockLister struct {
+	listRepos     func(ctx context.Context, gitoliteHost string) ([]*gitolite.Repo, error)
+	listUserRepos func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error)
+}
+
+func (m *mockLister) ListRepos(ctx context.Context, gitoliteHost string) ([]*gitolite.Repo, error) {
+	return m.listRepos(ctx, gitoliteHost)
+}
+
+func (m *mockLister) ListUserRepos(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+	return m.listUserRepos(ctx, gitoliteHost, user)
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := NewProvider("extsvc:gitolite:1", testHost, &mockLister{
+		listUserRepos: func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+			if gitoliteHost != testHost {
+				return nil, errors.Errorf("unexpected host %q", gitoliteHost)
+			}
+			if user != "alice" {
+				return nil, nil
+			}
+			return []*gitolite.Repo{
+				{Name: "myrepo", URL: testHost + ":myrepo"},
+				{Name: "team/secret", URL: testHost + ":team/secret"},
+			}, nil
+		},
+	})
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 1, Username: "alice"}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSpec := extsvc.AccountSpec{
+		ServiceType: extsvc.TypeGitolite,
+		ServiceID:   testHost,
+		AccountID:   "alice",
+	}
+	if diff := cmp.Diff(wantSpec, acct.AccountSpec); diff != "" {
+		t.Fatalf("AccountSpec mismatch (-want +got):\n%s", diff)
+	}
+
+	perms, err := p.FetchUserPerms(context.Background(), acct, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &authz.ExternalUserPermissions{Exacts: []extsvc.RepoID{"myrepo", "team/secret"}}
+	if diff := cmp.Diff(want, perms); diff != "" {
+		t.Fatalf("permissions mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitolite,
+				ServiceID:   "git@other.example.com",
+				AccountID:   "alice",
+			},
+		}, authz.FetchPermsOptions{})
+		if err == nil {
+			t.Fatal("want error but got nil")
+		}
+	})
+}
+
+func TestProvider_ValidateConnection(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		listRepos    error
+		listUserRepo error
+		wantWarnings int
+	}{
+		{name: "ok"},
+		{name: "unreachable", listRepos: errors.New("connection refused"), wantWarnings: 1},
+		{name: "sudo disabled", listUserRepo: errors.New("unknown git/gitolite command: 'sudo'"), wantWarnings: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewProvider("extsvc:gitolite:1", testHost, &mockLister{
+				listRepos: func(ctx context.Context, gitoliteHost string) ([]*gitolite.Repo, error) {
+					return nil, tc.listRepos
+				},
+				listUserRepos: func(ctx context.Context, gitoliteHost, user string) ([]*gitolite.Repo, error) {
+					return nil, tc.listUserRepo
+				},
+			})
+			if warnings := p.ValidateConnection(context.Background()); len(warnings) != tc.wantWarnings {
+				t.Fatalf("want %d warnings but got %v", tc.wantWarnings, warnings)
+			}
+		})
+	}
+}
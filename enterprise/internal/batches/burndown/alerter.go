@@ -0,0 +1,114 @@
+package burndown
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/slack-go/slack"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewAlerter returns an Alerter that sends alerts through the channels
+// configured on the SLA with the sender shared with code monitors, notifying
+// the creator of the SLA by email.
+func NewAlerter(db database.DB) Alerter {
+	return &alerter{db: db}
+}
+
+type alerter struct {
+	db database.DB
+}
+
+var slaEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Batch change {{.Name}} is behind schedule`,
+	Text: `
+The batch change {{.Name}} is behind schedule to be completed by {{.TargetDate}}.
+
+{{.Completed}} of its changesets are merged or closed, but {{.Expected}} should be by now.
+
+View the batch change:
+
+  {{.URL}}
+`,
+	HTML: `
+<p>The batch change <a href="{{.URL}}"><strong>{{.Name}}</strong></a> is behind schedule to be completed by {{.TargetDate}}.</p>
+
+<p>{{.Completed}} of its changesets are merged or closed, but {{.Expected}} should be by now.</p>
+`,
+})
+
+// templateData is the data the alert templates and payloads are rendered
+// from.
+type templateData struct {
+	Name       string `json:"batchChange"`
+	URL        string `json:"url"`
+	TargetDate string `json:"targetDate"`
+	Completed  string `json:"completed"`
+	Expected   string `json:"expected"`
+}
+
+func (a *alerter) Alert(ctx context.Context, alert *Alert) ([]string, error) {
+	u, err := batchChangeURL(ctx, a.db, alert.BatchChange)
+	if err != nil {
+		return alert.SLA.SentAlertChannels, err
+	}
+
+	data := &templateData{
+		Name:       alert.BatchChange.Name,
+		URL:        u,
+		TargetDate: alert.SLA.TargetDate.Format("January 2, 2006"),
+		Completed:  percent(alert.Snapshot.Progress()),
+		Expected:   percent(alert.Expected),
+	}
+
+	send := &background.Alert{
+		EmailTemplates:  slaEmailTemplates,
+		SlackWebhookURL: alert.SLA.SlackWebhookURL,
+		SlackMessage: &slack.WebhookMessage{
+			Text: fmt.Sprintf("Batch change <%s|%s> is behind schedule to be completed by %s: %s of its changesets are merged or closed, but %s should be by now.",
+				data.URL, data.Name, data.TargetDate, data.Completed, data.Expected),
+		},
+		WebhookURL: alert.SLA.WebhookURL,
+		Data:       data,
+	}
+	if alert.SLA.NotifyEmail {
+		send.EmailUserID = alert.SLA.CreatorID
+	}
+	return background.SendAlert(ctx, a.db, send, alert.SLA.SentAlertChannels)
+}
+
+func percent(f float64) string {
+	return fmt.Sprintf("%.0f%%", f*100)
+}
+
+// batchChangeURL returns the absolute URL of the batch change. This needs to
+// be kept consistent with resolvers.batchChangeURL().
+func batchChangeURL(ctx context.Context, db database.DB, bc *btypes.BatchChange) (string, error) {
+	ns, err := db.Namespaces().GetByID(ctx, bc.NamespaceOrgID, bc.NamespaceUserID)
+	if err != nil {
+		return "", errors.Wrap(err, "getting namespace")
+	}
+
+	extStr, err := internalapi.Client.ExternalURL(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "getting external Sourcegraph URL")
+	}
+	extURL, err := url.Parse(extStr)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing external Sourcegraph URL")
+	}
+
+	prefix := "/users/"
+	if ns.Organization != 0 {
+		prefix = "/organizations/"
+	}
+	return extURL.ResolveReference(&url.URL{Path: prefix + ns.Name + "/batch-changes/" + bc.Name}).String(), nil
+}
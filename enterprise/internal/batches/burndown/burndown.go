@@ -0,0 +1,211 @@
+package burndown
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// snapshotInterval is how often the snapshot of the current day is
+	// refreshed. Snapshots are stored per day, so later runs on the same day
+	// overwrite the earlier ones.
+	snapshotInterval = time.Hour
+
+	// pageSize is the number of batch changes loaded from the database at
+	// once.
+	pageSize = 100
+)
+
+// Store is the subset of store.Store the snapshotter uses.
+type Store interface {
+	Clock() func() time.Time
+	ListBatchChanges(ctx context.Context, opts store.ListBatchChangesOpts) ([]*btypes.BatchChange, int64, error)
+	ListChangesets(ctx context.Context, opts store.ListChangesetsOpts) (btypes.Changesets, int64, error)
+	ListChangesetEvents(ctx context.Context, opts store.ListChangesetEventsOpts) ([]*btypes.ChangesetEvent, int64, error)
+	UpsertBatchChangeSnapshot(ctx context.Context, snapshot *btypes.BatchChangeSnapshot) error
+	GetBatchChangeSLA(ctx context.Context, batchChangeID int64) (*btypes.BatchChangeSLA, error)
+	UpdateBatchChangeSLAState(ctx context.Context, sla *btypes.BatchChangeSLA) error
+}
+
+// Alerter sends the alert of a batch change that fell behind its SLA to the
+// recipients configured on the SLA.
+type Alerter interface {
+	Alert(ctx context.Context, alert *Alert) ([]string, error)
+}
+
+// An Alert is sent when the progress of a batch change falls below the
+// projected line of its SLA. It's only sent through the channels that aren't
+// in SLA.SentAlertChannels, and Alert returns the channels it has been sent
+// through.
+type Alert struct {
+	BatchChange *btypes.BatchChange
+	SLA         *btypes.BatchChangeSLA
+	Snapshot    *btypes.BatchChangeSnapshot
+	// Expected is the fraction of changesets that should have been merged or
+	// closed by now.
+	Expected float64
+}
+
+// NewSnapshotter creates a new goroutine.PeriodicGoroutine that records a
+// daily snapshot of the changeset counts of every open batch change, and
+// alerts the recipients of the SLA of a batch change once it falls behind
+// schedule.
+func NewSnapshotter(ctx context.Context, logger log.Logger, s Store, alerter Alerter) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		snapshotInterval,
+		goroutine.NewHandlerWithErrorMessage("taking batch change snapshots", func(ctx context.Context) error {
+			return takeSnapshots(ctx, logger, s, alerter, pageSize)
+		}),
+	)
+}
+
+// takeSnapshots takes the snapshot of every open batch change. A failure to
+// snapshot a single batch change is logged and doesn't stop the others from
+// being snapshotted.
+func takeSnapshots(ctx context.Context, logger log.Logger, s Store, alerter Alerter, pageSize int) error {
+	opts := store.ListBatchChangesOpts{
+		LimitOpts: store.LimitOpts{Limit: pageSize},
+		States:    []btypes.BatchChangeState{btypes.BatchChangeStateOpen},
+	}
+	for {
+		bcs, next, err := s.ListBatchChanges(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, bc := range bcs {
+			if bc.IsDraft() {
+				continue
+			}
+			if err := takeSnapshot(ctx, s, alerter, bc); err != nil {
+				logger.Error("taking batch change snapshot", log.Int64("batchChangeID", bc.ID), log.Error(err))
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		opts.Cursor = next
+	}
+}
+
+func takeSnapshot(ctx context.Context, s Store, alerter Alerter, bc *btypes.BatchChange) error {
+	publishedState := btypes.ChangesetPublicationStatePublished
+	cs, _, err := s.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:    bc.ID,
+		PublicationState: &publishedState,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	var es []*btypes.ChangesetEvent
+	if ids := cs.IDs(); len(ids) > 0 {
+		es, _, err = s.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{ChangesetIDs: ids, Kinds: state.RequiredEventTypesForHistory})
+		if err != nil {
+			return errors.Wrap(err, "listing changeset events")
+		}
+	}
+	// CalcCountsAt depends on the events being sorted.
+	events := state.ChangesetEvents(es)
+	sort.Sort(events)
+
+	now := s.Clock()()
+	counts, err := state.CalcCountsAt(now, cs, events...)
+	if err != nil {
+		return errors.Wrap(err, "calculating changeset counts")
+	}
+	latency, _ := state.CalcReviewLatency(cs, events...)
+
+	snapshot := &btypes.BatchChangeSnapshot{
+		BatchChangeID: bc.ID,
+		Date:          now,
+		Total:         counts.Total,
+		Open:          counts.Open,
+		Draft:         counts.Draft,
+		Merged:        counts.Merged,
+		Closed:        counts.Closed,
+		ReviewLatency: latency,
+	}
+	if err := s.UpsertBatchChangeSnapshot(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "upserting snapshot")
+	}
+
+	sla, err := s.GetBatchChangeSLA(ctx, bc.ID)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return errors.Wrap(err, "getting SLA")
+	}
+
+	return checkSLA(ctx, s, alerter, bc, sla, snapshot, now)
+}
+
+// checkSLA starts the schedule of the SLA once the batch change has published
+// changesets, and sends an alert when a batch change that was on track falls
+// behind schedule. The alert is rearmed once the batch change is back on
+// schedule. When the alert couldn't be sent through all channels, the channels
+// it has been sent through are recorded so that the retry skips them.
+func checkSLA(ctx context.Context, s Store, alerter Alerter, bc *btypes.BatchChange, sla *btypes.BatchChangeSLA, snapshot *btypes.BatchChangeSnapshot, now time.Time) error {
+	updated := sla.Clone()
+	if updated.StartedAt.IsZero() && snapshot.Total > 0 {
+		updated.StartedAt = now
+	}
+
+	switch {
+	case updated.OnTrack && updated.BehindSchedule(now, snapshot):
+		sent, err := alerter.Alert(ctx, &Alert{
+			BatchChange: bc,
+			SLA:         updated,
+			Snapshot:    snapshot,
+			Expected:    updated.ExpectedProgress(now),
+		})
+		if err != nil {
+			err = errors.Wrap(err, "sending SLA alert")
+			updated.SentAlertChannels = sent
+			if updateErr := s.UpdateBatchChangeSLAState(ctx, updated); updateErr != nil {
+				err = errors.Append(err, errors.Wrap(updateErr, "updating SLA state"))
+			}
+			return err
+		}
+		updated.OnTrack = false
+		updated.AlertedAt = now
+		updated.SentAlertChannels = nil
+
+	case !updated.OnTrack && updated.OnSchedule(now, snapshot):
+		updated.OnTrack = true
+	}
+
+	if sameState(updated, sla) {
+		return nil
+	}
+	return s.UpdateBatchChangeSLAState(ctx, updated)
+}
+
+// sameState returns true if the state UpdateBatchChangeSLAState stores is the
+// same in both SLAs.
+func sameState(a, b *btypes.BatchChangeSLA) bool {
+	if !a.StartedAt.Equal(b.StartedAt) || a.OnTrack != b.OnTrack || !a.AlertedAt.Equal(b.AlertedAt) {
+		return false
+	}
+	if len(a.SentAlertChannels) != len(b.SentAlertChannels) {
+		return false
+	}
+	for i := range a.SentAlertChannels {
+		if a.SentAlertChannels[i] != b.SentAlertChannels[i] {
+			return false
+		}
+	}
+	return true
+}
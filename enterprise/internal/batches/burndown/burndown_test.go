@@ -0,0 +1,231 @@
+package burndown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeStore struct {
+	now          time.Time
+	batchChanges []*btypes.BatchChange
+	changesets   map[int64]btypes.Changesets
+	events       []*btypes.ChangesetEvent
+	slas         map[int64]*btypes.BatchChangeSLA
+	snapshots    map[int64]*btypes.BatchChangeSnapshot
+}
+
+func (s *fakeStore) Clock() func() time.Time { return func() time.Time { return s.now } }
+
+func (s *fakeStore) ListBatchChanges(_ context.Context, opts store.ListBatchChangesOpts) ([]*btypes.BatchChange, int64, error) {
+	var bcs []*btypes.BatchChange
+	for _, bc := range s.batchChanges {
+		if opts.Cursor == 0 || bc.ID <= opts.Cursor {
+			bcs = append(bcs, bc)
+		}
+	}
+	if len(bcs) > opts.Limit {
+		return bcs[:opts.Limit], bcs[opts.Limit].ID, nil
+	}
+	return bcs, 0, nil
+}
+
+func (s *fakeStore) ListChangesets(_ context.Context, opts store.ListChangesetsOpts) (btypes.Changesets, int64, error) {
+	return s.changesets[opts.BatchChangeID], 0, nil
+}
+
+func (s *fakeStore) ListChangesetEvents(_ context.Context, opts store.ListChangesetEventsOpts) ([]*btypes.ChangesetEvent, int64, error) {
+	var es []*btypes.ChangesetEvent
+	for _, e := range s.events {
+		for _, id := range opts.ChangesetIDs {
+			if e.ChangesetID == id {
+				es = append(es, e)
+			}
+		}
+	}
+	return es, 0, nil
+}
+
+func (s *fakeStore) UpsertBatchChangeSnapshot(_ context.Context, snapshot *btypes.BatchChangeSnapshot) error {
+	s.snapshots[snapshot.BatchChangeID] = snapshot
+	return nil
+}
+
+func (s *fakeStore) GetBatchChangeSLA(_ context.Context, batchChangeID int64) (*btypes.BatchChangeSLA, error) {
+	sla, ok := s.slas[batchChangeID]
+	if !ok {
+		return nil, store.ErrNoResults
+	}
+	return sla, nil
+}
+
+func (s *fakeStore) UpdateBatchChangeSLAState(_ context.Context, sla *btypes.BatchChangeSLA) error {
+	s.slas[sla.BatchChangeID] = sla
+	return nil
+}
+
+type fakeAlerter struct {
+	alerts []*Alert
+	// skipped are the channels each alert was already sent through.
+	skipped [][]string
+	// fail makes the alerter fail after sending the alert through the first
+	// channel.
+	fail bool
+}
+
+func (a *fakeAlerter) Alert(_ context.Context, alert *Alert) ([]string, error) {
+	a.alerts = append(a.alerts, alert)
+	a.skipped = append(a.skipped, alert.SLA.SentAlertChannels)
+	if a.fail {
+		return append(alert.SLA.SentAlertChannels, "email"), errors.New("delivery failed")
+	}
+	return append(alert.SLA.SentAlertChannels, "email", "webhook"), nil
+}
+
+func TestTakeSnapshots(t *testing.T) {
+	now := time.Date(2022, 10, 20, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-10 * 24 * time.Hour)
+	applied := created.Add(time.Hour)
+
+	changeset := func(id int64) *btypes.Changeset {
+		return &btypes.Changeset{ID: id, Metadata: &github.PullRequest{CreatedAt: applied}}
+	}
+	merged := func(id int64) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{ChangesetID: id, Kind: btypes.ChangesetEventKindGitHubMerged, Metadata: &github.MergedEvent{CreatedAt: applied.Add(time.Hour)}}
+	}
+	closed := func(id int64) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{ChangesetID: id, Kind: btypes.ChangesetEventKindGitHubClosed, Metadata: &github.ClosedEvent{CreatedAt: applied.Add(time.Hour)}}
+	}
+
+	s := &fakeStore{
+		now: now,
+		batchChanges: []*btypes.BatchChange{
+			{ID: 5, Name: "not-started", CreatedAt: created, LastAppliedAt: applied},
+			{ID: 4, Name: "behind-from-start", CreatedAt: created, LastAppliedAt: applied},
+			{ID: 3, Name: "on-schedule", CreatedAt: created, LastAppliedAt: applied},
+			{ID: 2, Name: "behind", CreatedAt: created, LastAppliedAt: applied},
+			{ID: 1, Name: "no-sla", CreatedAt: created, LastAppliedAt: applied},
+		},
+		changesets: map[int64]btypes.Changesets{
+			1: {changeset(1)},
+			2: {
+				changeset(2),
+				changeset(3),
+				changeset(4),
+				changeset(5),
+			},
+			3: {
+				changeset(6),
+				changeset(7),
+				changeset(8),
+			},
+			4: {
+				changeset(9),
+				changeset(10),
+			},
+			5: {
+				changeset(11),
+			},
+		},
+		events: []*btypes.ChangesetEvent{merged(2), merged(6), closed(7)},
+		slas: map[int64]*btypes.BatchChangeSLA{
+			// Halfway to the target date.
+			2: {ID: 20, BatchChangeID: 2, StartedAt: created, TargetDate: now.Add(10 * 24 * time.Hour), OnTrack: true},
+			3: {ID: 30, BatchChangeID: 3, StartedAt: created, TargetDate: now.Add(10 * 24 * time.Hour), AlertedAt: created},
+			4: {ID: 40, BatchChangeID: 4, StartedAt: created, TargetDate: now.Add(10 * 24 * time.Hour)},
+			5: {ID: 50, BatchChangeID: 5, TargetDate: now.Add(10 * 24 * time.Hour)},
+		},
+		snapshots: map[int64]*btypes.BatchChangeSnapshot{},
+	}
+	alerter := &fakeAlerter{}
+
+	if err := takeSnapshots(context.Background(), logtest.Scoped(t), s, alerter, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := len(s.snapshots), 5; have != want {
+		t.Fatalf("wrong number of snapshots. want=%d, have=%d", want, have)
+	}
+	if snapshot := s.snapshots[2]; snapshot.Total != 4 || snapshot.Merged != 1 || snapshot.Open != 3 {
+		t.Errorf("wrong snapshot: %+v", snapshot)
+	}
+
+	if have, want := len(alerter.alerts), 1; have != want {
+		t.Fatalf("wrong number of alerts. want=%d, have=%d", want, have)
+	}
+	if alert := alerter.alerts[0]; alert.BatchChange.ID != 2 || alert.Expected != 0.5 {
+		t.Errorf("wrong alert: %+v", alert)
+	}
+	if sla := s.slas[2]; !sla.AlertedAt.Equal(now) || sla.OnTrack {
+		t.Errorf("SLA that fell behind not updated: %+v", sla)
+	}
+	if sla := s.slas[3]; !sla.OnTrack || !sla.AlertedAt.Equal(created) {
+		t.Errorf("SLA back on schedule not rearmed: %+v", sla)
+	}
+	// A batch change that has never been on track doesn't alert.
+	if sla := s.slas[4]; sla.OnTrack || !sla.AlertedAt.IsZero() {
+		t.Errorf("SLA behind from the start updated: %+v", sla)
+	}
+	// The schedule starts once changesets are published, so no progress is
+	// expected yet.
+	if sla := s.slas[5]; !sla.StartedAt.Equal(now) || !sla.OnTrack {
+		t.Errorf("SLA not started: %+v", sla)
+	}
+
+	if sla := s.slas[2]; len(sla.SentAlertChannels) != 0 {
+		t.Errorf("sent channels not cleared after the alert was sent: %+v", sla)
+	}
+
+	t.Run("only alerts once", func(t *testing.T) {
+		if err := takeSnapshots(context.Background(), logtest.Scoped(t), s, alerter, 2); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(alerter.alerts), 1; have != want {
+			t.Fatalf("wrong number of alerts. want=%d, have=%d", want, have)
+		}
+	})
+}
+
+func TestCheckSLARetry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 10, 20, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-10 * 24 * time.Hour)
+
+	bc := &btypes.BatchChange{ID: 1}
+	s := &fakeStore{slas: map[int64]*btypes.BatchChangeSLA{
+		1: {ID: 10, BatchChangeID: 1, StartedAt: started, TargetDate: now.Add(10 * 24 * time.Hour), OnTrack: true},
+	}}
+	// Halfway to the target date, but nothing merged or closed.
+	snapshot := &btypes.BatchChangeSnapshot{BatchChangeID: 1, Total: 2, Open: 2}
+	alerter := &fakeAlerter{fail: true}
+
+	if err := checkSLA(ctx, s, alerter, bc, s.slas[1], snapshot, now); err == nil {
+		t.Fatal("expected error")
+	}
+	sla := s.slas[1]
+	if !sla.OnTrack || !sla.AlertedAt.IsZero() {
+		t.Fatalf("SLA recorded as alerted although the alert failed: %+v", sla)
+	}
+	if len(sla.SentAlertChannels) != 1 || sla.SentAlertChannels[0] != "email" {
+		t.Fatalf("wrong sent channels recorded: %v", sla.SentAlertChannels)
+	}
+
+	alerter.fail = false
+	if err := checkSLA(ctx, s, alerter, bc, s.slas[1], snapshot, now); err != nil {
+		t.Fatal(err)
+	}
+	if have := alerter.skipped[1]; len(have) != 1 || have[0] != "email" {
+		t.Fatalf("retry doesn't skip the channels already sent: %v", have)
+	}
+	sla = s.slas[1]
+	if sla.OnTrack || !sla.AlertedAt.Equal(now) || len(sla.SentAlertChannels) != 0 {
+		t.Fatalf("SLA not updated after the retry: %+v", sla)
+	}
+}
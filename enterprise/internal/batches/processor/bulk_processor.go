@@ -3,6 +3,7 @@ package processor
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/inconshreveable/log15"
 
@@ -13,6 +14,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -93,6 +96,8 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.reenqueueChangeset(ctx)
 	case btypes.ChangesetJobTypeMerge:
 		return b.mergeChangeset(ctx, job)
+	case btypes.ChangesetJobTypeMergeWhenReady:
+		return b.mergeChangesetWhenReady(ctx, job)
 	case btypes.ChangesetJobTypeClose:
 		return b.closeChangeset(ctx)
 	case btypes.ChangesetJobTypePublish:
@@ -164,6 +169,10 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobMergePayload{}, job.Payload)
 	}
 
+	return b.merge(ctx, typedPayload.Squash)
+}
+
+func (b *bulkProcessor) merge(ctx context.Context, squash bool) (err error) {
 	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
 	if err != nil {
 		return errors.Wrap(err, "loading remote repo")
@@ -174,7 +183,7 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		TargetRepo: b.repo,
 		RemoteRepo: remoteRepo,
 	}
-	if err := b.css.MergeChangeset(ctx, cs, typedPayload.Squash); err != nil {
+	if err := b.css.MergeChangeset(ctx, cs, squash); err != nil {
 		return err
 	}
 
@@ -198,6 +207,208 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 	return nil
 }
 
+// mergeChangesetWhenReady merges the changeset once its checks have passed
+// and it has been approved. The check and review state are kept up to date by
+// the syncer, so until then the job is requeued and checked again later, up
+// to mergeWhenReadyTimeout after the job was created. To not overwhelm CI
+// shared between repositories, merges are rate limited per code host and
+// paused while the base branch of a changeset merged by the same bulk
+// operation has failing checks.
+func (b *bulkProcessor) mergeChangesetWhenReady(ctx context.Context, job *btypes.ChangesetJob) (err error) {
+	typedPayload, ok := job.Payload.(*btypes.ChangesetJobMergeWhenReadyPayload)
+	if !ok {
+		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobMergeWhenReadyPayload{}, job.Payload)
+	}
+
+	now := b.tx.Clock()()
+
+	switch b.ch.ExternalState {
+	case btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft:
+		break
+	default:
+		// The changeset has been merged or closed in the meantime, so there's
+		// nothing left to do.
+		return nil
+	}
+
+	if now.Sub(job.CreatedAt) > mergeWhenReadyTimeout {
+		return mergeWhenReadyTimeoutErr{}
+	}
+
+	// Drafts can't be merged, but might be marked as ready for review later.
+	if b.ch.ExternalState == btypes.ChangesetExternalStateDraft || !readyToMerge(b.ch) {
+		return b.tx.RequeueChangesetJob(ctx, job.ID, now.Add(mergeWhenReadyPollInterval))
+	}
+
+	broken, err := b.baseBranchBroken(ctx, job)
+	if err != nil {
+		return err
+	}
+	if broken {
+		return b.tx.RequeueChangesetJob(ctx, job.ID, now.Add(mergeWhenReadyPollInterval))
+	}
+
+	// Concurrent jobs merging on the same code host are serialized until this
+	// job's transaction ends, so that they can't all see a free slot and
+	// exceed the limit together.
+	if err := b.tx.LockCodeHostMerges(ctx, b.repo.ExternalRepo.ServiceID); err != nil {
+		return errors.Wrap(err, "locking code host merges")
+	}
+	next, err := b.nextMergeSlot(ctx, now)
+	if err != nil {
+		return err
+	}
+	if next.After(now) {
+		return b.tx.RequeueChangesetJob(ctx, job.ID, next)
+	}
+
+	// The reservation is rolled back together with the transaction if the
+	// merge fails.
+	if err := b.tx.ReserveQueuedMerge(ctx, job.ID, now); err != nil {
+		return errors.Wrap(err, "reserving merge")
+	}
+	return b.merge(ctx, typedPayload.Squash)
+}
+
+// mergeWhenReadyPollInterval is how long a "merge when ready" job waits before
+// checking again whether the changeset can be merged.
+const mergeWhenReadyPollInterval = 5 * time.Minute
+
+// mergeWhenReadyTimeout is how long a "merge when ready" job waits for its
+// changeset to become ready to be merged before giving up.
+const mergeWhenReadyTimeout = 7 * 24 * time.Hour
+
+// mergeWhenReadyTimeoutErr is returned when a "merge when ready" job gives up
+// waiting for its changeset to become ready to be merged.
+type mergeWhenReadyTimeoutErr struct{}
+
+func (mergeWhenReadyTimeoutErr) Error() string {
+	return fmt.Sprintf("changeset wasn't ready to be merged within %s", mergeWhenReadyTimeout)
+}
+
+func (mergeWhenReadyTimeoutErr) NonRetryable() bool {
+	return true
+}
+
+// defaultMaxMergesPerHour is the number of changesets merged per hour and code
+// host if batchChanges.mergeQueueMaxMergesPerHour is not set.
+const defaultMaxMergesPerHour = 10
+
+// readyToMerge returns whether the checks of the changeset have passed and it
+// has been approved. Changesets without any checks are considered to have
+// passed them.
+func readyToMerge(ch *btypes.Changeset) bool {
+	if ch.ExternalCheckState != btypes.ChangesetCheckStatePassed && ch.ExternalCheckState != btypes.ChangesetCheckStateUnknown {
+		return false
+	}
+	return ch.ExternalReviewState == btypes.ChangesetReviewStateApproved
+}
+
+// baseBranchBroken returns whether the checks on the base branch of any
+// changeset merged by the bulk operation of the job are failing. Once the
+// checks on the base branch have been found to pass after a merge, or if the
+// code host can't report the state of a branch's checks, the merge isn't
+// considered again.
+func (b *bulkProcessor) baseBranchBroken(ctx context.Context, job *btypes.ChangesetJob) (bool, error) {
+	merges, err := b.tx.ListUncheckedQueuedMerges(ctx, job.BulkGroup)
+	if err != nil {
+		return false, errors.Wrap(err, "loading merged changesets")
+	}
+
+	// Changesets of the same bulk operation often share a repository and base
+	// branch, so we only ask the code host once per branch.
+	type branch struct {
+		repo    api.RepoID
+		baseRef string
+	}
+	states := make(map[branch]btypes.ChangesetCheckState, len(merges))
+
+	for _, m := range merges {
+		merged, err := b.tx.GetChangeset(ctx, store.GetChangesetOpts{ID: m.ChangesetID})
+		if err != nil {
+			return false, errors.Wrap(err, "loading merged changeset")
+		}
+		baseRef, err := merged.BaseRef()
+		if err != nil {
+			return false, errors.Wrap(err, "getting base ref")
+		}
+
+		key := branch{repo: merged.RepoID, baseRef: baseRef}
+		state, ok := states[key]
+		if !ok {
+			state, err = b.loadBaseBranchCheckState(ctx, job, merged.RepoID, baseRef)
+			if err != nil {
+				return false, err
+			}
+			states[key] = state
+		}
+
+		switch state {
+		case btypes.ChangesetCheckStateFailed:
+			return true, nil
+		case btypes.ChangesetCheckStatePending:
+			// The checks triggered by the merge might still fail.
+		default:
+			if err := b.tx.MarkQueuedMergeChecked(ctx, m.ID); err != nil {
+				return false, errors.Wrap(err, "marking merge as checked")
+			}
+		}
+	}
+	return false, nil
+}
+
+// loadBaseBranchCheckState returns the state of the checks on the given base
+// branch of the repository with the given ID. It returns
+// ChangesetCheckStateUnknown if the code host can't report the state of a
+// branch's checks, which is currently only supported on GitHub.
+func (b *bulkProcessor) loadBaseBranchCheckState(ctx context.Context, job *btypes.ChangesetJob, repoID api.RepoID, baseRef string) (btypes.ChangesetCheckState, error) {
+	repo, err := b.tx.Repos().Get(ctx, repoID)
+	if err != nil {
+		return "", errors.Wrap(err, "loading repo")
+	}
+
+	css, err := b.sourcer.ForUser(ctx, b.tx, job.UserID, repo)
+	if err != nil {
+		return "", errors.Wrap(err, "loading ChangesetSource")
+	}
+	checkSource, ok := css.(sources.BaseBranchCheckStateSource)
+	if !ok {
+		return btypes.ChangesetCheckStateUnknown, nil
+	}
+
+	state, err := checkSource.LoadBaseBranchCheckState(ctx, repo, baseRef)
+	if err != nil {
+		return "", errors.Wrap(err, "loading base branch check state")
+	}
+	return state, nil
+}
+
+// nextMergeSlot returns the earliest time at which another changeset can be
+// merged on the code host of the changeset without exceeding
+// batchChanges.mergeQueueMaxMergesPerHour. Callers should hold the lock taken
+// by LockCodeHostMerges.
+func (b *bulkProcessor) nextMergeSlot(ctx context.Context, now time.Time) (time.Time, error) {
+	limit := defaultMaxMergesPerHour
+	if l := conf.Get().BatchChangesMergeQueueMaxMergesPerHour; l != nil {
+		limit = *l
+	}
+	if limit == 0 {
+		return now, nil
+	}
+
+	merges, err := b.tx.ListQueuedMergeTimes(ctx, b.repo.ExternalRepo.ServiceID, now.Add(-time.Hour))
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "loading recent merges")
+	}
+	if len(merges) < limit {
+		return now, nil
+	}
+
+	// Once the merges exceeding the limit are more than an hour old, there's
+	// room for another one.
+	return merges[len(merges)-limit].Add(time.Hour), nil
+}
+
 func (b *bulkProcessor) closeChangeset(ctx context.Context) (err error) {
 	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -199,6 +200,66 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Merge when ready job not ready", func(t *testing.T) {
+		changeset := bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []types.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			Metadata:            &github.PullRequest{},
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:  btypes.ChangesetCheckStatePending,
+		})
+		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: stesting.NewFakeSourcer(nil, fake),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeMergeWhenReady,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobMergeWhenReadyPayload{},
+			CreatedAt:   bstore.Clock()(),
+		}
+		err := bp.Process(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset not to be called but was")
+		}
+	})
+
+	t.Run("Merge when ready job timed out", func(t *testing.T) {
+		changeset := bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []types.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			Metadata:            &github.PullRequest{},
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ExternalCheckState:  btypes.ChangesetCheckStatePending,
+		})
+		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: stesting.NewFakeSourcer(nil, fake),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeMergeWhenReady,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobMergeWhenReadyPayload{},
+			CreatedAt:   bstore.Clock()().Add(-mergeWhenReadyTimeout - time.Minute),
+		}
+		err := bp.Process(ctx, job)
+		if !errcode.IsNonRetryable(err) {
+			t.Fatalf("expected non-retryable timeout error, got %v", err)
+		}
+		if fake.MergeChangesetCalled {
+			t.Fatal("expected MergeChangeset not to be called but was")
+		}
+	})
+
 	t.Run("Close job", func(t *testing.T) {
 		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
 		bp := &bulkProcessor{
@@ -375,3 +436,27 @@ func TestBulkProcessor(t *testing.T) {
 		})
 	})
 }
+
+func TestReadyToMerge(t *testing.T) {
+	for name, tc := range map[string]struct {
+		checkState  btypes.ChangesetCheckState
+		reviewState btypes.ChangesetReviewState
+		want        bool
+	}{
+		"passed and approved":     {btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateApproved, true},
+		"no checks and approved":  {btypes.ChangesetCheckStateUnknown, btypes.ChangesetReviewStateApproved, true},
+		"pending and approved":    {btypes.ChangesetCheckStatePending, btypes.ChangesetReviewStateApproved, false},
+		"failed and approved":     {btypes.ChangesetCheckStateFailed, btypes.ChangesetReviewStateApproved, false},
+		"passed and not reviewed": {btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStatePending, false},
+		"passed and changes requested": {
+			btypes.ChangesetCheckStatePassed, btypes.ChangesetReviewStateChangesRequested, false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ch := &btypes.Changeset{ExternalCheckState: tc.checkState, ExternalReviewState: tc.reviewState}
+			if have := readyToMerge(ch); have != tc.want {
+				t.Errorf("wrong result: have=%t want=%t", have, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package rebaser
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+const (
+	checkInterval = 10 * time.Minute
+
+	// pageSize is the number of changesets loaded from the database at once.
+	pageSize = 500
+)
+
+// GitserverClient is the subset of gitserver.Client the rebaser needs to
+// look up the latest commit of a base branch.
+type GitserverClient interface {
+	ResolveRevision(ctx context.Context, repo api.RepoName, spec string, opt gitserver.ResolveRevisionOptions) (api.CommitID, error)
+}
+
+// Store is the subset of store.Store the rebaser uses.
+type Store interface {
+	ListChangesetsToRebase(ctx context.Context, opts store.ListChangesetsToRebaseOpts) ([]*store.RebaseCandidate, error)
+	EnqueueChangesetRebase(ctx context.Context, id int64, onto api.CommitID) error
+}
+
+// NewRebaser creates a new goroutine.PeriodicGoroutine that checks whether
+// the base branches of open changesets have moved on, and enqueues the
+// changesets to be rebased by the reconciler if so. It only does so if
+// batchChanges.autoRebase is enabled in the site configuration.
+func NewRebaser(ctx context.Context, logger log.Logger, s Store, client GitserverClient) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		checkInterval,
+		goroutine.NewHandlerWithErrorMessage("checking changesets to rebase", func(ctx context.Context) error {
+			// get the configuration value when the handler runs to get the latest value
+			if !conf.Get().BatchChangesAutoRebase {
+				return nil
+			}
+			return enqueueRebases(ctx, logger, s, client, pageSize)
+		}),
+	)
+}
+
+// enqueueRebases enqueues a rebase for every open changeset whose base branch
+// points to a commit other than the one the changeset is based on. Changesets
+// that already conflicted with the current base commit are skipped, so that
+// they're only retried once the base branch moves on again.
+func enqueueRebases(ctx context.Context, logger log.Logger, s Store, client GitserverClient, pageSize int) error {
+	// Changesets in the same repository usually share a base branch, so we
+	// only resolve each of them once per run.
+	type baseRef struct {
+		repo api.RepoName
+		ref  string
+	}
+	heads := make(map[baseRef]api.CommitID)
+
+	opts := store.ListChangesetsToRebaseOpts{Limit: pageSize}
+	for {
+		cs, err := s.ListChangesetsToRebase(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cs {
+			ref := baseRef{repo: c.RepoName, ref: c.BaseRef}
+			head, ok := heads[ref]
+			if !ok {
+				head, err = client.ResolveRevision(ctx, c.RepoName, c.BaseRef, gitserver.ResolveRevisionOptions{})
+				if err != nil {
+					// The repository or branch might have gone away, in which
+					// case there's nothing to rebase onto.
+					logger.Warn("resolving base ref", log.String("repo", string(c.RepoName)), log.String("ref", c.BaseRef), log.Error(err))
+				}
+				heads[ref] = head
+			}
+
+			if head == "" || string(head) == c.BaseRev || string(head) == c.ConflictingBaseRev {
+				continue
+			}
+
+			if err := s.EnqueueChangesetRebase(ctx, c.ChangesetID, head); err != nil {
+				return err
+			}
+		}
+
+		if len(cs) < pageSize {
+			return nil
+		}
+		opts.Cursor = cs[len(cs)-1].ChangesetID
+	}
+}
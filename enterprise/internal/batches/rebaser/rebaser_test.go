@@ -0,0 +1,83 @@
+package rebaser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type fakeStore struct {
+	candidates []*store.RebaseCandidate
+	enqueued   map[int64]api.CommitID
+}
+
+func (s *fakeStore) ListChangesetsToRebase(_ context.Context, opts store.ListChangesetsToRebaseOpts) ([]*store.RebaseCandidate, error) {
+	var cs []*store.RebaseCandidate
+	for _, c := range s.candidates {
+		if c.ChangesetID > opts.Cursor && len(cs) < opts.Limit {
+			cs = append(cs, c)
+		}
+	}
+	return cs, nil
+}
+
+func (s *fakeStore) EnqueueChangesetRebase(_ context.Context, id int64, onto api.CommitID) error {
+	s.enqueued[id] = onto
+	return nil
+}
+
+type fakeGitserverClient struct {
+	heads map[string]api.CommitID
+	calls int
+}
+
+func (c *fakeGitserverClient) ResolveRevision(_ context.Context, repo api.RepoName, spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+	c.calls++
+	head, ok := c.heads[string(repo)+"@"+spec]
+	if !ok {
+		return "", errors.New("revision not found")
+	}
+	return head, nil
+}
+
+func TestEnqueueRebases(t *testing.T) {
+	s := &fakeStore{
+		candidates: []*store.RebaseCandidate{
+			// Up to date.
+			{ChangesetID: 1, RepoName: "a", BaseRef: "refs/heads/main", BaseRev: "head-a"},
+			// Outdated.
+			{ChangesetID: 2, RepoName: "a", BaseRef: "refs/heads/main", BaseRev: "old-a"},
+			// Already conflicting with the current head.
+			{ChangesetID: 3, RepoName: "a", BaseRef: "refs/heads/main", BaseRev: "old-a", ConflictingBaseRev: "head-a"},
+			// Conflicting with an older head.
+			{ChangesetID: 4, RepoName: "b", BaseRef: "refs/heads/main", BaseRev: "old-b", ConflictingBaseRev: "older-b"},
+			// Base ref doesn't exist anymore.
+			{ChangesetID: 5, RepoName: "c", BaseRef: "refs/heads/main", BaseRev: "old-c"},
+		},
+		enqueued: map[int64]api.CommitID{},
+	}
+	client := &fakeGitserverClient{heads: map[string]api.CommitID{
+		"a@refs/heads/main": "head-a",
+		"b@refs/heads/main": "head-b",
+	}}
+
+	// Use a small page size to exercise pagination.
+	if err := enqueueRebases(context.Background(), logtest.Scoped(t), s, client, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[int64]api.CommitID{2: "head-a", 4: "head-b"}
+	if diff := cmp.Diff(want, s.enqueued); diff != "" {
+		t.Errorf("wrong changesets enqueued (-want +got):\n%s", diff)
+	}
+	if have, want := client.calls, 3; have != want {
+		t.Errorf("wrong number of resolved revisions: have=%d want=%d", have, want)
+	}
+}
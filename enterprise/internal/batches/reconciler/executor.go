@@ -10,6 +10,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/hmarr/codeowners"
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/log"
@@ -22,8 +23,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -93,6 +97,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationPush:
 			err = e.pushChangesetPatch(ctx)
 
+		case btypes.ReconcilerOperationRebase:
+			err = e.rebaseChangeset(ctx)
+
 		case btypes.ReconcilerOperationPublish:
 			err = e.publishChangeset(ctx, false)
 
@@ -165,6 +172,58 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 		return errPublishSameBranch{}
 	}
 
+	// Changesets with dependencies are only pushed for real once their
+	// dependencies have been merged into the base branch, so we rebase them
+	// onto its latest commit instead of the one the batch spec was run on.
+	baseCommit := api.CommitID(e.spec.BaseRev)
+	if len(e.spec.DependsOn) > 0 {
+		baseCommit, err = e.gitserverClient.ResolveRevision(ctx, e.targetRepo.Name, e.spec.BaseRef, gitserver.ResolveRevisionOptions{})
+		if err != nil {
+			return errors.Wrap(err, "resolving base ref")
+		}
+	}
+
+	if err := e.pushChangesetCommit(ctx, baseCommit); err != nil {
+		return err
+	}
+
+	// The new commit supersedes any pending rebase.
+	e.ch.BaseRev = string(baseCommit)
+	e.ch.RebaseOnto = ""
+	e.ch.ConflictingBaseRev = ""
+	return nil
+}
+
+// rebaseChangeset recreates the commit of the changeset on top of the commit
+// of the base branch it should be rebased onto and pushes it. If the diff
+// doesn't apply cleanly on top of that commit, the changeset is marked as
+// conflicting instead.
+func (e *executor) rebaseChangeset(ctx context.Context) error {
+	onto := e.ch.RebaseOnto
+	if onto == "" {
+		return nil
+	}
+
+	err := e.pushChangesetCommit(ctx, api.CommitID(onto))
+	var pce pushCommitError
+	if errors.As(err, &pce) && pce.patchConflict() {
+		e.ch.RebaseOnto = ""
+		e.ch.ConflictingBaseRev = onto
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	e.ch.BaseRev = onto
+	e.ch.RebaseOnto = ""
+	e.ch.ConflictingBaseRev = ""
+	return nil
+}
+
+// pushChangesetCommit creates a commit for the diff of the changeset spec on
+// top of baseCommit and pushes it to the code host.
+func (e *executor) pushChangesetCommit(ctx context.Context, baseCommit api.CommitID) error {
 	// Create a commit and push it
 	// Figure out which authenticator we should use to modify the changeset.
 	// au is nil if we want to use the global credentials stored in the external
@@ -192,6 +251,7 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	opts.BaseCommit = baseCommit
 
 	err = e.pushCommit(ctx, opts)
 	var pce pushCommitError
@@ -273,11 +333,94 @@ func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err erro
 			}
 		}
 	}
+
+	if e.spec.RequestCodeOwnerReviews {
+		// Failing to request reviews shouldn't fail the publication, since the
+		// changeset already exists on the code host at this point.
+		if err := e.requestCodeOwnerReviews(ctx, css, cs); err != nil {
+			e.logger.Warn("requesting code owner reviews", log.Int64("changeset", e.ch.ID), log.Error(err))
+		}
+	}
+
 	// Set the changeset to published.
 	e.ch.PublicationState = btypes.ChangesetPublicationStatePublished
 	return nil
 }
 
+// requestCodeOwnerReviews requests reviews on the published changeset from the
+// owners of the changed paths, as defined by the CODEOWNERS file on the base
+// revision of the target repository.
+func (e *executor) requestCodeOwnerReviews(ctx context.Context, css sources.ChangesetSource, cs *sources.Changeset) error {
+	rcss, ok := css.(sources.ReviewerChangesetSource)
+	if !ok {
+		return nil
+	}
+
+	paths, err := e.spec.ChangedPaths()
+	if err != nil {
+		return errors.Wrap(err, "parsing diff")
+	}
+
+	ruleset, err := codeownership.NewRuleset(ctx, e.gitserverClient, e.targetRepo.Name, api.CommitID(e.spec.BaseRev))
+	if err != nil {
+		return errors.Wrap(err, "loading CODEOWNERS")
+	}
+
+	// The author of a changeset can't be requested to review it.
+	author, err := cs.Changeset.AuthorName()
+	if err != nil {
+		return errors.Wrap(err, "getting changeset author")
+	}
+
+	reviewers, err := codeOwnerReviewers(&ruleset, paths, author)
+	if err != nil {
+		return err
+	}
+	if reviewers.IsEmpty() {
+		return nil
+	}
+
+	return rcss.RequestReviewers(ctx, cs, reviewers)
+}
+
+// codeOwnerReviewers returns the reviewers owning any of the given paths,
+// except for the given author. Owners identified by email address are left to
+// the changeset source to resolve to users on the code host.
+func codeOwnerReviewers(ruleset *codeownership.Ruleset, paths []string, author string) (sources.ChangesetReviewers, error) {
+	var reviewers sources.ChangesetReviewers
+	seen := map[codeowners.Owner]struct{}{}
+	for _, path := range paths {
+		owners, err := ruleset.Match(path)
+		if err != nil {
+			return reviewers, errors.Wrapf(err, "matching owners of %q", path)
+		}
+
+		for _, owner := range owners {
+			if _, ok := seen[owner]; ok {
+				continue
+			}
+			seen[owner] = struct{}{}
+
+			switch owner.Type {
+			case codeowners.UsernameOwner:
+				if strings.EqualFold(owner.Value, author) {
+					continue
+				}
+				reviewers.Usernames = append(reviewers.Usernames, owner.Value)
+			case codeowners.EmailOwner:
+				reviewers.Emails = append(reviewers.Emails, owner.Value)
+			case codeowners.TeamOwner:
+				// Teams are given as org/team, but code hosts expect the slug
+				// of a team in the organization owning the repository.
+				if _, slug, ok := strings.Cut(owner.Value, "/"); ok {
+					reviewers.Teams = append(reviewers.Teams, slug)
+				}
+			}
+		}
+	}
+	return reviewers, nil
+}
+
 func (e *executor) syncChangeset(ctx context.Context) error {
 	if err := e.loadChangeset(ctx); err != nil {
 		if !errors.HasType(err, sources.ChangesetNotFoundError{}) {
@@ -561,6 +704,12 @@ func (e pushCommitError) Error() string {
 		e.RepositoryName, e.InternalError, e.Command, strings.TrimSpace(e.CombinedOutput))
 }
 
+// patchConflict returns whether the commit couldn't be created because the
+// patch doesn't apply to the base commit.
+func (e pushCommitError) patchConflict() bool {
+	return strings.HasPrefix(e.Command, "git apply")
+}
+
 func (e *executor) pushCommit(ctx context.Context, opts protocol.CreateCommitFromPatchRequest) error {
 	_, err := e.gitserverClient.CreateCommitFromPatch(ctx, opts)
 	if err != nil {
@@ -638,6 +787,15 @@ func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *pro
 		Push:         pushOpts,
 	}
 
+	// Gerrit creates and updates changes when commits are pushed to its magic
+	// ref, so we have to push there and make sure the commit carries a stable
+	// Change-Id.
+	if repo.ExternalRepo.ServiceType == extsvc.TypeGerrit {
+		opts.CommitInfo.Message = sources.GerritCommitMessage(spec.CommitMessage, sources.GenerateGerritChangeID(repo, spec.HeadRef))
+		pushRef := sources.GerritPushRef(spec.BaseRef, spec.HeadRef)
+		opts.PushRef = &pushRef
+	}
+
 	return opts, nil
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/batches/git"
@@ -1119,6 +1120,61 @@ func TestDecorateChangesetBody(t *testing.T) {
 	}
 }
 
+func TestCodeOwnerReviewers(t *testing.T) {
+	ctx := context.Background()
+	gitserverClient := &bt.FakeGitserverClient{
+		ReadFileResponses: map[string][]byte{
+			".github/CODEOWNERS": []byte(`
+*.go @gopher
+/docs/ @acme/docs-team writer@example.com
+/docs/api/ @gopher @acme/api-team
+`),
+		},
+	}
+
+	ruleset, err := codeownership.NewRuleset(ctx, gitserverClient, "github.com/acme/repo", "deadbeef")
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		paths  []string
+		author string
+		want   sources.ChangesetReviewers
+	}{
+		"no owners": {
+			paths: []string{"README.md"},
+			want:  sources.ChangesetReviewers{},
+		},
+		"users, emails and teams": {
+			paths: []string{"main.go", "docs/index.md"},
+			want: sources.ChangesetReviewers{
+				Usernames: []string{"gopher"},
+				Emails:    []string{"writer@example.com"},
+				Teams:     []string{"docs-team"},
+			},
+		},
+		"deduplicated": {
+			paths: []string{"main.go", "docs/api/index.md", "cmd/main.go"},
+			want: sources.ChangesetReviewers{
+				Usernames: []string{"gopher"},
+				Teams:     []string{"api-team"},
+			},
+		},
+		"author skipped": {
+			paths:  []string{"main.go", "docs/api/index.md"},
+			author: "Gopher",
+			want: sources.ChangesetReviewers{
+				Teams: []string{"api-team"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := codeOwnerReviewers(&ruleset, tc.paths, tc.author)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
 func TestHandleArchivedRepo(t *testing.T) {
 	ctx := context.Background()
 
@@ -15,6 +15,7 @@ var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationDetach:       0,
 	btypes.ReconcilerOperationArchive:      0,
 	btypes.ReconcilerOperationReattach:     0,
+	btypes.ReconcilerOperationRebase:       0,
 	btypes.ReconcilerOperationImport:       1,
 	btypes.ReconcilerOperationPublish:      1,
 	btypes.ReconcilerOperationPublishDraft: 1,
@@ -50,6 +51,16 @@ func (ops Operations) Equal(b Operations) bool {
 	return true
 }
 
+// Contains returns whether the given operation is one of the operations.
+func (ops Operations) Contains(op btypes.ReconcilerOperation) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
 func (ops Operations) String() string {
 	if ops.IsNone() {
 		return "No operations required"
@@ -103,6 +114,66 @@ type Plan struct {
 func (p *Plan) AddOp(op btypes.ReconcilerOperation) { p.Ops = append(p.Ops, op) }
 func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 
+// HoldForDependencies changes the plan of a changeset that depends on
+// changesets that haven't been merged yet, so that it isn't published: if the
+// plan would publish the changeset, it is published as a draft instead if the
+// code host supports it, and kept unpublished otherwise. Drafts aren't
+// undrafted.
+func (p *Plan) HoldForDependencies() {
+	ops := make(Operations, 0, len(p.Ops))
+	for _, op := range p.Ops {
+		switch op {
+		case btypes.ReconcilerOperationPublish:
+			if p.Changeset.SupportsDraft() {
+				ops = append(ops, btypes.ReconcilerOperationPublishDraft)
+			}
+		case btypes.ReconcilerOperationPush:
+			if p.Changeset.Published() || p.Changeset.SupportsDraft() {
+				ops = append(ops, op)
+			}
+		case btypes.ReconcilerOperationUndraft:
+			// Wait until the dependencies have been merged.
+		default:
+			ops = append(ops, op)
+		}
+	}
+	p.Ops = ops
+}
+
+// ReleaseFromDependencies changes the plan of a changeset whose dependencies
+// have all been merged, so that a draft that was held back is rebased onto the
+// base branch when it gets undrafted.
+func (p *Plan) ReleaseFromDependencies() {
+	for _, op := range p.Ops {
+		if op == btypes.ReconcilerOperationUndraft {
+			p.AddOp(btypes.ReconcilerOperationPush)
+			return
+		}
+	}
+}
+
+// HoldForRollout changes the plan of an unpublished changeset that hasn't been
+// released by the rollout of its batch change yet, so that it stays
+// unpublished.
+func (p *Plan) HoldForRollout() {
+	ops := make(Operations, 0, len(p.Ops))
+	for _, op := range p.Ops {
+		switch op {
+		case btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPublishDraft, btypes.ReconcilerOperationPush:
+			// Wait until the rollout reaches the changeset.
+		default:
+			ops = append(ops, op)
+		}
+	}
+	p.Ops = ops
+}
+
+// Publishes returns true if the plan publishes the changeset, either as a
+// draft or not.
+func (p *Plan) Publishes() bool {
+	return p.Ops.Contains(btypes.ReconcilerOperationPublish) || p.Ops.Contains(btypes.ReconcilerOperationPublishDraft)
+}
+
 // DeterminePlan looks at the given changeset to determine what action the
 // reconciler should take.
 // It consumes the current and the previous changeset spec, if they exist. If
@@ -248,6 +319,17 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 			}
 		}
 
+		// If the base branch moved on since the commit of the changeset was
+		// created, we recreate the commit on top of it, unless we're pushing
+		// a new commit anyway.
+		if wantedChangeset.RebaseOnto != "" && !pl.Ops.Contains(btypes.ReconcilerOperationPush) {
+			pl.AddOp(btypes.ReconcilerOperationRebase)
+			if !pl.Ops.Contains(btypes.ReconcilerOperationSync) {
+				pl.AddOp(btypes.ReconcilerOperationSleep)
+				pl.AddOp(btypes.ReconcilerOperationSync)
+			}
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", wantedChangeset.PublicationState)
 	}
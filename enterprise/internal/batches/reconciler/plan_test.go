@@ -419,6 +419,47 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationImport,
 			},
 		},
+		{
+			name:         "rebase",
+			previousSpec: &bt.TestSpecOpts{Published: true},
+			currentSpec:  &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				RebaseOnto:       "d34db33f",
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationRebase,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "rebase with commit update",
+			previousSpec: &bt.TestSpecOpts{Published: true, CommitDiff: "old diff"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, CommitDiff: "new diff"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				RebaseOnto:       "d34db33f",
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "rebase merged changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true},
+			currentSpec:  &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateMerged,
+				RebaseOnto:       "d34db33f",
+			},
+			wantOperations: Operations{},
+		},
 		{
 			name: "detaching an importing changeset but remains imported by another",
 			changeset: bt.TestChangesetOpts{
@@ -463,3 +504,141 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 func uiPublicationStatePtr(state btypes.ChangesetUiPublicationState) *btypes.ChangesetUiPublicationState {
 	return &state
 }
+
+func TestPlan_HoldForDependencies(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name           string
+		changeset      bt.TestChangesetOpts
+		ops            Operations
+		wantOperations Operations
+	}{
+		{
+			name: "publish with draft support",
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			},
+			ops: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationPublish,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationPublishDraft,
+			},
+		},
+		{
+			name: "publish without draft support",
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStateUnpublished,
+			},
+			ops: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationPublish,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name: "undraft",
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateDraft,
+			},
+			ops: Operations{
+				btypes.ReconcilerOperationUndraft,
+				btypes.ReconcilerOperationUpdate,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationUpdate,
+			},
+		},
+		{
+			name: "push to draft",
+			changeset: bt.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStatePublished,
+				ExternalState:       btypes.ChangesetExternalStateDraft,
+			},
+			ops: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := &Plan{Changeset: bt.BuildChangeset(tc.changeset), Ops: tc.ops}
+			plan.HoldForDependencies()
+			if have, want := plan.Ops, tc.wantOperations; !have.Equal(want) {
+				t.Fatalf("incorrect plan determined, want=%v have=%v", want, have)
+			}
+		})
+	}
+}
+
+func TestPlan_ReleaseFromDependencies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("undraft", func(t *testing.T) {
+		plan := &Plan{Ops: Operations{btypes.ReconcilerOperationUndraft}}
+		plan.ReleaseFromDependencies()
+		want := Operations{btypes.ReconcilerOperationUndraft, btypes.ReconcilerOperationPush}
+		if !plan.Ops.Equal(want) {
+			t.Fatalf("incorrect plan determined, want=%v have=%v", want, plan.Ops)
+		}
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		plan := &Plan{Ops: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish}}
+		plan.ReleaseFromDependencies()
+		want := Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish}
+		if !plan.Ops.Equal(want) {
+			t.Fatalf("incorrect plan determined, want=%v have=%v", want, plan.Ops)
+		}
+	})
+}
+
+func TestPlan_HoldForRollout(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		ops            Operations
+		wantOperations Operations
+	}{
+		"publish": {
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantOperations: Operations{},
+		},
+		"publish draft": {
+			ops:            Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublishDraft},
+			wantOperations: Operations{},
+		},
+		"reattach": {
+			ops:            Operations{btypes.ReconcilerOperationReattach, btypes.ReconcilerOperationPush, btypes.ReconcilerOperationPublish},
+			wantOperations: Operations{btypes.ReconcilerOperationReattach},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			plan := &Plan{Ops: tc.ops}
+			if !plan.Publishes() {
+				t.Fatal("plan doesn't publish")
+			}
+			plan.HoldForRollout()
+			if have, want := plan.Ops, tc.wantOperations; !have.Equal(want) {
+				t.Fatalf("incorrect plan determined, want=%v have=%v", want, have)
+			}
+			if plan.Publishes() {
+				t.Fatal("plan still publishes")
+			}
+		})
+	}
+}
@@ -7,15 +7,22 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type GitserverClient interface {
 	CreateCommitFromPatch(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error)
+	ResolveRevision(ctx context.Context, repo api.RepoName, spec string, opt gitserver.ResolveRevisionOptions) (api.CommitID, error)
+	ReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, name string, checker authz.SubRepoPermissionChecker) ([]byte, error)
 }
 
 // Reconciler processes changesets and reconciles their current state — in
@@ -84,6 +91,28 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return err
 	}
 
+	if curr != nil && len(curr.DependsOn) > 0 {
+		pending, err := pendingDependencies(ctx, tx, ch, curr)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			plan.HoldForDependencies()
+		} else {
+			plan.ReleaseFromDependencies()
+		}
+	}
+
+	if curr != nil && plan.Publishes() {
+		released, err := releasedByRollout(ctx, tx, ch)
+		if err != nil {
+			return err
+		}
+		if !released {
+			plan.HoldForRollout()
+		}
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
@@ -112,3 +141,56 @@ func loadChangesetSpecs(ctx context.Context, tx *store.Store, ch *btypes.Changes
 	}
 	return
 }
+
+// pendingDependencies returns the number of changesets the given changeset
+// depends on that haven't been merged yet. An error is returned if a dependency
+// can't be found in the batch change that owns the changeset.
+func pendingDependencies(ctx context.Context, tx *store.Store, ch *btypes.Changeset, spec *btypes.ChangesetSpec) (int, error) {
+	deps, err := tx.GetChangesetDependencies(ctx, ch.OwnedByBatchChangeID, spec.DependsOn)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, dep := range spec.DependsOn {
+		parent, ok := deps[dep]
+		if !ok {
+			return 0, errors.Newf("changeset depends on %s in %s, which is not part of the batch change", dep.HeadRef, dep.Repository)
+		}
+		if parent.ExternalState != btypes.ChangesetExternalStateMerged {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// releasedByRollout returns true if the given changeset may be published
+// according to the rollout defined in the current batch spec of the batch
+// change that owns it. Changesets of batch changes without a rollout are always
+// released. The stage of the rollout is read from the batch change, where it's
+// kept up to date when changesets are synced, so that reconciling a changeset
+// doesn't require looking at all other changesets of the batch change.
+func releasedByRollout(ctx context.Context, tx *store.Store, ch *btypes.Changeset) (bool, error) {
+	if ch.OwnedByBatchChangeID == 0 {
+		return true, nil
+	}
+
+	batchChange, err := tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: ch.OwnedByBatchChangeID})
+	if err != nil {
+		return false, errors.Wrap(err, "getting batch change")
+	}
+	batchSpec, err := tx.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return false, errors.Wrap(err, "getting batch spec")
+	}
+	if batchSpec.Spec == nil || batchSpec.Spec.Rollout == nil {
+		return true, nil
+	}
+
+	position, err := tx.GetChangesetRolloutPosition(ctx, ch)
+	if err != nil {
+		return false, errors.Wrap(err, "getting rollout position")
+	}
+
+	return state.IsReleasedInStage(batchSpec.Spec.Rollout, batchChange.RolloutStage, position), nil
+}
@@ -90,6 +90,12 @@ type operations struct {
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
+	createBatchChangeTemplate            *observation.Operation
+	updateBatchChangeTemplate            *observation.Operation
+	deleteBatchChangeTemplate            *observation.Operation
+	createBatchSpecFromTemplate          *observation.Operation
+	setBatchChangeSLA                    *observation.Operation
+	deleteBatchChangeSLA                 *observation.Operation
 }
 
 var (
@@ -140,6 +146,12 @@ func newOperations(observationContext *observation.Context) *operations {
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
+			createBatchChangeTemplate:            op("CreateBatchChangeTemplate"),
+			updateBatchChangeTemplate:            op("UpdateBatchChangeTemplate"),
+			deleteBatchChangeTemplate:            op("DeleteBatchChangeTemplate"),
+			createBatchSpecFromTemplate:          op("CreateBatchSpecFromTemplate"),
+			setBatchChangeSLA:                    op("SetBatchChangeSLA"),
+			deleteBatchChangeSLA:                 op("DeleteBatchChangeSLA"),
 		}
 	})
 
@@ -997,6 +1009,183 @@ func (s *Service) DeleteBatchChange(ctx context.Context, id int64) (err error) {
 	return s.store.DeleteBatchChange(ctx, id)
 }
 
+type CreateBatchChangeTemplateOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	Name        string
+	Description string
+	RawTemplate string
+	InputSchema string
+}
+
+// CreateBatchChangeTemplate validates and creates a new batch change template
+// in the given namespace. It enforces namespace permissions of the caller.
+func (s *Service) CreateBatchChangeTemplate(ctx context.Context, opts CreateBatchChangeTemplateOpts) (template *btypes.BatchChangeTemplate, err error) {
+	ctx, _, endObservation := s.operations.createBatchChangeTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: Check whether the current user has access to either one of
+	// the namespaces.
+	if err := s.CheckNamespaceAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	template = &btypes.BatchChangeTemplate{
+		Name:            opts.Name,
+		Description:     opts.Description,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+		// Actor is guaranteed to be set here, because CheckNamespaceAccess
+		// above enforces it.
+		CreatorID:   actor.FromContext(ctx).UID,
+		RawTemplate: opts.RawTemplate,
+		InputSchema: opts.InputSchema,
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.CreateBatchChangeTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+type UpdateBatchChangeTemplateOpts struct {
+	ID int64
+
+	// The fields that are nil are left unchanged.
+	Name        *string
+	Description *string
+	RawTemplate *string
+	InputSchema *string
+}
+
+// UpdateBatchChangeTemplate updates the given fields of the batch change
+// template with the given ID, if the current user has access to its namespace.
+// The updated template is validated as a whole.
+func (s *Service) UpdateBatchChangeTemplate(ctx context.Context, opts UpdateBatchChangeTemplateOpts) (template *btypes.BatchChangeTemplate, err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	template, err = s.store.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: opts.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users who can administer the namespace of the template
+	// can update it.
+	if err := s.CheckNamespaceAccess(ctx, template.NamespaceUserID, template.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	if opts.Name != nil {
+		template.Name = *opts.Name
+	}
+	if opts.Description != nil {
+		template.Description = *opts.Description
+	}
+	if opts.RawTemplate != nil {
+		template.RawTemplate = *opts.RawTemplate
+	}
+	if opts.InputSchema != nil {
+		template.InputSchema = *opts.InputSchema
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.UpdateBatchChangeTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteBatchChangeTemplate deletes the batch change template with the given
+// ID, if the current user has access to its namespace.
+func (s *Service) DeleteBatchChangeTemplate(ctx context.Context, id int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchChangeTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	template, err := s.store.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only users who can administer the namespace of the template
+	// can delete it.
+	if err := s.CheckNamespaceAccess(ctx, template.NamespaceUserID, template.NamespaceOrgID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteBatchChangeTemplate(ctx, id)
+}
+
+type SetBatchChangeSLAOpts struct {
+	BatchChangeID int64
+
+	TargetDate      time.Time
+	NotifyEmail     bool
+	SlackWebhookURL string
+	WebhookURL      string
+}
+
+// SetBatchChangeSLA creates or replaces the SLA of the batch change with the
+// given ID, if the current user can administer the batch change.
+func (s *Service) SetBatchChangeSLA(ctx context.Context, opts SetBatchChangeSLAOpts) (sla *btypes.BatchChangeSLA, err error) {
+	ctx, _, endObservation := s.operations.setBatchChangeSLA.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: opts.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting batch change")
+	}
+
+	// 🚨 SECURITY: Only the creator of the batch change or site admins can
+	// set its SLA.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return nil, err
+	}
+
+	if !opts.TargetDate.After(batchChange.CreatedAt) {
+		return nil, errors.New("target date must be after the creation of the batch change")
+	}
+
+	sla = &btypes.BatchChangeSLA{
+		BatchChangeID:   batchChange.ID,
+		CreatorID:       actor.FromContext(ctx).UID,
+		TargetDate:      opts.TargetDate,
+		NotifyEmail:     opts.NotifyEmail,
+		SlackWebhookURL: opts.SlackWebhookURL,
+		WebhookURL:      opts.WebhookURL,
+	}
+	if err := s.store.UpsertBatchChangeSLA(ctx, sla); err != nil {
+		return nil, err
+	}
+	return sla, nil
+}
+
+// DeleteBatchChangeSLA deletes the SLA of the batch change with the given ID,
+// if the current user can administer the batch change.
+func (s *Service) DeleteBatchChangeSLA(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchChangeSLA.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		return errors.Wrap(err, "getting batch change")
+	}
+
+	// 🚨 SECURITY: Only the creator of the batch change or site admins can
+	// delete its SLA.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteBatchChangeSLA(ctx, batchChangeID)
+}
+
 // EnqueueChangesetSync loads the given changeset from the database, checks
 // whether the actor in the context has permission to enqueue a sync and then
 // enqueues a sync by calling the repoupdater client.
@@ -1283,7 +1472,8 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 }
 
 // ValidateChangesetSpecs checks whether the given BachSpec has ChangesetSpecs
-// that would publish to the same branch in the same repository.
+// that would publish to the same branch in the same repository, or that depend
+// on changesets that aren't part of the BatchSpec or on each other in a cycle.
 // If the return value is nil, then the BatchSpec is valid.
 func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64) error {
 	// We don't use `err` here to distinguish between errors we want to trace
@@ -1299,7 +1489,7 @@ func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64)
 	}
 
 	if len(conflicts) == 0 {
-		return nil
+		return s.validateChangesetSpecDependencies(ctx, batchSpecID)
 	}
 
 	repoIDs := make([]api.RepoID, 0, len(conflicts))
@@ -1314,7 +1504,7 @@ func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64)
 		return nonValidationErr
 	}
 
-	var errs changesetSpecHeadRefConflictErrs
+	var errs changesetSpecValidationErrs
 	for _, c := range conflicts {
 		conflictErr := &changesetSpecHeadRefConflict{count: c.Count, headRef: c.HeadRef}
 
@@ -1340,11 +1530,125 @@ func (c changesetSpecHeadRefConflict) Error() string {
 	return fmt.Sprintf("%d changeset specs in the same repository use the same branch: %s", c.count, c.headRef)
 }
 
-// changesetSpecHeadRefConflictErrs represents a set of changesetSpecHeadRefConflict and
-// implements `Error` to render the errors nicely.
-type changesetSpecHeadRefConflictErrs []*changesetSpecHeadRefConflict
+// validateChangesetSpecDependencies checks that the changeset specs of the
+// given BatchSpec only depend on changesets created by the BatchSpec, and that
+// they don't depend on each other in a cycle, which would hold the changesets
+// in the cycle back forever.
+func (s *Service) validateChangesetSpecDependencies(ctx context.Context, batchSpecID int64) error {
+	specs, err := s.store.ListChangesetSpecDependencies(ctx, batchSpecID)
+	if err != nil {
+		return err
+	}
+
+	bySpecKey := make(map[batcheslib.ChangesetDependency]*store.ChangesetSpecDependencies, len(specs))
+	for _, spec := range specs {
+		bySpecKey[batcheslib.ChangesetDependency{Repository: spec.RepoName, HeadRef: spec.HeadRef}] = spec
+	}
+
+	var unknown []*changesetSpecUnknownDependency
+	var cycles [][]*store.ChangesetSpecDependencies
+
+	// We walk the dependency graph depth-first, and find a cycle whenever we
+	// reach a changeset spec that is still on the path we're walking.
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	visited := make(map[*store.ChangesetSpecDependencies]int, len(specs))
+	var path []*store.ChangesetSpecDependencies
+	var visit func(spec *store.ChangesetSpecDependencies)
+	visit = func(spec *store.ChangesetSpecDependencies) {
+		visited[spec] = onPath
+		path = append(path, spec)
+		for _, dep := range spec.DependsOn {
+			parent, ok := bySpecKey[dep]
+			if !ok {
+				unknown = append(unknown, &changesetSpecUnknownDependency{spec: spec, dependency: dep})
+				continue
+			}
+			switch visited[parent] {
+			case unvisited:
+				visit(parent)
+			case onPath:
+				for i, p := range path {
+					if p == parent {
+						cycles = append(cycles, append([]*store.ChangesetSpecDependencies{}, path[i:]...))
+						break
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		visited[spec] = done
+	}
+	for _, spec := range specs {
+		if visited[spec] == unvisited {
+			visit(spec)
+		}
+	}
+
+	if len(unknown) == 0 && len(cycles) == 0 {
+		return nil
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(specs))
+	for _, spec := range specs {
+		repoIDs = append(repoIDs, spec.RepoID)
+	}
+
+	// 🚨 SECURITY: database.Repos.GetRepoIDsSet uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	accessibleReposByID, err := s.store.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return err
+	}
+	describe := func(spec *store.ChangesetSpecDependencies) string {
+		if repo, ok := accessibleReposByID[spec.RepoID]; ok {
+			return fmt.Sprintf("%s in %s", spec.HeadRef, repo.Name)
+		}
+		return fmt.Sprintf("%s in a repository you don't have access to", spec.HeadRef)
+	}
+
+	var errs changesetSpecValidationErrs
+	for _, u := range unknown {
+		u.describedSpec = describe(u.spec)
+		errs = append(errs, u)
+	}
+	for _, cycle := range cycles {
+		described := make([]string, 0, len(cycle)+1)
+		for _, spec := range cycle {
+			described = append(described, describe(spec))
+		}
+		described = append(described, described[0])
+		errs = append(errs, &changesetSpecDependencyCycle{describedSpecs: described})
+	}
+	return errs
+}
+
+type changesetSpecUnknownDependency struct {
+	spec          *store.ChangesetSpecDependencies
+	describedSpec string
+	dependency    batcheslib.ChangesetDependency
+}
+
+func (u changesetSpecUnknownDependency) Error() string {
+	return fmt.Sprintf("changeset spec for %s depends on %s in %s, which is not created by the batch spec", u.describedSpec, u.dependency.HeadRef, u.dependency.Repository)
+}
 
-func (es changesetSpecHeadRefConflictErrs) Error() string {
+type changesetSpecDependencyCycle struct {
+	describedSpecs []string
+}
+
+func (c changesetSpecDependencyCycle) Error() string {
+	return fmt.Sprintf("changeset specs depend on each other in a cycle: %s", strings.Join(c.describedSpecs, " -> "))
+}
+
+// changesetSpecValidationErrs represents a set of errors found when validating
+// changeset specs and implements `Error` to render the errors nicely.
+type changesetSpecValidationErrs []error
+
+func (es changesetSpecValidationErrs) Error() string {
 	if len(es) == 1 {
 		return fmt.Sprintf("Validating changeset specs resulted in an error:\n* %s\n", es[0])
 	}
@@ -1588,6 +1892,8 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 		btypes.ChangesetJobTypeMerge:     0,
 		btypes.ChangesetJobTypePublish:   0,
 		btypes.ChangesetJobTypeReenqueue: 0,
+
+		btypes.ChangesetJobTypeMergeWhenReady: 0,
 	}
 
 	changesets, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
@@ -1645,6 +1951,11 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 			bulkOperationsCounter[btypes.ChangesetJobTypeMerge] += 1
 		}
 
+		// MERGE_WHEN_READY
+		if !isChangesetArchived && !isChangesetJobFailed && (isChangesetOpen || isChangesetDraft) {
+			bulkOperationsCounter[btypes.ChangesetJobTypeMergeWhenReady] += 1
+		}
+
 		// COMMENT
 		if isChangesetCommentable {
 			bulkOperationsCounter[btypes.ChangesetJobTypeComment] += 1
@@ -167,6 +167,13 @@ func (s *Service) ApplyBatchChange(
 		}
 	}
 
+	// The new batch spec might define a different rollout, or none at all, so
+	// the stage of the rollout has to be calculated again before the
+	// reconciler looks at it. All changesets are enqueued above anyway.
+	if _, err := tx.UpdateBatchChangeRolloutStage(ctx, batchChange.ID); err != nil {
+		return nil, err
+	}
+
 	return batchChange, nil
 }
 
@@ -200,3 +207,41 @@ func (s *Service) ReconcileBatchChange(
 	batchChange.Description = batchSpec.Spec.Description
 	return batchChange, previousSpecID, nil
 }
+
+type CreateBatchSpecFromTemplateOpts struct {
+	TemplateID int64
+	Inputs     map[string]any
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	BatchChange int64
+}
+
+// CreateBatchSpecFromTemplate renders the batch change template with the given
+// inputs and creates a batch spec from the result, just like
+// CreateBatchSpecFromRaw. Templates are a catalog: any user can render a
+// template from any namespace into a namespace they have access to.
+func (s *Service) CreateBatchSpecFromTemplate(ctx context.Context, opts CreateBatchSpecFromTemplateOpts) (spec *btypes.BatchSpec, err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecFromTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	template, err := s.store.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: opts.TemplateID})
+	if err != nil {
+		return nil, err
+	}
+
+	rawSpec, err := template.Render(opts.Inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// CreateBatchSpecFromRaw checks the access to the target namespace and
+	// the batch change.
+	return s.CreateBatchSpecFromRaw(ctx, CreateBatchSpecFromRawOpts{
+		RawSpec:         rawSpec,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+		BatchChange:     opts.BatchChange,
+	})
+}
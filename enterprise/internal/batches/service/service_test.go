@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -1794,6 +1796,131 @@ changesetTemplate:
 		})
 	})
 
+	t.Run("BatchChangeTemplates", func(t *testing.T) {
+		inputSchema := `{"type": "object", "properties": {"message": {"type": "string"}}, "required": ["message"]}`
+		rawTemplate := strings.Replace(bt.TestRawBatchSpecYAML, "echo 'foobar'", "echo '${{ inputs.message }}'", 1)
+
+		t.Run("create in namespace without access", func(t *testing.T) {
+			_, err := svc.CreateBatchChangeTemplate(user2Ctx, CreateBatchChangeTemplateOpts{
+				NamespaceUserID: user.ID,
+				Name:            "echo",
+				RawTemplate:     rawTemplate,
+				InputSchema:     inputSchema,
+			})
+			assert.Error(t, err)
+		})
+
+		t.Run("create with undeclared input", func(t *testing.T) {
+			_, err := svc.CreateBatchChangeTemplate(userCtx, CreateBatchChangeTemplateOpts{
+				NamespaceUserID: user.ID,
+				Name:            "echo",
+				RawTemplate:     rawTemplate + "# ${{ inputs.other }}",
+				InputSchema:     inputSchema,
+			})
+			assert.Error(t, err)
+		})
+
+		template, err := svc.CreateBatchChangeTemplate(userCtx, CreateBatchChangeTemplateOpts{
+			NamespaceUserID: user.ID,
+			Name:            "echo",
+			RawTemplate:     rawTemplate,
+			InputSchema:     inputSchema,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, template.CreatorID)
+
+		t.Run("create batch spec with invalid inputs", func(t *testing.T) {
+			_, err := svc.CreateBatchSpecFromTemplate(user2Ctx, CreateBatchSpecFromTemplateOpts{
+				TemplateID:      template.ID,
+				NamespaceUserID: user2.ID,
+			})
+			assert.Error(t, err)
+		})
+
+		t.Run("create batch spec in another namespace", func(t *testing.T) {
+			spec, err := svc.CreateBatchSpecFromTemplate(user2Ctx, CreateBatchSpecFromTemplateOpts{
+				TemplateID:      template.ID,
+				Inputs:          map[string]any{"message": "hello"},
+				NamespaceUserID: user2.ID,
+			})
+			require.NoError(t, err)
+			assert.True(t, spec.CreatedFromRaw)
+			assert.Equal(t, user2.ID, spec.NamespaceUserID)
+			assert.Contains(t, spec.RawSpec, "echo 'hello'")
+		})
+
+		t.Run("update without access", func(t *testing.T) {
+			name := "echo-2"
+			_, err := svc.UpdateBatchChangeTemplate(user2Ctx, UpdateBatchChangeTemplateOpts{ID: template.ID, Name: &name})
+			assert.Error(t, err)
+		})
+
+		t.Run("update with undeclared input", func(t *testing.T) {
+			raw := rawTemplate + "# ${{ inputs.other }}"
+			_, err := svc.UpdateBatchChangeTemplate(userCtx, UpdateBatchChangeTemplateOpts{ID: template.ID, RawTemplate: &raw})
+			assert.Error(t, err)
+		})
+
+		t.Run("update", func(t *testing.T) {
+			description := "Echoes a message"
+			updated, err := svc.UpdateBatchChangeTemplate(userCtx, UpdateBatchChangeTemplateOpts{ID: template.ID, Description: &description})
+			require.NoError(t, err)
+			assert.Equal(t, "echo", updated.Name)
+			assert.Equal(t, description, updated.Description)
+			assert.Equal(t, rawTemplate, updated.RawTemplate)
+		})
+
+		t.Run("delete without access", func(t *testing.T) {
+			err := svc.DeleteBatchChangeTemplate(user2Ctx, template.ID)
+			assert.Error(t, err)
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			err := svc.DeleteBatchChangeTemplate(userCtx, template.ID)
+			require.NoError(t, err)
+		})
+	})
+
+	t.Run("BatchChangeSLAs", func(t *testing.T) {
+		spec := testBatchSpec(user.ID)
+		require.NoError(t, s.CreateBatchSpec(ctx, spec))
+		batchChange := testBatchChange(user.ID, spec)
+		require.NoError(t, s.CreateBatchChange(ctx, batchChange))
+
+		opts := SetBatchChangeSLAOpts{
+			BatchChangeID: batchChange.ID,
+			TargetDate:    batchChange.CreatedAt.Add(30 * 24 * time.Hour),
+			NotifyEmail:   true,
+		}
+
+		t.Run("set without access", func(t *testing.T) {
+			_, err := svc.SetBatchChangeSLA(user2Ctx, opts)
+			assert.Error(t, err)
+		})
+
+		t.Run("set with target date in the past", func(t *testing.T) {
+			_, err := svc.SetBatchChangeSLA(userCtx, SetBatchChangeSLAOpts{
+				BatchChangeID: batchChange.ID,
+				TargetDate:    batchChange.CreatedAt.Add(-time.Hour),
+			})
+			assert.Error(t, err)
+		})
+
+		sla, err := svc.SetBatchChangeSLA(userCtx, opts)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, sla.CreatorID)
+		assert.True(t, sla.NotifyEmail)
+
+		t.Run("delete without access", func(t *testing.T) {
+			err := svc.DeleteBatchChangeSLA(user2Ctx, batchChange.ID)
+			assert.Error(t, err)
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			require.NoError(t, svc.DeleteBatchChangeSLA(userCtx, batchChange.ID))
+		})
+	})
+
 	t.Run("UpsertBatchSpecInput", func(t *testing.T) {
 		adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
 		t.Run("new spec", func(t *testing.T) {
@@ -1887,6 +2014,47 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("ValidateChangesetSpecs dependencies", func(t *testing.T) {
+		dep := func(repo *types.Repo, headRef string) batcheslib.ChangesetDependency {
+			return batcheslib.ChangesetDependency{Repository: string(repo.Name), HeadRef: headRef}
+		}
+
+		t.Run("valid", func(t *testing.T) {
+			batchSpec := bt.CreateBatchSpec(t, ctx, s, "stacked-batch-spec", admin.ID, 0)
+			for _, opts := range []bt.TestSpecOpts{
+				{HeadRef: "refs/heads/lib", Typ: btypes.ChangesetSpecTypeBranch, Repo: rs[0].ID, BatchSpec: batchSpec.ID},
+				{HeadRef: "refs/heads/consumer", Typ: btypes.ChangesetSpecTypeBranch, Repo: rs[1].ID, BatchSpec: batchSpec.ID, DependsOn: []batcheslib.ChangesetDependency{dep(rs[0], "refs/heads/lib")}},
+				{HeadRef: "refs/heads/stacked", Typ: btypes.ChangesetSpecTypeBranch, Repo: rs[1].ID, BatchSpec: batchSpec.ID, DependsOn: []batcheslib.ChangesetDependency{dep(rs[1], "refs/heads/consumer"), dep(rs[0], "refs/heads/lib")}},
+			} {
+				bt.CreateChangesetSpec(t, ctx, s, opts)
+			}
+			assert.NoError(t, svc.ValidateChangesetSpecs(ctx, batchSpec.ID))
+		})
+
+		t.Run("unknown and cyclic dependencies", func(t *testing.T) {
+			batchSpec := bt.CreateBatchSpec(t, ctx, s, "cyclic-batch-spec", admin.ID, 0)
+			for _, opts := range []bt.TestSpecOpts{
+				{HeadRef: "refs/heads/a", Typ: btypes.ChangesetSpecTypeBranch, Repo: rs[0].ID, BatchSpec: batchSpec.ID, DependsOn: []batcheslib.ChangesetDependency{dep(rs[1], "refs/heads/b")}},
+				{HeadRef: "refs/heads/b", Typ: btypes.ChangesetSpecTypeBranch, Repo: rs[1].ID, BatchSpec: batchSpec.ID, DependsOn: []batcheslib.ChangesetDependency{dep(rs[0], "refs/heads/a")}},
+				{HeadRef: "refs/heads/c", Typ: btypes.ChangesetSpecTypeBranch, Repo: rs[2].ID, BatchSpec: batchSpec.ID, DependsOn: []batcheslib.ChangesetDependency{dep(rs[2], "refs/heads/missing")}},
+			} {
+				bt.CreateChangesetSpec(t, ctx, s, opts)
+			}
+			err := svc.ValidateChangesetSpecs(ctx, batchSpec.ID)
+			if err == nil {
+				t.Fatal("expected error, but got none")
+			}
+
+			want := `2 errors when validating changeset specs:
+* changeset spec for refs/heads/c in repo-1-3 depends on refs/heads/missing in repo-1-3, which is not created by the batch spec
+* changeset specs depend on each other in a cycle: refs/heads/a in repo-1-1 -> refs/heads/b in repo-1-2 -> refs/heads/a in repo-1-1
+`
+			if diff := cmp.Diff(want, err.Error()); diff != "" {
+				t.Fatalf("wrong error message: %s", diff)
+			}
+		})
+	})
+
 	t.Run("ComputeBatchSpecState", func(t *testing.T) {
 		t.Run("success", func(t *testing.T) {
 			spec := testBatchSpec(admin.ID)
@@ -2546,7 +2714,7 @@ changesetTemplate:
 				t.Fatal(err)
 			}
 
-			expectedBulkOperations := []string{"CLOSE", "COMMENT", "PUBLISH"}
+			expectedBulkOperations := []string{"CLOSE", "COMMENT", "PUBLISH", "MERGE_WHEN_READY"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -2572,7 +2740,7 @@ changesetTemplate:
 				t.Fatal(err)
 			}
 
-			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "PUBLISH"}
+			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "PUBLISH", "MERGE_WHEN_READY"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -2666,7 +2834,7 @@ changesetTemplate:
 			})
 
 			assert.NoError(t, err)
-			expectedBulkOperations := []string{"COMMENT", "CLOSE", "MERGE"}
+			expectedBulkOperations := []string{"COMMENT", "CLOSE", "MERGE", "MERGE_WHEN_READY"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -0,0 +1,308 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/smithy-go"
+	"golang.org/x/net/http2"
+
+	awscodecommitbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AWSCodeCommitSource is a ChangesetSource for AWS CodeCommit. The AWS API is
+// always accessed with the IAM credentials of the code host connection: the
+// authenticator only holds the HTTPS Git credentials used to push commits.
+type AWSCodeCommitSource struct {
+	client *awscodecommit.Client
+	region string
+	au     auth.Authenticator
+}
+
+var _ ChangesetSource = AWSCodeCommitSource{}
+
+// NewAWSCodeCommitSource returns a new AWSCodeCommitSource from the given
+// external service.
+func NewAWSCodeCommitSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AWSCodeCommitSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AWSCodeCommitConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer(func(c *http.Client) error {
+		tr := awshttp.NewBuildableClient().GetTransport()
+		if err := http2.ConfigureTransport(tr); err != nil {
+			return err
+		}
+		c.Transport = tr
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(c.Region),
+		config.WithCredentialsProvider(
+			awscredentials.StaticCredentialsProvider{
+				Value: aws.Credentials{
+					AccessKeyID:     c.AccessKeyID,
+					SecretAccessKey: c.SecretAccessKey,
+					Source:          "sourcegraph-site-configuration",
+				},
+			},
+		),
+		config.WithHTTPClient(cli),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading AWS config")
+	}
+
+	return &AWSCodeCommitSource{
+		client: awscodecommit.NewClient(awsConfig),
+		region: c.Region,
+		au: &auth.BasicAuth{
+			Username: c.GitCredentials.Username,
+			Password: c.GitCredentials.Password,
+		},
+	}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AWSCodeCommitSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.au)
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s AWSCodeCommitSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AWSCodeCommitSource", a)
+	}
+
+	return &AWSCodeCommitSource{client: s.client, region: s.region, au: a}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+//
+// HTTPS Git credentials can't be validated through the AWS API, so the best we
+// can do is to make sure that they are complete.
+func (s AWSCodeCommitSource) ValidateAuthenticator(ctx context.Context) error {
+	var basic *auth.BasicAuth
+	switch a := s.au.(type) {
+	case *auth.BasicAuth:
+		basic = a
+	case *auth.BasicAuthWithSSH:
+		basic = &a.BasicAuth
+	}
+
+	if basic == nil || basic.Username == "" || basic.Password == "" {
+		return errors.New("AWS CodeCommit Git credentials require a username and a password")
+	}
+	return nil
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AWSCodeCommitSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	pr, err := s.client.GetPullRequest(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, pr, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AWSCodeCommitSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	repo := cs.TargetRepo.Metadata.(*awscodecommit.Repository)
+
+	// AWS CodeCommit happily creates multiple pull requests for the same
+	// branches, so we have to check for an existing one ourselves.
+	existing, err := s.findOpenPullRequest(ctx, repo.Name, cs.HeadRef, cs.BaseRef)
+	if err != nil {
+		return false, err
+	}
+	if existing != nil {
+		return true, s.setChangesetMetadata(ctx, existing, cs)
+	}
+
+	pr, err := s.client.CreatePullRequest(ctx, awscodecommit.CreatePullRequestInput{
+		RepositoryName:       repo.Name,
+		Title:                cs.Title,
+		Description:          cs.Body,
+		SourceReference:      gitdomain.EnsureRefPrefix(cs.HeadRef),
+		DestinationReference: gitdomain.EnsureRefPrefix(cs.BaseRef),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "creating pull request")
+	}
+
+	return false, s.setChangesetMetadata(ctx, pr, cs)
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s AWSCodeCommitSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	pr, err := s.client.ClosePullRequest(ctx, cs.ExternalID)
+	if err != nil {
+		return errors.Wrap(err, "closing pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, pr, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s AWSCodeCommitSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*awscodecommitbatches.AnnotatedPullRequest)
+
+	// The destination of a pull request can't be changed, so the only option
+	// is to replace the pull request with a new one.
+	if gitdomain.EnsureRefPrefix(cs.BaseRef) != pr.Target().DestinationReference {
+		if _, err := s.client.ClosePullRequest(ctx, pr.ID); err != nil {
+			return errors.Wrap(err, "closing pull request")
+		}
+		_, err := s.CreateChangeset(ctx, cs)
+		return err
+	}
+
+	updated := pr.PullRequest
+	if cs.Title != pr.Title {
+		var err error
+		if updated, err = s.client.UpdatePullRequestTitle(ctx, pr.ID, cs.Title); err != nil {
+			return errors.Wrap(err, "updating pull request title")
+		}
+	}
+	if cs.Body != pr.Description {
+		var err error
+		if updated, err = s.client.UpdatePullRequestDescription(ctx, pr.ID, cs.Body); err != nil {
+			return errors.Wrap(err, "updating pull request description")
+		}
+	}
+
+	return s.setChangesetMetadata(ctx, updated, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s AWSCodeCommitSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*awscodecommitbatches.AnnotatedPullRequest)
+	if pr.Status == awscodecommit.PullRequestStatusOpen || pr.Target().IsMerged {
+		return nil
+	}
+
+	// Much like Bitbucket Cloud, AWS CodeCommit doesn't allow closed pull
+	// requests to be reopened, so we create a new pull request instead.
+	_, err := s.CreateChangeset(ctx, cs)
+	return err
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AWSCodeCommitSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	pr := cs.Metadata.(*awscodecommitbatches.AnnotatedPullRequest)
+	return s.client.CreatePullRequestComment(ctx, pr.PullRequest, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s AWSCodeCommitSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	pr := cs.Metadata.(*awscodecommitbatches.AnnotatedPullRequest)
+
+	merged, err := s.client.MergePullRequest(ctx, pr.PullRequest, squash)
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && !errcode.IsNotFound(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, merged, cs)
+}
+
+// findOpenPullRequest returns the open pull request from headRef to baseRef
+// that was created with the IAM credentials of the code host connection, if
+// any. The pull requests can't be filtered by branch through the API, so only
+// considering the pull requests we created keeps the number of requests low
+// in repositories with many open pull requests.
+func (s AWSCodeCommitSource) findOpenPullRequest(ctx context.Context, repositoryName, headRef, baseRef string) (*awscodecommit.PullRequest, error) {
+	authorARN, err := s.client.GetCallerARN(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting caller identity")
+	}
+
+	ids, err := s.client.ListOpenPullRequestIDs(ctx, repositoryName, authorARN)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pull requests")
+	}
+
+	for _, id := range ids {
+		pr, err := s.client.GetPullRequest(ctx, id)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting pull request")
+		}
+
+		target := pr.Target()
+		if target.SourceReference == gitdomain.EnsureRefPrefix(headRef) && target.DestinationReference == gitdomain.EnsureRefPrefix(baseRef) {
+			return pr, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s AWSCodeCommitSource) setChangesetMetadata(ctx context.Context, pr *awscodecommit.PullRequest, cs *Changeset) error {
+	approvals, err := s.client.GetPullRequestApprovals(ctx, pr.ID, pr.RevisionID)
+	if err != nil {
+		return errors.Wrap(err, "getting pull request approvals")
+	}
+
+	if err := cs.SetMetadata(&awscodecommitbatches.AnnotatedPullRequest{
+		PullRequest: pr,
+		Approvals:   approvals,
+		Region:      s.region,
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
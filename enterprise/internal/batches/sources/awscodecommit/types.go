@@ -0,0 +1,36 @@
+package awscodecommit
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+)
+
+// AnnotatedPullRequest adds metadata we need that lives outside the main
+// PullRequest type returned by the AWS CodeCommit API alongside the pull
+// request. This type is used as the primary metadata type for AWS CodeCommit
+// changesets.
+type AnnotatedPullRequest struct {
+	*awscodecommit.PullRequest
+	Approvals []*awscodecommit.Approval
+	// Region is the AWS region of the repository, which is needed to build the
+	// console URL of the pull request.
+	Region string
+}
+
+// URL returns the AWS console URL of the pull request.
+func (pr *AnnotatedPullRequest) URL() string {
+	return "https://" + pr.Region + ".console.aws.amazon.com/codesuite/codecommit/repositories/" +
+		url.PathEscape(pr.Target().RepositoryName) + "/pull-requests/" + url.PathEscape(pr.ID) +
+		"?region=" + url.QueryEscape(pr.Region)
+}
+
+// AuthorName returns the name of the IAM principal that created the pull
+// request, which is the last segment of its ARN.
+func (pr *AnnotatedPullRequest) AuthorName() string {
+	if i := strings.LastIndex(pr.AuthorARN, "/"); i >= 0 {
+		return pr.AuthorARN[i+1:]
+	}
+	return pr.AuthorARN
+}
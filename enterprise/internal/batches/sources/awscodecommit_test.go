@@ -0,0 +1,435 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	awscodecommitbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewAWSCodeCommitSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"region": ["not a string"]}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				s, err := NewAWSCodeCommitSource(ctx, &types.ExternalService{
+					Config: extsvc.NewUnencryptedConfig(input),
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		// A custom CA bundle can't be applied to our HTTP client, so make
+		// sure the environment doesn't provide one.
+		t.Setenv("AWS_CA_BUNDLE", "")
+
+		ctx := context.Background()
+		s, err := NewAWSCodeCommitSource(ctx, &types.ExternalService{
+			Config: extsvc.NewUnencryptedConfig(`{
+				"region": "us-west-2",
+				"accessKeyID": "access",
+				"secretAccessKey": "secret",
+				"gitCredentials": {"username": "user", "password": "pass"}
+			}`),
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, &auth.BasicAuth{Username: "user", Password: "pass"}, s.au)
+		assert.Equal(t, "us-west-2", s.region)
+	})
+}
+
+func TestAWSCodeCommitSource_WithAuthenticator(t *testing.T) {
+	s := AWSCodeCommitSource{region: "us-west-2"}
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthBearerToken{},
+			&auth.OAuthBearerTokenWithSSH{},
+			&auth.OAuthClient{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, newSource)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("supported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.BasicAuth{},
+			&auth.BasicAuthWithSSH{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, err)
+				assert.Same(t, au, newSource.(*AWSCodeCommitSource).au)
+				assert.Equal(t, "us-west-2", newSource.(*AWSCodeCommitSource).region)
+			})
+		}
+	})
+}
+
+func TestAWSCodeCommitSource_ValidateAuthenticator(t *testing.T) {
+	ctx := context.Background()
+
+	for name, tc := range map[string]struct {
+		au      auth.Authenticator
+		wantErr bool
+	}{
+		"basic auth":          {au: &auth.BasicAuth{Username: "user", Password: "pass"}},
+		"basic auth with SSH": {au: &auth.BasicAuthWithSSH{BasicAuth: auth.BasicAuth{Username: "user", Password: "pass"}}},
+		"missing username":    {au: &auth.BasicAuth{Password: "pass"}, wantErr: true},
+		"missing password":    {au: &auth.BasicAuth{Username: "user"}, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := AWSCodeCommitSource{au: tc.au}.ValidateAuthenticator(ctx)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestAnnotatedPullRequest(t *testing.T) {
+	pr := &awscodecommitbatches.AnnotatedPullRequest{
+		PullRequest: &awscodecommit.PullRequest{
+			ID:        "7",
+			AuthorARN: "arn:aws:iam::123456789012:user/alice",
+			Targets:   []awscodecommit.PullRequestTarget{{RepositoryName: "src-cli"}},
+		},
+		Region: "us-west-2",
+	}
+
+	assert.Equal(t, "alice", pr.AuthorName())
+	assert.Equal(t, "https://us-west-2.console.aws.amazon.com/codesuite/codecommit/repositories/src-cli/pull-requests/7?region=us-west-2", pr.URL())
+}
+
+func TestAWSCodeCommitSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs:   awsCodeCommitTestChangeset("7"),
+		},
+		{
+			name: "not-found",
+			cs:   awsCodeCommitTestChangeset("999"),
+			err:  "Changeset with external ID 999 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newAWSCodeCommitTestSource(t, tc.name)
+			defer save(t)
+
+			err := src.LoadChangeset(context.Background(), tc.cs)
+			if assertAWSCodeCommitError(t, err, tc.err) {
+				return
+			}
+
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), tc.cs.Changeset.Metadata)
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_CreateChangeset(t *testing.T) {
+	testCases := []struct {
+		name       string
+		exists     bool
+		externalID string
+	}{
+		{
+			// The only open pull request of the code host connection's user
+			// is for another branch, so a new one is created.
+			name:       "success",
+			externalID: "8",
+		},
+		{
+			name:       "already-exists",
+			exists:     true,
+			externalID: "7",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_CreateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newAWSCodeCommitTestSource(t, tc.name)
+			defer save(t)
+
+			cs := awsCodeCommitTestChangeset("")
+			exists, err := src.CreateChangeset(context.Background(), cs)
+			if assertAWSCodeCommitError(t, err, "") {
+				return
+			}
+
+			assert.Equal(t, tc.exists, exists)
+			assert.Equal(t, tc.externalID, cs.ExternalID)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), cs.Changeset.Metadata)
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_UpdateChangeset(t *testing.T) {
+	testCases := []struct {
+		name       string
+		baseRef    string
+		externalID string
+	}{
+		{
+			name:       "success",
+			baseRef:    "refs/heads/main",
+			externalID: "7",
+		},
+		{
+			// The destination of a pull request can't be changed, so it's
+			// replaced with a new pull request.
+			name:       "moved",
+			baseRef:    "refs/heads/release",
+			externalID: "8",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_UpdateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newAWSCodeCommitTestSource(t, tc.name)
+			defer save(t)
+
+			cs := awsCodeCommitTestChangeset("7")
+			cs.Title = "This is a new title"
+			cs.Body = "This is a new body"
+			cs.BaseRef = tc.baseRef
+			cs.Metadata = awsCodeCommitTestPullRequest(awscodecommit.PullRequestStatusOpen)
+
+			err := src.UpdateChangeset(context.Background(), cs)
+			if assertAWSCodeCommitError(t, err, "") {
+				return
+			}
+
+			assert.Equal(t, tc.externalID, cs.ExternalID)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), cs.Changeset.Metadata)
+		})
+	}
+}
+
+func TestAWSCodeCommitSource_CloseChangeset(t *testing.T) {
+	name := "AWSCodeCommitSource_CloseChangeset_success"
+	src, save := newAWSCodeCommitTestSource(t, name)
+	defer save(t)
+
+	cs := awsCodeCommitTestChangeset("7")
+	cs.Metadata = awsCodeCommitTestPullRequest(awscodecommit.PullRequestStatusOpen)
+
+	err := src.CloseChangeset(context.Background(), cs)
+	if assertAWSCodeCommitError(t, err, "") {
+		return
+	}
+
+	assert.Equal(t, awscodecommit.PullRequestStatusClosed, cs.Metadata.(*awscodecommitbatches.AnnotatedPullRequest).Status)
+	testutil.AssertGolden(t, "testdata/golden/"+name, update(name), cs.Changeset.Metadata)
+}
+
+func TestAWSCodeCommitSource_ReopenChangeset(t *testing.T) {
+	name := "AWSCodeCommitSource_ReopenChangeset_success"
+	src, save := newAWSCodeCommitTestSource(t, name)
+	defer save(t)
+
+	cs := awsCodeCommitTestChangeset("7")
+	cs.Metadata = awsCodeCommitTestPullRequest(awscodecommit.PullRequestStatusClosed)
+
+	err := src.ReopenChangeset(context.Background(), cs)
+	if assertAWSCodeCommitError(t, err, "") {
+		return
+	}
+
+	// Closed pull requests can't be reopened, so a new one is created.
+	assert.Equal(t, "8", cs.ExternalID)
+	assert.Equal(t, awscodecommit.PullRequestStatusOpen, cs.Metadata.(*awscodecommitbatches.AnnotatedPullRequest).Status)
+	testutil.AssertGolden(t, "testdata/golden/"+name, update(name), cs.Changeset.Metadata)
+}
+
+func TestAWSCodeCommitSource_CreateComment(t *testing.T) {
+	name := "AWSCodeCommitSource_CreateComment_success"
+	src, save := newAWSCodeCommitTestSource(t, name)
+	defer save(t)
+
+	cs := awsCodeCommitTestChangeset("7")
+	cs.Metadata = awsCodeCommitTestPullRequest(awscodecommit.PullRequestStatusOpen)
+
+	err := src.CreateComment(context.Background(), cs, "test-comment")
+	assertAWSCodeCommitError(t, err, "")
+}
+
+func TestAWSCodeCommitSource_MergeChangeset(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mergeable bool
+	}{
+		{
+			name:      "success",
+			mergeable: true,
+		},
+		{
+			name: "conflict",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "AWSCodeCommitSource_MergeChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newAWSCodeCommitTestSource(t, tc.name)
+			defer save(t)
+
+			cs := awsCodeCommitTestChangeset("7")
+			cs.Metadata = awsCodeCommitTestPullRequest(awscodecommit.PullRequestStatusOpen)
+
+			err := src.MergeChangeset(context.Background(), cs, true)
+			if !tc.mergeable {
+				assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+				return
+			}
+			if assertAWSCodeCommitError(t, err, "") {
+				return
+			}
+
+			assert.True(t, cs.Metadata.(*awscodecommitbatches.AnnotatedPullRequest).Target().IsMerged)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), cs.Changeset.Metadata)
+		})
+	}
+}
+
+// newAWSCodeCommitTestSource returns an AWSCodeCommitSource replaying the
+// cassette with the given name. To update the cassettes, the credentials of an
+// IAM user with access to the src-cli repository in us-west-2 have to be
+// provided through AWS_CODECOMMIT_ACCESS_KEY_ID and
+// AWS_CODECOMMIT_SECRET_ACCESS_KEY.
+func newAWSCodeCommitTestSource(t *testing.T, name string) (*AWSCodeCommitSource, func(testing.TB)) {
+	t.Helper()
+
+	// A custom CA bundle can't be applied to our HTTP client, so make sure the
+	// environment doesn't provide one.
+	t.Setenv("AWS_CA_BUNDLE", "")
+
+	// The AWS SDK refuses to sign requests without credentials, even when
+	// they're only replayed.
+	accessKeyID, secretAccessKey := os.Getenv("AWS_CODECOMMIT_ACCESS_KEY_ID"), os.Getenv("AWS_CODECOMMIT_SECRET_ACCESS_KEY")
+	if accessKeyID == "" || secretAccessKey == "" {
+		accessKeyID, secretAccessKey = "access", "secret"
+	}
+
+	cf, save := newClientFactory(t, name)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindAWSCodeCommit,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.AWSCodeCommitConnection{
+			Region:          "us-west-2",
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			GitCredentials: schema.AWSCodeCommitGitCredentials{
+				Username: "user",
+				Password: "pass",
+			},
+		})),
+	}
+
+	src, err := NewAWSCodeCommitSource(context.Background(), svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src, save
+}
+
+// assertAWSCodeCommitError asserts that err matches want, where an empty want
+// means no error, and returns whether an error occurred.
+func assertAWSCodeCommitError(t *testing.T, err error, want string) bool {
+	t.Helper()
+
+	if want == "" {
+		want = "<nil>"
+	}
+	if have := fmt.Sprint(err); have != want {
+		t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+	}
+	return err != nil
+}
+
+func awsCodeCommitTestChangeset(externalID string) *Changeset {
+	return &Changeset{
+		Changeset: &btypes.Changeset{ExternalID: externalID},
+		TargetRepo: &types.Repo{
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "f001337a-3450-46fd-b7d2-650c0EXAMPLE",
+				ServiceType: extsvc.TypeAWSCodeCommit,
+			},
+			Metadata: &awscodecommit.Repository{
+				ARN:       "arn:aws:codecommit:us-west-2:123456789012:src-cli",
+				AccountID: "123456789012",
+				ID:        "f001337a-3450-46fd-b7d2-650c0EXAMPLE",
+				Name:      "src-cli",
+			},
+		},
+		Title:   "Update README",
+		Body:    "This updates the README.",
+		HeadRef: "refs/heads/batch-change",
+		BaseRef: "refs/heads/main",
+	}
+}
+
+func awsCodeCommitTestPullRequest(status awscodecommit.PullRequestStatus) *awscodecommitbatches.AnnotatedPullRequest {
+	return &awscodecommitbatches.AnnotatedPullRequest{
+		PullRequest: &awscodecommit.PullRequest{
+			ID:          "7",
+			Title:       "Update README",
+			Description: "This updates the README.",
+			Status:      status,
+			AuthorARN:   "arn:aws:iam::123456789012:user/batch-changes",
+			RevisionID:  "9f3c1b5e0a7d2c4e6b8a0f1d3c5e7a9b1d3f5a7c9e1b3d5f7a9c1e3b5d7f9a1c",
+			Targets: []awscodecommit.PullRequestTarget{{
+				RepositoryName:       "src-cli",
+				SourceReference:      "refs/heads/batch-change",
+				SourceCommit:         "4d2a8c6e0b1f3a5c7e9b2d4f6a8c0e1b3d5f7a9c",
+				DestinationReference: "refs/heads/main",
+				DestinationCommit:    "9b2e7c4d1a0f3e6b8c5d2a7f4e1b0c9d8a6f3e2b",
+				MergeBase:            "9b2e7c4d1a0f3e6b8c5d2a7f4e1b0c9d8a6f3e2b",
+			}},
+		},
+		Region: "us-west-2",
+	}
+}
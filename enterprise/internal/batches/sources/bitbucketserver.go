@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/inconshreveable/log15"
 
@@ -23,6 +24,7 @@ type BitbucketServerSource struct {
 }
 
 var _ ForkableChangesetSource = BitbucketServerSource{}
+var _ ReviewerChangesetSource = BitbucketServerSource{}
 
 // NewBitbucketServerSource returns a new BitbucketServerSource from the given external service.
 func NewBitbucketServerSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketServerSource, error) {
@@ -238,6 +240,74 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 	return c.Changeset.SetMetadata(updated)
 }
 
+// RequestReviewers adds the given users to the reviewers of the pull request,
+// keeping any reviewers it already has. Email addresses are resolved to the
+// users with that email address. Bitbucket Server has no concept of team
+// reviewers, so teams are ignored.
+func (s BitbucketServerSource) RequestReviewers(ctx context.Context, c *Changeset, reviewers ChangesetReviewers) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	existing := make(map[string]struct{}, len(pr.Reviewers)+1)
+	// The author of a pull request can't review it.
+	if pr.Author.User != nil {
+		existing[pr.Author.User.Name] = struct{}{}
+	}
+	all := make([]bitbucketserver.Reviewer, 0, len(pr.Reviewers)+len(reviewers.Usernames))
+	for _, r := range pr.Reviewers {
+		if r.User == nil {
+			continue
+		}
+		existing[r.User.Name] = struct{}{}
+		all = append(all, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: r.User.Name}})
+	}
+	added := false
+	addUser := func(username string) {
+		if _, ok := existing[username]; ok {
+			return
+		}
+		existing[username] = struct{}{}
+		all = append(all, bitbucketserver.Reviewer{User: &bitbucketserver.User{Name: username}})
+		added = true
+	}
+
+	for _, username := range reviewers.Usernames {
+		addUser(username)
+	}
+	for _, email := range reviewers.Emails {
+		// The user filter matches substrings of usernames, names and email
+		// addresses, so only exact email address matches are kept.
+		users, _, err := s.client.Users(ctx, nil, bitbucketserver.UserFilter{Filter: email})
+		if err != nil {
+			return errors.Wrapf(err, "looking up Bitbucket Server user with email %q", email)
+		}
+		for _, u := range users {
+			if strings.EqualFold(u.EmailAddress, email) {
+				addUser(u.Name)
+			}
+		}
+	}
+	if !added {
+		return nil
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, &bitbucketserver.UpdatePullRequestInput{
+		PullRequestID: strconv.Itoa(pr.ID),
+		Version:       pr.Version,
+		Title:         pr.Title,
+		Description:   pr.Description,
+		ToRef:         pr.ToRef,
+		Reviewers:     all,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
 // ReopenChangeset reopens the *Changeset on the code host and updates the
 // Metadata column in the *batches.Changeset.
 func (s BitbucketServerSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
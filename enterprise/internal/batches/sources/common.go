@@ -48,6 +48,44 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A BaseBranchCheckStateSource can load the state of the CI checks on the
+// base branch of a repository.
+type BaseBranchCheckStateSource interface {
+	ChangesetSource
+
+	// LoadBaseBranchCheckState returns the combined state of the checks of
+	// the commit the given base ref of the repository points to.
+	LoadBaseBranchCheckState(ctx context.Context, repo *types.Repo, baseRef string) (btypes.ChangesetCheckState, error)
+}
+
+// ChangesetReviewers are the users and teams reviews are requested from.
+type ChangesetReviewers struct {
+	Usernames []string
+	// Emails are the email addresses of users, which are resolved to users on
+	// the code host. Addresses that don't resolve to a user are ignored.
+	Emails []string
+	// Teams are the slugs of teams in the organization that owns the
+	// repository.
+	Teams []string
+}
+
+// IsEmpty returns true if there are neither users nor teams to request
+// reviews from.
+func (r ChangesetReviewers) IsEmpty() bool {
+	return len(r.Usernames) == 0 && len(r.Emails) == 0 && len(r.Teams) == 0
+}
+
+// A ReviewerChangesetSource can request reviews on a published changeset.
+type ReviewerChangesetSource interface {
+	ChangesetSource
+
+	// RequestReviewers requests reviews on the given Changeset from the given
+	// reviewers, in addition to the reviewers it already has. The author of
+	// the Changeset is never requested to review it. Code hosts that can't
+	// request reviews from teams ignore them.
+	RequestReviewers(ctx context.Context, cs *Changeset, reviewers ChangesetReviewers) error
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
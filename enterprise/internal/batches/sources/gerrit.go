@@ -0,0 +1,244 @@
+package sources
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GerritSource is a ChangesetSource for Gerrit. Unlike other code hosts,
+// Gerrit creates changes when commits are pushed to the magic refs/for/<branch>
+// ref, and identifies them by the Change-Id trailer of the commit message.
+// Publishing a changeset therefore happens at push time (see GerritPushRef and
+// GerritCommitMessage), and the source only loads and manages the change after
+// the fact.
+type GerritSource struct {
+	client *gerrit.Client
+}
+
+var _ ChangesetSource = GerritSource{}
+
+// NewGerritSource returns a new GerritSource from the given external service.
+func NewGerritSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	client, err := gerrit.NewClient(svc.URN(), &c, cli)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating Gerrit client")
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s GerritSource) GitserverPushConfig(repo *types.Repo) (*protocol.PushConfig, error) {
+	return GitserverPushConfig(repo, s.client.Authenticator())
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s GerritSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("GerritSource", a)
+	}
+
+	client, err := s.client.WithAuthenticator(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GerritSource{client: client}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s GerritSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.GetAuthenticatedUserAccount(ctx)
+	return err
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s GerritSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	change, err := s.client.GetChange(ctx, cs.ExternalID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting change")
+	}
+
+	return s.setChangesetMetadata(change, cs)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s GerritSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	project := cs.TargetRepo.Metadata.(*gerrit.Project)
+
+	// The change has already been created by pushing the commit, so all we
+	// need to do here is to look it up.
+	changeID := GenerateGerritChangeID(cs.TargetRepo, cs.HeadRef)
+	change, err := s.client.GetChange(ctx, project.ID+"~"+gitdomain.AbbreviateRef(cs.BaseRef)+"~"+changeID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return false, errors.Newf("change %s not found after pushing to %s", changeID, GerritPushRef(cs.BaseRef, cs.HeadRef))
+		}
+		return false, errors.Wrap(err, "getting change")
+	}
+
+	if err := s.setChangesetMetadata(change, cs); err != nil {
+		return false, err
+	}
+
+	// The subject and body of the change are taken from the commit message,
+	// which is usually not the title and body we want. We always report that
+	// the change exists so that the IsOutdated check runs and updates them.
+	return true, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s GerritSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status == gerrit.ChangeStatusNew {
+		if _, err := s.client.AbandonChange(ctx, cs.ExternalID); err != nil {
+			return errors.Wrap(err, "abandoning change")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// UpdateChangeset can update Changesets.
+func (s GerritSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+
+	if baseBranch := gitdomain.AbbreviateRef(cs.BaseRef); baseBranch != change.Branch {
+		if _, err := s.client.MoveChange(ctx, cs.ExternalID, baseBranch); err != nil {
+			return errors.Wrap(err, "moving change")
+		}
+	}
+
+	if cs.Title != change.Subject || cs.Body != change.Body() {
+		message := GerritCommitMessage(cs.Title+"\n\n"+cs.Body, change.ChangeID)
+		if err := s.client.SetCommitMessage(ctx, cs.ExternalID, message); err != nil {
+			return errors.Wrap(err, "setting commit message")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s GerritSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	change := cs.Metadata.(*gerritbatches.AnnotatedChange)
+	if change.Status == gerrit.ChangeStatusAbandoned {
+		if _, err := s.client.RestoreChange(ctx, cs.ExternalID); err != nil {
+			return errors.Wrap(err, "restoring change")
+		}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GerritSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	return s.client.CreateChangeComment(ctx, cs.ExternalID, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// Gerrit changes always consist of a single commit, so squash has no effect:
+// the project's submit type determines how the change is merged.
+func (s GerritSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	if _, err := s.client.SubmitChange(ctx, cs.ExternalID); err != nil {
+		if errcode.IsNotFound(err) {
+			return errors.Wrap(err, "submitting change")
+		}
+		return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+	}
+
+	return s.LoadChangeset(ctx, cs)
+}
+
+func (s GerritSource) setChangesetMetadata(change *gerrit.Change, cs *Changeset) error {
+	if err := cs.SetMetadata(&gerritbatches.AnnotatedChange{
+		Change:      change,
+		CodeHostURL: s.client.URL.String(),
+	}); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return nil
+}
+
+// GenerateGerritChangeID returns the Change-Id of the Gerrit change for the
+// changeset with the given head ref in the given repo. It is derived
+// deterministically, so that pushing updated commits adds new patch sets to
+// the existing change instead of creating a new one.
+func GenerateGerritChangeID(repo *types.Repo, headRef string) string {
+	sum := sha1.Sum([]byte(repo.ExternalRepo.ID + ":" + gitdomain.EnsureRefPrefix(headRef)))
+	return "I" + hex.EncodeToString(sum[:])
+}
+
+// GerritPushRef returns the ref commits need to be pushed to in order to
+// create or update a change against the given base ref. The head ref is set as
+// the topic of the change, which is how we keep track of the branch a change
+// was created from.
+func GerritPushRef(baseRef, headRef string) string {
+	return "refs/for/" + gitdomain.AbbreviateRef(baseRef) + "%topic=" + gitdomain.AbbreviateRef(headRef)
+}
+
+// GerritCommitMessage returns the given commit message with its Change-Id
+// trailer set to the given Change-Id. Any existing Change-Id trailer is
+// replaced, since Gerrit rejects commits with more than one.
+func GerritCommitMessage(message, changeID string) string {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "Change-Id: ") {
+			kept = append(kept, line)
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n")) + "\n\nChange-Id: " + changeID + "\n"
+}
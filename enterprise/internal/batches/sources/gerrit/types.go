@@ -0,0 +1,43 @@
+package gerrit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+)
+
+// AnnotatedChange adds metadata we need that lives outside the main Change
+// type returned by the Gerrit API alongside the change. This type is used as
+// the primary metadata type for Gerrit changesets.
+type AnnotatedChange struct {
+	*gerrit.Change
+	// CodeHostURL is the base URL of the Gerrit instance, which is needed to
+	// build the web URL of the change.
+	CodeHostURL string
+}
+
+// URL returns the web URL of the change.
+func (c *AnnotatedChange) URL() string {
+	return strings.TrimSuffix(c.CodeHostURL, "/") + "/c/" + c.Project + "/+/" + strconv.Itoa(c.Number)
+}
+
+// Body returns the commit message of the current revision, minus the subject
+// line and the Change-Id trailer. This is the closest equivalent Gerrit has to
+// the description of a pull request.
+func (c *AnnotatedChange) Body() string {
+	commit := c.CurrentCommit()
+	if commit == nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSpace(commit.Message), "\n")
+	if len(lines) > 0 && strings.HasPrefix(lines[len(lines)-1], "Change-Id: ") {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 0 {
+		lines = lines[1:]
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
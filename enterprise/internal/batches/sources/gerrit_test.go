@@ -0,0 +1,428 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/testutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNewGerritSource(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, input := range map[string]string{
+			"invalid JSON":   "invalid JSON",
+			"invalid schema": `{"username": ["not a string"]}`,
+			"bad URL":        `{"url": "http://[::1]:namedport"}`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				s, err := NewGerritSource(ctx, &types.ExternalService{
+					Config: extsvc.NewUnencryptedConfig(input),
+				}, nil)
+				assert.Nil(t, s)
+				assert.NotNil(t, err)
+			})
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		ctx := context.Background()
+		s, err := NewGerritSource(ctx, &types.ExternalService{
+			Config: extsvc.NewUnencryptedConfig(`{"url": "https://gerrit.sgdev.org", "username": "user", "password": "pass"}`),
+		}, nil)
+		assert.NotNil(t, s)
+		assert.Nil(t, err)
+	})
+}
+
+func TestGerritSource_WithAuthenticator(t *testing.T) {
+	s, _ := mockGerritSource(t, nil)
+
+	t.Run("unsupported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.OAuthBearerToken{},
+			&auth.OAuthBearerTokenWithSSH{},
+			&auth.OAuthClient{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, newSource)
+				assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+			})
+		}
+	})
+
+	t.Run("supported types", func(t *testing.T) {
+		for _, au := range []auth.Authenticator{
+			&auth.BasicAuth{},
+			&auth.BasicAuthWithSSH{},
+		} {
+			t.Run(fmt.Sprintf("%T", au), func(t *testing.T) {
+				newSource, err := s.WithAuthenticator(au)
+				assert.Nil(t, err)
+				assert.Same(t, au, newSource.(*GerritSource).client.Authenticator())
+			})
+		}
+	})
+}
+
+func TestGerritSource_LoadChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "found",
+			cs:   gerritTestChangeset("1"),
+		},
+		{
+			name: "not-found",
+			cs:   gerritTestChangeset("999"),
+			err:  "Changeset with external ID 999 not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GerritSource_LoadChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newGerritTestSource(t, tc.name)
+			defer save(t)
+
+			err := src.LoadChangeset(context.Background(), tc.cs)
+			if assertGerritError(t, err, tc.err) {
+				return
+			}
+
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), tc.cs.Changeset.Metadata)
+		})
+	}
+}
+
+func TestGerritSource_CreateChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+		err  string
+	}{
+		{
+			name: "success",
+			cs:   gerritTestChangeset(""),
+		},
+		{
+			name: "not-pushed",
+			cs: func() *Changeset {
+				cs := gerritTestChangeset("")
+				cs.HeadRef = "refs/heads/not-pushed"
+				return cs
+			}(),
+			err: "change I9c8f3b0e2d09ae19254f4986572c9010bb7482d9 not found after pushing to refs/for/main%topic=not-pushed",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GerritSource_CreateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newGerritTestSource(t, tc.name)
+			defer save(t)
+
+			exists, err := src.CreateChangeset(context.Background(), tc.cs)
+			if assertGerritError(t, err, tc.err) {
+				return
+			}
+
+			// Changes are created by pushing, so they always exist already.
+			assert.True(t, exists)
+			assert.Equal(t, "1", tc.cs.ExternalID)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), tc.cs.Changeset.Metadata)
+		})
+	}
+}
+
+func TestGerritSource_UpdateChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		cs   *Changeset
+	}{
+		{
+			name: "success",
+			cs: func() *Changeset {
+				cs := gerritTestChangeset("1")
+				cs.Title = "This is a new title"
+				cs.Body = "This is a new body"
+				cs.Metadata = gerritTestChange(gerrit.ChangeStatusNew, "main")
+				return cs
+			}(),
+		},
+		{
+			name: "moved",
+			cs: func() *Changeset {
+				cs := gerritTestChangeset("1")
+				cs.Title = "This is a new title"
+				cs.Body = "This is a new body"
+				cs.BaseRef = "refs/heads/release"
+				cs.Metadata = gerritTestChange(gerrit.ChangeStatusNew, "main")
+				return cs
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GerritSource_UpdateChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newGerritTestSource(t, tc.name)
+			defer save(t)
+
+			err := src.UpdateChangeset(context.Background(), tc.cs)
+			if assertGerritError(t, err, "") {
+				return
+			}
+
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), tc.cs.Changeset.Metadata)
+		})
+	}
+}
+
+func TestGerritSource_CloseChangeset(t *testing.T) {
+	name := "GerritSource_CloseChangeset_success"
+	src, save := newGerritTestSource(t, name)
+	defer save(t)
+
+	cs := gerritTestChangeset("1")
+	cs.Metadata = gerritTestChange(gerrit.ChangeStatusNew, "main")
+
+	err := src.CloseChangeset(context.Background(), cs)
+	if assertGerritError(t, err, "") {
+		return
+	}
+
+	assert.Equal(t, gerrit.ChangeStatusAbandoned, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+	testutil.AssertGolden(t, "testdata/golden/"+name, update(name), cs.Changeset.Metadata)
+}
+
+func TestGerritSource_ReopenChangeset(t *testing.T) {
+	name := "GerritSource_ReopenChangeset_success"
+	src, save := newGerritTestSource(t, name)
+	defer save(t)
+
+	cs := gerritTestChangeset("1")
+	cs.Metadata = gerritTestChange(gerrit.ChangeStatusAbandoned, "main")
+
+	err := src.ReopenChangeset(context.Background(), cs)
+	if assertGerritError(t, err, "") {
+		return
+	}
+
+	assert.Equal(t, gerrit.ChangeStatusNew, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+	testutil.AssertGolden(t, "testdata/golden/"+name, update(name), cs.Changeset.Metadata)
+}
+
+func TestGerritSource_CreateComment(t *testing.T) {
+	name := "GerritSource_CreateComment_success"
+	src, save := newGerritTestSource(t, name)
+	defer save(t)
+
+	err := src.CreateComment(context.Background(), gerritTestChangeset("1"), "test-comment")
+	assertGerritError(t, err, "")
+}
+
+func TestGerritSource_MergeChangeset(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name: "not-submittable",
+			err:  "changeset cannot be merged:\n" + `Gerrit API HTTP error: code=409 url="${INSTANCEURL}/a/changes/1/submit" body="Change 1: needs Code-Review"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		tc.name = "GerritSource_MergeChangeset_" + tc.name
+
+		t.Run(tc.name, func(t *testing.T) {
+			src, save := newGerritTestSource(t, tc.name)
+			defer save(t)
+
+			cs := gerritTestChangeset("1")
+			cs.Metadata = gerritTestChange(gerrit.ChangeStatusNew, "main")
+
+			err := src.MergeChangeset(context.Background(), cs, false)
+			if tc.err != "" {
+				assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+			}
+			if assertGerritError(t, err, tc.err) {
+				return
+			}
+
+			assert.Equal(t, gerrit.ChangeStatusMerged, cs.Metadata.(*gerritbatches.AnnotatedChange).Status)
+			testutil.AssertGolden(t, "testdata/golden/"+tc.name, update(tc.name), cs.Changeset.Metadata)
+		})
+	}
+}
+
+func TestGenerateGerritChangeID(t *testing.T) {
+	repo := &types.Repo{ExternalRepo: api.ExternalRepoSpec{ID: "src-cli"}}
+	other := &types.Repo{ExternalRepo: api.ExternalRepoSpec{ID: "sourcegraph"}}
+
+	id := GenerateGerritChangeID(repo, "batch-change")
+	assert.Len(t, id, 41)
+	assert.Equal(t, byte('I'), id[0])
+	assert.Equal(t, id, GenerateGerritChangeID(repo, "refs/heads/batch-change"))
+	assert.NotEqual(t, id, GenerateGerritChangeID(repo, "other-batch-change"))
+	assert.NotEqual(t, id, GenerateGerritChangeID(other, "batch-change"))
+}
+
+func TestGerritPushRef(t *testing.T) {
+	assert.Equal(t, "refs/for/main%topic=batch-change", GerritPushRef("refs/heads/main", "refs/heads/batch-change"))
+	assert.Equal(t, "refs/for/main%topic=batch-change", GerritPushRef("main", "batch-change"))
+}
+
+func TestGerritCommitMessage(t *testing.T) {
+	for name, tc := range map[string]struct {
+		message string
+		want    string
+	}{
+		"subject only": {
+			message: "Update README",
+			want:    "Update README\n\nChange-Id: Iabc\n",
+		},
+		"with body": {
+			message: "Update README\n\nThis updates the README.\n",
+			want:    "Update README\n\nThis updates the README.\n\nChange-Id: Iabc\n",
+		},
+		"existing Change-Id": {
+			message: "Update README\n\nChange-Id: Idef\n",
+			want:    "Update README\n\nChange-Id: Iabc\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, GerritCommitMessage(tc.message, "Iabc"))
+		})
+	}
+}
+
+// mockGerritSource returns a GerritSource talking to a test server that
+// handles requests with the given handler, along with the server's URL.
+func mockGerritSource(t *testing.T, handler http.HandlerFunc) (*GerritSource, string) {
+	t.Helper()
+
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := gerrit.NewClient("extsvc:gerrit:1", &schema.GerritConnection{Url: srv.URL}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &GerritSource{client: client}, srv.URL
+}
+
+// gerritTestInstanceURL returns the URL of the Gerrit instance the test
+// fixtures are recorded against.
+func gerritTestInstanceURL() string {
+	if instanceURL := os.Getenv("GERRIT_URL"); instanceURL != "" {
+		return instanceURL
+	}
+	// The test fixtures and golden files were generated with this config
+	// pointed to gerrit.sgdev.org
+	return "https://gerrit.sgdev.org"
+}
+
+// newGerritTestSource returns a GerritSource that replays the HTTP
+// interactions recorded in the fixture with the given name, or records them
+// against the instance at GERRIT_URL when the fixture is updated.
+func newGerritTestSource(t *testing.T, name string) (*GerritSource, func(testing.TB)) {
+	t.Helper()
+
+	cf, save := newClientFactory(t, name)
+
+	svc := &types.ExternalService{
+		Kind: extsvc.KindGerrit,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.GerritConnection{
+			Url:      gerritTestInstanceURL(),
+			Username: os.Getenv("GERRIT_USERNAME"),
+			Password: os.Getenv("GERRIT_PASSWORD"),
+		})),
+	}
+
+	src, err := NewGerritSource(context.Background(), svc, cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src, save
+}
+
+// assertGerritError asserts that err matches want, where an empty want means
+// no error, and returns whether an error occurred.
+func assertGerritError(t *testing.T, err error, want string) bool {
+	t.Helper()
+
+	if want == "" {
+		want = "<nil>"
+	}
+	want = strings.ReplaceAll(want, "${INSTANCEURL}", gerritTestInstanceURL())
+	if have := fmt.Sprint(err); have != want {
+		t.Errorf("error:\nhave: %q\nwant: %q", have, want)
+	}
+	return err != nil
+}
+
+func gerritTestChangeset(externalID string) *Changeset {
+	return &Changeset{
+		Changeset: &btypes.Changeset{ExternalID: externalID},
+		TargetRepo: &types.Repo{
+			ExternalRepo: api.ExternalRepoSpec{ID: "src-cli", ServiceType: extsvc.TypeGerrit},
+			Metadata:     &gerrit.Project{ID: "src-cli", Name: "src-cli"},
+		},
+		Title:   "Update README",
+		Body:    "This updates the README.",
+		HeadRef: "refs/heads/batch-change",
+		BaseRef: "refs/heads/main",
+	}
+}
+
+func gerritTestChange(status gerrit.ChangeStatus, branch string) *gerritbatches.AnnotatedChange {
+	return &gerritbatches.AnnotatedChange{
+		Change: &gerrit.Change{
+			ID:       "src-cli~" + branch + "~I6bd657f0f6aa0f1669b7d0267ccd3eea3eb5dc74",
+			Project:  "src-cli",
+			Branch:   branch,
+			ChangeID: "I6bd657f0f6aa0f1669b7d0267ccd3eea3eb5dc74",
+			Subject:  "Update README",
+			Status:   status,
+			Number:   1,
+		},
+		CodeHostURL: gerritTestInstanceURL(),
+	}
+}
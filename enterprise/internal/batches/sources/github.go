@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -24,7 +25,11 @@ type GithubSource struct {
 	au     auth.Authenticator
 }
 
-var _ ForkableChangesetSource = GithubSource{}
+var (
+	_ ForkableChangesetSource    = GithubSource{}
+	_ BaseBranchCheckStateSource = GithubSource{}
+	_ ReviewerChangesetSource    = GithubSource{}
+)
 
 func NewGithubSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GithubSource, error) {
 	rawConfig, err := svc.Config.Decrypt(ctx)
@@ -293,6 +298,86 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// RequestReviewers requests reviews on the pull request from the given users
+// and teams. Email addresses are resolved to the users whose public email
+// address matches. The author of the pull request is skipped, since GitHub
+// rejects the whole request otherwise.
+func (s GithubSource) RequestReviewers(ctx context.Context, c *Changeset, reviewers ChangesetReviewers) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	logins := make([]string, 0, len(reviewers.Usernames))
+	seen := map[string]struct{}{strings.ToLower(pr.Author.Login): {}}
+	addLogin := func(login string) {
+		if _, ok := seen[strings.ToLower(login)]; ok {
+			return
+		}
+		seen[strings.ToLower(login)] = struct{}{}
+		logins = append(logins, login)
+	}
+
+	for _, username := range reviewers.Usernames {
+		addLogin(username)
+	}
+	for _, email := range reviewers.Emails {
+		matches, err := s.client.SearchUserLoginsByEmail(ctx, email)
+		if err != nil {
+			return errors.Wrapf(err, "looking up GitHub user with email %q", email)
+		}
+		// Only request a review if the address identifies a single user.
+		if len(matches) == 1 {
+			addLogin(matches[0])
+		}
+	}
+	if len(logins) == 0 && len(reviewers.Teams) == 0 {
+		return nil
+	}
+
+	repo, ok := c.TargetRepo.Metadata.(*github.Repository)
+	if !ok {
+		return errors.New("target repo is not a GitHub repo")
+	}
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "parsing repo name")
+	}
+
+	return s.client.RequestReviewers(ctx, owner, name, pr.Number, logins, reviewers.Teams)
+}
+
+// LoadBaseBranchCheckState returns the combined state of the commit statuses
+// and check runs of the commit the given base ref points to.
+func (s GithubSource) LoadBaseBranchCheckState(ctx context.Context, repo *types.Repo, baseRef string) (btypes.ChangesetCheckState, error) {
+	meta, ok := repo.Metadata.(*github.Repository)
+	if !ok || meta == nil {
+		return "", errors.New("repo is not a GitHub repo")
+	}
+
+	owner, name, err := github.SplitRepositoryNameWithOwner(meta.NameWithOwner)
+	if err != nil {
+		return "", errors.New("parsing repo name")
+	}
+
+	state, err := s.client.GetRefStatusCheckRollupState(ctx, owner, name, gitdomain.EnsureRefPrefix(baseRef))
+	if err != nil {
+		return "", err
+	}
+
+	switch state {
+	case "SUCCESS":
+		return btypes.ChangesetCheckStatePassed, nil
+	case "ERROR", "FAILURE":
+		return btypes.ChangesetCheckStateFailed, nil
+	case "EXPECTED", "PENDING":
+		return btypes.ChangesetCheckStatePending, nil
+	default:
+		return btypes.ChangesetCheckStateUnknown, nil
+	}
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
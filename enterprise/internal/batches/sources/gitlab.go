@@ -29,6 +29,7 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ ReviewerChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -487,6 +488,72 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	return c.Changeset.SetMetadata(updated)
 }
 
+// RequestReviewers adds the given users to the reviewers of the merge
+// request, keeping any reviewers it already has. Email addresses are resolved
+// to the users with that email address. GitLab has no concept of team
+// reviewers, so teams are ignored, as are usernames and email addresses that
+// don't resolve to a GitLab user.
+func (s *GitLabSource) RequestReviewers(ctx context.Context, c *Changeset, reviewers ChangesetReviewers) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	// The author of a merge request can't review it.
+	existing := map[int32]struct{}{mr.Author.ID: {}}
+	ids := make([]int32, 0, len(mr.Reviewers)+len(reviewers.Usernames)+len(reviewers.Emails))
+	for _, r := range mr.Reviewers {
+		existing[r.ID] = struct{}{}
+		ids = append(ids, r.ID)
+	}
+
+	added := false
+	addUsers := func(query string) error {
+		users, _, err := s.client.ListUsers(ctx, "users?"+query)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			if _, ok := existing[u.ID]; ok {
+				continue
+			}
+			existing[u.ID] = struct{}{}
+			ids = append(ids, u.ID)
+			added = true
+		}
+		return nil
+	}
+
+	for _, username := range reviewers.Usernames {
+		if err := addUsers("username=" + url.QueryEscape(username)); err != nil {
+			return errors.Wrapf(err, "looking up GitLab user %q", username)
+		}
+	}
+	for _, email := range reviewers.Emails {
+		// Searching for an email address only returns exact matches.
+		if err := addUsers("search=" + url.QueryEscape(email)); err != nil {
+			return errors.Wrapf(err, "looking up GitLab user with email %q", email)
+		}
+	}
+	if !added {
+		return nil
+	}
+
+	updated, err := s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{
+		ReviewerIDs: ids,
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request reviewers")
+	}
+
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
 // UndraftChangeset marks the changeset as *not* work in progress anymore.
 func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
 	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
@@ -3625,12 +3625,22 @@ type MockBitbucketCloudClient struct {
 	// ReposFunc is an instance of a mock function object controlling the
 	// behavior of the method Repos.
 	ReposFunc *BitbucketCloudClientReposFunc
+	// RepositoryPermissionsFunc is an instance of a mock function object
+	// controlling the behavior of the method RepositoryPermissions.
+	RepositoryPermissionsFunc *BitbucketCloudClientRepositoryPermissionsFunc
 	// UpdatePullRequestFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdatePullRequest.
 	UpdatePullRequestFunc *BitbucketCloudClientUpdatePullRequestFunc
+	// UserRepositoryPermissionsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UserRepositoryPermissions.
+	UserRepositoryPermissionsFunc *BitbucketCloudClientUserRepositoryPermissionsFunc
 	// WithAuthenticatorFunc is an instance of a mock function object
 	// controlling the behavior of the method WithAuthenticator.
 	WithAuthenticatorFunc *BitbucketCloudClientWithAuthenticatorFunc
+	// WorkspaceMemberFunc is an instance of a mock function object
+	// controlling the behavior of the method WorkspaceMember.
+	WorkspaceMemberFunc *BitbucketCloudClientWorkspaceMemberFunc
 }
 
 // NewMockBitbucketCloudClient creates a new mock of the Client interface.
@@ -3697,16 +3707,31 @@ func NewMockBitbucketCloudClient() *MockBitbucketCloudClient {
 				return
 			},
 		},
+		RepositoryPermissionsFunc: &BitbucketCloudClientRepositoryPermissionsFunc{
+			defaultHook: func(context.Context, string, string) (r0 []*bitbucketcloud.RepositoryPermission, r1 error) {
+				return
+			},
+		},
 		UpdatePullRequestFunc: &BitbucketCloudClientUpdatePullRequestFunc{
 			defaultHook: func(context.Context, *bitbucketcloud.Repo, int64, bitbucketcloud.PullRequestInput) (r0 *bitbucketcloud.PullRequest, r1 error) {
 				return
 			},
 		},
+		UserRepositoryPermissionsFunc: &BitbucketCloudClientUserRepositoryPermissionsFunc{
+			defaultHook: func(context.Context, string, string) (r0 []*bitbucketcloud.RepositoryPermission, r1 error) {
+				return
+			},
+		},
 		WithAuthenticatorFunc: &BitbucketCloudClientWithAuthenticatorFunc{
 			defaultHook: func(auth.Authenticator) (r0 bitbucketcloud.Client) {
 				return
 			},
 		},
+		WorkspaceMemberFunc: &BitbucketCloudClientWorkspaceMemberFunc{
+			defaultHook: func(context.Context, string, string) (r0 *bitbucketcloud.Account, r1 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -3774,16 +3799,31 @@ func NewStrictMockBitbucketCloudClient() *MockBitbucketCloudClient {
 				panic("unexpected invocation of MockBitbucketCloudClient.Repos")
 			},
 		},
+		RepositoryPermissionsFunc: &BitbucketCloudClientRepositoryPermissionsFunc{
+			defaultHook: func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error) {
+				panic("unexpected invocation of MockBitbucketCloudClient.RepositoryPermissions")
+			},
+		},
 		UpdatePullRequestFunc: &BitbucketCloudClientUpdatePullRequestFunc{
 			defaultHook: func(context.Context, *bitbucketcloud.Repo, int64, bitbucketcloud.PullRequestInput) (*bitbucketcloud.PullRequest, error) {
 				panic("unexpected invocation of MockBitbucketCloudClient.UpdatePullRequest")
 			},
 		},
+		UserRepositoryPermissionsFunc: &BitbucketCloudClientUserRepositoryPermissionsFunc{
+			defaultHook: func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error) {
+				panic("unexpected invocation of MockBitbucketCloudClient.UserRepositoryPermissions")
+			},
+		},
 		WithAuthenticatorFunc: &BitbucketCloudClientWithAuthenticatorFunc{
 			defaultHook: func(auth.Authenticator) bitbucketcloud.Client {
 				panic("unexpected invocation of MockBitbucketCloudClient.WithAuthenticator")
 			},
 		},
+		WorkspaceMemberFunc: &BitbucketCloudClientWorkspaceMemberFunc{
+			defaultHook: func(context.Context, string, string) (*bitbucketcloud.Account, error) {
+				panic("unexpected invocation of MockBitbucketCloudClient.WorkspaceMember")
+			},
+		},
 	}
 }
 
@@ -3828,12 +3868,21 @@ func NewMockBitbucketCloudClientFrom(i bitbucketcloud.Client) *MockBitbucketClou
 		ReposFunc: &BitbucketCloudClientReposFunc{
 			defaultHook: i.Repos,
 		},
+		RepositoryPermissionsFunc: &BitbucketCloudClientRepositoryPermissionsFunc{
+			defaultHook: i.RepositoryPermissions,
+		},
 		UpdatePullRequestFunc: &BitbucketCloudClientUpdatePullRequestFunc{
 			defaultHook: i.UpdatePullRequest,
 		},
+		UserRepositoryPermissionsFunc: &BitbucketCloudClientUserRepositoryPermissionsFunc{
+			defaultHook: i.UserRepositoryPermissions,
+		},
 		WithAuthenticatorFunc: &BitbucketCloudClientWithAuthenticatorFunc{
 			defaultHook: i.WithAuthenticator,
 		},
+		WorkspaceMemberFunc: &BitbucketCloudClientWorkspaceMemberFunc{
+			defaultHook: i.WorkspaceMember,
+		},
 	}
 }
 
@@ -5180,6 +5229,121 @@ func (c BitbucketCloudClientReposFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// BitbucketCloudClientRepositoryPermissionsFunc describes the behavior when
+// the RepositoryPermissions method of the parent MockBitbucketCloudClient
+// instance is invoked.
+type BitbucketCloudClientRepositoryPermissionsFunc struct {
+	defaultHook func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error)
+	hooks       []func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error)
+	history     []BitbucketCloudClientRepositoryPermissionsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepositoryPermissions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockBitbucketCloudClient) RepositoryPermissions(v0 context.Context, v1 string, v2 string) ([]*bitbucketcloud.RepositoryPermission, error) {
+	r0, r1 := m.RepositoryPermissionsFunc.nextHook()(v0, v1, v2)
+	m.RepositoryPermissionsFunc.appendCall(BitbucketCloudClientRepositoryPermissionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// RepositoryPermissions method of the parent MockBitbucketCloudClient
+// instance is invoked and the hook queue is empty.
+func (f *BitbucketCloudClientRepositoryPermissionsFunc) SetDefaultHook(hook func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepositoryPermissions method of the parent MockBitbucketCloudClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *BitbucketCloudClientRepositoryPermissionsFunc) PushHook(hook func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BitbucketCloudClientRepositoryPermissionsFunc) SetDefaultReturn(r0 []*bitbucketcloud.RepositoryPermission, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BitbucketCloudClientRepositoryPermissionsFunc) PushReturn(r0 []*bitbucketcloud.RepositoryPermission, r1 error) {
+	f.PushHook(func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error) {
+		return r0, r1
+	})
+}
+
+func (f *BitbucketCloudClientRepositoryPermissionsFunc) nextHook() func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BitbucketCloudClientRepositoryPermissionsFunc) appendCall(r0 BitbucketCloudClientRepositoryPermissionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BitbucketCloudClientRepositoryPermissionsFuncCall objects describing the
+// invocations of this function.
+func (f *BitbucketCloudClientRepositoryPermissionsFunc) History() []BitbucketCloudClientRepositoryPermissionsFuncCall {
+	f.mutex.Lock()
+	history := make([]BitbucketCloudClientRepositoryPermissionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BitbucketCloudClientRepositoryPermissionsFuncCall is an object that
+// describes an invocation of method RepositoryPermissions on an instance of
+// MockBitbucketCloudClient.
+type BitbucketCloudClientRepositoryPermissionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*bitbucketcloud.RepositoryPermission
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BitbucketCloudClientRepositoryPermissionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BitbucketCloudClientRepositoryPermissionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // BitbucketCloudClientUpdatePullRequestFunc describes the behavior when the
 // UpdatePullRequest method of the parent MockBitbucketCloudClient instance
 // is invoked.
@@ -5298,6 +5462,121 @@ func (c BitbucketCloudClientUpdatePullRequestFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// BitbucketCloudClientUserRepositoryPermissionsFunc describes the behavior
+// when the UserRepositoryPermissions method of the parent
+// MockBitbucketCloudClient instance is invoked.
+type BitbucketCloudClientUserRepositoryPermissionsFunc struct {
+	defaultHook func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error)
+	hooks       []func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error)
+	history     []BitbucketCloudClientUserRepositoryPermissionsFuncCall
+	mutex       sync.Mutex
+}
+
+// UserRepositoryPermissions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockBitbucketCloudClient) UserRepositoryPermissions(v0 context.Context, v1 string, v2 string) ([]*bitbucketcloud.RepositoryPermission, error) {
+	r0, r1 := m.UserRepositoryPermissionsFunc.nextHook()(v0, v1, v2)
+	m.UserRepositoryPermissionsFunc.appendCall(BitbucketCloudClientUserRepositoryPermissionsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// UserRepositoryPermissions method of the parent MockBitbucketCloudClient
+// instance is invoked and the hook queue is empty.
+func (f *BitbucketCloudClientUserRepositoryPermissionsFunc) SetDefaultHook(hook func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UserRepositoryPermissions method of the parent MockBitbucketCloudClient
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *BitbucketCloudClientUserRepositoryPermissionsFunc) PushHook(hook func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BitbucketCloudClientUserRepositoryPermissionsFunc) SetDefaultReturn(r0 []*bitbucketcloud.RepositoryPermission, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BitbucketCloudClientUserRepositoryPermissionsFunc) PushReturn(r0 []*bitbucketcloud.RepositoryPermission, r1 error) {
+	f.PushHook(func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error) {
+		return r0, r1
+	})
+}
+
+func (f *BitbucketCloudClientUserRepositoryPermissionsFunc) nextHook() func(context.Context, string, string) ([]*bitbucketcloud.RepositoryPermission, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BitbucketCloudClientUserRepositoryPermissionsFunc) appendCall(r0 BitbucketCloudClientUserRepositoryPermissionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BitbucketCloudClientUserRepositoryPermissionsFuncCall objects describing
+// the invocations of this function.
+func (f *BitbucketCloudClientUserRepositoryPermissionsFunc) History() []BitbucketCloudClientUserRepositoryPermissionsFuncCall {
+	f.mutex.Lock()
+	history := make([]BitbucketCloudClientUserRepositoryPermissionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BitbucketCloudClientUserRepositoryPermissionsFuncCall is an object that
+// describes an invocation of method UserRepositoryPermissions on an
+// instance of MockBitbucketCloudClient.
+type BitbucketCloudClientUserRepositoryPermissionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*bitbucketcloud.RepositoryPermission
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BitbucketCloudClientUserRepositoryPermissionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BitbucketCloudClientUserRepositoryPermissionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // BitbucketCloudClientWithAuthenticatorFunc describes the behavior when the
 // WithAuthenticator method of the parent MockBitbucketCloudClient instance
 // is invoked.
@@ -5403,3 +5682,118 @@ func (c BitbucketCloudClientWithAuthenticatorFuncCall) Args() []interface{} {
 func (c BitbucketCloudClientWithAuthenticatorFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// BitbucketCloudClientWorkspaceMemberFunc describes the behavior when the
+// WorkspaceMember method of the parent MockBitbucketCloudClient instance
+// is invoked.
+type BitbucketCloudClientWorkspaceMemberFunc struct {
+	defaultHook func(context.Context, string, string) (*bitbucketcloud.Account, error)
+	hooks       []func(context.Context, string, string) (*bitbucketcloud.Account, error)
+	history     []BitbucketCloudClientWorkspaceMemberFuncCall
+	mutex       sync.Mutex
+}
+
+// WorkspaceMember delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockBitbucketCloudClient) WorkspaceMember(v0 context.Context, v1 string, v2 string) (*bitbucketcloud.Account, error) {
+	r0, r1 := m.WorkspaceMemberFunc.nextHook()(v0, v1, v2)
+	m.WorkspaceMemberFunc.appendCall(BitbucketCloudClientWorkspaceMemberFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the WorkspaceMember
+// method of the parent MockBitbucketCloudClient instance is invoked and the
+// hook queue is empty.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) SetDefaultHook(hook func(context.Context, string, string) (*bitbucketcloud.Account, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WorkspaceMember method of the parent MockBitbucketCloudClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) PushHook(hook func(context.Context, string, string) (*bitbucketcloud.Account, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) SetDefaultReturn(r0 *bitbucketcloud.Account, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (*bitbucketcloud.Account, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) PushReturn(r0 *bitbucketcloud.Account, r1 error) {
+	f.PushHook(func(context.Context, string, string) (*bitbucketcloud.Account, error) {
+		return r0, r1
+	})
+}
+
+func (f *BitbucketCloudClientWorkspaceMemberFunc) nextHook() func(context.Context, string, string) (*bitbucketcloud.Account, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BitbucketCloudClientWorkspaceMemberFunc) appendCall(r0 BitbucketCloudClientWorkspaceMemberFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BitbucketCloudClientWorkspaceMemberFuncCall objects describing the
+// invocations of this function.
+func (f *BitbucketCloudClientWorkspaceMemberFunc) History() []BitbucketCloudClientWorkspaceMemberFuncCall {
+	f.mutex.Lock()
+	history := make([]BitbucketCloudClientWorkspaceMemberFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BitbucketCloudClientWorkspaceMemberFuncCall is an object that describes
+// an invocation of method WorkspaceMember on an instance of
+// MockBitbucketCloudClient.
+type BitbucketCloudClientWorkspaceMemberFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *bitbucketcloud.Account
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BitbucketCloudClientWorkspaceMemberFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BitbucketCloudClientWorkspaceMemberFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -44,7 +44,21 @@ func (cc *ChangesetCounts) String() string {
 // start and end, it generates `timestampCount` datapoints with each ChangesetCounts
 // representing a point in time. `es` are expected to be pre-sorted.
 func CalcCounts(start, end time.Time, cs []*btypes.Changeset, es ...*btypes.ChangesetEvent) ([]*ChangesetCounts, error) {
-	ts := GenerateTimestamps(start, end)
+	return calcCounts(GenerateTimestamps(start, end), cs, es...)
+}
+
+// CalcCountsAt calculates the ChangesetCounts for the given Changesets and
+// their ChangesetEvents at the single point in time t. `es` are expected to be
+// pre-sorted.
+func CalcCountsAt(t time.Time, cs []*btypes.Changeset, es ...*btypes.ChangesetEvent) (*ChangesetCounts, error) {
+	counts, err := calcCounts([]time.Time{t}, cs, es...)
+	if err != nil {
+		return nil, err
+	}
+	return counts[0], nil
+}
+
+func calcCounts(ts []time.Time, cs []*btypes.Changeset, es ...*btypes.ChangesetEvent) ([]*ChangesetCounts, error) {
 	counts := make([]*ChangesetCounts, len(ts))
 	for i, t := range ts {
 		counts[i] = &ChangesetCounts{Time: t}
@@ -108,6 +122,57 @@ func CalcCounts(start, end time.Time, cs []*btypes.Changeset, es ...*btypes.Chan
 	return counts, nil
 }
 
+// CalcReviewLatency calculates the average time between a changeset being
+// opened and the first review it received, across all of the given
+// Changesets that have been reviewed. The second return value is false if
+// none of them has. `es` are expected to be pre-sorted.
+func CalcReviewLatency(cs []*btypes.Changeset, es ...*btypes.ChangesetEvent) (time.Duration, bool) {
+	firstReview := make(map[int64]time.Time)
+	for _, e := range es {
+		if _, ok := firstReview[e.Changeset()]; ok || !isReviewEvent(e) {
+			continue
+		}
+		if t := e.Timestamp(); !t.IsZero() {
+			firstReview[e.Changeset()] = t
+		}
+	}
+
+	var (
+		total    time.Duration
+		reviewed int64
+	)
+	for _, c := range cs {
+		reviewedAt, ok := firstReview[c.ID]
+		if !ok {
+			continue
+		}
+		openedAt := c.ExternalCreatedAt()
+		if openedAt.IsZero() || reviewedAt.Before(openedAt) {
+			continue
+		}
+		total += reviewedAt.Sub(openedAt)
+		reviewed++
+	}
+
+	if reviewed == 0 {
+		return 0, false
+	}
+	return total / time.Duration(reviewed), true
+}
+
+func isReviewEvent(e *btypes.ChangesetEvent) bool {
+	switch e.Kind {
+	case btypes.ChangesetEventKindGitHubReviewed,
+		btypes.ChangesetEventKindBitbucketServerApproved,
+		btypes.ChangesetEventKindBitbucketServerReviewed,
+		btypes.ChangesetEventKindGitLabApproved,
+		btypes.ChangesetEventKindBitbucketCloudApproved,
+		btypes.ChangesetEventKindBitbucketCloudPullRequestApproved:
+		return true
+	}
+	return false
+}
+
 func GenerateTimestamps(start, end time.Time) []time.Time {
 	timeStep := end.Sub(start) / timestampCount
 	// Walk backwards from `end` to >= `start` in equal intervals.
@@ -1464,6 +1464,66 @@ func TestCalcCounts(t *testing.T) {
 	}
 }
 
+func TestCalcCountsAt(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	changesets := []*btypes.Changeset{
+		ghChangeset(1, daysAgo(3)),
+		ghChangeset(2, daysAgo(3)),
+		ghChangeset(3, daysAgo(1)),
+	}
+	events := []*btypes.ChangesetEvent{
+		event(t, daysAgo(2), btypes.ChangesetEventKindGitHubMerged, 1),
+		event(t, daysAgo(1), btypes.ChangesetEventKindGitHubClosed, 2),
+	}
+
+	have, err := CalcCountsAt(daysAgo(2), changesets, events...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ChangesetCounts{Time: daysAgo(2), Total: 2, Merged: 1, Open: 1, OpenPending: 1}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("wrong counts calculated. diff=%s", diff)
+	}
+}
+
+func TestCalcReviewLatency(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	changesets := []*btypes.Changeset{
+		ghChangeset(1, daysAgo(5)),
+		ghChangeset(2, daysAgo(5)),
+		ghChangeset(3, daysAgo(5)),
+	}
+
+	t.Run("no reviews", func(t *testing.T) {
+		_, ok := CalcReviewLatency(changesets)
+		if ok {
+			t.Fatal("expected no review latency")
+		}
+	})
+
+	t.Run("reviews", func(t *testing.T) {
+		events := []*btypes.ChangesetEvent{
+			ghReview(1, daysAgo(4), "reviewer", "APPROVED"),
+			event(t, daysAgo(3), btypes.ChangesetEventKindGitHubMerged, 1),
+			ghReview(2, daysAgo(2), "reviewer", "CHANGES_REQUESTED"),
+			// Only the first review of a changeset counts.
+			ghReview(2, daysAgo(1), "reviewer", "APPROVED"),
+		}
+
+		have, ok := CalcReviewLatency(changesets, events...)
+		if !ok {
+			t.Fatal("expected review latency")
+		}
+		if want := 2 * 24 * time.Hour; have != want {
+			t.Fatalf("wrong review latency: want=%s have=%s", want, have)
+		}
+	})
+}
+
 func ghChangeset(id int64, t time.Time) *btypes.Changeset {
 	return &btypes.Changeset{ID: id, Metadata: &github.PullRequest{CreatedAt: t}}
 }
@@ -0,0 +1,137 @@
+package state
+
+import (
+	"sort"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// RolloutStatus describes how far the staged rollout of the changesets of a
+// batch change has progressed.
+type RolloutStatus struct {
+	// Stage is the index of the current stage. It's equal to the number of
+	// stages in the rollout once the final stage, which releases all remaining
+	// changesets, has been reached.
+	Stage int
+	// Released is the number of changesets that may be published.
+	Released int
+	// Succeeded is the number of released changesets that have been merged
+	// and whose checks passed.
+	Succeeded int
+	// Failed is the number of released changesets that have been closed
+	// without being merged, whose checks failed, or that failed to be
+	// published.
+	Failed int
+	// Halted is true if the failure ratio exceeded the maximum of the rollout,
+	// in which case no further stages are released.
+	Halted bool
+
+	// released holds the IDs of the released changesets.
+	released map[int64]struct{}
+}
+
+// IsReleased returns true if the changeset with the given ID may be published.
+func (s *RolloutStatus) IsReleased(id int64) bool {
+	_, ok := s.released[id]
+	return ok
+}
+
+// CalcRolloutStatus calculates the status of the given rollout over the given
+// changesets, which are expected to be all changesets owned by a single batch
+// change. Imported changesets, which have no changeset spec, aren't part of
+// the rollout. Changesets are released in the order they were created in. A
+// stage is only released once all changesets of the previous stages have
+// either succeeded or failed, and as long as the ratio of failed to released
+// changesets doesn't exceed the maximum failure ratio.
+func CalcRolloutStatus(rollout *batcheslib.Rollout, cs []*btypes.Changeset) *RolloutStatus {
+	ordered := make([]*btypes.Changeset, 0, len(cs))
+	for _, c := range cs {
+		if c.CurrentSpecID != 0 {
+			ordered = append(ordered, c)
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].ID < ordered[j].ID })
+
+	status := &RolloutStatus{}
+	for {
+		if status.Stage < len(rollout.Stages) {
+			status.Released += rollout.Stages[status.Stage]
+		} else {
+			status.Released = len(ordered)
+		}
+		if status.Released > len(ordered) {
+			status.Released = len(ordered)
+		}
+
+		status.Succeeded, status.Failed = 0, 0
+		pending := 0
+		for _, c := range ordered[:status.Released] {
+			switch rolloutOutcome(c) {
+			case rolloutSucceeded:
+				status.Succeeded++
+			case rolloutFailed:
+				status.Failed++
+			default:
+				pending++
+			}
+		}
+
+		if status.Released > 0 && float64(status.Failed)/float64(status.Released) > rollout.MaxFailureRatio {
+			status.Halted = true
+			break
+		}
+		if pending > 0 || status.Released == len(ordered) {
+			break
+		}
+		status.Stage++
+	}
+
+	status.released = make(map[int64]struct{}, status.Released)
+	for _, c := range ordered[:status.Released] {
+		status.released[c.ID] = struct{}{}
+	}
+	return status
+}
+
+// IsReleasedInStage returns true if the changeset at the given position in a
+// rollout, which is the number of changesets of the rollout created before it,
+// may be published once the rollout reached the given stage. This allows
+// checking a single changeset against the stage calculated by
+// CalcRolloutStatus, without looking at all changesets of the batch change.
+func IsReleasedInStage(rollout *batcheslib.Rollout, stage, position int) bool {
+	if stage >= len(rollout.Stages) {
+		return true
+	}
+
+	released := 0
+	for _, n := range rollout.Stages[:stage+1] {
+		released += n
+	}
+	return position < released
+}
+
+type rolloutResult int
+
+const (
+	rolloutPending rolloutResult = iota
+	rolloutSucceeded
+	rolloutFailed
+)
+
+func rolloutOutcome(c *btypes.Changeset) rolloutResult {
+	switch {
+	// A merged changeset only succeeded once its checks passed, since the
+	// point of a rollout is to find out whether the change breaks anything.
+	case c.ExternalState == btypes.ChangesetExternalStateMerged && c.ExternalCheckState == btypes.ChangesetCheckStatePassed:
+		return rolloutSucceeded
+	case c.ReconcilerState == btypes.ReconcilerStateFailed:
+		return rolloutFailed
+	case c.ExternalCheckState == btypes.ChangesetCheckStateFailed:
+		return rolloutFailed
+	case c.ExternalState == btypes.ChangesetExternalStateClosed:
+		return rolloutFailed
+	default:
+		return rolloutPending
+	}
+}
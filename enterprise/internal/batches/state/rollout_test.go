@@ -0,0 +1,146 @@
+package state
+
+import (
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestCalcRolloutStatus(t *testing.T) {
+	unpublished := func(id int64) *btypes.Changeset {
+		return &btypes.Changeset{ID: id, CurrentSpecID: id, PublicationState: btypes.ChangesetPublicationStateUnpublished}
+	}
+	open := func(id int64) *btypes.Changeset {
+		return &btypes.Changeset{ID: id, CurrentSpecID: id, PublicationState: btypes.ChangesetPublicationStatePublished, ExternalState: btypes.ChangesetExternalStateOpen}
+	}
+	merged := func(id int64) *btypes.Changeset {
+		return &btypes.Changeset{ID: id, CurrentSpecID: id, PublicationState: btypes.ChangesetPublicationStatePublished, ExternalState: btypes.ChangesetExternalStateMerged, ExternalCheckState: btypes.ChangesetCheckStatePassed}
+	}
+	closed := func(id int64) *btypes.Changeset {
+		return &btypes.Changeset{ID: id, CurrentSpecID: id, PublicationState: btypes.ChangesetPublicationStatePublished, ExternalState: btypes.ChangesetExternalStateClosed}
+	}
+	imported := func(id int64) *btypes.Changeset {
+		return &btypes.Changeset{ID: id, PublicationState: btypes.ChangesetPublicationStatePublished, ExternalState: btypes.ChangesetExternalStateOpen}
+	}
+	checksFailed := func(id int64) *btypes.Changeset {
+		c := open(id)
+		c.ExternalCheckState = btypes.ChangesetCheckStateFailed
+		return c
+	}
+	mergedWithChecksFailed := func(id int64) *btypes.Changeset {
+		c := merged(id)
+		c.ExternalCheckState = btypes.ChangesetCheckStateFailed
+		return c
+	}
+	mergedWithChecksPending := func(id int64) *btypes.Changeset {
+		c := merged(id)
+		c.ExternalCheckState = btypes.ChangesetCheckStatePending
+		return c
+	}
+
+	for name, tc := range map[string]struct {
+		rollout    *batcheslib.Rollout
+		changesets []*btypes.Changeset
+		want       RolloutStatus
+		released   []int64
+	}{
+		"first stage": {
+			rollout:    &batcheslib.Rollout{Stages: []int{2, 3}},
+			changesets: []*btypes.Changeset{unpublished(3), unpublished(1), unpublished(2)},
+			want:       RolloutStatus{Stage: 0, Released: 2},
+			released:   []int64{1, 2},
+		},
+		"first stage pending": {
+			rollout:    &batcheslib.Rollout{Stages: []int{2, 3}},
+			changesets: []*btypes.Changeset{merged(1), open(2), unpublished(3)},
+			want:       RolloutStatus{Stage: 0, Released: 2, Succeeded: 1},
+			released:   []int64{1, 2},
+		},
+		"second stage": {
+			rollout:    &batcheslib.Rollout{Stages: []int{2, 3}},
+			changesets: []*btypes.Changeset{merged(1), merged(2), unpublished(3), unpublished(4), unpublished(5), unpublished(6)},
+			want:       RolloutStatus{Stage: 1, Released: 5, Succeeded: 2},
+			released:   []int64{1, 2, 3, 4, 5},
+		},
+		"final stage": {
+			rollout:    &batcheslib.Rollout{Stages: []int{1, 1}},
+			changesets: []*btypes.Changeset{merged(1), merged(2), unpublished(3), unpublished(4)},
+			want:       RolloutStatus{Stage: 2, Released: 4, Succeeded: 2},
+			released:   []int64{1, 2, 3, 4},
+		},
+		"failure within ratio": {
+			rollout:    &batcheslib.Rollout{Stages: []int{2}, MaxFailureRatio: 0.5},
+			changesets: []*btypes.Changeset{merged(1), closed(2), unpublished(3)},
+			want:       RolloutStatus{Stage: 1, Released: 3, Succeeded: 1, Failed: 1},
+			released:   []int64{1, 2, 3},
+		},
+		"halted": {
+			rollout:    &batcheslib.Rollout{Stages: []int{2}},
+			changesets: []*btypes.Changeset{merged(1), checksFailed(2), unpublished(3)},
+			want:       RolloutStatus{Stage: 0, Released: 2, Succeeded: 1, Failed: 1, Halted: true},
+			released:   []int64{1, 2},
+		},
+		"merged with failed checks": {
+			rollout:    &batcheslib.Rollout{Stages: []int{2}},
+			changesets: []*btypes.Changeset{merged(1), mergedWithChecksFailed(2), unpublished(3)},
+			want:       RolloutStatus{Stage: 0, Released: 2, Succeeded: 1, Failed: 1, Halted: true},
+			released:   []int64{1, 2},
+		},
+		"merged with pending checks": {
+			rollout:    &batcheslib.Rollout{Stages: []int{2}},
+			changesets: []*btypes.Changeset{merged(1), mergedWithChecksPending(2), unpublished(3)},
+			want:       RolloutStatus{Stage: 0, Released: 2, Succeeded: 1},
+			released:   []int64{1, 2},
+		},
+		"imported changesets": {
+			rollout:    &batcheslib.Rollout{Stages: []int{1}},
+			changesets: []*btypes.Changeset{imported(1), unpublished(2), unpublished(3)},
+			want:       RolloutStatus{Stage: 0, Released: 1},
+			released:   []int64{2},
+		},
+		"stage larger than changesets": {
+			rollout:    &batcheslib.Rollout{Stages: []int{5}},
+			changesets: []*btypes.Changeset{unpublished(1)},
+			want:       RolloutStatus{Stage: 0, Released: 1},
+			released:   []int64{1},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have := CalcRolloutStatus(tc.rollout, tc.changesets)
+			if have.Stage != tc.want.Stage || have.Released != tc.want.Released || have.Succeeded != tc.want.Succeeded || have.Failed != tc.want.Failed || have.Halted != tc.want.Halted {
+				t.Fatalf("wrong status. want=%+v, have=%+v", tc.want, *have)
+			}
+			for _, c := range tc.changesets {
+				want := false
+				for _, id := range tc.released {
+					want = want || id == c.ID
+				}
+				if have.IsReleased(c.ID) != want {
+					t.Errorf("wrong release of changeset %d. want=%t", c.ID, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsReleasedInStage(t *testing.T) {
+	rollout := &batcheslib.Rollout{Stages: []int{2, 3}}
+
+	for _, tc := range []struct {
+		stage    int
+		position int
+		want     bool
+	}{
+		{stage: 0, position: 0, want: true},
+		{stage: 0, position: 1, want: true},
+		{stage: 0, position: 2, want: false},
+		{stage: 1, position: 4, want: true},
+		{stage: 1, position: 5, want: false},
+		{stage: 2, position: 100, want: true},
+	} {
+		if have := IsReleasedInStage(rollout, tc.stage, tc.position); have != tc.want {
+			t.Errorf("stage %d, position %d: want=%t, have=%t", tc.stage, tc.position, tc.want, have)
+		}
+	}
+}
@@ -11,15 +11,19 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	awscodecommitbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -113,6 +117,9 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *gerritbatches.AnnotatedChange:
+		return computeGerritCheckState(m)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -239,6 +246,24 @@ func computeBitbucketCloudBuildState(lastSynced time.Time, apr *bbcs.AnnotatedPu
 	return combineCheckStates(states)
 }
 
+// computeGerritCheckState computes the check state of a Gerrit change from its
+// Verified label, which is where CI systems report their results.
+func computeGerritCheckState(change *gerritbatches.AnnotatedChange) btypes.ChangesetCheckState {
+	label, ok := change.Labels["Verified"]
+	if !ok {
+		return btypes.ChangesetCheckStateUnknown
+	}
+
+	switch {
+	case label.Rejected != nil:
+		return btypes.ChangesetCheckStateFailed
+	case label.Approved != nil:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStatePending
+	}
+}
+
 func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btypes.ChangesetCheckState {
 	switch s {
 	case bitbucketcloud.PullRequestStatusStateFailed, bitbucketcloud.PullRequestStatusStateStopped:
@@ -516,6 +541,34 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *gerritbatches.AnnotatedChange:
+		switch m.Status {
+		case gerrit.ChangeStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case gerrit.ChangeStatusMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case gerrit.ChangeStatusNew:
+			if m.WorkInProgress {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Gerrit change status: %s", m.Status)
+		}
+	case *awscodecommitbatches.AnnotatedPullRequest:
+		switch m.Status {
+		case awscodecommit.PullRequestStatusClosed:
+			if m.Target().IsMerged {
+				s = btypes.ChangesetExternalStateMerged
+			} else {
+				s = btypes.ChangesetExternalStateClosed
+			}
+		case awscodecommit.PullRequestStatusOpen:
+			s = btypes.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown AWS CodeCommit pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -585,6 +638,36 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *gerritbatches.AnnotatedChange:
+		// Gerrit reports the outcome of the Code-Review label directly: a
+		// negative vote blocks submission just like a rejection does.
+		label, ok := m.Labels["Code-Review"]
+		if !ok {
+			return btypes.ChangesetReviewStatePending, nil
+		}
+		if label.Rejected != nil {
+			return btypes.ChangesetReviewStateChangesRequested, nil
+		}
+		for _, vote := range label.All {
+			if vote.Value < 0 {
+				return btypes.ChangesetReviewStateChangesRequested, nil
+			}
+		}
+		if label.Approved != nil {
+			return btypes.ChangesetReviewStateApproved, nil
+		}
+		return btypes.ChangesetReviewStatePending, nil
+
+	case *awscodecommitbatches.AnnotatedPullRequest:
+		// AWS CodeCommit has no concept of requesting changes: reviewers can
+		// only approve a revision or revoke their approval.
+		for _, approval := range m.Approvals {
+			if approval.State == awscodecommit.ApprovalStateApprove {
+				return btypes.ChangesetReviewStateApproved, nil
+			}
+		}
+		return btypes.ChangesetReviewStatePending, nil
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchChangeSLAColumns are used by the batch change SLA related Store methods
+// to query and create batch change SLAs.
+var batchChangeSLAColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_slas.id"),
+	sqlf.Sprintf("batch_change_slas.batch_change_id"),
+	sqlf.Sprintf("batch_change_slas.creator_id"),
+	sqlf.Sprintf("batch_change_slas.target_date"),
+	sqlf.Sprintf("batch_change_slas.notify_email"),
+	sqlf.Sprintf("batch_change_slas.slack_webhook_url"),
+	sqlf.Sprintf("batch_change_slas.webhook_url"),
+	sqlf.Sprintf("batch_change_slas.started_at"),
+	sqlf.Sprintf("batch_change_slas.on_track"),
+	sqlf.Sprintf("batch_change_slas.alerted_at"),
+	sqlf.Sprintf("batch_change_slas.sent_alert_channels"),
+	sqlf.Sprintf("batch_change_slas.created_at"),
+	sqlf.Sprintf("batch_change_slas.updated_at"),
+}
+
+// batchChangeSLAInsertColumns is the list of batch change SLA columns that are
+// modified in UpsertBatchChangeSLA.
+var batchChangeSLAInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_id"),
+	sqlf.Sprintf("creator_id"),
+	sqlf.Sprintf("target_date"),
+	sqlf.Sprintf("notify_email"),
+	sqlf.Sprintf("slack_webhook_url"),
+	sqlf.Sprintf("webhook_url"),
+	sqlf.Sprintf("started_at"),
+	sqlf.Sprintf("on_track"),
+	sqlf.Sprintf("alerted_at"),
+	sqlf.Sprintf("sent_alert_channels"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// UpsertBatchChangeSLA creates the given SLA, or replaces the existing SLA of
+// the batch change. Since the schedule may have changed, replacing an SLA
+// resets whether the batch change is on track and when and through which
+// channels the last alert was sent, but keeps when the schedule started.
+func (s *Store) UpsertBatchChangeSLA(ctx context.Context, sla *btypes.BatchChangeSLA) (err error) {
+	ctx, _, endObservation := s.operations.upsertBatchChangeSLA.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(sla.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if sla.CreatedAt.IsZero() {
+		sla.CreatedAt = s.now()
+	}
+	sla.UpdatedAt = s.now()
+	sla.OnTrack = false
+	sla.AlertedAt = time.Time{}
+	sla.SentAlertChannels = nil
+
+	q := sqlf.Sprintf(
+		upsertBatchChangeSLAQueryFmtstr,
+		sqlf.Join(batchChangeSLAInsertColumns, ", "),
+		sla.BatchChangeID,
+		nullInt32Column(sla.CreatorID),
+		sla.TargetDate,
+		sla.NotifyEmail,
+		nullStringColumn(sla.SlackWebhookURL),
+		nullStringColumn(sla.WebhookURL),
+		nullTimeColumn(sla.StartedAt),
+		sla.OnTrack,
+		nullTimeColumn(sla.AlertedAt),
+		sentAlertChannelsColumn(sla.SentAlertChannels),
+		sla.CreatedAt,
+		sla.UpdatedAt,
+		sqlf.Join(batchChangeSLAColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchChangeSLA(sla, sc) })
+}
+
+var upsertBatchChangeSLAQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_slas.go:UpsertBatchChangeSLA
+INSERT INTO batch_change_slas (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (batch_change_id)
+DO UPDATE SET
+	creator_id = EXCLUDED.creator_id,
+	target_date = EXCLUDED.target_date,
+	notify_email = EXCLUDED.notify_email,
+	slack_webhook_url = EXCLUDED.slack_webhook_url,
+	webhook_url = EXCLUDED.webhook_url,
+	on_track = EXCLUDED.on_track,
+	alerted_at = EXCLUDED.alerted_at,
+	sent_alert_channels = EXCLUDED.sent_alert_channels,
+	updated_at = EXCLUDED.updated_at
+RETURNING %s
+`
+
+// UpdateBatchChangeSLAState updates when the schedule of the given SLA
+// started, whether the batch change is on track, and when and through which
+// channels the last alert was sent.
+func (s *Store) UpdateBatchChangeSLAState(ctx context.Context, sla *btypes.BatchChangeSLA) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeSLAState.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(sla.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		updateBatchChangeSLAStateQueryFmtstr,
+		nullTimeColumn(sla.StartedAt),
+		sla.OnTrack,
+		nullTimeColumn(sla.AlertedAt),
+		sentAlertChannelsColumn(sla.SentAlertChannels),
+		sla.ID,
+	))
+}
+
+var updateBatchChangeSLAStateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_slas.go:UpdateBatchChangeSLAState
+UPDATE batch_change_slas SET started_at = %s, on_track = %s, alerted_at = %s, sent_alert_channels = %s WHERE id = %s
+`
+
+// DeleteBatchChangeSLA deletes the SLA of the batch change with the given ID.
+func (s *Store) DeleteBatchChangeSLA(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchChangeSLA.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchChangeSLAQueryFmtstr, batchChangeID))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchChangeSLAQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_slas.go:DeleteBatchChangeSLA
+DELETE FROM batch_change_slas WHERE batch_change_id = %s
+`
+
+// GetBatchChangeSLA gets the SLA of the batch change with the given ID.
+func (s *Store) GetBatchChangeSLA(ctx context.Context, batchChangeID int64) (sla *btypes.BatchChangeSLA, err error) {
+	ctx, _, endObservation := s.operations.getBatchChangeSLA.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getBatchChangeSLAQueryFmtstr,
+		sqlf.Join(batchChangeSLAColumns, ", "),
+		batchChangeID,
+	)
+
+	var c btypes.BatchChangeSLA
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchChangeSLA(&c, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getBatchChangeSLAQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_slas.go:GetBatchChangeSLA
+SELECT %s FROM batch_change_slas
+WHERE batch_change_slas.batch_change_id = %s
+`
+
+// sentAlertChannelsColumn returns the value of the sent_alert_channels column,
+// which is an empty array rather than NULL when no channels have been sent.
+func sentAlertChannelsColumn(channels []string) any {
+	if channels == nil {
+		channels = []string{}
+	}
+	return pq.Array(channels)
+}
+
+func scanBatchChangeSLA(sla *btypes.BatchChangeSLA, s dbutil.Scanner) error {
+	return s.Scan(
+		&sla.ID,
+		&sla.BatchChangeID,
+		&dbutil.NullInt32{N: &sla.CreatorID},
+		&sla.TargetDate,
+		&sla.NotifyEmail,
+		&dbutil.NullString{S: &sla.SlackWebhookURL},
+		&dbutil.NullString{S: &sla.WebhookURL},
+		&dbutil.NullTime{Time: &sla.StartedAt},
+		&sla.OnTrack,
+		&dbutil.NullTime{Time: &sla.AlertedAt},
+		pq.Array(&sla.SentAlertChannels),
+		&sla.CreatedAt,
+		&sla.UpdatedAt,
+	)
+}
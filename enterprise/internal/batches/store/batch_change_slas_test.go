@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchChangeSLAs(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "slas", user.ID, 0)
+
+	var sla *btypes.BatchChangeSLA
+
+	t.Run("Get without SLA", func(t *testing.T) {
+		_, err := s.GetBatchChangeSLA(ctx, batchChange.ID)
+		assert.ErrorIs(t, err, ErrNoResults)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		sla = &btypes.BatchChangeSLA{
+			BatchChangeID: batchChange.ID,
+			CreatorID:     user.ID,
+			TargetDate:    clock.Now().Add(30 * 24 * time.Hour),
+			NotifyEmail:   true,
+			WebhookURL:    "https://example.com/webhook",
+		}
+
+		err := s.UpsertBatchChangeSLA(ctx, sla)
+		require.NoError(t, err)
+		assert.NotZero(t, sla.ID)
+		assert.Equal(t, clock.Now(), sla.CreatedAt)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetBatchChangeSLA(ctx, batchChange.ID)
+		require.NoError(t, err)
+		if diff := cmp.Diff(sla, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("UpdateState", func(t *testing.T) {
+		sla.StartedAt = clock.Now().Add(-time.Hour)
+		sla.OnTrack = true
+		sla.AlertedAt = clock.Now()
+		sla.SentAlertChannels = []string{"email"}
+		err := s.UpdateBatchChangeSLAState(ctx, sla)
+		require.NoError(t, err)
+
+		have, err := s.GetBatchChangeSLA(ctx, batchChange.ID)
+		require.NoError(t, err)
+		if diff := cmp.Diff(sla, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Upsert existing", func(t *testing.T) {
+		clock.Add(1)
+
+		updated := sla.Clone()
+		updated.ID = 0
+		updated.NotifyEmail = false
+		updated.SlackWebhookURL = "https://hooks.slack.com/services/x"
+
+		err := s.UpsertBatchChangeSLA(ctx, updated)
+		require.NoError(t, err)
+		assert.Equal(t, sla.ID, updated.ID)
+		assert.Equal(t, sla.CreatedAt, updated.CreatedAt)
+		assert.Equal(t, clock.Now(), updated.UpdatedAt)
+		// Replacing an SLA resets the last alert, but not when the schedule
+		// started.
+		assert.False(t, updated.OnTrack)
+		assert.Zero(t, updated.AlertedAt)
+		assert.Empty(t, updated.SentAlertChannels)
+		assert.Equal(t, sla.StartedAt, updated.StartedAt)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := s.DeleteBatchChangeSLA(ctx, batchChange.ID)
+		require.NoError(t, err)
+
+		_, err = s.GetBatchChangeSLA(ctx, batchChange.ID)
+		assert.ErrorIs(t, err, ErrNoResults)
+
+		err = s.DeleteBatchChangeSLA(ctx, batchChange.ID)
+		assert.ErrorIs(t, err, ErrNoResults)
+	})
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchChangeSnapshotColumns are used by the batch change snapshot related
+// Store methods to query and create batch change snapshots.
+var batchChangeSnapshotColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_snapshots.id"),
+	sqlf.Sprintf("batch_change_snapshots.batch_change_id"),
+	sqlf.Sprintf("batch_change_snapshots.snapshot_date"),
+	sqlf.Sprintf("batch_change_snapshots.total_count"),
+	sqlf.Sprintf("batch_change_snapshots.open_count"),
+	sqlf.Sprintf("batch_change_snapshots.draft_count"),
+	sqlf.Sprintf("batch_change_snapshots.merged_count"),
+	sqlf.Sprintf("batch_change_snapshots.closed_count"),
+	sqlf.Sprintf("batch_change_snapshots.review_latency_seconds"),
+	sqlf.Sprintf("batch_change_snapshots.created_at"),
+}
+
+// batchChangeSnapshotInsertColumns is the list of batch change snapshot
+// columns that are modified in UpsertBatchChangeSnapshot.
+var batchChangeSnapshotInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_id"),
+	sqlf.Sprintf("snapshot_date"),
+	sqlf.Sprintf("total_count"),
+	sqlf.Sprintf("open_count"),
+	sqlf.Sprintf("draft_count"),
+	sqlf.Sprintf("merged_count"),
+	sqlf.Sprintf("closed_count"),
+	sqlf.Sprintf("review_latency_seconds"),
+	sqlf.Sprintf("created_at"),
+}
+
+// UpsertBatchChangeSnapshot creates the given snapshot, or overwrites the
+// snapshot of the same batch change taken on the same day.
+func (s *Store) UpsertBatchChangeSnapshot(ctx context.Context, snapshot *btypes.BatchChangeSnapshot) (err error) {
+	ctx, _, endObservation := s.operations.upsertBatchChangeSnapshot.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(snapshot.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if snapshot.CreatedAt.IsZero() {
+		snapshot.CreatedAt = s.now()
+	}
+	if snapshot.Date.IsZero() {
+		snapshot.Date = snapshot.CreatedAt
+	}
+	snapshot.Date = snapshotDate(snapshot.Date)
+
+	var reviewLatency *int64
+	if snapshot.ReviewLatency != 0 {
+		seconds := int64(snapshot.ReviewLatency / time.Second)
+		reviewLatency = &seconds
+	}
+
+	q := sqlf.Sprintf(
+		upsertBatchChangeSnapshotQueryFmtstr,
+		sqlf.Join(batchChangeSnapshotInsertColumns, ", "),
+		snapshot.BatchChangeID,
+		snapshot.Date,
+		snapshot.Total,
+		snapshot.Open,
+		snapshot.Draft,
+		snapshot.Merged,
+		snapshot.Closed,
+		reviewLatency,
+		snapshot.CreatedAt,
+		sqlf.Join(batchChangeSnapshotColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchChangeSnapshot(snapshot, sc) })
+}
+
+var upsertBatchChangeSnapshotQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_snapshots.go:UpsertBatchChangeSnapshot
+INSERT INTO batch_change_snapshots (%s)
+VALUES (%s, %s::date, %s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (batch_change_id, snapshot_date)
+DO UPDATE SET
+	total_count = EXCLUDED.total_count,
+	open_count = EXCLUDED.open_count,
+	draft_count = EXCLUDED.draft_count,
+	merged_count = EXCLUDED.merged_count,
+	closed_count = EXCLUDED.closed_count,
+	review_latency_seconds = EXCLUDED.review_latency_seconds,
+	created_at = EXCLUDED.created_at
+RETURNING %s
+`
+
+// ListBatchChangeSnapshotsOpts captures the query options needed for listing
+// batch change snapshots.
+type ListBatchChangeSnapshotsOpts struct {
+	BatchChangeID int64
+	// From, if set, excludes snapshots taken before the given day.
+	From time.Time
+}
+
+// ListBatchChangeSnapshots lists the snapshots of a batch change, oldest
+// first.
+func (s *Store) ListBatchChangeSnapshots(ctx context.Context, opts ListBatchChangeSnapshotsOpts) (ss []*btypes.BatchChangeSnapshot, err error) {
+	ctx, _, endObservation := s.operations.listBatchChangeSnapshots.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("batch_change_snapshots.batch_change_id = %s", opts.BatchChangeID),
+	}
+	if !opts.From.IsZero() {
+		preds = append(preds, sqlf.Sprintf("batch_change_snapshots.snapshot_date >= %s::date", snapshotDate(opts.From)))
+	}
+
+	q := sqlf.Sprintf(
+		listBatchChangeSnapshotsQueryFmtstr,
+		sqlf.Join(batchChangeSnapshotColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var snapshot btypes.BatchChangeSnapshot
+		if err := scanBatchChangeSnapshot(&snapshot, sc); err != nil {
+			return err
+		}
+		ss = append(ss, &snapshot)
+		return nil
+	})
+
+	return ss, err
+}
+
+var listBatchChangeSnapshotsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_snapshots.go:ListBatchChangeSnapshots
+SELECT %s FROM batch_change_snapshots
+WHERE %s
+ORDER BY batch_change_snapshots.snapshot_date ASC
+`
+
+// snapshotDate truncates t to midnight UTC of the same day.
+func snapshotDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func scanBatchChangeSnapshot(snapshot *btypes.BatchChangeSnapshot, s dbutil.Scanner) error {
+	var reviewLatency int64
+	if err := s.Scan(
+		&snapshot.ID,
+		&snapshot.BatchChangeID,
+		&snapshot.Date,
+		&snapshot.Total,
+		&snapshot.Open,
+		&snapshot.Draft,
+		&snapshot.Merged,
+		&snapshot.Closed,
+		&dbutil.NullInt64{N: &reviewLatency},
+		&snapshot.CreatedAt,
+	); err != nil {
+		return err
+	}
+
+	snapshot.Date = snapshotDate(snapshot.Date)
+	snapshot.ReviewLatency = time.Duration(reviewLatency) * time.Second
+	return nil
+}
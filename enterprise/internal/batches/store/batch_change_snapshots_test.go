@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchChangeSnapshots(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "snapshots", user.ID, 0)
+
+	snapshots := make([]*btypes.BatchChangeSnapshot, 0, 3)
+
+	t.Run("Upsert", func(t *testing.T) {
+		for i := 0; i < cap(snapshots); i++ {
+			snapshot := &btypes.BatchChangeSnapshot{
+				BatchChangeID: batchChange.ID,
+				Date:          clock.Now().AddDate(0, 0, i),
+				Total:         10,
+				Open:          int32(10 - i),
+				Merged:        int32(i),
+			}
+			if i > 0 {
+				snapshot.ReviewLatency = time.Duration(i) * time.Hour
+			}
+
+			err := s.UpsertBatchChangeSnapshot(ctx, snapshot)
+			require.NoError(t, err)
+			assert.NotZero(t, snapshot.ID)
+			assert.Equal(t, snapshotDate(clock.Now().AddDate(0, 0, i)), snapshot.Date)
+
+			snapshots = append(snapshots, snapshot)
+		}
+	})
+
+	t.Run("Upsert same day", func(t *testing.T) {
+		snapshot := snapshots[2].Clone()
+		snapshot.ID = 0
+		snapshot.Date = snapshot.Date.Add(time.Hour)
+		snapshot.Open = 7
+		snapshot.Closed = 1
+
+		err := s.UpsertBatchChangeSnapshot(ctx, snapshot)
+		require.NoError(t, err)
+		assert.Equal(t, snapshots[2].ID, snapshot.ID)
+		assert.Equal(t, snapshots[2].Date, snapshot.Date)
+
+		snapshots[2] = snapshot
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListBatchChangeSnapshots(ctx, ListBatchChangeSnapshotsOpts{BatchChangeID: batchChange.ID})
+		require.NoError(t, err)
+		if diff := cmp.Diff(snapshots, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.ListBatchChangeSnapshots(ctx, ListBatchChangeSnapshotsOpts{
+			BatchChangeID: batchChange.ID,
+			From:          clock.Now().AddDate(0, 0, 1),
+		})
+		require.NoError(t, err)
+		if diff := cmp.Diff(snapshots[1:], have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, err = s.ListBatchChangeSnapshots(ctx, ListBatchChangeSnapshotsOpts{BatchChangeID: batchChange.ID + 1})
+		require.NoError(t, err)
+		assert.Empty(t, have)
+	})
+}
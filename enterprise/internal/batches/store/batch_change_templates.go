@@ -0,0 +1,308 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// batchChangeTemplateColumns are used by the batch change template related
+// Store methods to query and create batch change templates.
+var batchChangeTemplateColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_templates.id"),
+	sqlf.Sprintf("batch_change_templates.name"),
+	sqlf.Sprintf("batch_change_templates.description"),
+	sqlf.Sprintf("batch_change_templates.namespace_user_id"),
+	sqlf.Sprintf("batch_change_templates.namespace_org_id"),
+	sqlf.Sprintf("batch_change_templates.creator_id"),
+	sqlf.Sprintf("batch_change_templates.raw_template"),
+	sqlf.Sprintf("batch_change_templates.input_schema"),
+	sqlf.Sprintf("batch_change_templates.created_at"),
+	sqlf.Sprintf("batch_change_templates.updated_at"),
+}
+
+// batchChangeTemplateInsertColumns is the list of batch change template
+// columns that are modified in CreateBatchChangeTemplate and
+// UpdateBatchChangeTemplate.
+var batchChangeTemplateInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("name"),
+	sqlf.Sprintf("description"),
+	sqlf.Sprintf("namespace_user_id"),
+	sqlf.Sprintf("namespace_org_id"),
+	sqlf.Sprintf("creator_id"),
+	sqlf.Sprintf("raw_template"),
+	sqlf.Sprintf("input_schema"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// CreateBatchChangeTemplate creates the given batch change template.
+func (s *Store) CreateBatchChangeTemplate(ctx context.Context, t *btypes.BatchChangeTemplate) (err error) {
+	ctx, _, endObservation := s.operations.createBatchChangeTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	q := sqlf.Sprintf(
+		createBatchChangeTemplateQueryFmtstr,
+		sqlf.Join(batchChangeTemplateInsertColumns, ", "),
+		t.Name,
+		t.Description,
+		nullInt32Column(t.NamespaceUserID),
+		nullInt32Column(t.NamespaceOrgID),
+		nullInt32Column(t.CreatorID),
+		t.RawTemplate,
+		t.InputSchema,
+		t.CreatedAt,
+		t.UpdatedAt,
+		sqlf.Join(batchChangeTemplateColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchChangeTemplate(t, sc) })
+}
+
+var createBatchChangeTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:CreateBatchChangeTemplate
+INSERT INTO batch_change_templates (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateBatchChangeTemplate updates the given batch change template.
+func (s *Store) UpdateBatchChangeTemplate(ctx context.Context, t *btypes.BatchChangeTemplate) (err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeTemplate.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(t.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	t.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateBatchChangeTemplateQueryFmtstr,
+		sqlf.Join(batchChangeTemplateInsertColumns, ", "),
+		t.Name,
+		t.Description,
+		nullInt32Column(t.NamespaceUserID),
+		nullInt32Column(t.NamespaceOrgID),
+		nullInt32Column(t.CreatorID),
+		t.RawTemplate,
+		t.InputSchema,
+		t.CreatedAt,
+		t.UpdatedAt,
+		t.ID,
+		sqlf.Join(batchChangeTemplateColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchChangeTemplate(t, sc) })
+}
+
+var updateBatchChangeTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:UpdateBatchChangeTemplate
+UPDATE batch_change_templates
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING %s
+`
+
+// DeleteBatchChangeTemplate deletes the batch change template with the given
+// ID.
+func (s *Store) DeleteBatchChangeTemplate(ctx context.Context, id int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteBatchChangeTemplate.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchChangeTemplateQueryFmtstr, id))
+	if err != nil {
+		return err
+	}
+
+	// Check the template existed before.
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchChangeTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:DeleteBatchChangeTemplate
+DELETE FROM batch_change_templates WHERE id = %s
+`
+
+// GetBatchChangeTemplateOpts captures the query options needed for getting a
+// batch change template.
+type GetBatchChangeTemplateOpts struct {
+	ID int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	Name            string
+}
+
+// GetBatchChangeTemplate gets a batch change template matching the given
+// options.
+func (s *Store) GetBatchChangeTemplate(ctx context.Context, opts GetBatchChangeTemplateOpts) (t *btypes.BatchChangeTemplate, err error) {
+	ctx, _, endObservation := s.operations.getBatchChangeTemplate.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(opts.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := batchChangeTemplateNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID)
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_templates.id = %s", opts.ID))
+	}
+	if opts.Name != "" {
+		preds = append(preds, sqlf.Sprintf("batch_change_templates.name = %s", opts.Name))
+	}
+
+	q := sqlf.Sprintf(
+		getBatchChangeTemplateQueryFmtstr,
+		sqlf.Join(batchChangeTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	var c btypes.BatchChangeTemplate
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanBatchChangeTemplate(&c, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getBatchChangeTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:GetBatchChangeTemplate
+SELECT %s FROM batch_change_templates
+LEFT JOIN users namespace_user ON batch_change_templates.namespace_user_id = namespace_user.id
+LEFT JOIN orgs  namespace_org  ON batch_change_templates.namespace_org_id = namespace_org.id
+WHERE %s
+LIMIT 1
+`
+
+// ListBatchChangeTemplatesOpts captures the query options needed for listing
+// batch change templates.
+type ListBatchChangeTemplatesOpts struct {
+	LimitOpts
+	Cursor int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// ListBatchChangeTemplates lists the batch change templates matching the
+// given options, newest first. Templates in deleted namespaces are never
+// returned.
+func (s *Store) ListBatchChangeTemplates(ctx context.Context, opts ListBatchChangeTemplatesOpts) (ts []*btypes.BatchChangeTemplate, next int64, err error) {
+	ctx, _, endObservation := s.operations.listBatchChangeTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	preds := batchChangeTemplateNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID)
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_templates.id <= %s", opts.Cursor))
+	}
+
+	q := sqlf.Sprintf(
+		listBatchChangeTemplatesQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchChangeTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+
+	ts = make([]*btypes.BatchChangeTemplate, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var t btypes.BatchChangeTemplate
+		if err := scanBatchChangeTemplate(&t, sc); err != nil {
+			return err
+		}
+		ts = append(ts, &t)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ts) == opts.DBLimit() {
+		next = ts[len(ts)-1].ID
+		ts = ts[:len(ts)-1]
+	}
+
+	return ts, next, err
+}
+
+var listBatchChangeTemplatesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:ListBatchChangeTemplates
+SELECT %s FROM batch_change_templates
+LEFT JOIN users namespace_user ON batch_change_templates.namespace_user_id = namespace_user.id
+LEFT JOIN orgs  namespace_org  ON batch_change_templates.namespace_org_id = namespace_org.id
+WHERE %s
+ORDER BY batch_change_templates.id DESC
+`
+
+// CountBatchChangeTemplatesOpts captures the query options needed for
+// counting batch change templates.
+type CountBatchChangeTemplatesOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// CountBatchChangeTemplates returns the number of batch change templates
+// matching the given options.
+func (s *Store) CountBatchChangeTemplates(ctx context.Context, opts CountBatchChangeTemplatesOpts) (count int, err error) {
+	ctx, _, endObservation := s.operations.countBatchChangeTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, sqlf.Sprintf(
+		countBatchChangeTemplatesQueryFmtstr,
+		sqlf.Join(batchChangeTemplateNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID), "\n AND "),
+	))
+}
+
+var countBatchChangeTemplatesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:CountBatchChangeTemplates
+SELECT COUNT(batch_change_templates.id) FROM batch_change_templates
+LEFT JOIN users namespace_user ON batch_change_templates.namespace_user_id = namespace_user.id
+LEFT JOIN orgs  namespace_org  ON batch_change_templates.namespace_org_id = namespace_org.id
+WHERE %s
+`
+
+func batchChangeTemplateNamespacePreds(namespaceUserID, namespaceOrgID int32) []*sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("namespace_user.deleted_at IS NULL"),
+		sqlf.Sprintf("namespace_org.deleted_at IS NULL"),
+	}
+	if namespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_templates.namespace_user_id = %s", namespaceUserID))
+	}
+	if namespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_templates.namespace_org_id = %s", namespaceOrgID))
+	}
+	return preds
+}
+
+func scanBatchChangeTemplate(t *btypes.BatchChangeTemplate, s dbutil.Scanner) error {
+	return s.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&dbutil.NullInt32{N: &t.NamespaceUserID},
+		&dbutil.NullInt32{N: &t.NamespaceOrgID},
+		&dbutil.NullInt32{N: &t.CreatorID},
+		&t.RawTemplate,
+		&t.InputSchema,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+}
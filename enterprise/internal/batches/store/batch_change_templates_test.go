@@ -0,0 +1,197 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func testStoreBatchChangeTemplates(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	org := bt.CreateTestOrg(t, s.DatabaseDB(), "templates-org")
+
+	templates := make([]*btypes.BatchChangeTemplate, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(templates); i++ {
+			tmpl := &btypes.BatchChangeTemplate{
+				Name:        fmt.Sprintf("template-%d", i),
+				Description: "All the things",
+				CreatorID:   user.ID,
+				RawTemplate: "name: ${{ inputs.name }}",
+				InputSchema: `{"type": "object", "properties": {"name": {"type": "string"}}}`,
+			}
+			if i%2 == 0 {
+				tmpl.NamespaceOrgID = org.ID
+			} else {
+				tmpl.NamespaceUserID = user.ID
+			}
+
+			want := tmpl.Clone()
+			have := tmpl
+
+			err := s.CreateBatchChangeTemplate(ctx, have)
+			require.NoError(t, err)
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.CreatedAt = clock.Now()
+			want.UpdatedAt = clock.Now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			templates = append(templates, tmpl)
+		}
+	})
+
+	t.Run("Create duplicate name", func(t *testing.T) {
+		tmpl := templates[0].Clone()
+		tmpl.ID = 0
+		err := s.CreateBatchChangeTemplate(ctx, tmpl)
+		assert.Error(t, err)
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountBatchChangeTemplates(ctx, CountBatchChangeTemplatesOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, len(templates), count)
+
+		count, err = s.CountBatchChangeTemplates(ctx, CountBatchChangeTemplatesOpts{NamespaceOrgID: org.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		count, err = s.CountBatchChangeTemplates(ctx, CountBatchChangeTemplatesOpts{NamespaceUserID: user.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("All", func(t *testing.T) {
+			have, next, err := s.ListBatchChangeTemplates(ctx, ListBatchChangeTemplatesOpts{})
+			require.NoError(t, err)
+			assert.Zero(t, next)
+
+			want := []*btypes.BatchChangeTemplate{templates[2], templates[1], templates[0]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("Namespace", func(t *testing.T) {
+			have, _, err := s.ListBatchChangeTemplates(ctx, ListBatchChangeTemplatesOpts{NamespaceUserID: user.ID})
+			require.NoError(t, err)
+
+			want := []*btypes.BatchChangeTemplate{templates[1]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("Paginated", func(t *testing.T) {
+			var have []*btypes.BatchChangeTemplate
+			var cursor int64
+			for {
+				ts, next, err := s.ListBatchChangeTemplates(ctx, ListBatchChangeTemplatesOpts{
+					LimitOpts: LimitOpts{Limit: 1},
+					Cursor:    cursor,
+				})
+				require.NoError(t, err)
+				have = append(have, ts...)
+				if next == 0 {
+					break
+				}
+				cursor = next
+			}
+
+			want := []*btypes.BatchChangeTemplate{templates[2], templates[1], templates[0]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("ByID", func(t *testing.T) {
+			want := templates[1]
+			have, err := s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{ID: want.ID})
+			require.NoError(t, err)
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("ByNamespaceAndName", func(t *testing.T) {
+			want := templates[2]
+			have, err := s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{
+				NamespaceOrgID: org.ID,
+				Name:           want.Name,
+			})
+			require.NoError(t, err)
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{ID: 0xdeadbeef})
+			assert.ErrorIs(t, err, ErrNoResults)
+		})
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.Add(1)
+
+		tmpl := templates[0]
+		tmpl.Description = "Some of the things"
+		want := tmpl.Clone()
+		want.UpdatedAt = clock.Now()
+
+		err := s.UpdateBatchChangeTemplate(ctx, tmpl)
+		require.NoError(t, err)
+
+		if diff := cmp.Diff(tmpl, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Deleted namespace", func(t *testing.T) {
+		err := database.OrgsWith(s).Delete(ctx, org.ID)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			database.OrgsWith(s).HardDelete(ctx, org.ID)
+		})
+
+		have, _, err := s.ListBatchChangeTemplates(ctx, ListBatchChangeTemplatesOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, []*btypes.BatchChangeTemplate{templates[1]}, have)
+
+		_, err = s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{ID: templates[0].ID})
+		assert.ErrorIs(t, err, ErrNoResults)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := s.DeleteBatchChangeTemplate(ctx, templates[1].ID)
+		require.NoError(t, err)
+
+		_, err = s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{ID: templates[1].ID})
+		assert.ErrorIs(t, err, ErrNoResults)
+
+		err = s.DeleteBatchChangeTemplate(ctx, templates[1].ID)
+		assert.ErrorIs(t, err, ErrNoResults)
+	})
+}
@@ -0,0 +1,190 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// GetChangesetDependencies returns the changesets owned by the given batch
+// change that match the given dependencies, keyed by dependency. Dependencies
+// that don't match any changeset are omitted from the result.
+func (s *Store) GetChangesetDependencies(ctx context.Context, batchChangeID int64, deps []batcheslib.ChangesetDependency) (_ map[batcheslib.ChangesetDependency]*btypes.Changeset, err error) {
+	ctx, _, endObservation := s.operations.getChangesetDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+		log.Int("count", len(deps)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	matches := make(map[batcheslib.ChangesetDependency]*btypes.Changeset, len(deps))
+	if len(deps) == 0 {
+		return matches, nil
+	}
+
+	values := make([]*sqlf.Query, 0, len(deps))
+	for _, dep := range deps {
+		values = append(values, sqlf.Sprintf("(%s, %s)", dep.Repository, dep.HeadRef))
+	}
+
+	q := sqlf.Sprintf(
+		getChangesetDependenciesQueryFmtstr,
+		sqlf.Join(changesetColumns, ", "),
+		batchChangeID,
+		sqlf.Join(values, ", "),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var dep batcheslib.ChangesetDependency
+		var c btypes.Changeset
+		if err := scanChangeset(&c, prefixScanner{Scanner: sc, prefix: []any{&dep.Repository, &dep.HeadRef}}); err != nil {
+			return err
+		}
+		matches[dep] = &c
+		return nil
+	})
+	return matches, err
+}
+
+var getChangesetDependenciesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_dependencies.go:GetChangesetDependencies
+SELECT
+	repo.name,
+	changeset_specs.head_ref,
+	%s
+FROM changesets
+JOIN repo ON repo.id = changesets.repo_id
+JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+WHERE
+	changesets.owned_by_batch_change_id = %s
+	AND
+	repo.deleted_at IS NULL
+	AND
+	(repo.name::text, changeset_specs.head_ref) IN (VALUES %s)
+`
+
+// ChangesetSpecDependencies are the dependencies of a changeset spec that
+// creates a branch, identified by the repository and head ref of the
+// changeset it creates.
+type ChangesetSpecDependencies struct {
+	RepoID    api.RepoID
+	RepoName  string
+	HeadRef   string
+	DependsOn []batcheslib.ChangesetDependency
+}
+
+// ListChangesetSpecDependencies returns the dependencies of all changeset specs
+// in the given batch spec that create a branch, including those without
+// dependencies, so that the dependencies can be checked against them.
+func (s *Store) ListChangesetSpecDependencies(ctx context.Context, batchSpecID int64) (deps []*ChangesetSpecDependencies, err error) {
+	ctx, _, endObservation := s.operations.listChangesetSpecDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchSpecID", int(batchSpecID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(listChangesetSpecDependenciesQueryFmtstr, batchSpecID)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var d ChangesetSpecDependencies
+		var dependsOn []byte
+		if err := sc.Scan(&d.RepoID, &d.RepoName, &d.HeadRef, &dependsOn); err != nil {
+			return errors.Wrap(err, "scanning changeset spec dependencies")
+		}
+		if err := json.Unmarshal(dependsOn, &d.DependsOn); err != nil {
+			return errors.Wrap(err, "unmarshalling depends_on")
+		}
+		deps = append(deps, &d)
+		return nil
+	})
+	return deps, err
+}
+
+var listChangesetSpecDependenciesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_dependencies.go:ListChangesetSpecDependencies
+SELECT
+	changeset_specs.repo_id,
+	repo.name,
+	changeset_specs.head_ref,
+	changeset_specs.depends_on
+FROM changeset_specs
+JOIN repo ON repo.id = changeset_specs.repo_id
+WHERE
+	changeset_specs.batch_spec_id = %s
+	AND
+	changeset_specs.head_ref IS NOT NULL
+	AND
+	repo.deleted_at IS NULL
+ORDER BY changeset_specs.id ASC
+`
+
+// EnqueueChangesetsDependingOn enqueues the changesets that depend on the
+// given changeset and are still held back as unpublished or draft changesets,
+// so that the reconciler can publish them once all of their dependencies have
+// been merged. Changesets that are currently being processed are left alone,
+// and the number of failures of the others is kept so that a changeset that
+// keeps failing to be published isn't retried forever.
+func (s *Store) EnqueueChangesetsDependingOn(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.enqueueChangesetsDependingOn.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		enqueueChangesetsDependingOnQueryFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		cs.ID,
+		btypes.ChangesetPublicationStateUnpublished,
+		btypes.ChangesetExternalStateDraft,
+		btypes.ReconcilerStateProcessing.ToDB(),
+	))
+}
+
+var enqueueChangesetsDependingOnQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_dependencies.go:EnqueueChangesetsDependingOn
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	failure_message = NULL,
+	updated_at = %s
+FROM
+	changesets parent
+	JOIN repo ON repo.id = parent.repo_id
+	JOIN changeset_specs parent_spec ON parent_spec.id = parent.current_spec_id,
+	changeset_specs dependent_spec
+WHERE
+	parent.id = %s
+	AND
+	changesets.owned_by_batch_change_id = parent.owned_by_batch_change_id
+	AND
+	changesets.id != parent.id
+	AND
+	dependent_spec.id = changesets.current_spec_id
+	AND
+	dependent_spec.depends_on @> jsonb_build_array(jsonb_build_object('repository', repo.name::text, 'headRef', parent_spec.head_ref))
+	AND
+	(changesets.publication_state = %s OR changesets.external_state = %s)
+	AND
+	changesets.reconciler_state != %s
+`
+
+// prefixScanner scans the first columns of a row into prefix, and passes the
+// remaining columns on to the caller. This allows reusing the scan functions
+// of records when selecting additional columns.
+type prefixScanner struct {
+	dbutil.Scanner
+	prefix []any
+}
+
+func (s prefixScanner) Scan(dest ...any) error {
+	return s.Scanner.Scan(append(s.prefix, dest...)...)
+}
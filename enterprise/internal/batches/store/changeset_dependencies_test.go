@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func testStoreChangesetDependencies(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	ctx = actor.WithInternalActor(ctx)
+
+	batchSpec := bt.CreateBatchSpec(t, ctx, s, "stacked", user.ID, 0)
+	batchChange := bt.CreateBatchChange(t, ctx, s, "stacked", user.ID, batchSpec.ID)
+
+	parentDep := batcheslib.ChangesetDependency{Repository: string(repo.Name), HeadRef: "refs/heads/parent"}
+
+	createChangeset := func(headRef string, deps []batcheslib.ChangesetDependency, opts bt.TestChangesetOpts) *btypes.Changeset {
+		spec := bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+			User:      user.ID,
+			Repo:      repo.ID,
+			BatchSpec: batchSpec.ID,
+			HeadRef:   headRef,
+			Typ:       btypes.ChangesetSpecTypeBranch,
+			DependsOn: deps,
+		})
+
+		opts.Repo = repo.ID
+		opts.BatchChange = batchChange.ID
+		opts.OwnedByBatchChange = batchChange.ID
+		opts.CurrentSpec = spec.ID
+		return bt.CreateChangeset(t, ctx, s, opts)
+	}
+
+	parent := createChangeset("refs/heads/parent", nil, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateMerged,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	unpublished := createChangeset("refs/heads/unpublished", []batcheslib.ChangesetDependency{parentDep}, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	draft := createChangeset("refs/heads/draft", []batcheslib.ChangesetDependency{parentDep}, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateDraft,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	open := createChangeset("refs/heads/open", []batcheslib.ChangesetDependency{parentDep}, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	independent := createChangeset("refs/heads/independent", nil, bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+
+	t.Run("GetChangesetDependencies", func(t *testing.T) {
+		missingDep := batcheslib.ChangesetDependency{Repository: string(repo.Name), HeadRef: "refs/heads/missing"}
+
+		have, err := s.GetChangesetDependencies(ctx, batchChange.ID, []batcheslib.ChangesetDependency{parentDep, missingDep})
+		require.NoError(t, err)
+		require.Len(t, have, 1)
+		assert.Equal(t, parent.ID, have[parentDep].ID)
+
+		have, err = s.GetChangesetDependencies(ctx, batchChange.ID+1, []batcheslib.ChangesetDependency{parentDep})
+		require.NoError(t, err)
+		assert.Empty(t, have)
+	})
+
+	t.Run("ListChangesetSpecDependencies", func(t *testing.T) {
+		have, err := s.ListChangesetSpecDependencies(ctx, batchSpec.ID)
+		require.NoError(t, err)
+
+		want := []*ChangesetSpecDependencies{
+			{RepoID: repo.ID, RepoName: string(repo.Name), HeadRef: "refs/heads/parent", DependsOn: []batcheslib.ChangesetDependency{}},
+			{RepoID: repo.ID, RepoName: string(repo.Name), HeadRef: "refs/heads/unpublished", DependsOn: []batcheslib.ChangesetDependency{parentDep}},
+			{RepoID: repo.ID, RepoName: string(repo.Name), HeadRef: "refs/heads/draft", DependsOn: []batcheslib.ChangesetDependency{parentDep}},
+			{RepoID: repo.ID, RepoName: string(repo.Name), HeadRef: "refs/heads/open", DependsOn: []batcheslib.ChangesetDependency{parentDep}},
+			{RepoID: repo.ID, RepoName: string(repo.Name), HeadRef: "refs/heads/independent", DependsOn: []batcheslib.ChangesetDependency{}},
+		}
+		assert.Equal(t, want, have)
+
+		have, err = s.ListChangesetSpecDependencies(ctx, batchSpec.ID+1)
+		require.NoError(t, err)
+		assert.Empty(t, have)
+	})
+
+	t.Run("EnqueueChangesetsDependingOn", func(t *testing.T) {
+		require.NoError(t, s.EnqueueChangesetsDependingOn(ctx, parent))
+
+		for name, tc := range map[string]struct {
+			changeset *btypes.Changeset
+			want      btypes.ReconcilerState
+		}{
+			"unpublished": {changeset: unpublished, want: btypes.ReconcilerStateQueued},
+			"draft":       {changeset: draft, want: btypes.ReconcilerStateQueued},
+			"open":        {changeset: open, want: btypes.ReconcilerStateCompleted},
+			"independent": {changeset: independent, want: btypes.ReconcilerStateCompleted},
+			"parent":      {changeset: parent, want: btypes.ReconcilerStateCompleted},
+		} {
+			t.Run(name, func(t *testing.T) {
+				reloaded, err := s.GetChangesetByID(ctx, tc.changeset.ID)
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, reloaded.ReconcilerState)
+			})
+		}
+	})
+
+	t.Run("UpdateChangesetCodeHostState", func(t *testing.T) {
+		unpublished.ReconcilerState = btypes.ReconcilerStateCompleted
+		unpublished.NumFailures = 2
+		require.NoError(t, s.UpdateChangeset(ctx, unpublished))
+
+		assertReconcilerState := func(want btypes.ReconcilerState) {
+			t.Helper()
+			reloaded, err := s.GetChangesetByID(ctx, unpublished.ID)
+			require.NoError(t, err)
+			assert.Equal(t, want, reloaded.ReconcilerState)
+			assert.Equal(t, int64(2), reloaded.NumFailures)
+		}
+
+		// Syncing a parent that was already merged doesn't enqueue anything.
+		require.NoError(t, s.UpdateChangesetCodeHostState(ctx, parent))
+		assertReconcilerState(btypes.ReconcilerStateCompleted)
+
+		// The dependents are enqueued when the parent becomes merged.
+		parent.ExternalState = btypes.ChangesetExternalStateOpen
+		require.NoError(t, s.UpdateChangesetCodeHostState(ctx, parent))
+		assertReconcilerState(btypes.ReconcilerStateCompleted)
+		parent.ExternalState = btypes.ChangesetExternalStateMerged
+		require.NoError(t, s.UpdateChangesetCodeHostState(ctx, parent))
+		assertReconcilerState(btypes.ReconcilerStateQueued)
+	})
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go/log"
@@ -10,6 +11,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/database/locker"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -199,6 +201,8 @@ func scanChangesetJob(c *btypes.ChangesetJob, s dbutil.Scanner) error {
 		c.Payload = new(btypes.ChangesetJobReenqueuePayload)
 	case btypes.ChangesetJobTypeMerge:
 		c.Payload = new(btypes.ChangesetJobMergePayload)
+	case btypes.ChangesetJobTypeMergeWhenReady:
+		c.Payload = new(btypes.ChangesetJobMergeWhenReadyPayload)
 	case btypes.ChangesetJobTypeClose:
 		c.Payload = new(btypes.ChangesetJobClosePayload)
 	case btypes.ChangesetJobTypePublish:
@@ -208,3 +212,174 @@ func scanChangesetJob(c *btypes.ChangesetJob, s dbutil.Scanner) error {
 	}
 	return json.Unmarshal(raw, &c.Payload)
 }
+
+// RequeueChangesetJob puts the changeset job with the given ID back into the
+// queue, to be processed again after the given time.
+func (s *Store) RequeueChangesetJob(ctx context.Context, id int64, after time.Time) (err error) {
+	ctx, _, endObservation := s.operations.requeueChangesetJob.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+		log.String("after", after.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		requeueChangesetJobQueryFmtstr,
+		btypes.ChangesetJobStateQueued.ToDB(),
+		s.now(),
+		after,
+		s.now(),
+		id,
+	))
+}
+
+var requeueChangesetJobQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_jobs.go:RequeueChangesetJob
+UPDATE changeset_jobs
+SET
+	state = %s,
+	queued_at = %s,
+	started_at = NULL,
+	process_after = %s,
+	updated_at = %s
+WHERE id = %s
+`
+
+// ReserveQueuedMerge records that the "merge when ready" job with the given
+// ID merges its changeset at the given time, so that the merge counts against
+// the rate limit of the code host before it has completed. Callers should hold
+// the lock of the code host taken by LockCodeHostMerges.
+func (s *Store) ReserveQueuedMerge(ctx context.Context, id int64, at time.Time) (err error) {
+	ctx, _, endObservation := s.operations.reserveQueuedMerge.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(reserveQueuedMergeQueryFmtstr, at, s.now(), id))
+}
+
+var reserveQueuedMergeQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_jobs.go:ReserveQueuedMerge
+UPDATE changeset_jobs
+SET
+	merged_at = %s,
+	updated_at = %s
+WHERE id = %s
+`
+
+// LockCodeHostMerges takes a lock on the "merge when ready" merges on the
+// code host with the given external service ID, which is held until the
+// transaction the store is in is committed or rolled back. It blocks until the
+// lock can be taken.
+func (s *Store) LockCodeHostMerges(ctx context.Context, externalServiceID string) (err error) {
+	ctx, _, endObservation := s.operations.lockCodeHostMerges.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("externalServiceID", externalServiceID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	_, err = locker.NewWith(s, "batches_merge_queue").LockInTransaction(ctx, locker.StringKey(externalServiceID), true)
+	return err
+}
+
+// ListQueuedMergeTimes returns the times at which changesets in repositories
+// of the given code host have been merged, or reserved to be merged, by "merge
+// when ready" jobs since the given time, in ascending order.
+func (s *Store) ListQueuedMergeTimes(ctx context.Context, externalServiceID string, since time.Time) (ts []time.Time, err error) {
+	ctx, _, endObservation := s.operations.listQueuedMergeTimes.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("externalServiceID", externalServiceID),
+		log.String("since", since.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listQueuedMergeTimesQueryFmtstr,
+		btypes.ChangesetJobTypeMergeWhenReady,
+		externalServiceID,
+		since,
+	)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var t time.Time
+		if err := sc.Scan(&t); err != nil {
+			return err
+		}
+		ts = append(ts, t)
+		return nil
+	})
+	return ts, err
+}
+
+var listQueuedMergeTimesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_jobs.go:ListQueuedMergeTimes
+SELECT changeset_jobs.merged_at
+FROM changeset_jobs
+INNER JOIN changesets ON changesets.id = changeset_jobs.changeset_id
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE
+	changeset_jobs.job_type = %s
+	AND
+	repo.external_service_id = %s
+	AND
+	changeset_jobs.merged_at > %s
+ORDER BY changeset_jobs.merged_at ASC
+`
+
+// ListUncheckedQueuedMerges returns the "merge when ready" jobs of the given
+// bulk group that have merged their changeset, but for which the checks on the
+// base branch haven't been found to pass since.
+func (s *Store) ListUncheckedQueuedMerges(ctx context.Context, bulkGroup string) (jobs []*btypes.ChangesetJob, err error) {
+	ctx, _, endObservation := s.operations.listUncheckedQueuedMerges.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("bulkGroup", bulkGroup),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listUncheckedQueuedMergesQueryFmtstr,
+		sqlf.Join(changesetJobColumns.ToSqlf(), ", "),
+		bulkGroup,
+		btypes.ChangesetJobTypeMergeWhenReady,
+	)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var j btypes.ChangesetJob
+		if err := scanChangesetJob(&j, sc); err != nil {
+			return err
+		}
+		jobs = append(jobs, &j)
+		return nil
+	})
+	return jobs, err
+}
+
+var listUncheckedQueuedMergesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_jobs.go:ListUncheckedQueuedMerges
+SELECT %s
+FROM changeset_jobs
+WHERE
+	changeset_jobs.bulk_group = %s
+	AND
+	changeset_jobs.job_type = %s
+	AND
+	changeset_jobs.merged_at IS NOT NULL
+	AND
+	NOT changeset_jobs.base_branch_checked
+ORDER BY changeset_jobs.merged_at DESC
+`
+
+// MarkQueuedMergeChecked records that the checks on the base branch have been
+// found to pass after the "merge when ready" job with the given ID merged its
+// changeset.
+func (s *Store) MarkQueuedMergeChecked(ctx context.Context, id int64) (err error) {
+	ctx, _, endObservation := s.operations.markQueuedMergeChecked.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(markQueuedMergeCheckedQueryFmtstr, s.now(), id))
+}
+
+var markQueuedMergeCheckedQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_jobs.go:MarkQueuedMergeChecked
+UPDATE changeset_jobs
+SET
+	base_branch_checked = TRUE,
+	updated_at = %s
+WHERE id = %s
+`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -110,4 +111,77 @@ func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, clock b
 			}
 		})
 	})
+	t.Run("QueuedMerges", func(t *testing.T) {
+		merges := make([]*btypes.ChangesetJob, 0, 3)
+		for i := 0; i < cap(merges); i++ {
+			merges = append(merges, &btypes.ChangesetJob{
+				BulkGroup:     "merge-queue",
+				UserID:        1234,
+				BatchChangeID: 910,
+				ChangesetID:   changeset.ID,
+				JobType:       btypes.ChangesetJobTypeMergeWhenReady,
+				Payload:       &btypes.ChangesetJobMergeWhenReadyPayload{},
+			})
+		}
+		if err := s.CreateChangesetJob(ctx, merges...); err != nil {
+			t.Fatal(err)
+		}
+
+		tx, err := s.Transact(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.LockCodeHostMerges(ctx, repo.ExternalRepo.ServiceID); err != nil {
+			t.Fatal(err)
+		}
+		reservedAt := clock.Now().Add(-10 * time.Minute)
+		if err := tx.ReserveQueuedMerge(ctx, merges[0].ID, reservedAt); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.ReserveQueuedMerge(ctx, merges[1].ID, clock.Now().Add(-2*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Done(nil); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("ListQueuedMergeTimes", func(t *testing.T) {
+			have, err := s.ListQueuedMergeTimes(ctx, repo.ExternalRepo.ServiceID, clock.Now().Add(-time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff([]time.Time{reservedAt}, have); diff != "" {
+				t.Fatal(diff)
+			}
+
+			have, err = s.ListQueuedMergeTimes(ctx, "https://other.example.com/", clock.Now().Add(-time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 0 {
+				t.Fatalf("unexpected merge times: %v", have)
+			}
+		})
+
+		t.Run("ListUncheckedQueuedMerges", func(t *testing.T) {
+			have, err := s.ListUncheckedQueuedMerges(ctx, "merge-queue")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 2 || have[0].ID != merges[0].ID || have[1].ID != merges[1].ID {
+				t.Fatalf("unexpected unchecked merges: %+v", have)
+			}
+
+			if err := s.MarkQueuedMergeChecked(ctx, merges[1].ID); err != nil {
+				t.Fatal(err)
+			}
+			have, err = s.ListUncheckedQueuedMerges(ctx, "merge-queue")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 1 || have[0].ID != merges[0].ID {
+				t.Fatalf("unexpected unchecked merges: %+v", have)
+			}
+		})
+	})
 }
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// RebaseCandidate is an open changeset owned by a batch change that might
+// have to be rebased onto the latest commit of its base branch.
+type RebaseCandidate struct {
+	ChangesetID int64
+	RepoName    api.RepoName
+	BaseRef     string
+	// BaseRev is the commit on the base branch the changeset is currently
+	// based on.
+	BaseRev string
+	// ConflictingBaseRev is the commit on the base branch onto which the
+	// changeset could not be rebased last time, if any.
+	ConflictingBaseRev string
+}
+
+// ListChangesetsToRebaseOpts captures the query options needed for listing
+// rebase candidates.
+type ListChangesetsToRebaseOpts struct {
+	// Cursor is the changeset ID after which to start listing.
+	Cursor int64
+	Limit  int
+}
+
+// ListChangesetsToRebase lists the open changesets owned by a batch change
+// that are not currently being reconciled, ordered by ID. Since rebases are
+// done by the reconciler, this also excludes changesets with a pending rebase.
+func (s *Store) ListChangesetsToRebase(ctx context.Context, opts ListChangesetsToRebaseOpts) (cs []*RebaseCandidate, err error) {
+	ctx, _, endObservation := s.operations.listChangesetsToRebase.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("cursor", int(opts.Cursor)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	limit := sqlf.Sprintf("")
+	if opts.Limit > 0 {
+		limit = sqlf.Sprintf("LIMIT %s", opts.Limit)
+	}
+
+	q := sqlf.Sprintf(
+		listChangesetsToRebaseQueryFmtstr,
+		btypes.ChangesetPublicationStatePublished,
+		btypes.ChangesetExternalStateOpen,
+		btypes.ChangesetExternalStateDraft,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		btypes.ChangesetSpecTypeBranch,
+		opts.Cursor,
+		limit,
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c RebaseCandidate
+		if err := sc.Scan(
+			&c.ChangesetID,
+			&c.RepoName,
+			&c.BaseRef,
+			&c.BaseRev,
+			&c.ConflictingBaseRev,
+		); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+	return cs, err
+}
+
+var listChangesetsToRebaseQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_rebases.go:ListChangesetsToRebase
+SELECT
+	changesets.id,
+	repo.name,
+	changeset_specs.base_ref,
+	COALESCE(changesets.base_rev, changeset_specs.base_rev),
+	COALESCE(changesets.conflicting_base_rev, '')
+FROM changesets
+JOIN repo ON repo.id = changesets.repo_id
+JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+WHERE
+	changesets.owned_by_batch_change_id IS NOT NULL
+	AND
+	changesets.publication_state = %s
+	AND
+	changesets.external_state IN (%s, %s)
+	AND
+	changesets.reconciler_state = %s
+	AND
+	changeset_specs.type = %s
+	AND
+	repo.deleted_at IS NULL
+	AND
+	changesets.id > %s
+ORDER BY changesets.id ASC
+%s
+`
+
+// EnqueueChangesetRebase sets the commit the changeset with the given ID
+// should be rebased onto and enqueues it for the reconciler. Changesets that
+// are currently being reconciled are left alone, they'll be picked up again
+// by the next check.
+func (s *Store) EnqueueChangesetRebase(ctx context.Context, id int64, onto api.CommitID) (err error) {
+	ctx, _, endObservation := s.operations.enqueueChangesetRebase.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+		log.String("onto", string(onto)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		enqueueChangesetRebaseQueryFmtstr,
+		string(onto),
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		id,
+		btypes.ReconcilerStateCompleted.ToDB(),
+	))
+}
+
+var enqueueChangesetRebaseQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_rebases.go:EnqueueChangesetRebase
+UPDATE changesets
+SET
+	rebase_onto = %s,
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+WHERE
+	id = %s
+	AND
+	reconciler_state = %s
+`
@@ -0,0 +1,148 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// UpdateBatchChangeRolloutStage calculates the stage the rollout defined in the
+// current batch spec of the given batch change has reached and stores it on
+// the batch change. It returns true if the stage changed. Once the final stage
+// has been reached, the changesets aren't looked at anymore.
+func (s *Store) UpdateBatchChangeRolloutStage(ctx context.Context, batchChangeID int64) (changed bool, err error) {
+	ctx, _, endObservation := s.operations.updateBatchChangeRolloutStage.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	batchChange, err := s.GetBatchChange(ctx, GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		// Nothing is published in deleted namespaces anymore.
+		if err == ErrNoResults || err == ErrDeletedNamespace {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "getting batch change")
+	}
+	batchSpec, err := s.GetBatchSpec(ctx, GetBatchSpecOpts{ID: batchChange.BatchSpecID})
+	if err != nil {
+		return false, errors.Wrap(err, "getting batch spec")
+	}
+
+	stage := 0
+	if batchSpec.Spec != nil && batchSpec.Spec.Rollout != nil {
+		rollout := batchSpec.Spec.Rollout
+		if batchChange.RolloutStage >= len(rollout.Stages) {
+			return false, nil
+		}
+
+		cs, _, err := s.ListChangesets(ctx, ListChangesetsOpts{OwnedByBatchChangeID: batchChangeID})
+		if err != nil {
+			return false, errors.Wrap(err, "listing changesets")
+		}
+		stage = state.CalcRolloutStatus(rollout, cs).Stage
+	}
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(updateBatchChangeRolloutStageQueryFmtstr, stage, batchChangeID, stage))
+	if err != nil {
+		return false, err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+var updateBatchChangeRolloutStageQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_rollouts.go:UpdateBatchChangeRolloutStage
+UPDATE batch_changes
+SET rollout_stage = %s
+WHERE id = %s AND rollout_stage != %s
+`
+
+// GetChangesetRolloutPosition returns the position of the given changeset in
+// the rollout of the batch change that owns it, which is the number of
+// changesets of the rollout that were created before it. Together with the
+// stored stage of the rollout, this tells whether the changeset may be
+// published.
+func (s *Store) GetChangesetRolloutPosition(ctx context.Context, cs *btypes.Changeset) (position int, err error) {
+	ctx, _, endObservation := s.operations.getChangesetRolloutPosition.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.queryCount(ctx, sqlf.Sprintf(getChangesetRolloutPositionQueryFmtstr, cs.OwnedByBatchChangeID, cs.ID))
+}
+
+var getChangesetRolloutPositionQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_rollouts.go:GetChangesetRolloutPosition
+SELECT COUNT(*)
+FROM changesets
+WHERE
+	owned_by_batch_change_id = %s
+	AND
+	current_spec_id IS NOT NULL
+	AND
+	id < %s
+`
+
+// EnqueueChangesetsHeldByRollout enqueues the unpublished changesets owned by
+// the same batch change as the given changeset, if the current batch spec of
+// the batch change defines a rollout. This gives the reconciler a chance to
+// publish them once the rollout advanced to the next stage.
+// Changesets that are currently being processed are left alone.
+func (s *Store) EnqueueChangesetsHeldByRollout(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.enqueueChangesetsHeldByRollout.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if cs.OwnedByBatchChangeID == 0 {
+		return nil
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		enqueueChangesetsHeldByRolloutQueryFmtstr,
+		btypes.ReconcilerStateQueued.ToDB(),
+		s.now(),
+		cs.OwnedByBatchChangeID,
+		cs.ID,
+		btypes.ChangesetPublicationStateUnpublished,
+		btypes.ReconcilerStateProcessing.ToDB(),
+	))
+}
+
+var enqueueChangesetsHeldByRolloutQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_rollouts.go:EnqueueChangesetsHeldByRollout
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+FROM
+	batch_changes
+	JOIN batch_specs ON batch_specs.id = batch_changes.batch_spec_id
+WHERE
+	batch_changes.id = %s
+	AND
+	batch_specs.spec->'rollout' IS NOT NULL
+	AND
+	changesets.owned_by_batch_change_id = batch_changes.id
+	AND
+	changesets.id != %s
+	AND
+	changesets.current_spec_id IS NOT NULL
+	AND
+	changesets.publication_state = %s
+	AND
+	changesets.reconciler_state != %s
+`
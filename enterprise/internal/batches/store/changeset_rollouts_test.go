@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func testStoreChangesetRollouts(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
+	logger := logtest.Scoped(t)
+	repoStore := database.ReposWith(logger, s)
+	esStore := database.ExternalServicesWith(logger, s)
+
+	repo := bt.TestRepo(t, esStore, extsvc.KindGitHub)
+	require.NoError(t, repoStore.Create(ctx, repo))
+
+	user := bt.CreateTestUser(t, s.DatabaseDB(), false)
+	ctx = actor.WithInternalActor(ctx)
+
+	createBatchChange := func(name string, rollout *batcheslib.Rollout) (*btypes.BatchSpec, *btypes.BatchChange) {
+		batchSpec := bt.CreateBatchSpec(t, ctx, s, name, user.ID, 0)
+		batchSpec.Spec.Rollout = rollout
+		require.NoError(t, s.UpdateBatchSpec(ctx, batchSpec))
+		return batchSpec, bt.CreateBatchChange(t, ctx, s, name, user.ID, batchSpec.ID)
+	}
+
+	createChangeset := func(batchSpec *btypes.BatchSpec, batchChange *btypes.BatchChange, headRef string, opts bt.TestChangesetOpts) *btypes.Changeset {
+		spec := bt.CreateChangesetSpec(t, ctx, s, bt.TestSpecOpts{
+			User:      user.ID,
+			Repo:      repo.ID,
+			BatchSpec: batchSpec.ID,
+			HeadRef:   headRef,
+			Typ:       btypes.ChangesetSpecTypeBranch,
+		})
+
+		opts.Repo = repo.ID
+		opts.BatchChange = batchChange.ID
+		opts.OwnedByBatchChange = batchChange.ID
+		opts.CurrentSpec = spec.ID
+		return bt.CreateChangeset(t, ctx, s, opts)
+	}
+
+	rolloutSpec, rolloutBatchChange := createBatchChange("rollout", &batcheslib.Rollout{Stages: []int{1}})
+	merged := createChangeset(rolloutSpec, rolloutBatchChange, "refs/heads/merged", bt.TestChangesetOpts{
+		PublicationState:   btypes.ChangesetPublicationStatePublished,
+		ExternalState:      btypes.ChangesetExternalStateMerged,
+		ExternalCheckState: btypes.ChangesetCheckStatePending,
+		ReconcilerState:    btypes.ReconcilerStateCompleted,
+	})
+	held := createChangeset(rolloutSpec, rolloutBatchChange, "refs/heads/held", bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	processing := createChangeset(rolloutSpec, rolloutBatchChange, "refs/heads/processing", bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateProcessing,
+	})
+	open := createChangeset(rolloutSpec, rolloutBatchChange, "refs/heads/open", bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+
+	otherSpec, otherBatchChange := createBatchChange("no-rollout", nil)
+	otherMerged := createChangeset(otherSpec, otherBatchChange, "refs/heads/merged", bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateMerged,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	otherUnpublished := createChangeset(otherSpec, otherBatchChange, "refs/heads/unpublished", bt.TestChangesetOpts{
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+
+	require.NoError(t, s.EnqueueChangesetsHeldByRollout(ctx, merged))
+	require.NoError(t, s.EnqueueChangesetsHeldByRollout(ctx, otherMerged))
+
+	for name, tc := range map[string]struct {
+		changeset *btypes.Changeset
+		want      btypes.ReconcilerState
+	}{
+		"merged":            {changeset: merged, want: btypes.ReconcilerStateCompleted},
+		"held":              {changeset: held, want: btypes.ReconcilerStateQueued},
+		"processing":        {changeset: processing, want: btypes.ReconcilerStateProcessing},
+		"open":              {changeset: open, want: btypes.ReconcilerStateCompleted},
+		"without rollout":   {changeset: otherUnpublished, want: btypes.ReconcilerStateCompleted},
+		"other batch merge": {changeset: otherMerged, want: btypes.ReconcilerStateCompleted},
+	} {
+		t.Run(name, func(t *testing.T) {
+			reloaded, err := s.GetChangesetByID(ctx, tc.changeset.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, reloaded.ReconcilerState)
+		})
+	}
+	t.Run("UpdateBatchChangeRolloutStage", func(t *testing.T) {
+		// The only changeset of the first stage has been merged, but its
+		// checks haven't passed yet.
+		advanced, err := s.UpdateBatchChangeRolloutStage(ctx, rolloutBatchChange.ID)
+		require.NoError(t, err)
+		assert.False(t, advanced)
+
+		// Once they pass, the rollout advances to the final stage.
+		merged.ExternalCheckState = btypes.ChangesetCheckStatePassed
+		require.NoError(t, s.UpdateChangeset(ctx, merged))
+		advanced, err = s.UpdateBatchChangeRolloutStage(ctx, rolloutBatchChange.ID)
+		require.NoError(t, err)
+		assert.True(t, advanced)
+
+		reloaded, err := s.GetBatchChange(ctx, GetBatchChangeOpts{ID: rolloutBatchChange.ID})
+		require.NoError(t, err)
+		assert.Equal(t, 1, reloaded.RolloutStage)
+
+		advanced, err = s.UpdateBatchChangeRolloutStage(ctx, rolloutBatchChange.ID)
+		require.NoError(t, err)
+		assert.False(t, advanced)
+
+		advanced, err = s.UpdateBatchChangeRolloutStage(ctx, otherBatchChange.ID)
+		require.NoError(t, err)
+		assert.False(t, advanced)
+	})
+
+	t.Run("GetChangesetRolloutPosition", func(t *testing.T) {
+		for want, c := range []*btypes.Changeset{merged, held, processing, open} {
+			have, err := s.GetChangesetRolloutPosition(ctx, c)
+			require.NoError(t, err)
+			assert.Equal(t, want, have)
+		}
+	})
+}
@@ -45,6 +45,8 @@ var changesetSpecInsertColumns = []string{
 	"commit_author_name",
 	"commit_author_email",
 	"type",
+	"depends_on",
+	"code_owner_reviews",
 }
 
 // changesetSpecColumns are used by the changeset spec related Store methods to
@@ -72,6 +74,8 @@ var changesetSpecColumns = SQLColumns{
 	"changeset_specs.commit_author_name",
 	"changeset_specs.commit_author_email",
 	"changeset_specs.type",
+	"changeset_specs.depends_on",
+	"changeset_specs.code_owner_reviews",
 }
 
 var oneGigabyte = 1000000000
@@ -107,6 +111,14 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				}
 			}
 
+			dependsOn := []byte("[]")
+			if len(c.DependsOn) > 0 {
+				dependsOn, err = json.Marshal(c.DependsOn)
+				if err != nil {
+					return err
+				}
+			}
+
 			// We check if the resulting diff is greater than 1GB, since the limit
 			// for the diff column (which is bytea) is 1GB
 			if len(c.Diff) > oneGigabyte {
@@ -137,6 +149,8 @@ func (s *Store) CreateChangesetSpec(ctx context.Context, cs ...*btypes.Changeset
 				dbutil.NewNullString(c.CommitAuthorName),
 				dbutil.NewNullString(c.CommitAuthorEmail),
 				c.Type,
+				string(dependsOn),
+				c.RequestCodeOwnerReviews,
 			); err != nil {
 				return err
 			}
@@ -546,7 +560,7 @@ func deleteChangesetSpecsQuery(opts *DeleteChangesetSpecsOpts) *sqlf.Query {
 }
 
 func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
-	var published []byte
+	var published, dependsOn []byte
 	var typ string
 	err := s.Scan(
 		&c.ID,
@@ -571,6 +585,8 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &c.CommitAuthorName},
 		&dbutil.NullString{S: &c.CommitAuthorEmail},
 		&typ,
+		&dependsOn,
+		&c.RequestCodeOwnerReviews,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset spec")
@@ -584,6 +600,10 @@ func scanChangesetSpec(c *btypes.ChangesetSpec, s dbutil.Scanner) error {
 		}
 	}
 
+	if err := json.Unmarshal(dependsOn, &c.DependsOn); err != nil {
+		return errors.Wrap(err, "unmarshalling depends_on")
+	}
+
 	return nil
 }
 
@@ -15,15 +15,19 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/search"
+	awscodecommitbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/awscodecommit"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	gerritbatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/gerrit"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -68,6 +72,9 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
 	sqlf.Sprintf("changesets.detached_at"),
+	sqlf.Sprintf("changesets.base_rev"),
+	sqlf.Sprintf("changesets.rebase_onto"),
+	sqlf.Sprintf("changesets.conflicting_base_rev"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -105,6 +112,9 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("base_rev"),
+	sqlf.Sprintf("rebase_onto"),
+	sqlf.Sprintf("conflicting_base_rev"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -187,6 +197,9 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		nullStringColumn(c.BaseRev),
+		nullStringColumn(c.RebaseOnto),
+		nullStringColumn(c.ConflictingBaseRev),
 		nullStringColumn(title),
 	}
 
@@ -231,7 +244,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store/changesets.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -738,7 +751,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -817,9 +830,45 @@ func (s *Store) UpdateChangesetCodeHostState(ctx context.Context, cs *btypes.Cha
 		return err
 	}
 
-	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
-		return scanChangeset(cs, sc)
-	})
+	var previousExternalState, previousCheckState string
+	if err := s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		return scanChangeset(cs, prefixScanner{Scanner: sc, prefix: []any{
+			&dbutil.NullString{S: &previousExternalState},
+			&dbutil.NullString{S: &previousCheckState},
+		}})
+	}); err != nil {
+		return err
+	}
+
+	// Changesets can't be published before the changesets they depend on are
+	// merged, so once that happens we need to give the reconciler a chance to
+	// publish them. This is only done when the changeset becomes merged, not
+	// on every later sync.
+	if cs.ExternalState == btypes.ChangesetExternalStateMerged && btypes.ChangesetExternalState(previousExternalState) != btypes.ChangesetExternalStateMerged {
+		if err := s.EnqueueChangesetsDependingOn(ctx, cs); err != nil {
+			return err
+		}
+	}
+
+	// Likewise, changesets held back by a rollout can only be published once
+	// the changesets of the previous stages have succeeded or have failed, so
+	// the changesets of the next stage are enqueued when the rollout advances.
+	// Since that looks at all changesets of the batch change, it's only done
+	// when the changeset changed to a state that completes it.
+	stateChanged := btypes.ChangesetExternalState(previousExternalState) != cs.ExternalState ||
+		btypes.ChangesetCheckState(previousCheckState) != cs.ExternalCheckState
+	if cs.OwnedByBatchChangeID != 0 && stateChanged && (cs.ExternalState == btypes.ChangesetExternalStateMerged ||
+		cs.ExternalState == btypes.ChangesetExternalStateClosed ||
+		cs.ExternalCheckState == btypes.ChangesetCheckStateFailed) {
+		advanced, err := s.UpdateBatchChangeRolloutStage(ctx, cs.OwnedByBatchChangeID)
+		if err != nil {
+			return errors.Wrap(err, "updating rollout stage")
+		}
+		if advanced {
+			return s.EnqueueChangesetsHeldByRollout(ctx, cs)
+		}
+	}
+	return nil
 }
 
 func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error) {
@@ -859,12 +908,17 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 	return sqlf.Sprintf(updateChangesetCodeHostStateQueryFmtstr, vars...), nil
 }
 
+// The previous state is returned as well, so that callers can tell whether the
+// changeset changed state.
 var updateChangesetCodeHostStateQueryFmtstr = `
 -- source: enterprise/internal/batches/store/changesets.go:UpdateChangesetCodeHostState
 UPDATE changesets
 SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
-WHERE id = %s
+FROM (SELECT id, external_state, external_check_state FROM changesets WHERE id = %s FOR UPDATE) previous
+WHERE changesets.id = previous.id
 RETURNING
+  previous.external_state,
+  previous.external_check_state,
   %s
 `
 
@@ -1151,6 +1205,9 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
 		&dbutil.NullTime{Time: &t.DetachedAt},
+		&dbutil.NullString{S: &t.BaseRev},
+		&dbutil.NullString{S: &t.RebaseOnto},
+		&dbutil.NullString{S: &t.ConflictingBaseRev},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1179,6 +1236,16 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		// Ensure the inner PR is initialized, it should never be nil.
 		m.PullRequest = &bitbucketcloud.PullRequest{}
 		t.Metadata = m
+	case extsvc.TypeGerrit:
+		m := new(gerritbatches.AnnotatedChange)
+		// Ensure the inner change is initialized, it should never be nil.
+		m.Change = &gerrit.Change{}
+		t.Metadata = m
+	case extsvc.TypeAWSCodeCommit:
+		m := new(awscodecommitbatches.AnnotatedPullRequest)
+		// Ensure the inner PR is initialized, it should never be nil.
+		m.PullRequest = &awscodecommit.PullRequest{}
+		t.Metadata = m
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -24,6 +24,9 @@ func TestIntegration(t *testing.T) {
 	t.Run("Store", func(t *testing.T) {
 		t.Run("BatchChanges", storeTest(db, nil, testStoreBatchChanges))
 		t.Run("BatchChangesDeletedNamespace", storeTest(db, nil, testBatchChangesDeletedNamespace))
+		t.Run("BatchChangeTemplates", storeTest(db, nil, testStoreBatchChangeTemplates))
+		t.Run("BatchChangeSnapshots", storeTest(db, nil, testStoreBatchChangeSnapshots))
+		t.Run("BatchChangeSLAs", storeTest(db, nil, testStoreBatchChangeSLAs))
 		t.Run("Changesets", storeTest(db, nil, testStoreChangesets))
 		t.Run("ChangesetEvents", storeTest(db, nil, testStoreChangesetEvents))
 		t.Run("ChangesetScheduling", storeTest(db, nil, testStoreChangesetScheduling))
@@ -37,6 +40,8 @@ func TestIntegration(t *testing.T) {
 		t.Run("ChangesetSpecsCurrentStateAndTextSearch", storeTest(db, nil, testStoreChangesetSpecsCurrentStateAndTextSearch))
 		t.Run("ChangesetSpecsTextSearch", storeTest(db, nil, testStoreChangesetSpecsTextSearch))
 		t.Run("ChangesetSpecsPublishedValues", storeTest(db, nil, testStoreChangesetSpecsPublishedValues))
+		t.Run("ChangesetDependencies", storeTest(db, nil, testStoreChangesetDependencies))
+		t.Run("ChangesetRollouts", storeTest(db, nil, testStoreChangesetRollouts))
 		t.Run("CodeHosts", storeTest(db, nil, testStoreCodeHost))
 		t.Run("UserDeleteCascades", storeTest(db, nil, testUserDeleteCascades))
 		t.Run("ChangesetJobs", storeTest(db, nil, testStoreChangesetJobs))
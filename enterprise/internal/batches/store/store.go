@@ -180,6 +180,21 @@ type operations struct {
 	getRepoDiffStat        *observation.Operation
 	listBatchChanges       *observation.Operation
 
+	createBatchChangeTemplate *observation.Operation
+	updateBatchChangeTemplate *observation.Operation
+	deleteBatchChangeTemplate *observation.Operation
+	getBatchChangeTemplate    *observation.Operation
+	listBatchChangeTemplates  *observation.Operation
+	countBatchChangeTemplates *observation.Operation
+
+	upsertBatchChangeSnapshot *observation.Operation
+	listBatchChangeSnapshots  *observation.Operation
+
+	upsertBatchChangeSLA      *observation.Operation
+	updateBatchChangeSLAState *observation.Operation
+	deleteBatchChangeSLA      *observation.Operation
+	getBatchChangeSLA         *observation.Operation
+
 	createBatchSpecExecution *observation.Operation
 	getBatchSpecExecution    *observation.Operation
 	cancelBatchSpecExecution *observation.Operation
@@ -212,8 +227,14 @@ type operations struct {
 	countChangesetEvents  *observation.Operation
 	upsertChangesetEvents *observation.Operation
 
-	createChangesetJob *observation.Operation
-	getChangesetJob    *observation.Operation
+	createChangesetJob        *observation.Operation
+	getChangesetJob           *observation.Operation
+	requeueChangesetJob       *observation.Operation
+	reserveQueuedMerge        *observation.Operation
+	lockCodeHostMerges        *observation.Operation
+	listQueuedMergeTimes      *observation.Operation
+	listUncheckedQueuedMerges *observation.Operation
+	markQueuedMergeChecked    *observation.Operation
 
 	createChangesetSpec                      *observation.Operation
 	updateChangesetSpecBatchSpecID           *observation.Operation
@@ -246,6 +267,14 @@ type operations struct {
 	enqueueNextScheduledChangeset     *observation.Operation
 	getChangesetPlaceInSchedulerQueue *observation.Operation
 	cleanDetachedChangesets           *observation.Operation
+	getChangesetDependencies          *observation.Operation
+	enqueueChangesetsDependingOn      *observation.Operation
+	listChangesetSpecDependencies     *observation.Operation
+	listChangesetsToRebase            *observation.Operation
+	enqueueChangesetRebase            *observation.Operation
+	enqueueChangesetsHeldByRollout    *observation.Operation
+	updateBatchChangeRolloutStage     *observation.Operation
+	getChangesetRolloutPosition       *observation.Operation
 
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
@@ -322,6 +351,21 @@ func newOperations(observationContext *observation.Context) *operations {
 			getBatchChangeDiffStat: op("GetBatchChangeDiffStat"),
 			getRepoDiffStat:        op("GetRepoDiffStat"),
 
+			createBatchChangeTemplate: op("CreateBatchChangeTemplate"),
+			updateBatchChangeTemplate: op("UpdateBatchChangeTemplate"),
+			deleteBatchChangeTemplate: op("DeleteBatchChangeTemplate"),
+			getBatchChangeTemplate:    op("GetBatchChangeTemplate"),
+			listBatchChangeTemplates:  op("ListBatchChangeTemplates"),
+			countBatchChangeTemplates: op("CountBatchChangeTemplates"),
+
+			upsertBatchChangeSnapshot: op("UpsertBatchChangeSnapshot"),
+			listBatchChangeSnapshots:  op("ListBatchChangeSnapshots"),
+
+			upsertBatchChangeSLA:      op("UpsertBatchChangeSLA"),
+			updateBatchChangeSLAState: op("UpdateBatchChangeSLAState"),
+			deleteBatchChangeSLA:      op("DeleteBatchChangeSLA"),
+			getBatchChangeSLA:         op("GetBatchChangeSLA"),
+
 			createBatchSpecExecution: op("CreateBatchSpecExecution"),
 			getBatchSpecExecution:    op("GetBatchSpecExecution"),
 			cancelBatchSpecExecution: op("CancelBatchSpecExecution"),
@@ -354,8 +398,14 @@ func newOperations(observationContext *observation.Context) *operations {
 			countChangesetEvents:  op("CountChangesetEvents"),
 			upsertChangesetEvents: op("UpsertChangesetEvents"),
 
-			createChangesetJob: op("CreateChangesetJob"),
-			getChangesetJob:    op("GetChangesetJob"),
+			createChangesetJob:        op("CreateChangesetJob"),
+			getChangesetJob:           op("GetChangesetJob"),
+			requeueChangesetJob:       op("RequeueChangesetJob"),
+			reserveQueuedMerge:        op("ReserveQueuedMerge"),
+			lockCodeHostMerges:        op("LockCodeHostMerges"),
+			listQueuedMergeTimes:      op("ListQueuedMergeTimes"),
+			listUncheckedQueuedMerges: op("ListUncheckedQueuedMerges"),
+			markQueuedMergeChecked:    op("MarkQueuedMergeChecked"),
 
 			createChangesetSpec:                      op("CreateChangesetSpec"),
 			updateChangesetSpecBatchSpecID:           op("UpdateChangesetSpecBatchSpecID"),
@@ -388,6 +438,14 @@ func newOperations(observationContext *observation.Context) *operations {
 			enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
 			getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
 			cleanDetachedChangesets:           op("CleanDetachedChangesets"),
+			getChangesetDependencies:          op("GetChangesetDependencies"),
+			enqueueChangesetsDependingOn:      op("EnqueueChangesetsDependingOn"),
+			listChangesetSpecDependencies:     op("ListChangesetSpecDependencies"),
+			listChangesetsToRebase:            op("ListChangesetsToRebase"),
+			enqueueChangesetRebase:            op("EnqueueChangesetRebase"),
+			enqueueChangesetsHeldByRollout:    op("EnqueueChangesetsHeldByRollout"),
+			updateBatchChangeRolloutStage:     op("UpdateBatchChangeRolloutStage"),
+			getChangesetRolloutPosition:       op("GetChangesetRolloutPosition"),
 
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
@@ -48,6 +48,10 @@ type TestChangesetOpts struct {
 	IsArchived bool
 	Archive    bool
 
+	BaseRev            string
+	RebaseOnto         string
+	ConflictingBaseRev string
+
 	Metadata any
 }
 
@@ -96,6 +100,10 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 
 		Closing: opts.Closing,
 
+		BaseRev:            opts.BaseRev,
+		RebaseOnto:         opts.RebaseOnto,
+		ConflictingBaseRev: opts.ConflictingBaseRev,
+
 		ReconcilerState: opts.ReconcilerState,
 		NumFailures:     opts.NumFailures,
 		NumResets:       opts.NumResets,
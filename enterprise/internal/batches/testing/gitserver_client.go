@@ -3,7 +3,11 @@ package testing
 import (
 	"context"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // FakeGitserverClient is a test implementation of the GitserverClient
@@ -14,6 +18,13 @@ type FakeGitserverClient struct {
 
 	CreateCommitFromPatchCalled bool
 	CreateCommitFromPatchReq    *protocol.CreateCommitFromPatchRequest
+
+	ResolveRevisionResponse api.CommitID
+	ResolveRevisionErr      error
+
+	// ReadFileResponses maps file names to their content. Files that aren't
+	// present are reported as not found.
+	ReadFileResponses map[string][]byte
 }
 
 func (f *FakeGitserverClient) CreateCommitFromPatch(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
@@ -21,3 +32,14 @@ func (f *FakeGitserverClient) CreateCommitFromPatch(ctx context.Context, req pro
 	f.CreateCommitFromPatchReq = &req
 	return f.Response, f.ResponseErr
 }
+
+func (f *FakeGitserverClient) ResolveRevision(ctx context.Context, repo api.RepoName, spec string, opt gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+	return f.ResolveRevisionResponse, f.ResolveRevisionErr
+}
+
+func (f *FakeGitserverClient) ReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, name string, checker authz.SubRepoPermissionChecker) ([]byte, error) {
+	if content, ok := f.ReadFileResponses[name]; ok {
+		return content, nil
+	}
+	return nil, errors.Newf("file %q not found", name)
+}
@@ -31,6 +31,11 @@ type BatchChange struct {
 
 	ClosedAt time.Time
 
+	// RolloutStage is the stage the rollout defined in the current batch spec
+	// has reached. It's only updated through
+	// Store.UpdateBatchChangeRolloutStage.
+	RolloutStage int
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
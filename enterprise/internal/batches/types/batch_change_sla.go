@@ -0,0 +1,87 @@
+package types
+
+import "time"
+
+// A BatchChangeSLA is a target date by which all changesets of a batch change
+// should be merged or closed. When the batch change falls behind the projected
+// line between the start of the schedule and the target date, alerts are sent
+// to the configured recipients.
+type BatchChangeSLA struct {
+	ID            int64
+	BatchChangeID int64
+	CreatorID     int32
+
+	TargetDate time.Time
+
+	// NotifyEmail is true if the creator of the SLA should be notified by
+	// email.
+	NotifyEmail     bool
+	SlackWebhookURL string
+	WebhookURL      string
+
+	// StartedAt is when the schedule started: when the first snapshot with
+	// published changesets was taken after the SLA was set. Until then, no
+	// progress is expected, so that the time it takes to publish the
+	// changesets doesn't count against the schedule.
+	StartedAt time.Time
+	// OnTrack is true if the batch change has been seen on schedule since it
+	// last fell behind. Alerts are only sent when a batch change that was on
+	// track falls behind, so that a batch change that is behind from the start
+	// or keeps hovering around the projected line doesn't alert repeatedly.
+	OnTrack bool
+	// AlertedAt is when the last alert was sent.
+	AlertedAt time.Time
+	// SentAlertChannels are the channels the current alert has already been
+	// sent through, so that retrying a failed delivery skips them.
+	SentAlertChannels []string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a BatchChangeSLA.
+func (s *BatchChangeSLA) Clone() *BatchChangeSLA {
+	ss := *s
+	ss.SentAlertChannels = append([]string(nil), s.SentAlertChannels...)
+	return &ss
+}
+
+// BehindScheduleTolerance is how far the progress of a batch change may fall
+// below the projected line before it's considered behind schedule, so that a
+// few changesets being merged a little later than projected don't trigger an
+// alert.
+const BehindScheduleTolerance = 0.05
+
+// ExpectedProgress returns the fraction of changesets that should be merged or
+// closed at the given time to meet the target date, assuming a linear burndown
+// from the start of the schedule. No progress is expected before the schedule
+// has started.
+func (s *BatchChangeSLA) ExpectedProgress(t time.Time) float64 {
+	if s.StartedAt.IsZero() || !t.After(s.StartedAt) {
+		return 0
+	}
+	if !t.Before(s.TargetDate) || !s.TargetDate.After(s.StartedAt) {
+		return 1
+	}
+	return float64(t.Sub(s.StartedAt)) / float64(s.TargetDate.Sub(s.StartedAt))
+}
+
+// BehindSchedule returns true if the progress recorded in the given snapshot is
+// more than BehindScheduleTolerance below the projected line at time t.
+func (s *BatchChangeSLA) BehindSchedule(t time.Time, snapshot *BatchChangeSnapshot) bool {
+	if snapshot.Total == 0 {
+		return false
+	}
+	return snapshot.Progress() < s.ExpectedProgress(t)-BehindScheduleTolerance
+}
+
+// OnSchedule returns true if the progress recorded in the given snapshot is at
+// or above the projected line at time t. Progress within
+// BehindScheduleTolerance below the line is neither on nor behind schedule,
+// so that a batch change hovering around the line doesn't flip back and forth.
+func (s *BatchChangeSLA) OnSchedule(t time.Time, snapshot *BatchChangeSnapshot) bool {
+	if snapshot.Total == 0 {
+		return true
+	}
+	return snapshot.Progress() >= s.ExpectedProgress(t)
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchChangeSLA_ExpectedProgress(t *testing.T) {
+	start := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	sla := &BatchChangeSLA{StartedAt: start, TargetDate: start.Add(10 * 24 * time.Hour)}
+
+	assert.Equal(t, 0.0, sla.ExpectedProgress(start.Add(-time.Hour)))
+	assert.Equal(t, 0.5, sla.ExpectedProgress(start.Add(5*24*time.Hour)))
+	assert.Equal(t, 1.0, sla.ExpectedProgress(start.Add(11*24*time.Hour)))
+
+	notStarted := &BatchChangeSLA{TargetDate: start.Add(10 * 24 * time.Hour)}
+	assert.Equal(t, 0.0, notStarted.ExpectedProgress(start.Add(11*24*time.Hour)))
+}
+
+func TestBatchChangeSLA_Schedule(t *testing.T) {
+	start := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	sla := &BatchChangeSLA{StartedAt: start, TargetDate: start.Add(10 * 24 * time.Hour)}
+
+	for name, tc := range map[string]struct {
+		sla        *BatchChangeSLA
+		t          time.Time
+		snapshot   *BatchChangeSnapshot
+		wantBehind bool
+		wantOn     bool
+	}{
+		"no changesets": {
+			t:        start.Add(5 * 24 * time.Hour),
+			snapshot: &BatchChangeSnapshot{},
+			wantOn:   true,
+		},
+		"before start": {
+			t:        start.Add(-time.Hour),
+			snapshot: &BatchChangeSnapshot{Total: 10, Open: 10},
+			wantOn:   true,
+		},
+		"not started": {
+			sla:      &BatchChangeSLA{TargetDate: start.Add(10 * 24 * time.Hour)},
+			t:        start.Add(11 * 24 * time.Hour),
+			snapshot: &BatchChangeSnapshot{Total: 10, Open: 10},
+			wantOn:   true,
+		},
+		"on schedule": {
+			t:        start.Add(5 * 24 * time.Hour),
+			snapshot: &BatchChangeSnapshot{Total: 10, Open: 5, Merged: 4, Closed: 1},
+			wantOn:   true,
+		},
+		"within tolerance": {
+			t:        start.Add(5*24*time.Hour + 12*time.Hour),
+			snapshot: &BatchChangeSnapshot{Total: 10, Open: 5, Merged: 5},
+		},
+		"behind schedule": {
+			t:          start.Add(5 * 24 * time.Hour),
+			snapshot:   &BatchChangeSnapshot{Total: 10, Open: 6, Merged: 4},
+			wantBehind: true,
+		},
+		"past target date": {
+			t:          start.Add(11 * 24 * time.Hour),
+			snapshot:   &BatchChangeSnapshot{Total: 100, Open: 10, Merged: 90},
+			wantBehind: true,
+		},
+		"done": {
+			t:        start.Add(11 * 24 * time.Hour),
+			snapshot: &BatchChangeSnapshot{Total: 10, Merged: 9, Closed: 1},
+			wantOn:   true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := sla
+			if tc.sla != nil {
+				s = tc.sla
+			}
+			assert.Equal(t, tc.wantBehind, s.BehindSchedule(tc.t, tc.snapshot), "BehindSchedule")
+			assert.Equal(t, tc.wantOn, s.OnSchedule(tc.t, tc.snapshot), "OnSchedule")
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package types
+
+import "time"
+
+// A BatchChangeSnapshot records the state of the changesets of a batch change
+// on a given day, so that its progress can be tracked over time.
+type BatchChangeSnapshot struct {
+	ID            int64
+	BatchChangeID int64
+	// Date is the day the snapshot was taken on, at midnight UTC.
+	Date time.Time
+
+	Total  int32
+	Open   int32
+	Draft  int32
+	Merged int32
+	Closed int32
+
+	// ReviewLatency is the average time between a changeset being opened and
+	// its first review, for all changesets that have been reviewed. It's zero
+	// if no changeset has been reviewed yet.
+	ReviewLatency time.Duration
+
+	CreatedAt time.Time
+}
+
+// Clone returns a clone of a BatchChangeSnapshot.
+func (s *BatchChangeSnapshot) Clone() *BatchChangeSnapshot {
+	ss := *s
+	return &ss
+}
+
+// Progress returns the fraction of changesets that are either merged or
+// closed, which is what the burndown chart burns down to.
+func (s *BatchChangeSnapshot) Progress() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+	return float64(s.Merged+s.Closed) / float64(s.Total)
+}
@@ -0,0 +1,234 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/jsonschema"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// A BatchChangeTemplate is a batch spec with input parameters, published in a
+// namespace so that others can create batch specs from it by only providing
+// the values of its inputs.
+type BatchChangeTemplate struct {
+	ID          int64
+	Name        string
+	Description string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	CreatorID int32
+
+	// RawTemplate is the batch spec YAML, in which ${{ inputs.NAME }}
+	// placeholders in scalars are replaced with the values of the inputs when
+	// the template is rendered.
+	RawTemplate string
+	// InputSchema is the JSON schema the inputs are validated against. It
+	// must describe an object, whose properties are the inputs.
+	InputSchema string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a BatchChangeTemplate.
+func (t *BatchChangeTemplate) Clone() *BatchChangeTemplate {
+	tt := *t
+	return &tt
+}
+
+// templateInputPattern matches the placeholders of inputs in a template.
+var templateInputPattern = regexp.MustCompile(`\$\{\{\s*inputs\.([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// templateInputSchema is the part of the input schema of a template that is
+// needed to render it.
+type templateInputSchema struct {
+	Type       string `json:"type"`
+	Properties map[string]struct {
+		Default any `json:"default"`
+	} `json:"properties"`
+}
+
+func (t *BatchChangeTemplate) parseInputSchema() (*templateInputSchema, error) {
+	var s templateInputSchema
+	if err := json.Unmarshal([]byte(t.InputSchema), &s); err != nil {
+		return nil, errors.Wrap(err, "parsing input schema")
+	}
+	if s.Type != "object" {
+		return nil, errors.New(`input schema must be of type "object"`)
+	}
+	return &s, nil
+}
+
+func (t *BatchChangeTemplate) parseTemplate() (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(t.RawTemplate), &doc); err != nil {
+		return nil, errors.Wrap(err, "parsing template")
+	}
+	return &doc, nil
+}
+
+// Validate returns an error if the template is not valid YAML, if the input
+// schema of the template is not a valid JSON schema describing an object, or
+// if the template references an input that is not a property of that object.
+func (t *BatchChangeTemplate) Validate() error {
+	if t.Name == "" {
+		return errors.New("template name must not be blank")
+	}
+
+	if _, err := t.parseTemplate(); err != nil {
+		return err
+	}
+
+	if _, err := gojsonschema.NewSchemaLoader().Compile(gojsonschema.NewStringLoader(t.InputSchema)); err != nil {
+		return errors.Wrap(err, "compiling input schema")
+	}
+	s, err := t.parseInputSchema()
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, name := range t.InputNames() {
+		if _, ok := s.Properties[name]; !ok {
+			errs = errors.Append(errs, errors.Errorf("template references undeclared input %q", name))
+		}
+	}
+	return errs
+}
+
+// InputNames returns the sorted names of the inputs referenced by the
+// template.
+func (t *BatchChangeTemplate) InputNames() []string {
+	seen := map[string]struct{}{}
+	var names []string
+	for _, m := range templateInputPattern.FindAllStringSubmatch(t.RawTemplate, -1) {
+		if _, ok := seen[m[1]]; ok {
+			continue
+		}
+		seen[m[1]] = struct{}{}
+		names = append(names, m[1])
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render validates the given inputs against the input schema and returns the
+// raw batch spec with the placeholders replaced by their values. Inputs that
+// are not given are replaced with their default value from the input schema,
+// or null if they have none.
+//
+// The placeholders are substituted in the parsed template rather than in its
+// text, so that inputs can't change the structure of the batch spec: a scalar
+// that consists of a single placeholder is replaced with the value of the
+// input, whatever its type, and placeholders within other scalars are
+// replaced with the string representation of the value, making the scalar a
+// string.
+func (t *BatchChangeTemplate) Render(inputs map[string]any) (string, error) {
+	if inputs == nil {
+		inputs = map[string]any{}
+	}
+
+	raw, err := json.Marshal(inputs)
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling inputs")
+	}
+	if err := jsonschema.Validate(t.InputSchema, raw); err != nil {
+		return "", errors.Wrap(err, "validating inputs")
+	}
+
+	s, err := t.parseInputSchema()
+	if err != nil {
+		return "", err
+	}
+	doc, err := t.parseTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	value := func(name string) any {
+		if v, ok := inputs[name]; ok {
+			return v
+		}
+		return s.Properties[name].Default
+	}
+	if err := renderTemplateNode(doc, value, false); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return "", errors.Wrap(err, "encoding batch spec")
+	}
+	if err := enc.Close(); err != nil {
+		return "", errors.Wrap(err, "encoding batch spec")
+	}
+	return buf.String(), nil
+}
+
+// renderTemplateNode substitutes the placeholders in the scalars of the given
+// node and its descendants. Mapping keys are always rendered as strings.
+func renderTemplateNode(node *yaml.Node, value func(name string) any, isKey bool) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			if err := renderTemplateNode(n, value, false); err != nil {
+				return err
+			}
+		}
+
+	case yaml.MappingNode:
+		for i, n := range node.Content {
+			if err := renderTemplateNode(n, value, i%2 == 0); err != nil {
+				return err
+			}
+		}
+
+	case yaml.ScalarNode:
+		if !templateInputPattern.MatchString(node.Value) {
+			return nil
+		}
+
+		if m := templateInputPattern.FindStringSubmatch(node.Value); !isKey && m[0] == strings.TrimSpace(node.Value) {
+			var rendered yaml.Node
+			if err := rendered.Encode(value(m[1])); err != nil {
+				return errors.Wrapf(err, "rendering input %q", m[1])
+			}
+			rendered.HeadComment, rendered.LineComment, rendered.FootComment = node.HeadComment, node.LineComment, node.FootComment
+			*node = rendered
+			return nil
+		}
+
+		var errs error
+		node.Value = templateInputPattern.ReplaceAllStringFunc(node.Value, func(placeholder string) string {
+			name := templateInputPattern.FindStringSubmatch(placeholder)[1]
+			switch v := value(name).(type) {
+			case nil:
+				return ""
+			case string:
+				return v
+			default:
+				b, err := json.Marshal(v)
+				if err != nil {
+					errs = errors.Append(errs, errors.Wrapf(err, "rendering input %q", name))
+				}
+				return string(b)
+			}
+		})
+		node.Tag = "!!str"
+		return errs
+	}
+
+	return nil
+}
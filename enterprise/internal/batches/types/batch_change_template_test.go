@@ -0,0 +1,137 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const testTemplateInputSchema = `{
+  "type": "object",
+  "properties": {
+    "library": {"type": "string"},
+    "version": {"type": "string", "pattern": "^[0-9]+\\.[0-9]+\\.[0-9]+$"},
+    "draft": {"type": "boolean", "default": true}
+  },
+  "required": ["library", "version"]
+}`
+
+const testTemplate = `name: bump-${{ inputs.library }}
+on:
+  - repositoriesMatchingQuery: file:package.json ${{inputs.library}}
+steps:
+  - run: yarn upgrade ${{ inputs.library }}@${{ inputs.version }}
+    container: node:16
+changesetTemplate:
+  title: Bump ${{ inputs.library }} to ${{ inputs.version }}
+  branch: bump-${{ inputs.library }}
+  commit:
+    message: Bump ${{ inputs.library }} to ${{ inputs.version }}
+  published:
+    draft: ${{ inputs.draft }}
+`
+
+func TestBatchChangeTemplate_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		template *BatchChangeTemplate
+		wantErr  bool
+	}{
+		"valid": {
+			template: &BatchChangeTemplate{Name: "bump", RawTemplate: testTemplate, InputSchema: testTemplateInputSchema},
+		},
+		"blank name": {
+			template: &BatchChangeTemplate{RawTemplate: testTemplate, InputSchema: testTemplateInputSchema},
+			wantErr:  true,
+		},
+		"invalid schema": {
+			template: &BatchChangeTemplate{Name: "bump", RawTemplate: testTemplate, InputSchema: `{"type": 42}`},
+			wantErr:  true,
+		},
+		"schema not an object": {
+			template: &BatchChangeTemplate{Name: "bump", RawTemplate: testTemplate, InputSchema: `{"type": "string"}`},
+			wantErr:  true,
+		},
+		"undeclared input": {
+			template: &BatchChangeTemplate{Name: "bump", RawTemplate: testTemplate + "# ${{ inputs.team }}", InputSchema: testTemplateInputSchema},
+			wantErr:  true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.template.Validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBatchChangeTemplate_InputNames(t *testing.T) {
+	template := &BatchChangeTemplate{RawTemplate: testTemplate}
+	assert.Equal(t, []string{"draft", "library", "version"}, template.InputNames())
+}
+
+func TestBatchChangeTemplate_Render(t *testing.T) {
+	template := &BatchChangeTemplate{RawTemplate: testTemplate, InputSchema: testTemplateInputSchema}
+
+	t.Run("valid inputs", func(t *testing.T) {
+		have, err := template.Render(map[string]any{"library": "lodash", "version": "4.17.21"})
+		assert.NoError(t, err)
+		assert.Equal(t, `name: bump-lodash
+on:
+  - repositoriesMatchingQuery: file:package.json lodash
+steps:
+  - run: yarn upgrade lodash@4.17.21
+    container: node:16
+changesetTemplate:
+  title: Bump lodash to 4.17.21
+  branch: bump-lodash
+  commit:
+    message: Bump lodash to 4.17.21
+  published:
+    draft: true
+`, have)
+	})
+
+	t.Run("overriding a default", func(t *testing.T) {
+		have, err := template.Render(map[string]any{"library": "lodash", "version": "4.17.21", "draft": false})
+		assert.NoError(t, err)
+		assert.Contains(t, have, "draft: false\n")
+	})
+
+	t.Run("inputs can't change the structure", func(t *testing.T) {
+		library := "lodash\nsteps: []\n# "
+		have, err := template.Render(map[string]any{"library": library, "version": "4.17.21"})
+		assert.NoError(t, err)
+
+		var spec struct {
+			Name  string `yaml:"name"`
+			Steps []struct {
+				Run string `yaml:"run"`
+			} `yaml:"steps"`
+			ChangesetTemplate struct {
+				Title string `yaml:"title"`
+			} `yaml:"changesetTemplate"`
+		}
+		if err := yaml.Unmarshal([]byte(have), &spec); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "bump-"+library, spec.Name)
+		if assert.Len(t, spec.Steps, 1) {
+			assert.Equal(t, "yarn upgrade "+library+"@4.17.21", spec.Steps[0].Run)
+		}
+		assert.Equal(t, "Bump "+library+" to 4.17.21", spec.ChangesetTemplate.Title)
+	})
+
+	t.Run("missing required input", func(t *testing.T) {
+		_, err := template.Render(map[string]any{"library": "lodash"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := template.Render(map[string]any{"library": "lodash", "version": "latest"})
+		assert.Error(t, err)
+	})
+}
@@ -47,6 +47,8 @@ var (
 	ChangesetJobTypeMerge     ChangesetJobType = "merge"
 	ChangesetJobTypeClose     ChangesetJobType = "close"
 	ChangesetJobTypePublish   ChangesetJobType = "publish"
+
+	ChangesetJobTypeMergeWhenReady ChangesetJobType = "merge_when_ready"
 )
 
 type ChangesetJobCommentPayload struct {
@@ -61,6 +63,10 @@ type ChangesetJobMergePayload struct {
 	Squash bool `json:"squash,omitempty"`
 }
 
+type ChangesetJobMergeWhenReadyPayload struct {
+	Squash bool `json:"squash,omitempty"`
+}
+
 type ChangesetJobClosePayload struct{}
 
 type ChangesetJobPublishPayload struct {
@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -72,6 +73,8 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		c.CommitMessage = commitMsg
 		c.CommitAuthorName = authorName
 		c.CommitAuthorEmail = authorEmail
+		c.DependsOn = spec.DependsOn
+		c.RequestCodeOwnerReviews = spec.RequestCodeOwnerReviews
 	}
 
 	c.computeForkNamespace()
@@ -113,6 +116,14 @@ type ChangesetSpec struct {
 	CommitAuthorName  string
 	CommitAuthorEmail string
 
+	// DependsOn are the changesets in the same batch change that need to be
+	// merged before this changeset is published.
+	DependsOn []batcheslib.ChangesetDependency
+
+	// RequestCodeOwnerReviews is true if reviews should be requested from
+	// the code owners of the changed files when the changeset is published.
+	RequestCodeOwnerReviews bool
+
 	ForkNamespace *string
 }
 
@@ -171,6 +182,40 @@ func (cs *ChangesetSpec) DiffStat() diff.Stat {
 	}
 }
 
+// ChangedPaths returns the paths of all files touched by the Diff. For
+// renamed files, both the old and the new path are returned.
+func (cs *ChangesetSpec) ChangedPaths() ([]string, error) {
+	var paths []string
+	seen := map[string]struct{}{}
+	add := func(name string) {
+		if name == "" || name == "/dev/null" {
+			return
+		}
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "a/"), "b/")
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		paths = append(paths, name)
+	}
+
+	reader := diff.NewMultiFileDiffReader(bytes.NewReader(cs.Diff))
+	for {
+		fileDiff, err := reader.ReadFile()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		add(fileDiff.OrigName)
+		add(fileDiff.NewName)
+	}
+
+	return paths, nil
+}
+
 // ChangesetSpecTTL specifies the TTL of ChangesetSpecs that haven't been
 // attached to a BatchSpec.
 // It's lower than BatchSpecTTL because ChangesetSpecs should be attached to
@@ -49,3 +49,28 @@ func TestChangesetSpec_SetForkToUser(t *testing.T) {
 }
 
 func strPtr(s string) *string { return &s }
+
+func TestChangesetSpec_ChangedPaths(t *testing.T) {
+	cs := &ChangesetSpec{Diff: []byte(`diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-hello
++world
+diff --git a/old.go b/new.go
+--- a/old.go
++++ b/new.go
+@@ -1 +1 @@
+-package old
++package new
+diff --git a/docs/added.md b/docs/added.md
+--- /dev/null
++++ b/docs/added.md
+@@ -0,0 +1 @@
++added
+`)}
+
+	have, err := cs.ChangedPaths()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "old.go", "new.go", "docs/added.md"}, have)
+}
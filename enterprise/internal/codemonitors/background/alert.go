@@ -0,0 +1,82 @@
+package background
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// The channels an Alert is sent through, as returned by SendAlert.
+const (
+	AlertChannelEmail        = "email"
+	AlertChannelSlackWebhook = "slack_webhook"
+	AlertChannelWebhook      = "webhook"
+)
+
+// An Alert is sent by other features the same way code monitors send their
+// notifications: by email to a user, to a Slack webhook and to a generic
+// webhook, depending on which of them are set.
+type Alert struct {
+	// EmailUserID is the user the email is sent to, or 0 to not send one.
+	EmailUserID    int32
+	EmailTemplates txtypes.Templates
+
+	SlackWebhookURL string
+	SlackMessage    *slack.WebhookMessage
+
+	WebhookURL string
+
+	// Data is rendered by the email templates and posted as the payload of the
+	// generic webhook.
+	Data any
+}
+
+// SendAlert sends the alert through each of its channels which isn't in sent.
+// It returns the channels the alert has been sent through, including the ones
+// in sent, so that the caller can record them and skip them when retrying the
+// channels that failed.
+func SendAlert(ctx context.Context, db database.DB, alert *Alert, sent []string) ([]string, error) {
+	return sendAlert(ctx, db, httpcli.ExternalDoer, alert, sent)
+}
+
+func sendAlert(ctx context.Context, db database.DB, doer httpcli.Doer, alert *Alert, sent []string) ([]string, error) {
+	done := make(map[string]bool, len(sent))
+	for _, c := range sent {
+		done[c] = true
+	}
+	delivered := append([]string{}, sent...)
+
+	var errs error
+	send := func(channel string, f func() error) {
+		if done[channel] {
+			return
+		}
+		if err := f(); err != nil {
+			errs = errors.Append(errs, err)
+			return
+		}
+		delivered = append(delivered, channel)
+	}
+
+	if alert.EmailUserID != 0 {
+		send(AlertChannelEmail, func() error {
+			return errors.Wrap(sendEmail(ctx, db, alert.EmailUserID, alert.EmailTemplates, alert.Data), "sending email")
+		})
+	}
+	if alert.SlackWebhookURL != "" {
+		send(AlertChannelSlackWebhook, func() error {
+			return errors.Wrap(postSlackWebhook(ctx, doer, alert.SlackWebhookURL, alert.SlackMessage), "posting Slack webhook")
+		})
+	}
+	if alert.WebhookURL != "" {
+		send(AlertChannelWebhook, func() error {
+			return errors.Wrap(postWebhook(ctx, doer, alert.WebhookURL, alert.Data), "posting webhook")
+		})
+	}
+	return delivered, errs
+}
@@ -0,0 +1,44 @@
+package background
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendAlert(t *testing.T) {
+	posts := map[string]int{}
+	failSlack := true
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts[r.URL.Path]++
+		if r.URL.Path == "/slack" && failSlack {
+			w.WriteHeader(500)
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer s.Close()
+
+	alert := &Alert{
+		SlackWebhookURL: s.URL + "/slack",
+		SlackMessage:    &slack.WebhookMessage{Text: "alert"},
+		WebhookURL:      s.URL + "/webhook",
+		Data:            map[string]string{"message": "alert"},
+	}
+
+	// A failed channel doesn't keep the others from being sent.
+	sent, err := sendAlert(context.Background(), nil, s.Client(), alert, nil)
+	require.Error(t, err)
+	require.Equal(t, []string{AlertChannelWebhook}, sent)
+
+	// Retries skip the channels the alert has already been sent through.
+	failSlack = false
+	sent, err = sendAlert(context.Background(), nil, s.Client(), alert, sent)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{AlertChannelWebhook, AlertChannelSlackWebhook}, sent)
+	require.Equal(t, map[string]int{"/slack": 2, "/webhook": 1}, posts)
+}
@@ -0,0 +1,127 @@
+package background
+
+import (
+	"context"
+	_ "embed"
+	"strings"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+var MockSendEmailForDigest func(ctx context.Context, db database.DB, userID int32, data *TemplateDataDigest) error
+
+func SendEmailForDigest(ctx context.Context, db database.DB, userID int32, data *TemplateDataDigest) error {
+	if MockSendEmailForDigest != nil {
+		return MockSendEmailForDigest(ctx, db, userID, data)
+	}
+	return sendEmail(ctx, db, userID, digestEmailTemplates, data)
+}
+
+var (
+	//go:embed digest_template.html.tmpl
+	digestHTMLTemplate string
+
+	//go:embed digest_template.txt.tmpl
+	digestTextTemplate string
+)
+
+var digestEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `{{.Priority}}Sourcegraph code monitor {{.Description}} {{.Interval}} digest: {{.TotalCount}} new {{.ResultPluralized}} in {{len .Repos}} {{.RepoPluralized}}`,
+	Text:    digestTextTemplate,
+	HTML:    digestHTMLTemplate,
+})
+
+type TemplateDataDigest struct {
+	Priority                  string
+	CodeMonitorURL            string
+	SearchURL                 string
+	Description               string
+	Interval                  string
+	IncludeResults            bool
+	Repos                     []*DigestRepo
+	RepoPluralized            string
+	TotalCount                int
+	TruncatedCount            int
+	ResultPluralized          string
+	TruncatedResultPluralized string
+	DisplayMoreLink           bool
+}
+
+// DigestRepo is the summary of the results of a digest in one repository.
+type DigestRepo struct {
+	RepoName         string
+	TotalCount       int
+	ResultPluralized string
+	// Results are the results in the repository that are included in the
+	// digest, which may be fewer than TotalCount.
+	Results []*DisplayResult
+}
+
+// NewTemplateDataForDigest summarizes the results of a digest by repository,
+// including at most email.DigestMaxResults results.
+func NewTemplateDataForDigest(args actionArgs, email *edb.EmailAction) *TemplateDataDigest {
+	priority := ""
+	if email.Priority == priorityCritical {
+		priority = "[Critical] "
+	}
+	interval := "daily"
+	if email.DigestInterval != nil {
+		interval = strings.ToLower(*email.DigestInterval)
+	}
+
+	// Count the results of each repository before truncating them, which
+	// modifies them.
+	groups := groupResultsByRepo(args.Results)
+	repos := make([]*DigestRepo, len(groups))
+	index := make(map[string]*DigestRepo, len(groups))
+	for i, g := range groups {
+		count := countResults(g.Results)
+		repos[i] = &DigestRepo{
+			RepoName:         string(g.RepoName),
+			TotalCount:       count,
+			ResultPluralized: pluralize("result", count),
+		}
+		index[repos[i].RepoName] = repos[i]
+	}
+
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, int(email.DigestMaxResults))
+	if args.IncludeResults {
+		for _, r := range truncatedResults {
+			repo := index[string(r.Repo.Name)]
+			repo.Results = append(repo.Results, toDisplayResult(r, args.ExternalURL))
+		}
+	}
+
+	repoPluralized := "repositories"
+	if len(repos) == 1 {
+		repoPluralized = "repository"
+	}
+
+	return &TemplateDataDigest{
+		Priority:                  priority,
+		CodeMonitorURL:            getCodeMonitorURL(args.ExternalURL, email.Monitor, utmSourceEmail),
+		SearchURL:                 getSearchURL(args.ExternalURL, args.Query, utmSourceEmail),
+		Description:               args.MonitorDescription,
+		Interval:                  interval,
+		IncludeResults:            args.IncludeResults,
+		Repos:                     repos,
+		RepoPluralized:            repoPluralized,
+		TotalCount:                totalCount,
+		TruncatedCount:            truncatedCount,
+		ResultPluralized:          pluralize("result", totalCount),
+		TruncatedResultPluralized: pluralize("result", truncatedCount),
+		DisplayMoreLink:           args.IncludeResults && truncatedCount > 0,
+	}
+}
+
+func countResults(results []*result.CommitMatch) int {
+	matches := make(result.Matches, len(results))
+	for i, r := range results {
+		matches[i] = r
+	}
+	return matches.ResultCount()
+}
@@ -0,0 +1,128 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDigestEmail(t *testing.T) {
+	template := txemail.MustParseTemplate(digestEmailTemplates)
+
+	otherRepoResult := copyResult(&commitResultMock)
+	otherRepoResult.Repo = types.MinimalRepo{ID: 2, Name: api.RepoName("github.com/test/other")}
+
+	hourly := "HOURLY"
+	email := &edb.EmailAction{Monitor: 1, Priority: "NORMAL", DigestInterval: &hourly, DigestMaxResults: 2}
+	args := actionArgs{
+		MonitorDescription: "My test monitor",
+		ExternalURL:        externalURLMock,
+		Query:              "test patternType:literal",
+	}
+
+	for _, includeResults := range []bool{false, true} {
+		name := "without results"
+		if includeResults {
+			name = "with results"
+		}
+		t.Run(name, func(t *testing.T) {
+			args := args
+			args.IncludeResults = includeResults
+			args.Results = []*result.CommitMatch{copyResult(&diffResultMock), copyResult(&commitResultMock), copyResult(otherRepoResult)}
+			data := NewTemplateDataForDigest(args, email)
+
+			t.Run("html", func(t *testing.T) {
+				var buf bytes.Buffer
+				err := template.Html.Execute(&buf, data)
+				require.NoError(t, err)
+				autogold.Equal(t, autogold.Raw(buf.String()))
+			})
+
+			t.Run("text", func(t *testing.T) {
+				var buf bytes.Buffer
+				err := template.Text.Execute(&buf, data)
+				require.NoError(t, err)
+				autogold.Equal(t, autogold.Raw(buf.String()))
+			})
+
+			t.Run("subject", func(t *testing.T) {
+				var buf bytes.Buffer
+				err := template.Subj.Execute(&buf, data)
+				require.NoError(t, err)
+				require.Equal(t, "Sourcegraph code monitor My test monitor hourly digest: 4 new results in 2 repositories", buf.String())
+			})
+		})
+	}
+}
+
+func TestHandleDigestEmail(t *testing.T) {
+	MockExternalURL = func() *url.URL { return externalURLMock }
+	t.Cleanup(func() { MockExternalURL = nil })
+
+	var sent []*TemplateDataDigest
+	MockSendEmailForDigest = func(_ context.Context, _ database.DB, _ int32, data *TemplateDataDigest) error {
+		sent = append(sent, data)
+		return nil
+	}
+	t.Cleanup(func() { MockSendEmailForDigest = nil })
+
+	emailID := int64(1)
+	userID := int32(1)
+	digestAt := time.Date(2022, 10, 20, 12, 0, 0, 0, time.UTC)
+	first := &edb.ActionJob{ID: 1, Email: &emailID, TriggerEvent: 1, DigestAt: &digestAt}
+	second := &edb.ActionJob{ID: 2, Email: &emailID, TriggerEvent: 2, DigestAt: &digestAt}
+
+	s := edb.NewMockCodeMonitorStore()
+	s.TransactFunc.SetDefaultReturn(s, nil)
+	s.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	s.ListActionJobsFunc.SetDefaultReturn([]*edb.ActionJob{first, second}, nil)
+	s.GetActionJobMetadataFunc.SetDefaultHook(func(_ context.Context, id int32) (*edb.ActionJobMetadata, error) {
+		results := []*result.CommitMatch{copyResult(&commitResultMock)}
+		if id == second.ID {
+			results = []*result.CommitMatch{copyResult(&diffResultMock)}
+		}
+		return &edb.ActionJobMetadata{Description: "My test monitor", MonitorID: 1, Query: "test", Results: results}, nil
+	})
+	s.GetEmailActionFunc.SetDefaultReturn(&edb.EmailAction{ID: emailID, Monitor: 1, DigestMaxResults: edb.DefaultDigestMaxResults}, nil)
+	s.ListRecipientsFunc.SetDefaultReturn([]*edb.Recipient{{NamespaceUserID: &userID}}, nil)
+
+	r := &actionRunner{s}
+
+	// Jobs other than the first one of the digest don't send anything.
+	require.NoError(t, r.Handle(context.Background(), logtest.Scoped(t), second))
+	require.Empty(t, sent)
+
+	// The first one sends the results of all of them.
+	require.NoError(t, r.Handle(context.Background(), logtest.Scoped(t), first))
+	require.Len(t, sent, 1)
+	require.Equal(t, 3, sent[0].TotalCount)
+	require.Len(t, sent[0].Repos, 1)
+}
+
+// copyResult copies a result, so that truncating it doesn't modify the
+// shared mocks.
+func copyResult(r *result.CommitMatch) *result.CommitMatch {
+	c := *r
+	for _, ms := range []**result.MatchedString{&c.DiffPreview, &c.MessagePreview} {
+		if *ms != nil {
+			copied := **ms
+			copied.MatchedRanges = append(result.Ranges(nil), copied.MatchedRanges...)
+			*ms = &copied
+		}
+	}
+	return &c
+}
@@ -0,0 +1,343 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// issueTracker opens and comments on issues in a repository on its code host.
+type issueTracker interface {
+	CreateIssue(ctx context.Context, title, body string, labels []string) (number int64, url string, err error)
+	// IsOpen returns false for issues that were closed or deleted.
+	IsOpen(ctx context.Context, number int64) (bool, error)
+	Comment(ctx context.Context, number int64, body string) error
+}
+
+// newIssueTracker returns an issueTracker for the repository, authenticated
+// with the token of the first external service it is synced by that has one.
+var newIssueTracker = func(ctx context.Context, db database.DB, repo *types.Repo) (issueTracker, error) {
+	switch repo.ExternalRepo.ServiceType {
+	case extsvc.TypeGitHub, extsvc.TypeGitLab:
+	default:
+		return nil, errors.Newf("opening issues is not supported for %s repositories", repo.ExternalRepo.ServiceType)
+	}
+
+	var githubAppService *types.ExternalService
+	for _, info := range repo.Sources {
+		svc, err := db.ExternalServices().GetByID(ctx, info.ExternalServiceID())
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		rawConfig, err := svc.Config.Decrypt(ctx)
+		if err != nil {
+			return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+		}
+
+		switch repo.ExternalRepo.ServiceType {
+		case extsvc.TypeGitHub:
+			var c schema.GitHubConnection
+			if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+				return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+			}
+			if c.Token == "" {
+				if c.GithubAppInstallationID != "" && githubAppService == nil {
+					githubAppService = svc
+				}
+				continue
+			}
+			return newGitHubIssueTracker(svc.URN(), &c, repo)
+
+		case extsvc.TypeGitLab:
+			var c schema.GitLabConnection
+			if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+				return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+			}
+			if c.Token == "" {
+				continue
+			}
+			return newGitLabIssueTracker(svc.URN(), &c, repo)
+		}
+	}
+
+	if githubAppService != nil {
+		return nil, errors.Newf("%s is synced by the code host connection %q, which authenticates as a GitHub App: add a token to the connection to open issues", repo.Name, githubAppService.DisplayName)
+	}
+	return nil, errors.Newf("no code host connection with a token syncs %s", repo.Name)
+}
+
+// checkIssueActionOwner returns an error unless the owner of the code monitor
+// is a site admin. Issues are opened with the token of the code host
+// connection rather than with the credentials of the owner, so issue actions
+// are restricted to site admins, who can read that token anyway.
+func checkIssueActionOwner(ctx context.Context, db database.DB, monitor *edb.Monitor) error {
+	owner, err := db.Users().GetByID(ctx, monitor.UserID)
+	if err != nil {
+		return errors.Wrap(err, "getting code monitor owner")
+	}
+	if !owner.SiteAdmin {
+		return errors.Newf("issue actions are restricted to code monitors owned by site admins, and %s is not a site admin", owner.Username)
+	}
+	return nil
+}
+
+type githubIssueTracker struct {
+	client      *github.V3Client
+	owner, name string
+}
+
+func newGitHubIssueTracker(urn string, c *schema.GitHubConnection, repo *types.Repo) (*githubIssueTracker, error) {
+	meta, ok := repo.Metadata.(*github.Repository)
+	if !ok {
+		return nil, errors.Newf("missing GitHub metadata for %s", repo.Name)
+	}
+	owner, name, err := github.SplitRepositoryNameWithOwner(meta.NameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	apiURL, _ := github.APIRoot(extsvc.NormalizeBaseURL(baseURL))
+	return &githubIssueTracker{
+		client: github.NewV3Client(log.Scoped("codeMonitorIssues", "opens issues for code monitors"), urn, apiURL, &auth.OAuthBearerToken{Token: c.Token}, httpcli.ExternalDoer),
+		owner:  owner,
+		name:   name,
+	}, nil
+}
+
+func (t *githubIssueTracker) CreateIssue(ctx context.Context, title, body string, labels []string) (int64, string, error) {
+	issue, err := t.client.CreateIssue(ctx, t.owner, t.name, title, body, labels)
+	if err != nil {
+		return 0, "", err
+	}
+	return issue.Number, issue.HTMLURL, nil
+}
+
+func (t *githubIssueTracker) IsOpen(ctx context.Context, number int64) (bool, error) {
+	issue, err := t.client.GetIssue(ctx, t.owner, t.name, number)
+	if err != nil {
+		if code := github.HTTPErrorCode(err); code == http.StatusNotFound || code == http.StatusGone {
+			return false, nil
+		}
+		return false, err
+	}
+	return issue.State == "open", nil
+}
+
+func (t *githubIssueTracker) Comment(ctx context.Context, number int64, body string) error {
+	return t.client.CreateIssueComment(ctx, t.owner, t.name, number, body)
+}
+
+type gitlabIssueTracker struct {
+	client  *gitlab.Client
+	project *gitlab.Project
+}
+
+func newGitLabIssueTracker(urn string, c *schema.GitLabConnection, repo *types.Repo) (*gitlabIssueTracker, error) {
+	project, ok := repo.Metadata.(*gitlab.Project)
+	if !ok {
+		return nil, errors.Newf("missing GitLab metadata for %s", repo.Name)
+	}
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	var a auth.Authenticator
+	switch c.TokenType {
+	case "oauth":
+		a = &auth.OAuthBearerToken{Token: c.Token}
+	default:
+		a = &gitlab.SudoableToken{Token: c.Token}
+	}
+	provider := gitlab.NewClientProvider(urn, extsvc.NormalizeBaseURL(baseURL), httpcli.ExternalDoer, nil)
+	return &gitlabIssueTracker{
+		client:  provider.GetAuthenticatorClient(a),
+		project: project,
+	}, nil
+}
+
+func (t *gitlabIssueTracker) CreateIssue(ctx context.Context, title, body string, labels []string) (int64, string, error) {
+	issue, err := t.client.CreateIssue(ctx, t.project, gitlab.CreateIssueOpts{
+		Title:       title,
+		Description: body,
+		Labels:      strings.Join(labels, ","),
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	return int64(issue.IID), issue.WebURL, nil
+}
+
+func (t *gitlabIssueTracker) IsOpen(ctx context.Context, number int64) (bool, error) {
+	issue, err := t.client.GetIssue(ctx, t.project, gitlab.ID(number))
+	if err != nil {
+		if errors.Is(err, gitlab.ErrIssueNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return issue.State == gitlab.IssueStateOpened, nil
+}
+
+func (t *gitlabIssueTracker) Comment(ctx context.Context, number int64, body string) error {
+	return t.client.CreateIssueNote(ctx, t.project, gitlab.ID(number), body)
+}
+
+// repoResults are the results of a code monitor in a single repository.
+type repoResults struct {
+	RepoID   api.RepoID
+	RepoName api.RepoName
+	Results  []*result.CommitMatch
+}
+
+// groupResultsByRepo groups the results by repository, in the order in which
+// the repositories first appear.
+func groupResultsByRepo(results []*result.CommitMatch) []repoResults {
+	var groups []repoResults
+	index := map[api.RepoID]int{}
+	for _, r := range results {
+		i, ok := index[r.Repo.ID]
+		if !ok {
+			i = len(groups)
+			index[r.Repo.ID] = i
+			groups = append(groups, repoResults{RepoID: r.Repo.ID, RepoName: r.Repo.Name})
+		}
+		groups[i].Results = append(groups[i].Results, r)
+	}
+	return groups
+}
+
+// fileIssue comments on the issue the action opened in the repository before
+// if it is still open, and opens a new issue otherwise. The action job is
+// recorded with the issue, so that the repository is skipped if the job is
+// retried because filing the results of another repository failed.
+func fileIssue(ctx context.Context, s edb.CodeMonitorStore, tracker issueTracker, action *edb.IssueAction, repoID api.RepoID, actionJobID int32, args actionArgs) error {
+	body := issueBody(args)
+
+	filing, err := s.GetIssueFiling(ctx, action.ID, repoID)
+	if err != nil {
+		return errors.Wrap(err, "GetIssueFiling")
+	}
+	if filing != nil {
+		if filing.LastActionJob != nil && *filing.LastActionJob == actionJobID {
+			return nil
+		}
+		open, err := tracker.IsOpen(ctx, filing.Number)
+		if err != nil {
+			return errors.Wrap(err, "checking issue state")
+		}
+		if open {
+			if err := tracker.Comment(ctx, filing.Number, body); err != nil {
+				return errors.Wrap(err, "commenting on issue")
+			}
+			return errors.Wrap(s.UpsertIssueFiling(ctx, action.ID, repoID, filing.Number, filing.URL, actionJobID), "UpsertIssueFiling")
+		}
+	}
+
+	number, url, err := tracker.CreateIssue(ctx, issueTitle(args), body, action.Labels)
+	if err != nil {
+		return errors.Wrap(err, "creating issue")
+	}
+	return errors.Wrap(s.UpsertIssueFiling(ctx, action.ID, repoID, number, url, actionJobID), "UpsertIssueFiling")
+}
+
+func issueTitle(args actionArgs) string {
+	return fmt.Sprintf("Sourcegraph code monitor: %s", args.MonitorDescription)
+}
+
+// issueBody renders the results of a code monitor in a single repository as
+// Markdown.
+func issueBody(args actionArgs) string {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 10)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s's Sourcegraph code monitor, **%s**, detected **%d** new matches.\n\n",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	)
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			content := ""
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+				content = result.DiffPreview.Content
+			} else if result.MessagePreview != nil {
+				content = result.MessagePreview.Content
+			}
+			fmt.Fprintf(&b, "%s match: [%s@%s](%s)\n\n",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)
+			fence := markdownFence(content)
+			fmt.Fprintf(&b, "%s\n%s%s\n\n", fence, ensureTrailingNewline(truncateString(content, 20)), fence)
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b, "...and [%d more matches](%s).\n\n", truncatedCount, getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+		}
+	} else {
+		fmt.Fprintf(&b, "[View results](%s)\n\n", getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+	}
+
+	fmt.Fprintf(&b, "If you are %s, you can [edit your code monitor](%s).\n",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)
+	return b.String()
+}
+
+var backtickRuns = regexp.MustCompile("`{3,}")
+
+// markdownFence returns a code fence longer than any run of backticks in
+// content, so that the content can't close it.
+func markdownFence(content string) string {
+	fence := "```"
+	for _, run := range backtickRuns.FindAllString(content, -1) {
+		if len(run) >= len(fence) {
+			fence = strings.Repeat("`", len(run)+1)
+		}
+	}
+	return fence
+}
+
+func ensureTrailingNewline(s string) string {
+	if strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
+
+// repoScopedQuery restricts the query of a code monitor to a single
+// repository, so that links from an issue only show the results it is about.
+func repoScopedQuery(query string, name api.RepoName) string {
+	return fmt.Sprintf("%s repo:^%s$", query, regexp.QuoteMeta(string(name)))
+}
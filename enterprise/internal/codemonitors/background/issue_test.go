@@ -0,0 +1,157 @@
+package background
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestIssueBody(t *testing.T) {
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		MonitorID:          1,
+		ExternalURL:        externalURLMock,
+		UTMSource:          "code-monitor-issue",
+		Query:              repoScopedQuery("-file:id_rsa.pub BEGIN", "github.com/test/test"),
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+	}
+
+	t.Run("without results", func(t *testing.T) {
+		autogold.Equal(t, autogold.Raw(issueBody(action)))
+	})
+
+	t.Run("with results", func(t *testing.T) {
+		action := action
+		action.IncludeResults = true
+		autogold.Equal(t, autogold.Raw(issueBody(action)))
+	})
+}
+
+func TestMarkdownFence(t *testing.T) {
+	require.Equal(t, "```", markdownFence("no backticks"))
+	require.Equal(t, "```", markdownFence("`inline` code"))
+	require.Equal(t, "````", markdownFence("```go\nfenced\n```"))
+	require.Equal(t, "``````", markdownFence("`````"))
+}
+
+func TestGroupResultsByRepo(t *testing.T) {
+	match := func(id api.RepoID) *result.CommitMatch {
+		return &result.CommitMatch{Repo: types.MinimalRepo{ID: id, Name: api.RepoName("repo")}}
+	}
+	a1, b, a2 := match(1), match(2), match(1)
+
+	groups := groupResultsByRepo([]*result.CommitMatch{a1, b, a2})
+	require.Equal(t, []repoResults{
+		{RepoID: 1, RepoName: "repo", Results: []*result.CommitMatch{a1, a2}},
+		{RepoID: 2, RepoName: "repo", Results: []*result.CommitMatch{b}},
+	}, groups)
+}
+
+type fakeIssueTracker struct {
+	open     map[int64]bool
+	created  []string
+	comments map[int64][]string
+}
+
+func (f *fakeIssueTracker) CreateIssue(_ context.Context, title, _ string, _ []string) (int64, string, error) {
+	f.created = append(f.created, title)
+	number := int64(len(f.open) + 1)
+	f.open[number] = true
+	return number, "https://github.com/test/test/issues/" + string(rune('0'+number)), nil
+}
+
+func (f *fakeIssueTracker) IsOpen(_ context.Context, number int64) (bool, error) {
+	return f.open[number], nil
+}
+
+func (f *fakeIssueTracker) Comment(_ context.Context, number int64, body string) error {
+	f.comments[number] = append(f.comments[number], body)
+	return nil
+}
+
+func TestFileIssue(t *testing.T) {
+	ctx := context.Background()
+	action := &edb.IssueAction{ID: 1, Monitor: 1, Enabled: true}
+	args := actionArgs{
+		MonitorDescription: "My test monitor",
+		ExternalURL:        externalURLMock,
+		Results:            []*result.CommitMatch{&diffResultMock},
+	}
+
+	filings := map[api.RepoID]*edb.IssueFiling{}
+	s := edb.NewMockCodeMonitorStore()
+	s.GetIssueFilingFunc.SetDefaultHook(func(_ context.Context, _ int64, repoID api.RepoID) (*edb.IssueFiling, error) {
+		return filings[repoID], nil
+	})
+	s.UpsertIssueFilingFunc.SetDefaultHook(func(_ context.Context, issueID int64, repoID api.RepoID, number int64, url string, actionJobID int32) error {
+		filings[repoID] = &edb.IssueFiling{IssueAction: issueID, RepoID: repoID, Number: number, URL: url, LastActionJob: &actionJobID}
+		return nil
+	})
+	tracker := &fakeIssueTracker{open: map[int64]bool{}, comments: map[int64][]string{}}
+
+	// The first results open an issue.
+	require.NoError(t, fileIssue(ctx, s, tracker, action, 1, 1, args))
+	require.Equal(t, []string{"Sourcegraph code monitor: My test monitor"}, tracker.created)
+	require.Equal(t, int64(1), filings[1].Number)
+
+	// Later results are added to it while it is open.
+	require.NoError(t, fileIssue(ctx, s, tracker, action, 1, 2, args))
+	require.Len(t, tracker.created, 1)
+	require.Len(t, tracker.comments[1], 1)
+
+	// A retried job doesn't comment on the issue again.
+	require.NoError(t, fileIssue(ctx, s, tracker, action, 1, 2, args))
+	require.Len(t, tracker.comments[1], 1)
+
+	// Once it is closed, a new issue is opened.
+	tracker.open[1] = false
+	require.NoError(t, fileIssue(ctx, s, tracker, action, 1, 3, args))
+	require.Len(t, tracker.created, 2)
+	require.Equal(t, int64(2), filings[1].Number)
+	require.Len(t, tracker.comments[1], 1)
+}
+
+func TestNewIssueTrackerGitHubApp(t *testing.T) {
+	externalServices := database.NewMockExternalServiceStore()
+	externalServices.GetByIDFunc.SetDefaultReturn(&types.ExternalService{
+		ID:          1,
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GitHub App",
+		Config:      extsvc.NewUnencryptedConfig(`{"url": "https://github.com", "githubAppInstallationID": "123", "repos": ["test/test"]}`),
+	}, nil)
+	db := database.NewMockDB()
+	db.ExternalServicesFunc.SetDefaultReturn(externalServices)
+
+	repo := &types.Repo{
+		Name:         "github.com/test/test",
+		ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeGitHub},
+		Sources: map[string]*types.SourceInfo{
+			"extsvc:github:1": {ID: "extsvc:github:1"},
+		},
+	}
+
+	_, err := newIssueTracker(context.Background(), db, repo)
+	require.ErrorContains(t, err, `the code host connection "GitHub App", which authenticates as a GitHub App`)
+}
+
+func TestCheckIssueActionOwner(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "user", SiteAdmin: id == 1}, nil
+	})
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	require.NoError(t, checkIssueActionOwner(context.Background(), db, &edb.Monitor{UserID: 1}))
+	require.ErrorContains(t, checkIssueActionOwner(context.Background(), db, &edb.Monitor{UserID: 2}), "restricted to code monitors owned by site admins")
+}
@@ -26,6 +26,15 @@ import (
 
 const (
 	eventRetentionInDays int = 30
+
+	// pollInterval is how often the queries of monitors are run.
+	pollInterval = 5 * time.Minute
+
+	// eventDrivenPollInterval is how often the queries of event-driven monitors
+	// are run in addition to being run when new commits are fetched, so that
+	// they don't miss commits for which no notification was sent, for example
+	// because repo-updater restarted.
+	eventDrivenPollInterval = time.Hour
 )
 
 func newTriggerQueryRunner(ctx context.Context, logger log.Logger, db edb.EnterpriseDB, metrics codeMonitorsMetrics) *workerutil.Worker {
@@ -132,6 +141,18 @@ type queryRunner struct {
 	db edb.EnterpriseDB
 }
 
+var _ workerutil.WithPreDequeue = &queryRunner{}
+
+// PreDequeue skips the trigger jobs of queries which are already being run. An
+// event-driven query is enqueued again when commits arrive during its search,
+// and the jobs must not run concurrently.
+func (r *queryRunner) PreDequeue(_ context.Context, _ log.Logger) (bool, any, error) {
+	return true, []*sqlf.Query{sqlf.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM cm_trigger_jobs running
+		WHERE running.query = cm_trigger_jobs.query AND running.state = 'processing'
+	)`)}, nil
+}
+
 func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) (err error) {
 	defer func() {
 		if err != nil {
@@ -180,7 +201,11 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, record work
 
 	// Log next_run and latest_result to table cm_queries.
 	newLatestResult := latestResultTime(q.LatestResult, results, searchErr)
-	err = s.SetQueryTriggerNextRun(ctx, q.ID, s.Clock()().Add(5*time.Minute), newLatestResult.UTC())
+	interval := pollInterval
+	if q.EventDriven {
+		interval = eventDrivenPollInterval
+	}
+	err = s.SetQueryTriggerNextRun(ctx, q.ID, s.Clock()().Add(interval), newLatestResult.UTC())
 	if err != nil {
 		return err
 	}
@@ -229,12 +254,18 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, record wor
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.Issue != nil:
+		return r.handleIssue(ctx, logger, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, or issue")
 	}
 }
 
 func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error {
+	if j.DigestAt != nil {
+		return r.handleDigestEmail(ctx, j)
+	}
+
 	s, err := r.CodeMonitorStore.Transact(ctx)
 	if err != nil {
 		return err
@@ -292,6 +323,84 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *edb.ActionJob) error
 	return nil
 }
 
+// handleDigestEmail sends the results of all jobs of the email action in
+// the same digest window in one email per recipient. The first of those jobs
+// sends the digest, and the others complete without sending anything, so
+// that it doesn't matter in which order they are dequeued.
+func (r *actionRunner) handleDigestEmail(ctx context.Context, j *edb.ActionJob) (err error) {
+	s, err := r.CodeMonitorStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = s.Done(err) }()
+
+	emailID := int(*j.Email)
+	jobs, err := s.ListActionJobs(ctx, edb.ListActionJobsOpts{EmailID: &emailID, DigestAt: j.DigestAt})
+	if err != nil {
+		return errors.Wrap(err, "ListActionJobs")
+	}
+	if len(jobs) == 0 || jobs[0].ID != j.ID {
+		return nil
+	}
+
+	// The query of the first run covers the results of all later runs.
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+	results := m.Results
+	for _, job := range jobs[1:] {
+		jm, err := s.GetActionJobMetadata(ctx, job.ID)
+		if err != nil {
+			return errors.Wrap(err, "GetActionJobMetadata")
+		}
+		results = append(results, jm.Results...)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	e, err := s.GetEmailAction(ctx, *j.Email)
+	if err != nil {
+		return errors.Wrap(err, "GetEmailAction")
+	}
+
+	recs, err := s.ListRecipients(ctx, edb.ListRecipientsOpts{EmailID: j.Email})
+	if err != nil {
+		return errors.Wrap(err, "ListRecipients")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	data := NewTemplateDataForDigest(actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          m.MonitorID,
+		ExternalURL:        externalURL,
+		UTMSource:          utmSourceEmail,
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            results,
+		IncludeResults:     e.IncludeResults,
+	}, e)
+	for _, rec := range recs {
+		if rec.NamespaceOrgID != nil {
+			// TODO (stefan): Send emails to org members.
+			continue
+		}
+		if rec.NamespaceUserID == nil {
+			return errors.New("nil recipient")
+		}
+		err = SendEmailForDigest(ctx, database.NewDBWith(log.Scoped("handleDigestEmail", ""), r.CodeMonitorStore), *rec.NamespaceUserID, data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) error {
 	s, err := r.CodeMonitorStore.Transact(ctx)
 	if err != nil {
@@ -364,6 +473,67 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+// handleIssue files the results of each repository in an issue on the code host
+// of the repository. Unlike the other actions, it doesn't run in a transaction:
+// the issues it files results in must be recorded even if filing the results of
+// another repository fails, so that the repositories which succeeded are
+// skipped when the job is retried.
+func (r *actionRunner) handleIssue(ctx context.Context, logger log.Logger, j *edb.ActionJob) error {
+	m, err := r.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	a, err := r.GetIssueAction(ctx, *j.Issue)
+	if err != nil {
+		return errors.Wrap(err, "GetIssueAction")
+	}
+
+	monitor, err := r.GetMonitor(ctx, a.Monitor)
+	if err != nil {
+		return errors.Wrap(err, "GetMonitor")
+	}
+
+	db := database.NewDBWith(logger, r.CodeMonitorStore)
+	if err := checkIssueActionOwner(ctx, db, monitor); err != nil {
+		return err
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, group := range groupResultsByRepo(m.Results) {
+		args := actionArgs{
+			MonitorDescription: m.Description,
+			MonitorID:          a.Monitor,
+			ExternalURL:        externalURL,
+			UTMSource:          "code-monitor-issue",
+			Query:              repoScopedQuery(m.Query, group.RepoName),
+			MonitorOwnerName:   m.OwnerName,
+			Results:            group.Results,
+			IncludeResults:     a.IncludeResults,
+		}
+
+		repo, err := db.Repos().Get(ctx, group.RepoID)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "getting repository %s", group.RepoName))
+			continue
+		}
+		tracker, err := newIssueTracker(ctx, db, repo)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		if err := fileIssue(ctx, r.CodeMonitorStore, tracker, a, repo.ID, j.ID, args); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "filing issue in %s", repo.Name))
+		}
+	}
+	return errs
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
@@ -0,0 +1,244 @@
+// Package repochange runs event-driven code monitors when repo-updater fetches
+// new commits for a repository.
+package repochange
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+const (
+	// queryRefreshInterval is how often the repo filters of the event-driven
+	// queries are reloaded. A monitor created or edited in between is matched
+	// against changed repositories at most this long after it was saved.
+	queryRefreshInterval = time.Minute
+
+	// changeBufferSize is the number of changed repositories which can wait to
+	// be matched. Changes beyond that are dropped; their monitors still run on
+	// the next change.
+	changeBufferSize = 1000
+)
+
+// Trigger enqueues a trigger job for each event-driven code monitor whose query
+// can match a repository that changed. It is a background routine: Start loads
+// and periodically refreshes the parsed repo filters of the queries, and
+// matches and enqueues the changes reported by HandleRepoChanged.
+type Trigger struct {
+	logger  log.Logger
+	store   edb.CodeMonitorStore
+	changes chan api.RepoName
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.RWMutex
+	queries []eventDrivenQuery
+}
+
+var _ goroutine.BackgroundRoutine = &Trigger{}
+
+func NewTrigger(logger log.Logger, store edb.CodeMonitorStore) *Trigger {
+	ctx, cancel := context.WithCancel(actor.WithInternalActor(context.Background()))
+	return &Trigger{
+		logger:  logger.Scoped("codeMonitorRepoChangeTrigger", "enqueues event-driven code monitors when repositories change"),
+		store:   store,
+		changes: make(chan api.RepoName, changeBufferSize),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// HandleRepoChanged is called after new commits were fetched for a repository.
+// It only hands the repository to the routine started by Start, so it returns
+// immediately and never blocks the caller.
+func (t *Trigger) HandleRepoChanged(_ context.Context, id api.RepoID, name api.RepoName) {
+	select {
+	case t.changes <- name:
+	default:
+		t.logger.Warn("dropping repository change, too many changes are waiting", log.Int32("repoID", int32(id)))
+	}
+}
+
+// Start refreshes the cached queries every queryRefreshInterval and enqueues the
+// queries which match changed repositories until Stop is called.
+func (t *Trigger) Start() {
+	ticker := time.NewTicker(queryRefreshInterval)
+	defer ticker.Stop()
+
+	t.refresh(t.ctx)
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			t.refresh(t.ctx)
+		case name := <-t.changes:
+			t.enqueue(t.ctx, t.drain(name))
+		}
+	}
+}
+
+func (t *Trigger) Stop() {
+	t.cancel()
+}
+
+// refresh reloads and parses the event-driven queries. On error the previous
+// queries are kept.
+func (t *Trigger) refresh(ctx context.Context) {
+	triggers, err := t.store.ListEventDrivenQueryTriggers(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			t.logger.Error("listing event-driven code monitor queries", log.Error(err))
+		}
+		return
+	}
+
+	queries := make([]eventDrivenQuery, 0, len(triggers))
+	for _, q := range triggers {
+		queries = append(queries, parseEventDrivenQuery(q.ID, q.QueryString))
+	}
+
+	t.mu.Lock()
+	t.queries = queries
+	t.mu.Unlock()
+}
+
+// drain returns name and the other changed repositories which are already
+// waiting, so that a burst of changes is enqueued at once.
+func (t *Trigger) drain(name api.RepoName) map[api.RepoName]struct{} {
+	names := map[api.RepoName]struct{}{name: {}}
+	for {
+		select {
+		case name := <-t.changes:
+			names[name] = struct{}{}
+		default:
+			return names
+		}
+	}
+}
+
+// enqueue enqueues the queries which can match any of the repositories. Jobs
+// are never enqueued for a query that is already queued or running, so a burst
+// of changes only runs a monitor once.
+func (t *Trigger) enqueue(ctx context.Context, names map[api.RepoName]struct{}) {
+	t.mu.RLock()
+	var ids []int64
+	for _, q := range t.queries {
+		for name := range names {
+			if q.matchesRepo(name) {
+				ids = append(ids, q.id)
+				break
+			}
+		}
+	}
+	t.mu.RUnlock()
+
+	if len(ids) == 0 {
+		return
+	}
+
+	jobs, err := t.store.EnqueueTriggerJobsForQueries(ctx, ids)
+	if err != nil {
+		if ctx.Err() == nil {
+			t.logger.Error("enqueuing event-driven code monitor queries", log.Int("repos", len(names)), log.Error(err))
+		}
+		return
+	}
+	t.logger.Debug("enqueued event-driven code monitor queries", log.Int("repos", len(names)), log.Int("count", len(jobs)))
+}
+
+// eventDrivenQuery holds the parsed repo filters of an event-driven query.
+type eventDrivenQuery struct {
+	id int64
+	// basics holds the repo filters of each basic query of the plan. It is nil
+	// when the query fails to parse.
+	basics []repoFilters
+}
+
+// repoFilters are the compiled repo filters of a basic query. A nil pattern is
+// a filter which failed to compile.
+type repoFilters struct {
+	include, exclude []*regexp.Regexp
+}
+
+func parseEventDrivenQuery(id int64, q string) eventDrivenQuery {
+	plan, err := query.Pipeline(query.InitRegexp(q))
+	if err != nil {
+		return eventDrivenQuery{id: id}
+	}
+
+	basics := make([]repoFilters, 0, len(plan))
+	for _, basic := range plan {
+		include, exclude := basic.ToParseTree().Repositories()
+		basics = append(basics, repoFilters{
+			include: compileRepoPatterns(include),
+			exclude: compileRepoPatterns(exclude),
+		})
+	}
+	return eventDrivenQuery{id: id, basics: basics}
+}
+
+// matchesRepo returns whether the query can match commits in the repository,
+// based on its repo filters. It errs on the side of matching: a query which
+// failed to parse or has no repo filter is considered to match, since running a
+// monitor needlessly only costs a search.
+func (q eventDrivenQuery) matchesRepo(name api.RepoName) bool {
+	if q.basics == nil {
+		return true
+	}
+	for _, basic := range q.basics {
+		if basic.matchesRepo(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f repoFilters) matchesRepo(name api.RepoName) bool {
+	for _, re := range f.exclude {
+		if matchRepoPattern(re, name) {
+			return false
+		}
+	}
+	for _, re := range f.include {
+		if !matchRepoPattern(re, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// compileRepoPatterns compiles repo filter values, which may carry a revision
+// suffix, case-insensitively like the search backend does. Invalid patterns are
+// kept as nil.
+func compileRepoPatterns(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		if i := strings.Index(pattern, "@"); i >= 0 {
+			pattern = pattern[:i]
+		}
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			re = nil
+		}
+		res = append(res, re)
+	}
+	return res
+}
+
+// matchRepoPattern matches a compiled repo filter. Invalid patterns match, for
+// the same reason as in matchesRepo.
+func matchRepoPattern(re *regexp.Regexp, name api.RepoName) bool {
+	return re == nil || re.MatchString(string(name))
+}
@@ -0,0 +1,75 @@
+package repochange
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestQueryMatchesRepo(t *testing.T) {
+	const repo = api.RepoName("github.com/sourcegraph/sourcegraph")
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "type:diff TODO", want: true},
+		{query: "repo:sourcegraph/sourcegraph$ type:diff file:^migrations/", want: true},
+		{query: "repo:SourceGraph type:commit", want: true},
+		{query: "repo:^github\\.com/sourcegraph/sourcegraph$@main type:diff", want: true},
+		{query: "repo:sourcegraph/about type:diff", want: false},
+		{query: "-repo:sourcegraph type:diff", want: false},
+		{query: "repo:sourcegraph -repo:about type:diff", want: true},
+		{query: "repo:sourcegraph repo:about type:diff", want: false},
+		{query: "(repo:about or repo:sourcegraph$) type:diff", want: true},
+		{query: "repo:( type:diff", want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.query, func(t *testing.T) {
+			if have := parseEventDrivenQuery(1, tc.query).matchesRepo(repo); have != tc.want {
+				t.Errorf("matchesRepo: have %t, want %t", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	store := edb.NewMockCodeMonitorStore()
+	store.ListEventDrivenQueryTriggersFunc.SetDefaultReturn([]*edb.QueryTrigger{
+		{ID: 1, QueryString: "repo:^github\\.com/sourcegraph/sourcegraph$ type:diff"},
+		{ID: 2, QueryString: "repo:^github\\.com/sourcegraph/about$ type:diff"},
+		{ID: 3, QueryString: "type:commit"},
+	}, nil)
+	enqueued := make(chan []int64, 1)
+	store.EnqueueTriggerJobsForQueriesFunc.SetDefaultHook(func(_ context.Context, ids []int64) ([]*edb.TriggerJob, error) {
+		enqueued <- ids
+		return nil, nil
+	})
+
+	trigger := NewTrigger(logtest.Scoped(t), store)
+	trigger.HandleRepoChanged(context.Background(), 1, "github.com/sourcegraph/sourcegraph")
+	trigger.HandleRepoChanged(context.Background(), 2, "github.com/sourcegraph/sourcegraph")
+	go trigger.Start()
+	defer trigger.Stop()
+
+	select {
+	case ids := <-enqueued:
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		if diff := cmp.Diff([]int64{1, 3}, ids); diff != "" {
+			t.Errorf("unexpected enqueued queries (-want +have):\n%s", diff)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for queries to be enqueued")
+	}
+
+	if have := len(store.ListEventDrivenQueryTriggersFunc.History()); have != 1 {
+		t.Errorf("queries loaded %d times, want once", have)
+	}
+}
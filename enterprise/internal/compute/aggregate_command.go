@@ -0,0 +1,102 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Aggregate groups the values produced by an output template and counts
+// them. Run produces the output of a single match; the counting across
+// matches is done by an Aggregator.
+type Aggregate struct {
+	Output *Output
+}
+
+func (c *Aggregate) ToSearchPattern() string {
+	return c.Output.ToSearchPattern()
+}
+
+func (c *Aggregate) String() string {
+	return fmt.Sprintf("Count by: (%s) -> (%s)", c.Output.SearchPattern.String(), c.Output.OutputPattern)
+}
+
+func (c *Aggregate) Run(ctx context.Context, db database.DB, r result.Match) (Result, error) {
+	return c.Output.Run(ctx, db, r)
+}
+
+// Aggregator counts the values of text results by group, where each group is
+// a distinct value. It is not safe for concurrent use.
+type Aggregator struct {
+	limit  int
+	counts map[string]int
+	total  int
+	dirty  bool
+}
+
+// NewAggregator returns an Aggregator whose tables contain the limit groups
+// with the highest counts. A negative limit includes all groups.
+func NewAggregator(limit int) *Aggregator {
+	return &Aggregator{limit: limit, counts: map[string]int{}}
+}
+
+// Add counts the values of r, which are separated by newlines.
+func (a *Aggregator) Add(r Result) {
+	var value string
+	switch v := r.(type) {
+	case *Text:
+		value = v.Value
+	case *TextExtra:
+		value = v.Value
+	default:
+		return
+	}
+	for _, group := range strings.Split(value, "\n") {
+		if group == "" {
+			continue
+		}
+		a.counts[group]++
+		a.total++
+		a.dirty = true
+	}
+}
+
+// Dirty returns whether values were counted since the last call to Table.
+func (a *Aggregator) Dirty() bool {
+	return a.dirty
+}
+
+// Table returns the groups counted so far, ordered by descending count and
+// then by value.
+func (a *Aggregator) Table() *Table {
+	a.dirty = false
+
+	rows := make([]TableRow, 0, len(a.counts))
+	for value, count := range a.counts {
+		rows = append(rows, TableRow{
+			Value:      value,
+			Count:      count,
+			Percentage: float64(count) * 100 / float64(a.total),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Value < rows[j].Value
+	})
+	if a.limit >= 0 && len(rows) > a.limit {
+		rows = rows[:a.limit]
+	}
+
+	return &Table{
+		Kind:       "table",
+		Rows:       rows,
+		TotalCount: a.total,
+		GroupCount: len(a.counts),
+	}
+}
@@ -0,0 +1,70 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestAggregator(t *testing.T) {
+	test := func(q string, limit int, matches ...result.Match) string {
+		computeQuery, err := Parse(q)
+		if err != nil {
+			return err.Error()
+		}
+		aggregator := NewAggregator(limit)
+		for _, m := range matches {
+			res, err := computeQuery.Command.Run(context.Background(), database.NewMockDB(), m)
+			if err != nil {
+				return err.Error()
+			}
+			aggregator.Add(res)
+		}
+		table, _ := json.Marshal(aggregator.Table())
+		return string(table)
+	}
+
+	autogold.Want(
+		"count by capture group",
+		`{"kind":"table","rows":[{"value":"b","count":3,"percentage":60},{"value":"a","count":1,"percentage":20},{"value":"c","count":1,"percentage":20}],"totalCount":5,"groupCount":3}`).
+		Equal(t, test(`content:count(x(\w) -> $1)`, -1, fileMatch("xa xb", "xb"), fileMatch("xc xb")))
+
+	autogold.Want(
+		"top N",
+		`{"kind":"table","rows":[{"value":"b","count":3,"percentage":60}],"totalCount":5,"groupCount":3}`).
+		Equal(t, test(`content:count(x(\w) -> $1)`, 1, fileMatch("xa xb", "xb"), fileMatch("xc xb")))
+
+	autogold.Want(
+		"count by metavariable",
+		`{"kind":"table","rows":[{"value":"my/awesome/repo","count":2,"percentage":100}],"totalCount":2,"groupCount":1}`).
+		Equal(t, test(`content:count(\d -> $repo)`, -1, fileMatch("1", "2")))
+
+	autogold.Want(
+		"no results",
+		`{"kind":"table","rows":[],"totalCount":0,"groupCount":0}`).
+		Equal(t, test(`content:count(x(\w) -> $1)`, -1))
+}
+
+func TestAggregator_Dirty(t *testing.T) {
+	aggregator := NewAggregator(-1)
+	if aggregator.Dirty() {
+		t.Fatal("expected new aggregator not to be dirty")
+	}
+	aggregator.Add(&Text{Value: "a\n", Kind: "output"})
+	if !aggregator.Dirty() {
+		t.Fatal("expected aggregator to be dirty after adding a value")
+	}
+	aggregator.Table()
+	if aggregator.Dirty() {
+		t.Fatal("expected aggregator not to be dirty after getting its table")
+	}
+	aggregator.Add(&Text{Value: "", Kind: "output"})
+	if aggregator.Dirty() {
+		t.Fatal("expected aggregator not to be dirty after adding no values")
+	}
+}
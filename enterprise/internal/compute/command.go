@@ -18,8 +18,12 @@ var (
 	_ Command = (*MatchOnly)(nil)
 	_ Command = (*Replace)(nil)
 	_ Command = (*Output)(nil)
+	_ Command = (*Rewrite)(nil)
+	_ Command = (*Aggregate)(nil)
 )
 
 func (MatchOnly) command() {}
 func (Replace) command()   {}
 func (Output) command()    {}
+func (Rewrite) command()   {}
+func (Aggregate) command() {}
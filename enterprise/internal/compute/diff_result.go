@@ -0,0 +1,10 @@
+package compute
+
+// Diff is a unified diff of the changes that a rewrite makes to a file.
+type Diff struct {
+	Text
+	Path         string `json:"path"`
+	Commit       string `json:"commit"`
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+}
@@ -2,6 +2,7 @@ package compute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/regexp"
 
@@ -104,6 +105,12 @@ var ComputePredicateRegistry = query.PredicateRegistry{
 		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
 		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
 		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"rewrite":            func() query.Predicate { return query.EmptyPredicate{} },
+		"rewrite.structural": func() query.Predicate { return query.EmptyPredicate{} },
+		"rewrite.regexp":     func() query.Predicate { return query.EmptyPredicate{} },
+		"count":              func() query.Predicate { return query.EmptyPredicate{} },
+		"count.regexp":       func() query.Predicate { return query.EmptyPredicate{} },
+		"count.structural":   func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
@@ -217,6 +224,117 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}, true, nil
 }
 
+func parseRewrite(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+	switch name {
+	case "rewrite", "rewrite.structural", "rewrite.regexp":
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+	left, right, err := parseArrowSyntax(args)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Parentheses, arrows and backslashes may be escaped with a backslash so
+	// that unbalanced patterns can be expressed. Regexp match patterns keep
+	// their escapes, which the regexp syntax already understands.
+	var matchPattern MatchPattern
+	if name == "rewrite.regexp" {
+		matchPattern, err = toRegexpPattern(left)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "rewrite command")
+		}
+	} else {
+		// structural search doesn't do any match pattern validation
+		matchPattern = &Comby{Value: unescapeRewritePattern(left)}
+	}
+
+	return &Rewrite{SearchPattern: matchPattern, RewritePattern: unescapeRewritePattern(right)}, true, nil
+}
+
+// unescapeRewritePattern removes the backslash in front of escaped
+// parentheses, '>' and backslashes of a rewrite command pattern. Other
+// backslashes, such as the one in "\n", are kept.
+func unescapeRewritePattern(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '\\' && i+1 < len(pattern) {
+			switch pattern[i+1] {
+			case '\\', '(', ')', '>':
+				i++
+			}
+		}
+		b.WriteByte(pattern[i])
+	}
+	return b.String()
+}
+
+func parseAggregate(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+	left, right, err := parseArrowSyntax(args)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The output kind determines how the content of matches is chunked.
+	var matchPattern MatchPattern
+	var kind string
+	switch name {
+	case "count", "count.regexp":
+		var err error
+		matchPattern, err = toRegexpPattern(left)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "count command")
+		}
+		kind = "output"
+	case "count.structural":
+		// structural search doesn't do any match pattern validation
+		matchPattern = &Comby{Value: left}
+		kind = "output.structural"
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	var typeValue string
+	query.VisitField(q.ToParseTree(), query.FieldType, func(value string, _ bool, _ query.Annotation) {
+		typeValue = value
+	})
+
+	var selector string
+	query.VisitField(q.ToParseTree(), query.FieldSelect, func(value string, _ bool, _ query.Annotation) {
+		selector = value
+	})
+
+	// Values are grouped per line of output.
+	return &Aggregate{Output: &Output{
+		SearchPattern: matchPattern,
+		OutputPattern: right,
+		Separator:     "\n",
+		TypeValue:     typeValue,
+		Selector:      selector,
+		Kind:          kind,
+	}}, true, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -260,6 +378,8 @@ func first(parsers ...commandParser) commandParser {
 var parseCommand = first(
 	parseReplace,
 	parseOutput,
+	parseRewrite,
+	parseAggregate,
 	parseMatchOnly,
 )
 
@@ -50,6 +50,30 @@ func TestParse(t *testing.T) {
 	autogold.Want("replace no left hand side",
 		"Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Want("rewrite",
+		"Command: `Rewrite: (foo(:[x], :[y])) -> (foo(:[y], :[x]))`").
+		Equal(t, test("content:rewrite(foo(:[x], :[y]) -> foo(:[y], :[x]))"))
+
+	autogold.Want("rewrite.structural",
+		"Command: `Rewrite: (a) -> (b)`").
+		Equal(t, test("content:rewrite.structural(a -> b)"))
+
+	autogold.Want("rewrite.structural escaped",
+		"Command: `Rewrite: (foo(:[x]) -> ((:[x]-> \\n)`").
+		Equal(t, test(`content:rewrite.structural(foo\(:[x] -> \(:[x]-\> \\n)`))
+
+	autogold.Want("rewrite.regexp",
+		"Command: `Rewrite: (foo\\((\\w+)) -> (bar($1)`").
+		Equal(t, test(`content:rewrite.regexp(foo\((\w+) -> bar\($1)`))
+
+	autogold.Want("count",
+		"Command: `Count by: (x(\\w)) -> ($1)`").
+		Equal(t, test(`content:count(x(\w) -> $1)`))
+
+	autogold.Want("count.structural",
+		"Command: `Count by: (foo(:[x])) -> (:[x])`").
+		Equal(t, test("content:count.structural(foo(:[x]) -> :[x])"))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -73,4 +97,12 @@ func TestToSearchQuery(t *testing.T) {
 	autogold.Want("allow expressions on search parameters (filters)",
 		"((repo:foo file:bar lang:go OR repo:foo file:bar lang:text) AND colarado)").
 		Equal(t, test("content:replace(colarado -> colorodo) repo:foo file:bar (lang:go or lang:text)"))
+
+	autogold.Want("convert rewrite to search query",
+		`repo:foo (?:fmt\.Sprintf\()(?:.|\s)*?(?:\))`).
+		Equal(t, test("content:rewrite(fmt.Sprintf(:[args]) -> fmt.Sprint(:[args])) repo:foo"))
+
+	autogold.Want("convert regexp rewrite to search query",
+		`repo:foo foo\((\w+)`).
+		Equal(t, test(`content:rewrite.regexp(foo\((\w+) -> bar($1)) repo:foo`))
 }
@@ -0,0 +1,113 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Rewrite applies a structural or regexp rewrite to the whole content of each
+// matched file and returns the changes as unified diffs, without modifying
+// anything.
+type Rewrite struct {
+	SearchPattern  MatchPattern
+	RewritePattern string
+}
+
+func (c *Rewrite) ToSearchPattern() string {
+	if p, ok := c.SearchPattern.(*Comby); ok {
+		// Search for files that may match the structural pattern. Files that
+		// don't are discarded when running comby on them.
+		return comby.StructuralPatToRegexpQuery(p.Value, false)
+	}
+	return c.SearchPattern.String()
+}
+
+func (c *Rewrite) String() string {
+	return fmt.Sprintf("Rewrite: (%s) -> (%s)", c.SearchPattern.String(), c.RewritePattern)
+}
+
+func rewrite(ctx context.Context, content []byte, path string, matchPattern MatchPattern, rewritePattern string) (string, error) {
+	switch match := matchPattern.(type) {
+	case *Regexp:
+		return rewriteRegexp(content, path, match, rewritePattern), nil
+	case *Comby:
+		return rewriteStructural(ctx, content, path, match, rewritePattern)
+	default:
+		return "", errors.Errorf("unsupported rewrite operation for match pattern %T", match)
+	}
+}
+
+func rewriteRegexp(content []byte, path string, matchPattern *Regexp, rewritePattern string) string {
+	before := string(content)
+	after := matchPattern.Value.ReplaceAllString(before, rewritePattern)
+	if after == before {
+		// The pattern doesn't match anything in the file, or the rewrite
+		// doesn't change it.
+		return ""
+	}
+	edits := myers.ComputeEdits(span.URIFromPath(path), before, after)
+	return fmt.Sprint(gotextdiff.ToUnified("a/"+path, "b/"+path, before, edits))
+}
+
+func rewriteStructural(ctx context.Context, content []byte, path string, matchPattern *Comby, rewritePattern string) (string, error) {
+	diffs, err := comby.Diffs(ctx, comby.Args{
+		Input:           comby.FileContent(content),
+		MatchTemplate:   matchPattern.Value,
+		RewriteTemplate: rewritePattern,
+		Matcher:         ".generic", // TODO: use language or file filter
+		NumWorkers:      0,          // Just a single file's content.
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(diffs) == 0 {
+		// The pattern doesn't match anything in the file.
+		return "", nil
+	}
+	// There is only one diff since we passed in comby.FileContent.
+	return withDiffHeader(diffs[0].Diff, path), nil
+}
+
+// withDiffHeader replaces the file header of a diff produced by comby, which
+// has no file name when reading from stdin, with one for path.
+func withDiffHeader(diff, path string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for len(lines) > 0 && (strings.HasPrefix(lines[0], "---") || strings.HasPrefix(lines[0], "+++")) {
+		lines = lines[1:]
+	}
+	return fmt.Sprintf("--- a/%s\n+++ b/%s\n%s", path, path, strings.Join(lines, ""))
+}
+
+func (c *Rewrite) Run(ctx context.Context, db database.DB, r result.Match) (Result, error) {
+	switch m := r.(type) {
+	case *result.FileMatch:
+		content, err := gitserver.NewClient(db).ReadFile(ctx, m.Repo.Name, m.CommitID, m.Path, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			return nil, err
+		}
+		diff, err := rewrite(ctx, content, m.Path, c.SearchPattern, c.RewritePattern)
+		if err != nil || diff == "" {
+			return nil, err
+		}
+		return &Diff{
+			Text:         Text{Value: diff, Kind: "rewrite"},
+			Path:         m.Path,
+			Commit:       string(m.CommitID),
+			RepositoryID: int32(m.Repo.ID),
+			Repository:   string(m.Repo.Name),
+		}, nil
+	}
+	return nil, nil
+}
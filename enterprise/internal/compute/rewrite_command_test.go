@@ -0,0 +1,73 @@
+package compute
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/grafana/regexp"
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/internal/comby"
+)
+
+func Test_withDiffHeader(t *testing.T) {
+	autogold.Want("replaces comby header",
+		"--- a/main.go\n+++ b/main.go\n@@ -1,1 +1,1 @@\n-foo(bar, baz)\n+foo(baz, bar)\n").
+		Equal(t, withDiffHeader("--- \n+++ \n@@ -1,1 +1,1 @@\n-foo(bar, baz)\n+foo(baz, bar)\n", "main.go"))
+
+	autogold.Want("adds missing header",
+		"--- a/main.go\n+++ b/main.go\n@@ -1,1 +1,1 @@\n-a\n+b\n").
+		Equal(t, withDiffHeader("@@ -1,1 +1,1 @@\n-a\n+b\n", "main.go"))
+}
+
+func Test_rewrite(t *testing.T) {
+	// If we are not on CI skip the test if comby is not installed.
+	if os.Getenv("CI") == "" && !comby.Exists() {
+		t.Skip("comby is not installed on the PATH. Try running 'bash <(curl -sL get.comby.dev)'.")
+	}
+
+	cmd := &Rewrite{
+		SearchPattern:  &Comby{Value: `foo(:[x], :[y])`},
+		RewritePattern: "foo(:[y], :[x])",
+	}
+
+	diff, err := rewrite(context.Background(), []byte("foo(bar, baz)\n"), "main.go", cmd.SearchPattern, cmd.RewritePattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"--- a/main.go\n+++ b/main.go\n", "-foo(bar, baz)", "+foo(baz, bar)"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("expected diff to contain %q, got %q", want, diff)
+		}
+	}
+
+	diff, err = rewrite(context.Background(), []byte("bar(baz)\n"), "main.go", cmd.SearchPattern, cmd.RewritePattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("expected no diff when nothing matches, got %q", diff)
+	}
+}
+
+func Test_rewriteRegexp(t *testing.T) {
+	matchPattern := &Regexp{Value: regexp.MustCompile(`foo\((\w+)\)`)}
+
+	diff, err := rewrite(context.Background(), []byte("a\nfoo(bar)\nb\n"), "main.go", matchPattern, "baz($1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("regexp rewrite diff",
+		"--- a/main.go\n+++ b/main.go\n@@ -1,3 +1,3 @@\n a\n-foo(bar)\n+baz(bar)\n b\n").
+		Equal(t, diff)
+
+	diff, err = rewrite(context.Background(), []byte("bar(baz)\n"), "main.go", matchPattern, "baz($1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("expected no diff when nothing matches, got %q", diff)
+	}
+}
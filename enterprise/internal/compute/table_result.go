@@ -0,0 +1,22 @@
+package compute
+
+// Table is the result of aggregating the output of a command into groups. It
+// is a snapshot of all results seen so far, so a newer table replaces an
+// older one rather than adding to it.
+type Table struct {
+	Kind string     `json:"kind"`
+	Rows []TableRow `json:"rows"`
+	// TotalCount is the number of values counted across all groups,
+	// including the groups that are not in Rows.
+	TotalCount int `json:"totalCount"`
+	// GroupCount is the number of distinct groups, which may be larger than
+	// the number of rows.
+	GroupCount int `json:"groupCount"`
+}
+
+// TableRow is the count of one group in a Table.
+type TableRow struct {
+	Value      string  `json:"value"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
@@ -17,8 +17,13 @@ type ActionJob struct {
 	Email        *int64
 	Webhook      *int64
 	SlackWebhook *int64
+	Issue        *int64
 	TriggerEvent int32
 
+	// DigestAt is the end of the digest window that the results of the job
+	// are sent in, or nil if they are sent right away.
+	DigestAt *time.Time
+
 	// Fields demanded by any dbworker.
 	State          string
 	FailureMessage *string
@@ -51,7 +56,9 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
+	sqlf.Sprintf("cm_action_jobs.issue"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
+	sqlf.Sprintf("cm_action_jobs.digest_at"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
 	sqlf.Sprintf("cm_action_jobs.started_at"),
@@ -82,6 +89,14 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// IssueID, if set, will filter to only actions jobs that are executing
+	// the given issue action. Refers to cm_issues(id)
+	IssueID *int
+
+	// DigestAt, if set, will filter to only action jobs whose results are
+	// sent in the digest at the given time.
+	DigestAt *time.Time
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -105,6 +120,12 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.IssueID != nil {
+		conds = append(conds, sqlf.Sprintf("issue = %s", *o.IssueID))
+	}
+	if o.DigestAt != nil {
+		conds = append(conds, sqlf.Sprintf("digest_at = %s", *o.DigestAt))
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -170,10 +191,24 @@ WITH due_emails AS (
 	FROM cm_emails
 	WHERE monitor = %s
 		AND enabled = true
+		AND digest_interval IS NULL
 	EXCEPT
 	SELECT DISTINCT email as id FROM cm_action_jobs
-	WHERE state = 'queued'
-		OR state = 'processing'
+	WHERE (state = 'queued' OR state = 'processing')
+		AND digest_at IS NULL
+), digest_emails AS (
+	-- Emails sent as digests get a job for every run of the trigger, which are
+	-- all due at the end of the current digest window.
+	SELECT
+		id,
+		CASE digest_interval
+			WHEN 'HOURLY' THEN date_trunc('hour', %s::timestamptz) + interval '1 hour'
+			ELSE date_trunc('day', %s::timestamptz) + interval '1 day'
+		END AS digest_at
+	FROM cm_emails
+	WHERE monitor = %s
+		AND enabled = true
+		AND digest_interval IS NOT NULL
 ), due_webhooks AS (
 	SELECT id
 	FROM cm_webhooks
@@ -192,26 +227,46 @@ WITH due_emails AS (
 	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), due_issues AS (
+	SELECT id
+	FROM cm_issues
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT issue as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, issue, trigger_event, digest_at, process_after)
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMPTZ), CAST(NULL AS TIMESTAMPTZ) from due_emails
+UNION
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, digest_at, digest_at from digest_emails
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMPTZ), CAST(NULL AS TIMESTAMPTZ) from due_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer, CAST(NULL AS TIMESTAMPTZ), CAST(NULL AS TIMESTAMPTZ) from due_slack_webhooks
+UNION
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer, CAST(NULL AS TIMESTAMPTZ), CAST(NULL AS TIMESTAMPTZ) from due_issues
+ORDER BY 1, 2, 3, 4
 RETURNING %s
 `
 
 func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, monitorID int64, triggerJobID int32) ([]*ActionJob, error) {
+	now := s.Now()
 	q := sqlf.Sprintf(
 		enqueueActionEmailFmtStr,
 		monitorID,
+		now,
+		now,
+		monitorID,
+		monitorID,
 		monitorID,
 		monitorID,
 		triggerJobID,
 		triggerJobID,
 		triggerJobID,
+		triggerJobID,
+		triggerJobID,
 		sqlf.Join(ActionJobColumns, ","),
 	)
 	rows, err := s.Query(ctx, q)
@@ -283,7 +338,9 @@ func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.Email,
 		&aj.Webhook,
 		&aj.SlackWebhook,
+		&aj.Issue,
 		&aj.TriggerEvent,
+		&aj.DigestAt,
 		&aj.State,
 		&aj.FailureMessage,
 		&aj.StartedAt,
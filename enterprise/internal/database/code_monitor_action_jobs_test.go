@@ -96,3 +96,47 @@ func TestScanActionJob(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int(actionJobID), job.RecordID())
 }
+
+func TestEnqueueDigestActionJobs(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	fixtures := s.insertTestMonitor(userCTX, t)
+
+	hourly := "HOURLY"
+	_, err := s.UpdateEmailAction(userCTX, fixtures.emails[0].ID, &EmailActionArgs{
+		Enabled:        true,
+		Priority:       "NORMAL",
+		DigestInterval: &hourly,
+	})
+	require.NoError(t, err)
+
+	wantDigestAt := s.Now().UTC().Truncate(time.Hour).Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
+		require.NoError(t, err)
+		require.Len(t, triggerJobs, 1)
+		err = s.UpdateTriggerJobWithResults(ctx, triggerJobs[0].ID, testQuery, nil)
+		require.NoError(t, err)
+
+		_, err = s.EnqueueActionJobsForMonitor(ctx, fixtures.monitor.ID, triggerJobs[0].ID)
+		require.NoError(t, err)
+		err = (&TestStore{s}).SetJobStatus(ctx, TriggerJobs, Completed, int(triggerJobs[0].ID))
+		require.NoError(t, err)
+	}
+
+	// Every run of the trigger gets a digest job, due at the end of the hour.
+	digestEmailID := int(fixtures.emails[0].ID)
+	jobs, err := s.ListActionJobs(ctx, ListActionJobsOpts{EmailID: &digestEmailID, DigestAt: &wantDigestAt})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	for _, j := range jobs {
+		require.True(t, wantDigestAt.Equal(*j.DigestAt))
+		require.True(t, wantDigestAt.Equal(*j.ProcessAfter))
+	}
+
+	// Emails that aren't digests still only have one job queued at a time.
+	emailID := int(fixtures.emails[1].ID)
+	count, err := s.CountActionJobs(ctx, ListActionJobsOpts{EmailID: &emailID})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
@@ -22,14 +22,25 @@ type EmailAction struct {
 	CreatedAt      time.Time
 	ChangedBy      int32
 	ChangedAt      time.Time
+
+	// DigestInterval is HOURLY or DAILY if results are sent in one digest per
+	// interval, and nil if they are sent after each run of the trigger.
+	DigestInterval   *string
+	DigestMaxResults int32
 }
 
+// DefaultDigestMaxResults is the number of results included in a digest if
+// the email action doesn't set it.
+const DefaultDigestMaxResults = 50
+
 const updateActionEmailFmtStr = `
 UPDATE cm_emails
 SET enabled = %s,
     include_results = %s,
 	priority = %s,
 	header = %s,
+	digest_interval = %s,
+	digest_max_results = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -43,10 +54,19 @@ RETURNING %s;
 `
 
 type EmailActionArgs struct {
-	Enabled        bool
-	IncludeResults bool
-	Priority       string
-	Header         string
+	Enabled          bool
+	IncludeResults   bool
+	Priority         string
+	Header           string
+	DigestInterval   *string
+	DigestMaxResults int32
+}
+
+func (a *EmailActionArgs) digestMaxResults() int32 {
+	if a.DigestMaxResults <= 0 {
+		return DefaultDigestMaxResults
+	}
+	return a.DigestMaxResults
 }
 
 func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args *EmailActionArgs) (*EmailAction, error) {
@@ -57,6 +77,8 @@ func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args
 		args.IncludeResults,
 		args.Priority,
 		args.Header,
+		args.DigestInterval,
+		args.digestMaxResults(),
 		a.UID,
 		s.Now(),
 		id,
@@ -70,8 +92,8 @@ func (s *codeMonitorStore) UpdateEmailAction(ctx context.Context, id int64, args
 
 const createActionEmailFmtStr = `
 INSERT INTO cm_emails
-(monitor, enabled, include_results, priority, header, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, priority, header, digest_interval, digest_max_results, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
@@ -85,6 +107,8 @@ func (s *codeMonitorStore) CreateEmailAction(ctx context.Context, monitorID int6
 		args.IncludeResults,
 		args.Priority,
 		args.Header,
+		args.DigestInterval,
+		args.digestMaxResults(),
 		a.UID,
 		now,
 		a.UID,
@@ -206,6 +230,8 @@ var emailsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_emails.created_at"),
 	sqlf.Sprintf("cm_emails.changed_by"),
 	sqlf.Sprintf("cm_emails.changed_at"),
+	sqlf.Sprintf("cm_emails.digest_interval"),
+	sqlf.Sprintf("cm_emails.digest_max_results"),
 }
 
 func scanEmails(rows *sql.Rows) ([]*EmailAction, error) {
@@ -235,6 +261,8 @@ func scanEmail(scanner dbutil.Scanner) (*EmailAction, error) {
 		&m.CreatedAt,
 		&m.ChangedBy,
 		&m.ChangedAt,
+		&m.DigestInterval,
+		&m.DigestMaxResults,
 	)
 	return m, err
 }
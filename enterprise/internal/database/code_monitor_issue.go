@@ -0,0 +1,280 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// IssueAction opens an issue on the code host of each repository with new
+// results, or comments on the issue it opened before if it is still open.
+type IssueAction struct {
+	ID             int64
+	Monitor        int64
+	Enabled        bool
+	IncludeResults bool
+	Labels         []string
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+// IssueFiling is the issue an IssueAction opened in a repository.
+type IssueFiling struct {
+	IssueAction int64
+	RepoID      api.RepoID
+	Number      int64
+	URL         string
+
+	// LastActionJob is the last action job that filed results in the issue.
+	LastActionJob *int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+const updateIssueActionQuery = `
+UPDATE cm_issues
+SET enabled = %s,
+	include_results = %s,
+	labels = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = cm_issues.monitor
+			AND cm_monitors.namespace_user_id = %s
+	)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) UpdateIssueAction(ctx context.Context, id int64, enabled, includeResults bool, labels []string) (*IssueAction, error) {
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateIssueActionQuery,
+		enabled,
+		includeResults,
+		pq.Array(nonNilLabels(labels)),
+		a.UID,
+		s.Now(),
+		id,
+		a.UID,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const createIssueActionQuery = `
+INSERT INTO cm_issues
+(monitor, enabled, include_results, labels, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateIssueAction(ctx context.Context, monitorID int64, enabled, includeResults bool, labels []string) (*IssueAction, error) {
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createIssueActionQuery,
+		monitorID,
+		enabled,
+		includeResults,
+		pq.Array(nonNilLabels(labels)),
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+// nonNilLabels appeases the non-null constraint on cm_issues.labels.
+func nonNilLabels(labels []string) []string {
+	if labels == nil {
+		return []string{}
+	}
+	return labels
+}
+
+const deleteIssueActionQuery = `
+DELETE FROM cm_issues
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteIssueActions(ctx context.Context, monitorID int64, issueIDs ...int64) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(issueIDs))
+	for _, ids := range issueIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", ids))
+	}
+	q := sqlf.Sprintf(
+		deleteIssueActionQuery,
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countIssueActionsQuery = `
+SELECT COUNT(*)
+FROM cm_issues
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountIssueActions(ctx context.Context, monitorID int64) (int, error) {
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countIssueActionsQuery, monitorID)).Scan(&count)
+	return count, err
+}
+
+const getIssueActionQuery = `
+SELECT %s -- IssueActionColumns
+FROM cm_issues
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetIssueAction(ctx context.Context, issueID int64) (*IssueAction, error) {
+	q := sqlf.Sprintf(
+		getIssueActionQuery,
+		sqlf.Join(issueActionColumns, ","),
+		issueID,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const listIssueActionsQuery = `
+SELECT %s -- IssueActionColumns
+FROM cm_issues
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListIssueActions(ctx context.Context, opts ListActionsOpts) ([]*IssueAction, error) {
+	q := sqlf.Sprintf(
+		listIssueActionsQuery,
+		sqlf.Join(issueActionColumns, ","),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanIssueActions(rows)
+}
+
+const getIssueFilingQuery = `
+SELECT issue_action, repo_id, number, url, last_action_job, created_at, updated_at
+FROM cm_issue_filings
+WHERE issue_action = %s
+	AND repo_id = %s
+`
+
+// GetIssueFiling returns the issue the issue action opened in the repository,
+// or nil if it never opened one.
+func (s *codeMonitorStore) GetIssueFiling(ctx context.Context, issueID int64, repoID api.RepoID) (*IssueFiling, error) {
+	var f IssueFiling
+	err := s.QueryRow(ctx, sqlf.Sprintf(getIssueFilingQuery, issueID, int32(repoID))).Scan(
+		&f.IssueAction,
+		&f.RepoID,
+		&f.Number,
+		&f.URL,
+		&f.LastActionJob,
+		&f.CreatedAt,
+		&f.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &f, nil
+}
+
+const upsertIssueFilingQuery = `
+INSERT INTO cm_issue_filings (issue_action, repo_id, number, url, last_action_job, created_at, updated_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (issue_action, repo_id) DO UPDATE
+SET number = EXCLUDED.number,
+	url = EXCLUDED.url,
+	last_action_job = EXCLUDED.last_action_job,
+	updated_at = EXCLUDED.updated_at
+`
+
+// UpsertIssueFiling records the issue the issue action opened or commented on
+// in the repository for the given action job, replacing the one it opened
+// before.
+func (s *codeMonitorStore) UpsertIssueFiling(ctx context.Context, issueID int64, repoID api.RepoID, number int64, url string, actionJobID int32) error {
+	now := s.Now()
+	return s.Exec(ctx, sqlf.Sprintf(upsertIssueFilingQuery, issueID, int32(repoID), number, url, actionJobID, now, now))
+}
+
+// issueActionColumns is the set of columns in the cm_issues table
+// This must be kept in sync with scanIssueAction
+var issueActionColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_issues.id"),
+	sqlf.Sprintf("cm_issues.monitor"),
+	sqlf.Sprintf("cm_issues.enabled"),
+	sqlf.Sprintf("cm_issues.include_results"),
+	sqlf.Sprintf("cm_issues.labels"),
+	sqlf.Sprintf("cm_issues.created_by"),
+	sqlf.Sprintf("cm_issues.created_at"),
+	sqlf.Sprintf("cm_issues.changed_by"),
+	sqlf.Sprintf("cm_issues.changed_at"),
+}
+
+func scanIssueActions(rows *sql.Rows) ([]*IssueAction, error) {
+	var is []*IssueAction
+	for rows.Next() {
+		i, err := scanIssueAction(rows)
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, i)
+	}
+	return is, rows.Err()
+}
+
+// scanIssueAction scans an IssueAction from a *sql.Row or *sql.Rows.
+// It must be kept in sync with issueActionColumns.
+func scanIssueAction(scanner dbutil.Scanner) (*IssueAction, error) {
+	var i IssueAction
+	err := scanner.Scan(
+		&i.ID,
+		&i.Monitor,
+		&i.Enabled,
+		&i.IncludeResults,
+		pq.Array(&i.Labels),
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ChangedBy,
+		&i.ChangedAt,
+	)
+	return &i, err
+}
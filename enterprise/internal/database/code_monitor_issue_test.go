@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreIssues(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+
+	t.Run("CreateUpdateGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{}, action.Labels)
+
+		updated, err := s.UpdateIssueAction(ctx, action.ID, true, true, []string{"security"})
+		require.NoError(t, err)
+		require.Equal(t, true, updated.IncludeResults)
+		require.Equal(t, []string{"security"}, updated.Labels)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, updated, got)
+	})
+
+	t.Run("CreateDeleteCount", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action1, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, nil)
+		require.NoError(t, err)
+		action2, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, true, false, nil)
+		require.NoError(t, err)
+
+		err = s.DeleteIssueActions(ctx, fixtures.monitor.ID, action1.ID)
+		require.NoError(t, err)
+
+		count, err := s.CountIssueActions(ctx, fixtures.monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		got, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Equal(t, []*IssueAction{action2}, got)
+	})
+
+	t.Run("Filings", func(t *testing.T) {
+		t.Parallel()
+
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		ctx := actor.WithActor(ctx, actor.FromUser(fixtures.User.ID))
+		cm := db.CodeMonitors()
+
+		action, err := cm.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, nil)
+		require.NoError(t, err)
+
+		filing, err := cm.GetIssueFiling(ctx, action.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Nil(t, filing)
+
+		err = cm.UpsertIssueFiling(ctx, action.ID, fixtures.Repo.ID, 1, "https://github.com/test/test/issues/1", 1)
+		require.NoError(t, err)
+		err = cm.UpsertIssueFiling(ctx, action.ID, fixtures.Repo.ID, 2, "https://github.com/test/test/issues/2", 2)
+		require.NoError(t, err)
+
+		filing, err = cm.GetIssueFiling(ctx, action.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), filing.Number)
+		require.Equal(t, "https://github.com/test/test/issues/2", filing.URL)
+		require.Equal(t, int32(2), *filing.LastActionJob)
+	})
+}
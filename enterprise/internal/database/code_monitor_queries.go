@@ -20,6 +20,10 @@ type QueryTrigger struct {
 	CreatedAt    time.Time
 	ChangedBy    int32
 	ChangedAt    time.Time
+
+	// EventDriven queries are run as soon as new commits are fetched for a
+	// repository they can match, and only polled as a fallback.
+	EventDriven bool
 }
 
 // queryColumns is the set of columns in cm_queries
@@ -34,16 +38,17 @@ var queryColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_queries.created_at"),
 	sqlf.Sprintf("cm_queries.changed_by"),
 	sqlf.Sprintf("cm_queries.changed_at"),
+	sqlf.Sprintf("cm_queries.event_driven"),
 }
 
 const createTriggerQueryFmtStr = `
 INSERT INTO cm_queries
-(monitor, query, created_by, created_at, changed_by, changed_at, next_run, latest_result)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, query, created_by, created_at, changed_by, changed_at, next_run, latest_result, event_driven)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int64, query string) (*QueryTrigger, error) {
+func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int64, query string, eventDriven bool) (*QueryTrigger, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
@@ -56,6 +61,7 @@ func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int
 		now,
 		now,
 		now,
+		eventDriven,
 		sqlf.Join(queryColumns, ", "),
 	)
 	row := s.QueryRow(ctx, q)
@@ -67,7 +73,8 @@ UPDATE cm_queries
 SET query = %s,
 	changed_by = %s,
 	changed_at = %s,
-	latest_result = %s
+	latest_result = %s,
+	event_driven = %s
 WHERE
 	id = %s
 	AND EXISTS (
@@ -78,7 +85,7 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateQueryTrigger(ctx context.Context, id int64, query string) error {
+func (s *codeMonitorStore) UpdateQueryTrigger(ctx context.Context, id int64, query string, eventDriven bool) error {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
@@ -87,6 +94,7 @@ func (s *codeMonitorStore) UpdateQueryTrigger(ctx context.Context, id int64, que
 		a.UID,
 		now,
 		now,
+		eventDriven,
 		id,
 		a.UID,
 		sqlf.Join(queryColumns, ", "),
@@ -157,6 +165,39 @@ func (s *codeMonitorStore) SetQueryTriggerNextRun(ctx context.Context, triggerQu
 	return s.Exec(ctx, q)
 }
 
+const listEventDrivenQueryTriggersFmtStr = `
+SELECT %s -- queryColumns
+FROM cm_queries
+INNER JOIN cm_monitors ON cm_queries.monitor = cm_monitors.id
+WHERE cm_queries.event_driven = true
+AND cm_monitors.enabled = true
+ORDER BY cm_queries.id
+`
+
+// ListEventDrivenQueryTriggers returns the event-driven query triggers of all
+// the enabled monitors.
+func (s *codeMonitorStore) ListEventDrivenQueryTriggers(ctx context.Context) ([]*QueryTrigger, error) {
+	q := sqlf.Sprintf(
+		listEventDrivenQueryTriggersFmtStr,
+		sqlf.Join(queryColumns, ","),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var qs []*QueryTrigger
+	for rows.Next() {
+		q, err := scanTriggerQuery(rows)
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+	}
+	return qs, rows.Err()
+}
+
 // scanQueryTrigger scans a *sql.Rows or *sql.Row into a MonitorQuery
 // It must be kept in sync with queryColumns
 func scanTriggerQuery(scanner dbutil.Scanner) (*QueryTrigger, error) {
@@ -171,6 +212,7 @@ func scanTriggerQuery(scanner dbutil.Scanner) (*QueryTrigger, error) {
 		&m.CreatedAt,
 		&m.ChangedBy,
 		&m.ChangedAt,
+		&m.EventDriven,
 	)
 	return m, err
 }
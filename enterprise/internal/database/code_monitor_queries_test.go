@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/keegancsmith/sqlf"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -68,11 +69,11 @@ func TestUpdateTrigger(t *testing.T) {
 	_ = s.insertTestMonitor(ctx2, t)
 
 	// User1 can update it
-	err := s.UpdateQueryTrigger(ctx1, fixtures.query.ID, "query1")
+	err := s.UpdateQueryTrigger(ctx1, fixtures.query.ID, "query1", false)
 	require.NoError(t, err)
 
 	// User2 cannot update it
-	err = s.UpdateQueryTrigger(ctx2, fixtures.query.ID, "query2")
+	err = s.UpdateQueryTrigger(ctx2, fixtures.query.ID, "query2", false)
 	require.Error(t, err)
 
 	qt, err := s.GetQueryTriggerForMonitor(ctx1, fixtures.query.ID)
@@ -105,3 +106,45 @@ func TestResetTriggerQueryTimestamps(t *testing.T) {
 
 	require.Equal(t, want, got)
 }
+
+func TestEventDrivenQueryTriggers(t *testing.T) {
+	ctx, db, s := newTestStore(t)
+	_, _, userCTX := newTestUser(ctx, t, db)
+	polled := s.insertTestMonitor(userCTX, t)
+	eventDriven := s.insertTestMonitor(userCTX, t)
+
+	err := s.UpdateQueryTrigger(userCTX, eventDriven.query.ID, eventDriven.query.QueryString, true)
+	require.NoError(t, err)
+
+	qs, err := s.ListEventDrivenQueryTriggers(ctx)
+	require.NoError(t, err)
+	require.Len(t, qs, 1)
+	require.Equal(t, eventDriven.query.ID, qs[0].ID)
+	require.True(t, qs[0].EventDriven)
+
+	// Jobs are enqueued for the given queries only, and only once while a
+	// job is queued.
+	jobs, err := s.EnqueueTriggerJobsForQueries(ctx, []int64{eventDriven.query.ID})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, eventDriven.query.ID, jobs[0].Query)
+
+	jobs, err = s.EnqueueTriggerJobsForQueries(ctx, []int64{eventDriven.query.ID, polled.query.ID})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, polled.query.ID, jobs[0].Query)
+
+	// A job is enqueued again while the previous one is processing.
+	err = s.Exec(ctx, sqlf.Sprintf("UPDATE cm_trigger_jobs SET state = 'processing' WHERE query = %s", eventDriven.query.ID))
+	require.NoError(t, err)
+	jobs, err = s.EnqueueTriggerJobsForQueries(ctx, []int64{eventDriven.query.ID})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	// Disabled monitors are ignored.
+	_, err = s.UpdateMonitorEnabled(userCTX, eventDriven.monitor.ID, false)
+	require.NoError(t, err)
+	qs, err = s.ListEventDrivenQueryTriggers(ctx)
+	require.NoError(t, err)
+	require.Len(t, qs, 0)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
@@ -62,6 +63,39 @@ func (s *codeMonitorStore) EnqueueQueryTriggerJobs(ctx context.Context) ([]*Trig
 	return scanTriggerJobs(rows)
 }
 
+const enqueueTriggerQueriesFmtStr = `
+WITH due AS (
+    SELECT cm_queries.id as id
+    FROM cm_queries INNER JOIN cm_monitors ON cm_queries.monitor = cm_monitors.id
+    WHERE cm_queries.id = ANY(%s)
+    AND cm_monitors.enabled = true
+),
+busy AS (
+    SELECT DISTINCT query as id FROM cm_trigger_jobs
+    WHERE state = 'queued'
+)
+INSERT INTO cm_trigger_jobs (query)
+SELECT id from due EXCEPT SELECT id from busy ORDER BY id
+RETURNING %s
+`
+
+// EnqueueTriggerJobsForQueries enqueues trigger jobs for the given queries of
+// enabled monitors, regardless of when they are due to run next. Queries which
+// already have a queued job are skipped. Queries with a processing job are
+// enqueued again so that the commits which arrived during the search are found,
+// the trigger worker only dequeues the new job once the running one finished.
+func (s *codeMonitorStore) EnqueueTriggerJobsForQueries(ctx context.Context, queryIDs []int64) ([]*TriggerJob, error) {
+	if len(queryIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := s.Store.Query(ctx, sqlf.Sprintf(enqueueTriggerQueriesFmtStr, pq.Array(queryIDs), sqlf.Join(TriggerJobsColumns, ",")))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTriggerJobs(rows)
+}
+
 const logSearchFmtStr = `
 UPDATE cm_trigger_jobs
 SET query_string = %s,
@@ -2,16 +2,19 @@ package aggregation
 
 import (
 	"context"
+	"database/sql"
 	"regexp"
 	"sync"
 	"time"
 
-	"github.com/go-enry/go-enry/v2"
-
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	sgapi "github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/search/codeownership"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
@@ -56,7 +59,7 @@ func countLang(r result.Match) (map[MatchKey]int, error) {
 	var lang string
 	switch match := r.(type) {
 	case *result.FileMatch:
-		lang, _ = enry.GetLanguageByExtension(match.Path)
+		lang, _ = inventory.GetLanguageByFilename(match.Path)
 	default:
 	}
 	if lang != "" {
@@ -103,6 +106,73 @@ func countAuthor(r result.Match) (map[MatchKey]int, error) {
 	return nil, nil
 }
 
+func countOwnersFunc(ctx context.Context, client gitserver.Client) AggregationCountFunc {
+	rules := codeownership.NewRulesCache()
+	return func(r result.Match) (map[MatchKey]int, error) {
+		// Code ownership is only available for files.
+		match, ok := r.(*result.FileMatch)
+		if !ok {
+			return nil, nil
+		}
+
+		ruleset, err := rules.GetFromCacheOrFetch(ctx, client, match.Repo.Name, match.CommitID)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading code owners")
+		}
+		owners, err := ruleset.Match(match.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "matching code owners")
+		}
+
+		if len(owners) == 0 {
+			return nil, nil
+		}
+		matches := make(map[MatchKey]int, len(owners))
+		for _, owner := range owners {
+			matches[MatchKey{
+				RepoID: int32(r.RepoName().ID),
+				Repo:   string(r.RepoName().Name),
+				Group:  owner.String(),
+			}] = r.ResultCount()
+		}
+		return matches, nil
+	}
+}
+
+// RepoMetadataStore is the subset of database.RepoKVPStore used to look up
+// repository metadata.
+type RepoMetadataStore interface {
+	Get(ctx context.Context, repoID sgapi.RepoID, key string) (database.KeyValuePair, error)
+}
+
+func countRepoMetadataFunc(ctx context.Context, store RepoMetadataStore, key string) AggregationCountFunc {
+	// Results are counted one event at a time, so the cache doesn't need to be
+	// synchronized.
+	values := make(map[sgapi.RepoID]*string)
+	return func(r result.Match) (map[MatchKey]int, error) {
+		repo := r.RepoName()
+		value, ok := values[repo.ID]
+		if !ok {
+			kvp, err := store.Get(ctx, repo.ID, key)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return nil, errors.Wrap(err, "getting repository metadata")
+			}
+			value = kvp.Value
+			values[repo.ID] = value
+		}
+
+		// Repositories without a value for the key can't be grouped.
+		if value == nil || *value == "" {
+			return nil, nil
+		}
+		return map[MatchKey]int{{
+			RepoID: int32(repo.ID),
+			Repo:   string(repo.Name),
+			Group:  *value,
+		}: r.ResultCount()}, nil
+	}
+}
+
 func countCaptureGroupsFunc(querystring string) (AggregationCountFunc, error) {
 	pattern, err := getCasedPattern(querystring)
 	if err != nil {
@@ -166,19 +236,46 @@ func matchContent(event result.Match) []string {
 	}
 }
 
-func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
+// ModeDependencies holds what is needed by the aggregation modes that group
+// results by data that isn't part of the search results themselves.
+type ModeDependencies struct {
+	// Gitserver is used to read the CODEOWNERS files of OWNER aggregations.
+	Gitserver gitserver.Client
+	// RepoMetadata is used to look up the repository metadata of REPO_METADATA
+	// aggregations.
+	RepoMetadata RepoMetadataStore
+	// RepoMetadataKey is the key of the repository metadata REPO_METADATA
+	// aggregations group by.
+	RepoMetadataKey string
+}
+
+func GetCountFuncForMode(ctx context.Context, query, patternType string, mode types.SearchAggregationMode, deps ModeDependencies) (AggregationCountFunc, error) {
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
-		types.REPO_AGGREGATION_MODE:   countRepo,
-		types.PATH_AGGREGATION_MODE:   countPath,
-		types.AUTHOR_AGGREGATION_MODE: countAuthor,
+		types.REPO_AGGREGATION_MODE:     countRepo,
+		types.PATH_AGGREGATION_MODE:     countPath,
+		types.AUTHOR_AGGREGATION_MODE:   countAuthor,
+		types.LANGUAGE_AGGREGATION_MODE: countLang,
 	}
 
-	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
+	switch mode {
+	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		captureGroupsCount, err := countCaptureGroupsFunc(query)
 		if err != nil {
 			return nil, err
 		}
 		modeCountTypes[types.CAPTURE_GROUP_AGGREGATION_MODE] = captureGroupsCount
+
+	case types.OWNER_AGGREGATION_MODE:
+		if deps.Gitserver == nil {
+			return nil, errors.New("grouping by owner requires a gitserver client")
+		}
+		modeCountTypes[types.OWNER_AGGREGATION_MODE] = countOwnersFunc(ctx, deps.Gitserver)
+
+	case types.REPO_METADATA_AGGREGATION_MODE:
+		if deps.RepoMetadata == nil || deps.RepoMetadataKey == "" {
+			return nil, errors.New("grouping by repository metadata requires a metadata key")
+		}
+		modeCountTypes[types.REPO_METADATA_AGGREGATION_MODE] = countRepoMetadataFunc(ctx, deps.RepoMetadata, deps.RepoMetadataKey)
 	}
 
 	modeCountFunc, ok := modeCountTypes[mode]
@@ -2,6 +2,8 @@ package aggregation
 
 import (
 	"context"
+	"database/sql"
+	"os"
 	"testing"
 	"time"
 
@@ -9,6 +11,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -240,7 +245,7 @@ func TestRepoAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, ModeDependencies{})
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -302,7 +307,7 @@ func TestAuthorAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, ModeDependencies{})
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -399,7 +404,7 @@ func TestPathAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			countFunc, _ := GetCountFuncForMode(context.Background(), "", "", tc.mode, ModeDependencies{})
 			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
 			sra.Send(tc.searchEvent)
 			tc.want.Equal(t, aggregator.results)
@@ -588,7 +593,7 @@ func TestCaptureGroupAggregation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(context.Background(), tc.query, "regexp", tc.mode, ModeDependencies{})
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
@@ -624,7 +629,7 @@ func TestAggregationCancelation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.want.Name(), func(t *testing.T) {
 			aggregator := testAggregator{results: make(map[string]int)}
-			countFunc, err := GetCountFuncForMode(tc.query, "regexp", tc.mode)
+			countFunc, err := GetCountFuncForMode(context.Background(), tc.query, "regexp", tc.mode, ModeDependencies{})
 			if err != nil {
 				t.Errorf("expected test not to error, got %v", err)
 				t.FailNow()
@@ -641,3 +646,163 @@ func TestAggregationCancelation(t *testing.T) {
 		})
 	}
 }
+
+func TestLanguageAggregation(t *testing.T) {
+	testCases := []struct {
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					pathMatch("myRepo", "cmd/main.go", 1),
+					contentMatch("myRepo", "client/app.tsx", 1, "a", "b"),
+					contentMatch("myRepo2", "internal/store.go", 2, "a"),
+				},
+			},
+			autogold.Want("groups files by language", map[string]int{"Go": 2, "TypeScript": 2}),
+		},
+		{
+			types.LANGUAGE_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+					diffMatch("myRepo", "author-a", 1),
+				},
+			},
+			autogold.Want("ignores results without a file", map[string]int{}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, err := GetCountFuncForMode(context.Background(), "", "", tc.mode, ModeDependencies{})
+			if err != nil {
+				t.Fatalf("expected test not to error, got %v", err)
+			}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestOwnerAggregation(t *testing.T) {
+	client := gitserver.NewMockClient()
+	client.ReadFileFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if repo == "myRepo" && name == "CODEOWNERS" {
+			return []byte("*.go @team-go\n/client/ @team-frontend @alice\n"), nil
+		}
+		return nil, os.ErrNotExist
+	})
+
+	testCases := []struct {
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					pathMatch("myRepo", "cmd/main.go", 1),
+					contentMatch("myRepo", "client/app.tsx", 1, "a", "b"),
+					contentMatch("myRepo", "README.md", 1, "a"),
+				},
+			},
+			autogold.Want("groups files by owner", map[string]int{"@team-go": 1, "@team-frontend": 2, "@alice": 2}),
+		},
+		{
+			streaming.SearchEvent{
+				Results: []result.Match{
+					pathMatch("myRepo2", "cmd/main.go", 2),
+					repoMatch("myRepo", 1),
+				},
+			},
+			autogold.Want("ignores unowned files and non file results", map[string]int{}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, err := GetCountFuncForMode(context.Background(), "", "", types.OWNER_AGGREGATION_MODE, ModeDependencies{Gitserver: client})
+			if err != nil {
+				t.Fatalf("expected test not to error, got %v", err)
+			}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+
+	if _, err := GetCountFuncForMode(context.Background(), "", "", types.OWNER_AGGREGATION_MODE, ModeDependencies{}); err == nil {
+		t.Error("expected an error without a gitserver client")
+	}
+}
+
+type testRepoMetadataStore map[api.RepoID]map[string]string
+
+func (s testRepoMetadataStore) Get(_ context.Context, repoID api.RepoID, key string) (database.KeyValuePair, error) {
+	value, ok := s[repoID][key]
+	if !ok {
+		return database.KeyValuePair{}, sql.ErrNoRows
+	}
+	return database.KeyValuePair{Key: key, Value: &value}, nil
+}
+
+func TestRepoMetadataAggregation(t *testing.T) {
+	store := testRepoMetadataStore{
+		1: {"team": "search", "tier": "1"},
+		2: {"team": "insights"},
+		3: {"team": ""},
+	}
+
+	testCases := []struct {
+		key         string
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{
+			"team",
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+					contentMatch("myRepo", "file.go", 1, "a", "b"),
+					pathMatch("myRepo2", "file.go", 2),
+					pathMatch("myRepo3", "file.go", 3),
+					pathMatch("myRepo4", "file.go", 4),
+				},
+			},
+			autogold.Want("groups results by metadata value", map[string]int{"search": 3, "insights": 1}),
+		},
+		{
+			"tier",
+			streaming.SearchEvent{
+				Results: []result.Match{
+					repoMatch("myRepo", 1),
+					repoMatch("myRepo2", 2),
+				},
+			},
+			autogold.Want("ignores repositories without the key", map[string]int{"1": 1}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want.Name(), func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, err := GetCountFuncForMode(context.Background(), "", "", types.REPO_METADATA_AGGREGATION_MODE, ModeDependencies{RepoMetadata: store, RepoMetadataKey: tc.key})
+			if err != nil {
+				t.Fatalf("expected test not to error, got %v", err)
+			}
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+
+	if _, err := GetCountFuncForMode(context.Background(), "", "", types.REPO_METADATA_AGGREGATION_MODE, ModeDependencies{RepoMetadata: store}); err == nil {
+		t.Error("expected an error without a metadata key")
+	}
+}
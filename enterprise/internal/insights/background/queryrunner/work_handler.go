@@ -33,8 +33,15 @@ type workHandler struct {
 	seriesCache map[string]*types.InsightSeries
 
 	searchHandlers map[types.GenerationMethod]InsightsHandler
+
+	// seriesRecorded, if set, is called once the points of a recording enqueued by the insight enqueuer have
+	// been persisted.
+	seriesRecorded SeriesRecordedFunc
 }
 
+// SeriesRecordedFunc is called with the ID of a series once new points have been recorded for it.
+type SeriesRecordedFunc func(ctx context.Context, seriesID string) error
+
 type InsightsHandler func(ctx context.Context, job *Job, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error)
 
 func (r *workHandler) getSeries(ctx context.Context, seriesID string) (*types.InsightSeries, error) {
@@ -110,5 +117,17 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record work
 	if err != nil {
 		return err
 	}
-	return r.persistRecordings(ctx, job, series, recordings)
+	if err := r.persistRecordings(ctx, job, series, recordings); err != nil {
+		return err
+	}
+
+	// Jobs with a record time are backfilling historical points, only the recordings enqueued by the insight
+	// enqueuer add the latest point of a series.
+	if r.seriesRecorded != nil && store.PersistMode(job.PersistMode) == store.RecordMode && job.RecordTime == nil {
+		// The points are already persisted, so a failure must not fail the job and run the search again.
+		if err := r.seriesRecorded(ctx, series.SeriesID); err != nil {
+			r.logger.Error("insights.queryrunner.workHandler.seriesRecorded", log.String("seriesID", series.SeriesID), log.Error(err))
+		}
+	}
+	return nil
 }
@@ -0,0 +1,137 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/slack-go/slack"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// seriesAlertNotifier sends series alerts through the channels configured on them with the sender shared with
+// code monitors, notifying the creator of the alert by email.
+type seriesAlertNotifier struct {
+	db database.DB
+}
+
+func newSeriesAlertNotifier(db database.DB) *seriesAlertNotifier {
+	return &seriesAlertNotifier{db: db}
+}
+
+var seriesAlertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Code Insights alert: {{.Series}} on {{.Insight}}`,
+	Text: `
+{{.Message}}
+
+View the insight:
+
+  {{.URL}}
+`,
+	HTML: `
+<p>{{.Message}}</p>
+
+<p><a href="{{.URL}}">View the insight</a></p>
+`,
+})
+
+// seriesAlertData is the data the alert templates and payloads are rendered from.
+type seriesAlertData struct {
+	Insight       string   `json:"insight"`
+	Series        string   `json:"series"`
+	SeriesID      string   `json:"seriesId"`
+	Condition     string   `json:"condition"`
+	Threshold     float64  `json:"threshold"`
+	Value         float64  `json:"value"`
+	PreviousValue *float64 `json:"previousValue,omitempty"`
+	Time          string   `json:"time"`
+	Message       string   `json:"message"`
+	URL           string   `json:"url"`
+}
+
+func (n *seriesAlertNotifier) notify(ctx context.Context, alert types.InsightSeriesAlert, latest timeseries.Point, previous *timeseries.Point) ([]string, error) {
+	u, err := insightURL(ctx, alert.ViewUniqueID)
+	if err != nil {
+		return alert.SentChannels, err
+	}
+
+	data := &seriesAlertData{
+		Insight:   alert.ViewTitle,
+		Series:    alert.SeriesLabel,
+		SeriesID:  alert.SeriesID,
+		Condition: string(alert.Condition),
+		Threshold: alert.Threshold,
+		Value:     latest.Value,
+		Time:      latest.Time.UTC().Format(time.RFC3339),
+		Message:   seriesAlertMessage(alert, latest, previous),
+		URL:       u,
+	}
+	if previous != nil {
+		data.PreviousValue = &previous.Value
+	}
+
+	a := &cmbackground.Alert{
+		EmailTemplates: seriesAlertEmailTemplates,
+		SlackMessage:   &slack.WebhookMessage{Text: fmt.Sprintf("%s <%s|View the insight>", data.Message, data.URL)},
+		Data:           data,
+	}
+	if alert.NotifyEmail {
+		a.EmailUserID = alert.CreatorID
+	}
+	if alert.SlackWebhookURL != nil {
+		a.SlackWebhookURL = *alert.SlackWebhookURL
+	}
+	if alert.WebhookURL != nil {
+		a.WebhookURL = *alert.WebhookURL
+	}
+	return cmbackground.SendAlert(ctx, n.db, a, alert.SentChannels)
+}
+
+// seriesAlertMessage returns a sentence describing why the alert was triggered.
+func seriesAlertMessage(alert types.InsightSeriesAlert, latest timeseries.Point, previous *timeseries.Point) string {
+	subject := fmt.Sprintf("The series %q of the insight %q", alert.SeriesLabel, alert.ViewTitle)
+	date := latest.Time.UTC().Format("January 2, 2006")
+
+	switch alert.Condition {
+	case types.ValueAbove:
+		return fmt.Sprintf("%s is above %s with a value of %s on %s.", subject, formatValue(alert.Threshold), formatValue(latest.Value), date)
+	case types.ValueBelow:
+		return fmt.Sprintf("%s is below %s with a value of %s on %s.", subject, formatValue(alert.Threshold), formatValue(latest.Value), date)
+	case types.WeekOverWeekIncrease:
+		if previous != nil {
+			return fmt.Sprintf("%s increased by more than %s%% week over week, from %s on %s to %s on %s.", subject,
+				formatValue(alert.Threshold), formatValue(previous.Value), previous.Time.UTC().Format("January 2, 2006"), formatValue(latest.Value), date)
+		}
+	}
+	return fmt.Sprintf("%s has a value of %s on %s.", subject, formatValue(latest.Value), date)
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// insightURL returns the absolute URL of the insight view. The ID of the view needs to be kept consistent with
+// resolvers.insightViewResolver.ID().
+func insightURL(ctx context.Context, viewUniqueID string) (string, error) {
+	extStr, err := internalapi.Client.ExternalURL(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "getting external Sourcegraph URL")
+	}
+	extURL, err := url.Parse(extStr)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing external Sourcegraph URL")
+	}
+
+	return extURL.ResolveReference(&url.URL{Path: "/insights/insight/" + string(relay.MarshalID("insight_view", viewUniqueID))}).String(), nil
+}
@@ -0,0 +1,158 @@
+package background
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// newSeriesAlertEvaluator returns an evaluator of the alert rules on insight series. It's called by the query
+// runner worker whenever a recording enqueued by the insight enqueuer has been persisted, so alerts are sent as
+// soon as the new points of a series are recorded.
+func newSeriesAlertEvaluator(postgres database.DB, insightsdb edb.InsightsDB) *seriesAlertEvaluator {
+	return &seriesAlertEvaluator{
+		alertStore:  store.NewAlertStore(insightsdb),
+		seriesStore: store.New(insightsdb, store.NewInsightPermissionStore(postgres)),
+		notify:      newSeriesAlertNotifier(postgres).notify,
+		now:         time.Now,
+	}
+}
+
+type seriesAlertStore interface {
+	GetAlerts(ctx context.Context, args store.AlertQueryArgs) ([]types.InsightSeriesAlert, error)
+	UpdateAlertState(ctx context.Context, id int, evaluatedAt time.Time, firing bool, alertedAt *time.Time) error
+	UpdateAlertSentChannels(ctx context.Context, id int, channels []string) error
+}
+
+// seriesAlertNotifyFunc notifies the creator of an alert that its condition is met by the latest point of the
+// series. previous is the point the latest one was compared to, if any. The alert is only sent through the
+// channels which aren't in alert.SentChannels, and the channels it has been sent through are returned.
+type seriesAlertNotifyFunc func(ctx context.Context, alert types.InsightSeriesAlert, latest timeseries.Point, previous *timeseries.Point) ([]string, error)
+
+type seriesAlertEvaluator struct {
+	alertStore  seriesAlertStore
+	seriesStore store.Interface
+	notify      seriesAlertNotifyFunc
+	now         func() time.Time
+}
+
+// evaluateSeries evaluates the alerts on the series with the given ID.
+func (e *seriesAlertEvaluator) evaluateSeries(ctx context.Context, seriesID string) error {
+	alerts, err := e.alertStore.GetAlerts(ctx, store.AlertQueryArgs{SeriesID: seriesID, ExcludeDeletedSeries: true})
+	if err != nil {
+		return errors.Wrap(err, "GetAlerts")
+	}
+
+	var errs error
+	for _, alert := range alerts {
+		if err := e.evaluate(ctx, alert); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "evaluating alert %d", alert.ID))
+		}
+	}
+	return errs
+}
+
+// evaluate evaluates the alert for the latest recorded point of its series, unless it has already been evaluated
+// for it. An alert is only sent when the condition starts being met, and the state of the alert is only updated
+// once it has been sent so that failed deliveries are retried. The channels that didn't fail are recorded, so
+// that retries don't send the alert through them again.
+func (e *seriesAlertEvaluator) evaluate(ctx context.Context, alert types.InsightSeriesAlert) error {
+	// 🚨 SECURITY: The points are read with the permissions of the creator of the alert, so that alerts never
+	// disclose data from repositories they can't access.
+	points, err := e.seriesStore.SeriesPoints(actor.WithActor(ctx, actor.FromUser(alert.CreatorID)), store.SeriesPointsOpts{
+		SeriesID:         &alert.SeriesID,
+		ExcludeSnapshots: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "SeriesPoints")
+	}
+
+	values := sumPointsByTime(points)
+	if len(values) == 0 {
+		return nil
+	}
+	latest := values[len(values)-1]
+	if alert.LastEvaluatedAt != nil && !latest.Time.After(*alert.LastEvaluatedAt) {
+		return nil
+	}
+
+	met, previous := seriesAlertConditionMet(alert, values)
+	var alertedAt *time.Time
+	if met && !alert.Firing {
+		sent, err := e.notify(ctx, alert, latest, previous)
+		if err != nil {
+			if updateErr := e.alertStore.UpdateAlertSentChannels(ctx, alert.ID, sent); updateErr != nil {
+				err = errors.Append(err, errors.Wrap(updateErr, "UpdateAlertSentChannels"))
+			}
+			return errors.Wrap(err, "notify")
+		}
+		now := e.now()
+		alertedAt = &now
+	}
+	return e.alertStore.UpdateAlertState(ctx, alert.ID, latest.Time, met, alertedAt)
+}
+
+// sumPointsByTime sums the points recorded at the same time, which are the values of the different captures of a
+// series, and returns them sorted by time.
+func sumPointsByTime(points []store.SeriesPoint) []timeseries.Point {
+	sums := make(map[time.Time]float64, len(points))
+	for _, p := range points {
+		sums[p.Time] += p.Value
+	}
+
+	values := make([]timeseries.Point, 0, len(sums))
+	for t, v := range sums {
+		values = append(values, timeseries.Point{Time: t, Value: v})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Time.Before(values[j].Time) })
+	return values
+}
+
+const week = 7 * 24 * time.Hour
+
+// seriesAlertConditionMet returns whether the latest of the given points, which are sorted by time, meets the
+// condition of the alert. If the condition compares the latest point to a previous one, that point is returned as
+// well.
+func seriesAlertConditionMet(alert types.InsightSeriesAlert, points []timeseries.Point) (bool, *timeseries.Point) {
+	latest := points[len(points)-1]
+
+	switch alert.Condition {
+	case types.ValueAbove:
+		return latest.Value > alert.Threshold, nil
+
+	case types.ValueBelow:
+		return latest.Value < alert.Threshold, nil
+
+	case types.WeekOverWeekIncrease:
+		// Compare to the latest point recorded at least a week before, since series aren't necessarily recorded
+		// daily.
+		i := sort.Search(len(points), func(i int) bool { return points[i].Time.After(latest.Time.Add(-week)) }) - 1
+		if i < 0 {
+			return false, nil
+		}
+		previous := points[i]
+		return percentIncrease(previous.Value, latest.Value) > alert.Threshold, &previous
+	}
+
+	return false, nil
+}
+
+// percentIncrease returns the increase from one value to another in percent. Any increase from zero is infinite.
+func percentIncrease(from, to float64) float64 {
+	if from == 0 {
+		if to > 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return (to - from) / from * 100
+}
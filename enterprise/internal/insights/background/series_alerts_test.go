@@ -0,0 +1,228 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSeriesAlertConditionMet(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2022, 10, d, 0, 0, 0, 0, time.UTC) }
+
+	for _, tc := range []struct {
+		name      string
+		condition types.InsightSeriesAlertCondition
+		threshold float64
+		points    []timeseries.Point
+		want      bool
+		previous  *time.Time
+	}{
+		{
+			name:      "above threshold",
+			condition: types.ValueAbove,
+			threshold: 10,
+			points:    []timeseries.Point{{Time: day(1), Value: 5}, {Time: day(2), Value: 11}},
+			want:      true,
+		},
+		{
+			name:      "at threshold is not above",
+			condition: types.ValueAbove,
+			threshold: 10,
+			points:    []timeseries.Point{{Time: day(1), Value: 10}},
+		},
+		{
+			name:      "below threshold",
+			condition: types.ValueBelow,
+			threshold: 10,
+			points:    []timeseries.Point{{Time: day(1), Value: 9}},
+			want:      true,
+		},
+		{
+			name:      "week over week increase",
+			condition: types.WeekOverWeekIncrease,
+			threshold: 50,
+			points:    []timeseries.Point{{Time: day(1), Value: 100}, {Time: day(2), Value: 10}, {Time: day(9), Value: 16}},
+			want:      true,
+			previous:  timePtr(day(2)),
+		},
+		{
+			name:      "week over week increase below threshold",
+			condition: types.WeekOverWeekIncrease,
+			threshold: 50,
+			points:    []timeseries.Point{{Time: day(2), Value: 10}, {Time: day(9), Value: 15}},
+			previous:  timePtr(day(2)),
+		},
+		{
+			name:      "week over week increase from zero",
+			condition: types.WeekOverWeekIncrease,
+			threshold: 1000,
+			points:    []timeseries.Point{{Time: day(1), Value: 0}, {Time: day(10), Value: 1}},
+			want:      true,
+			previous:  timePtr(day(1)),
+		},
+		{
+			name:      "week over week without a point a week before",
+			condition: types.WeekOverWeekIncrease,
+			threshold: 0,
+			points:    []timeseries.Point{{Time: day(5), Value: 0}, {Time: day(9), Value: 100}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			alert := types.InsightSeriesAlert{Condition: tc.condition, Threshold: tc.threshold}
+			have, previous := seriesAlertConditionMet(alert, tc.points)
+			if have != tc.want {
+				t.Errorf("wrong result. want=%t, have=%t", tc.want, have)
+			}
+			switch {
+			case tc.previous == nil && previous != nil:
+				t.Errorf("unexpected previous point %v", previous)
+			case tc.previous != nil && (previous == nil || !previous.Time.Equal(*tc.previous)):
+				t.Errorf("wrong previous point. want=%s, have=%v", tc.previous, previous)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time { return &t }
+
+type fakeSeriesAlertStore struct {
+	alerts  []types.InsightSeriesAlert
+	updates map[int]bool
+}
+
+func (s *fakeSeriesAlertStore) GetAlerts(_ context.Context, args store.AlertQueryArgs) ([]types.InsightSeriesAlert, error) {
+	var alerts []types.InsightSeriesAlert
+	for _, alert := range s.alerts {
+		if args.SeriesID == "" || alert.SeriesID == args.SeriesID {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, nil
+}
+
+func (s *fakeSeriesAlertStore) UpdateAlertState(_ context.Context, id int, evaluatedAt time.Time, firing bool, alertedAt *time.Time) error {
+	for i := range s.alerts {
+		if s.alerts[i].ID == id {
+			s.alerts[i].LastEvaluatedAt = &evaluatedAt
+			s.alerts[i].Firing = firing
+			s.alerts[i].SentChannels = nil
+			if alertedAt != nil {
+				s.alerts[i].LastAlertedAt = alertedAt
+			}
+		}
+	}
+	s.updates[id] = firing
+	return nil
+}
+
+func (s *fakeSeriesAlertStore) UpdateAlertSentChannels(_ context.Context, id int, channels []string) error {
+	for i := range s.alerts {
+		if s.alerts[i].ID == id {
+			s.alerts[i].SentChannels = channels
+		}
+	}
+	return nil
+}
+
+func TestSeriesAlertEvaluator(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	var points []store.SeriesPoint
+	seriesStore := store.NewMockInterface()
+	seriesStore.SeriesPointsFunc.SetDefaultHook(func(ctx context.Context, opts store.SeriesPointsOpts) ([]store.SeriesPoint, error) {
+		if a := actor.FromContext(ctx); a.UID != 42 {
+			t.Errorf("points not read as the creator of the alert: %+v", a)
+		}
+		if !opts.ExcludeSnapshots {
+			t.Error("snapshot points should be excluded")
+		}
+		return points, nil
+	})
+
+	alerts := &fakeSeriesAlertStore{
+		alerts: []types.InsightSeriesAlert{
+			{ID: 1, SeriesID: "s1", CreatorID: 42, Condition: types.ValueAbove, Threshold: 10},
+			{ID: 2, SeriesID: "s2", CreatorID: 42, Condition: types.ValueAbove, Threshold: 0},
+		},
+		updates: map[int]bool{},
+	}
+	var notified []float64
+	var alreadySent []string
+	failNotify := false
+	evaluator := &seriesAlertEvaluator{
+		alertStore:  alerts,
+		seriesStore: seriesStore,
+		notify: func(_ context.Context, alert types.InsightSeriesAlert, latest timeseries.Point, _ *timeseries.Point) ([]string, error) {
+			alreadySent = alert.SentChannels
+			if failNotify {
+				return append(alert.SentChannels, "email"), errors.New("delivery failed")
+			}
+			notified = append(notified, latest.Value)
+			return append(alert.SentChannels, "email", "webhook"), nil
+		},
+		now: func() time.Time { return now },
+	}
+
+	record := func(d int, values ...float64) {
+		for _, v := range values {
+			points = append(points, store.SeriesPoint{SeriesID: "s1", Time: time.Date(2022, 10, d, 0, 0, 0, 0, time.UTC), Value: v})
+		}
+	}
+	evaluate := func() {
+		t.Helper()
+		if err := evaluator.evaluateSeries(ctx, "s1"); err != nil && !failNotify {
+			t.Fatal(err)
+		}
+	}
+
+	// Values of the captures recorded at the same time are summed up.
+	record(1, 4, 5)
+	evaluate()
+	if len(notified) != 0 || alerts.alerts[0].Firing {
+		t.Fatalf("unexpected alert: %v", notified)
+	}
+
+	// Crossing the threshold sends an alert, but only once while it stays above.
+	record(2, 6, 5)
+	evaluate()
+	evaluate()
+	record(3, 20)
+	evaluate()
+	if len(notified) != 1 || notified[0] != 11 {
+		t.Fatalf("wrong alerts: %v", notified)
+	}
+	if !alerts.alerts[0].Firing || alerts.alerts[0].LastAlertedAt == nil {
+		t.Fatalf("alert state not recorded: %+v", alerts.alerts[0])
+	}
+
+	// Failed deliveries are retried on the next evaluation.
+	record(4, 1)
+	evaluate()
+	record(5, 30)
+	failNotify = true
+	evaluate()
+	if alerts.alerts[0].Firing {
+		t.Fatal("alert recorded as firing although it wasn't delivered")
+	}
+	failNotify = false
+	evaluate()
+	if len(notified) != 2 || notified[1] != 30 {
+		t.Fatalf("wrong alerts: %v", notified)
+	}
+	// The retry skips the channels the alert was already sent through.
+	if len(alreadySent) != 1 || alreadySent[0] != "email" {
+		t.Fatalf("wrong channels already sent on retry: %v", alreadySent)
+	}
+
+	// Only the alerts on the recorded series are evaluated.
+	if _, ok := alerts.updates[2]; ok {
+		t.Fatal("alert on another series was evaluated")
+	}
+}
@@ -0,0 +1,188 @@
+package httpapi
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// exportedPoint is a point recorded for a series, as it is exported in CSV.
+type exportedPoint struct {
+	SeriesID    string
+	SeriesLabel string
+	Time        time.Time
+	Value       float64
+	Capture     *string
+}
+
+var exportHeader = []string{"series_id", "series_label", "time", "value", "capture"}
+
+// writeCSV writes the points as CSV, with a header row. The time of the points is formatted as RFC 3339 in
+// UTC, and the capture column is left empty for points which aren't generated from capture groups.
+func writeCSV(w io.Writer, points []exportedPoint) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportHeader); err != nil {
+		return err
+	}
+	for _, p := range points {
+		var capture string
+		if p.Capture != nil {
+			capture = *p.Capture
+		}
+		if err := cw.Write([]string{
+			p.SeriesID,
+			p.SeriesLabel,
+			p.Time.UTC().Format(time.RFC3339),
+			formatFloat(p.Value),
+			capture,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// parseImportCSV parses the points of a CSV import. The first row is a header naming the columns: series_id,
+// time and value are required, capture is optional and any other column is ignored, so that the output of
+// writeCSV can be imported back.
+func parseImportCSV(r io.Reader) ([]store.RecordSeriesPointArgs, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("missing CSV header")
+		}
+		return nil, errors.Wrap(err, "reading CSV header")
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"series_id", "time", "value"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Newf("missing CSV column %q", name)
+		}
+	}
+	captureColumn, hasCapture := columns["capture"]
+
+	var points []store.RecordSeriesPointArgs
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading CSV")
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		seriesID := field("series_id")
+		if seriesID == "" {
+			return nil, errors.Newf("line %d: missing series_id", line)
+		}
+		t, err := parseTime(field("time"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		value, err := strconv.ParseFloat(field("value"), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, errors.Newf("line %d: invalid value %q", line, field("value"))
+		}
+		var capture *string
+		if hasCapture && captureColumn < len(record) && record[captureColumn] != "" {
+			c := record[captureColumn]
+			capture = &c
+		}
+
+		points = append(points, store.RecordSeriesPointArgs{
+			SeriesID: seriesID,
+			Point: store.SeriesPoint{
+				SeriesID: seriesID,
+				Time:     t,
+				Value:    value,
+				Capture:  capture,
+			},
+			PersistMode: store.RecordMode,
+		})
+	}
+	return points, nil
+}
+
+// parseTime parses the time of an imported point, either as RFC 3339 or as a date.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.Newf("invalid time %q, expected RFC 3339 or YYYY-MM-DD", s)
+}
+
+// metricSample is the latest value of a series in the Prometheus exposition.
+type metricSample struct {
+	InsightID string
+	Insight   string
+	SeriesID  string
+	Series    string
+	Capture   *string
+	Value     float64
+	Time      time.Time
+}
+
+const metricName = "sourcegraph_insight_series_value"
+
+// writeMetrics writes the samples in the Prometheus text exposition format, as a single gauge labelled with
+// the insight and series. Samples carry the time they were recorded at, since insights are only recorded on
+// their sample interval.
+func writeMetrics(w io.Writer, samples []metricSample) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# HELP %s The latest value recorded for a Code Insights series.\n", metricName)
+	fmt.Fprintf(bw, "# TYPE %s gauge\n", metricName)
+	for _, s := range samples {
+		var capture string
+		if s.Capture != nil {
+			capture = *s.Capture
+		}
+		fmt.Fprintf(bw, "%s{insight_id=\"%s\",insight=\"%s\",series_id=\"%s\",series=\"%s\",capture=\"%s\"} %s %d\n",
+			metricName,
+			escapeLabelValue(s.InsightID),
+			escapeLabelValue(s.Insight),
+			escapeLabelValue(s.SeriesID),
+			escapeLabelValue(s.Series),
+			escapeLabelValue(capture),
+			formatFloat(s.Value),
+			s.Time.UnixMilli(),
+		)
+	}
+	return bw.Flush()
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,111 @@
+package httpapi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+)
+
+func TestCSVRoundTrip(t *testing.T) {
+	capture := "1.18"
+	points := []exportedPoint{
+		{SeriesID: "s1", SeriesLabel: "Go, \"modules\"", Time: time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), Value: 12},
+		{SeriesID: "s2", SeriesLabel: "versions", Time: time.Date(2022, 10, 8, 0, 0, 0, 0, time.UTC), Value: 1.5, Capture: &capture},
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, points); err != nil {
+		t.Fatal(err)
+	}
+	want := `series_id,series_label,time,value,capture
+s1,"Go, ""modules""",2022-10-01T00:00:00Z,12,
+s2,versions,2022-10-08T00:00:00Z,1.5,1.18
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatalf("unexpected CSV (-want +got):\n%s", diff)
+	}
+
+	imported, err := parseImportCSV(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantImported := []store.RecordSeriesPointArgs{
+		{SeriesID: "s1", Point: store.SeriesPoint{SeriesID: "s1", Time: points[0].Time, Value: 12}, PersistMode: store.RecordMode},
+		{SeriesID: "s2", Point: store.SeriesPoint{SeriesID: "s2", Time: points[1].Time, Value: 1.5, Capture: &capture}, PersistMode: store.RecordMode},
+	}
+	if diff := cmp.Diff(wantImported, imported); diff != "" {
+		t.Errorf("unexpected imported points (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	t.Run("dates and column order", func(t *testing.T) {
+		points, err := parseImportCSV(strings.NewReader("value,time,series_id\n3,2022-01-02,s1\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []store.RecordSeriesPointArgs{
+			{SeriesID: "s1", Point: store.SeriesPoint{SeriesID: "s1", Time: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC), Value: 3}, PersistMode: store.RecordMode},
+		}
+		if diff := cmp.Diff(want, points); diff != "" {
+			t.Errorf("unexpected points (-want +got):\n%s", diff)
+		}
+	})
+
+	for name, input := range map[string]string{
+		"empty":          "",
+		"missing column": "series_id,time\ns1,2022-01-02\n",
+		"missing series": "series_id,time,value\n,2022-01-02,1\n",
+		"invalid time":   "series_id,time,value\ns1,yesterday,1\n",
+		"invalid value":  "series_id,time,value\ns1,2022-01-02,many\n",
+		"infinite value": "series_id,time,value\ns1,2022-01-02,+Inf\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseImportCSV(strings.NewReader(input)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	capture := "v1"
+	var buf bytes.Buffer
+	if err := writeMetrics(&buf, []metricSample{
+		{InsightID: "aW5zaWdodA==", Insight: `Say "hi"`, SeriesID: "s1", Series: "greetings", Value: 4, Time: time.UnixMilli(1665000000000)},
+		{InsightID: "aW5zaWdodA==", Insight: `Say "hi"`, SeriesID: "s2", Series: "versions\\all", Capture: &capture, Value: 0.5, Time: time.UnixMilli(1665000000000)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# HELP sourcegraph_insight_series_value The latest value recorded for a Code Insights series.
+# TYPE sourcegraph_insight_series_value gauge
+sourcegraph_insight_series_value{insight_id="aW5zaWdodA==",insight="Say \"hi\"",series_id="s1",series="greetings",capture=""} 4 1665000000000
+sourcegraph_insight_series_value{insight_id="aW5zaWdodA==",insight="Say \"hi\"",series_id="s2",series="versions\\all",capture="v1"} 0.5 1665000000000
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("unexpected metrics (-want +got):\n%s", diff)
+	}
+}
+
+func TestLatestPoints(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2022, 10, d, 0, 0, 0, 0, time.UTC) }
+	a, b := "a", "b"
+	points := []store.SeriesPoint{
+		{Time: day(1), Value: 1, Capture: &a},
+		{Time: day(2), Value: 2, Capture: &b},
+		{Time: day(2), Value: 3, Capture: &a},
+	}
+	want := []store.SeriesPoint{
+		{Time: day(2), Value: 3, Capture: &a},
+		{Time: day(2), Value: 2, Capture: &b},
+	}
+	if diff := cmp.Diff(want, latestPoints(points)); diff != "" {
+		t.Errorf("unexpected points (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,377 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/opentracing/opentracing-go/log"
+	sglog "github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Handler handles exporting the points recorded for insights, in CSV and in the Prometheus text format, and
+// importing historical points from CSV.
+type Handler struct {
+	logger       sglog.Logger
+	postgres     database.DB
+	insightStore *store.InsightStore
+	seriesStore  *store.Store
+	operations   *Operations
+}
+
+// NewHandler creates a new Handler.
+func NewHandler(insightsDB edb.InsightsDB, postgres database.DB, operations *Operations) *Handler {
+	return &Handler{
+		logger:       sglog.Scoped("InsightsHandler", "Code Insights import and export REST API handler"),
+		postgres:     postgres,
+		insightStore: store.NewInsightStore(insightsDB),
+		// 🚨 SECURITY: Series points are filtered with the repository permissions of the user making the request.
+		seriesStore: store.New(insightsDB, store.NewInsightPermissionStore(postgres)),
+		operations:  operations,
+	}
+}
+
+// Export exports the points recorded for the series of an insight as CSV.
+func (h *Handler) Export() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		insight, points, statusCode, err := h.export(r)
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", exportFilename(insight)))
+		w.WriteHeader(statusCode)
+
+		if err := writeCSV(w, points); err != nil {
+			h.logger.Error("failed to write CSV to client", sglog.Error(err))
+		}
+	})
+}
+
+func (h *Handler) export(r *http.Request) (_ types.Insight, _ []exportedPoint, statusCode int, err error) {
+	ctx, _, endObservation := h.operations.export.With(r.Context(), &err, observation.Args{})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Int("statusCode", statusCode),
+		}})
+	}()
+
+	insight, statusCode, err := h.visibleInsight(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return types.Insight{}, nil, statusCode, err
+	}
+
+	var points []exportedPoint
+	for _, series := range recordedSeries(insight) {
+		seriesPoints, err := h.seriesPoints(ctx, insight, series, true, false)
+		if err != nil {
+			return types.Insight{}, nil, http.StatusInternalServerError, err
+		}
+		for _, p := range seriesPoints {
+			points = append(points, exportedPoint{
+				SeriesID:    series.SeriesID,
+				SeriesLabel: series.Label,
+				Time:        p.Time,
+				Value:       p.Value,
+				Capture:     p.Capture,
+			})
+		}
+	}
+	return insight, points, http.StatusOK, nil
+}
+
+// Metrics exposes the latest value of the series of all the insights visible to the user in the Prometheus
+// text format. The insights can be restricted by passing their IDs in id query parameters.
+func (h *Handler) Metrics() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		samples, statusCode, err := h.metrics(r)
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(statusCode)
+
+		if err := writeMetrics(w, samples); err != nil {
+			h.logger.Error("failed to write metrics to client", sglog.Error(err))
+		}
+	})
+}
+
+func (h *Handler) metrics(r *http.Request) (_ []metricSample, statusCode int, err error) {
+	ctx, _, endObservation := h.operations.metrics.With(r.Context(), &err, observation.Args{})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Int("statusCode", statusCode),
+		}})
+	}()
+
+	var uniqueIDs []string
+	for _, id := range r.URL.Query()["id"] {
+		uniqueID, err := unmarshalInsightViewID(id)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		uniqueIDs = append(uniqueIDs, uniqueID)
+	}
+
+	userIDs, orgIDs, err := userPermissions(ctx, h.postgres)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	insights, err := h.insightStore.GetAllMapped(ctx, store.InsightQueryArgs{UniqueIDs: uniqueIDs, UserID: userIDs, OrgID: orgIDs})
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "GetAllMapped")
+	}
+
+	var samples []metricSample
+	for _, insight := range insights {
+		insightID := string(insightViewID(insight.UniqueID))
+		for _, series := range recordedSeries(insight) {
+			points, err := h.seriesPoints(ctx, insight, series, false, true)
+			if err != nil {
+				return nil, http.StatusInternalServerError, err
+			}
+			for _, p := range latestPoints(points) {
+				samples = append(samples, metricSample{
+					InsightID: insightID,
+					Insight:   insight.Title,
+					SeriesID:  series.SeriesID,
+					Series:    series.Label,
+					Capture:   p.Capture,
+					Value:     p.Value,
+					Time:      p.Time,
+				})
+			}
+		}
+	}
+	return samples, http.StatusOK, nil
+}
+
+// latestPoints returns the points recorded at the latest time, one per capture.
+func latestPoints(points []store.SeriesPoint) []store.SeriesPoint {
+	var latest []store.SeriesPoint
+	for _, p := range points {
+		switch {
+		case len(latest) == 0 || p.Time.After(latest[0].Time):
+			latest = []store.SeriesPoint{p}
+		case p.Time.Equal(latest[0].Time):
+			latest = append(latest, p)
+		}
+	}
+	sort.SliceStable(latest, func(i, j int) bool {
+		return captureValue(latest[i].Capture) < captureValue(latest[j].Capture)
+	})
+	return latest
+}
+
+func captureValue(capture *string) string {
+	if capture == nil {
+		return ""
+	}
+	return *capture
+}
+
+const maxImportSize = 10 << 20 // 10MB
+
+// Import imports historical points for the series of an insight from CSV. The imported points replace the
+// points recorded for the same series at the same times. Only site admins can import points, since series are
+// shared between all the insights with the same query.
+func (h *Handler) Import() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+		responseBody, statusCode, err := h.import_(r)
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+
+		if err := json.NewEncoder(w).Encode(responseBody); err != nil {
+			h.logger.Error("failed to write json payload to client", sglog.Error(err))
+		}
+	})
+}
+
+type importResponse struct {
+	Imported int `json:"imported"`
+}
+
+func (h *Handler) import_(r *http.Request) (resp importResponse, statusCode int, err error) {
+	ctx, _, endObservation := h.operations.import_.With(r.Context(), &err, observation.Args{})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Int("statusCode", statusCode),
+		}})
+	}()
+
+	// 🚨 SECURITY: Only site admins can import points.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, h.postgres); err != nil {
+		switch {
+		case errors.Is(err, auth.ErrNotAuthenticated):
+			return resp, http.StatusUnauthorized, err
+		case errors.Is(err, auth.ErrMustBeSiteAdmin):
+			return resp, http.StatusForbidden, err
+		default:
+			return resp, http.StatusInternalServerError, err
+		}
+	}
+
+	uniqueID, err := unmarshalInsightViewID(mux.Vars(r)["id"])
+	if err != nil {
+		return resp, http.StatusBadRequest, err
+	}
+	insights, err := h.insightStore.GetMapped(ctx, store.InsightQueryArgs{UniqueID: uniqueID, WithoutAuthorization: true})
+	if err != nil {
+		return resp, http.StatusInternalServerError, errors.Wrap(err, "GetMapped")
+	}
+	if len(insights) == 0 {
+		return resp, http.StatusNotFound, errors.New("insight not found")
+	}
+
+	points, err := parseImportCSV(r.Body)
+	if err != nil {
+		return resp, http.StatusBadRequest, err
+	}
+	if err := validateImportedPoints(insights[0], points); err != nil {
+		return resp, http.StatusBadRequest, err
+	}
+
+	if err := h.seriesStore.ReplaceRecordedSeriesPoints(ctx, points); err != nil {
+		return resp, http.StatusInternalServerError, errors.Wrap(err, "ReplaceRecordedSeriesPoints")
+	}
+	return importResponse{Imported: len(points)}, http.StatusOK, nil
+}
+
+// validateImportedPoints checks that all the points belong to series of the insight which record points.
+func validateImportedPoints(insight types.Insight, points []store.RecordSeriesPointArgs) error {
+	series := map[string]struct{}{}
+	for _, s := range recordedSeries(insight) {
+		series[s.SeriesID] = struct{}{}
+	}
+	for _, p := range points {
+		if _, ok := series[p.SeriesID]; !ok {
+			return errors.Newf("series %q is not a recorded series of the insight", p.SeriesID)
+		}
+	}
+	return nil
+}
+
+// visibleInsight returns the insight with the given GraphQL ID if it is visible to the user.
+func (h *Handler) visibleInsight(ctx context.Context, id string) (types.Insight, int, error) {
+	uniqueID, err := unmarshalInsightViewID(id)
+	if err != nil {
+		return types.Insight{}, http.StatusBadRequest, err
+	}
+
+	userIDs, orgIDs, err := userPermissions(ctx, h.postgres)
+	if err != nil {
+		return types.Insight{}, http.StatusInternalServerError, err
+	}
+	// 🚨 SECURITY: Only insights granted to the user, their organizations or globally are returned. We return a
+	// generic not found error to prevent leaking the existence of the insight.
+	insights, err := h.insightStore.GetAllMapped(ctx, store.InsightQueryArgs{UniqueID: uniqueID, UserID: userIDs, OrgID: orgIDs})
+	if err != nil {
+		return types.Insight{}, http.StatusInternalServerError, errors.Wrap(err, "GetAllMapped")
+	}
+	if len(insights) == 0 {
+		return types.Insight{}, http.StatusNotFound, errors.New("insight not found")
+	}
+	return insights[0], http.StatusOK, nil
+}
+
+// seriesPoints returns the points of the series with the filters of the insight applied. Snapshot points are
+// excluded when only recorded points are wanted, and only the points of the latest time are returned when only
+// the latest points are wanted.
+func (h *Handler) seriesPoints(ctx context.Context, insight types.Insight, series types.InsightViewSeries, recordedOnly, latestOnly bool) ([]store.SeriesPoint, error) {
+	opts, err := resolvers.AllRecordedSeriesPointOpts(ctx, h.postgres, series, insight.Filters)
+	if err != nil {
+		return nil, errors.Wrap(err, "AllRecordedSeriesPointOpts")
+	}
+	opts.ExcludeSnapshots = recordedOnly
+	opts.LatestOnly = latestOnly
+	points, err := h.seriesStore.SeriesPoints(ctx, *opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "SeriesPoints")
+	}
+	return points, nil
+}
+
+// recordedSeries returns the series of the insight which have points recorded. Derived series are calculated
+// from the other series when they are resolved and don't record points.
+func recordedSeries(insight types.Insight) []types.InsightViewSeries {
+	series := make([]types.InsightViewSeries, 0, len(insight.Series))
+	for _, s := range insight.Series {
+		if s.GenerationMethod == types.Derived {
+			continue
+		}
+		series = append(series, s)
+	}
+	return series
+}
+
+func userPermissions(ctx context.Context, db database.DB) (userIDs []int, orgIDs []int, err error) {
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, nil, nil
+	}
+	orgs, err := db.Orgs().GetByUserID(ctx, uid)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "loading user organizations")
+	}
+	userIDs = []int{int(uid)}
+	for _, org := range orgs {
+		orgIDs = append(orgIDs, int(org.ID))
+	}
+	return userIDs, orgIDs, nil
+}
+
+// insightViewID returns the GraphQL ID of an insight view. It needs to be kept consistent with
+// resolvers.insightViewResolver.ID().
+func insightViewID(uniqueID string) graphql.ID {
+	return relay.MarshalID("insight_view", uniqueID)
+}
+
+func unmarshalInsightViewID(id string) (string, error) {
+	if id == "" {
+		return "", errors.New("insight ID not provided")
+	}
+	var uniqueID string
+	if err := relay.UnmarshalSpec(graphql.ID(id), &uniqueID); err != nil {
+		return "", errors.Wrap(err, "invalid insight ID")
+	}
+	return uniqueID, nil
+}
+
+func exportFilename(insight types.Insight) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, insight.UniqueID)
+	return "insight-" + name + ".csv"
+}
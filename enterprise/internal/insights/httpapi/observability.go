@@ -0,0 +1,37 @@
+package httpapi
+
+import (
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type Operations struct {
+	export  *observation.Operation
+	metrics *observation.Operation
+	import_ *observation.Operation
+}
+
+func NewOperations(observationContext *observation.Context) *Operations {
+	m := metrics.NewREDMetrics(
+		observationContext.Registerer,
+		"insights_httpapi",
+		metrics.WithLabels("op"),
+		metrics.WithCountHelp("Total number of method invocations."),
+	)
+
+	op := func(name string) *observation.Operation {
+		return observationContext.Operation(observation.Op{
+			Name:              fmt.Sprintf("insights.httpapi.%s", name),
+			MetricLabelValues: []string{name},
+			Metrics:           m,
+		})
+	}
+
+	return &Operations{
+		export:  op("export"),
+		metrics: op("metrics"),
+		import_: op("import"),
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/httpapi"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -57,6 +58,11 @@ func Init(ctx context.Context, postgres database.DB, _ conftypes.UnifiedWatchabl
 	}
 	enterpriseServices.InsightsResolver = resolvers.New(db, postgres)
 
+	handler := httpapi.NewHandler(db, postgres, httpapi.NewOperations(observationContext))
+	enterpriseServices.InsightsExportHandler = handler.Export()
+	enterpriseServices.InsightsMetricsHandler = handler.Metrics()
+	enterpriseServices.InsightsImportHandler = handler.Import()
+
 	return nil
 }
 
@@ -282,7 +282,46 @@ func buildFilterText(raw string) string {
 	return fmt.Sprintf("^%s$", quoted)
 }
 
+// AddLangFilter adds a lang: filter for the given language to the query.
+func AddLangFilter(query BasicQuery, lang string) (BasicQuery, error) {
+	return addParameter(query, quotedParameter(searchquery.FieldLang, lang))
+}
+
+// AddOwnerFilter adds a file:has.owner() filter for the given code owner to the query.
+func AddOwnerFilter(query BasicQuery, owner string) (BasicQuery, error) {
+	return addParameter(query, searchquery.Parameter{
+		Field: searchquery.FieldFile,
+		Value: fmt.Sprintf("has.owner(%s)", owner),
+	})
+}
+
+// AddRepoMetadataFilter adds a repo:has() filter for the given repository metadata to the query.
+func AddRepoMetadataFilter(query BasicQuery, key, value string) (BasicQuery, error) {
+	return addParameter(query, searchquery.Parameter{
+		Field: searchquery.FieldRepo,
+		Value: fmt.Sprintf("has(%s:%s)", key, value),
+	})
+}
+
+// quotedParameter returns a parameter with the given value, which is quoted if it contains spaces.
+func quotedParameter(field, value string) searchquery.Parameter {
+	parameter := searchquery.Parameter{Field: field, Value: value}
+	if strings.Contains(value, " ") {
+		parameter.Annotation.Labels.Set(searchquery.Quoted)
+	}
+	return parameter
+}
+
 func addFilterSimple(query BasicQuery, field, value string) (BasicQuery, error) {
+	return addParameter(query, searchquery.Parameter{
+		Field:      field,
+		Value:      buildFilterText(value),
+		Negated:    false,
+		Annotation: searchquery.Annotation{},
+	})
+}
+
+func addParameter(query BasicQuery, parameter searchquery.Parameter) (BasicQuery, error) {
 	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
 	if err != nil {
 		return "", err
@@ -291,12 +330,7 @@ func addFilterSimple(query BasicQuery, field, value string) (BasicQuery, error)
 	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
 		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
 		modified = append(modified, basic.Parameters...)
-		modified = append(modified, searchquery.Parameter{
-			Field:      field,
-			Value:      buildFilterText(value),
-			Negated:    false,
-			Annotation: searchquery.Annotation{},
-		})
+		modified = append(modified, parameter)
 		return basic.MapParameters(modified)
 	})
 	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
@@ -601,3 +601,48 @@ func Test_addFileFilter(t *testing.T) {
 		})
 	}
 }
+
+func Test_addLangFilter(t *testing.T) {
+	tests := []struct {
+		input string
+		lang  string
+		want  autogold.Value
+	}{
+		{
+			input: "myquery repo:supergreat",
+			lang:  "Go",
+			want:  autogold.Want("single word language", BasicQuery("repo:supergreat lang:Go myquery")),
+		},
+		{
+			input: "myquery",
+			lang:  "Visual Basic .NET",
+			want:  autogold.Want("language with spaces", BasicQuery(`lang:"Visual Basic .NET" myquery`)),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.want.Name(), func(t *testing.T) {
+			got, err := AddLangFilter(BasicQuery(test.input), test.lang)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addOwnerFilter(t *testing.T) {
+	got, err := AddOwnerFilter(BasicQuery("myquery repo:supergreat"), "@sourcegraph/search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("owner filter", BasicQuery("repo:supergreat file:has.owner(@sourcegraph/search) myquery")).Equal(t, got)
+}
+
+func Test_addRepoMetadataFilter(t *testing.T) {
+	got, err := AddRepoMetadataFilter(BasicQuery("myquery"), "team", "search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("repo metadata filter", BasicQuery("repo:has(team:search) myquery")).Equal(t, got)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
@@ -39,6 +40,9 @@ const authNotCommitDiffMsg = "Grouping by author is only available for diff and
 const cgInvalidQueryMsg = "Grouping by capture group is only available for regexp searches that contain a capturing group."
 const cgMultipleQueryPatternMsg = "Grouping by capture group does not support search patterns with the following: and, or, negation."
 const cgUnsupportedSelectFmt = `Grouping by capture group is not available for searches with "%s:%s".`
+const langUnsupportedFieldValueFmt = `Grouping by language is not available for searches with "%s:%s".`
+const ownerUnsupportedFieldValueFmt = `Grouping by owner is not available for searches with "%s:%s".`
+const repoMetadataKeyMissingMsg = "Grouping by repository metadata requires a metadata key."
 
 // Possible reasons that grouping would fail
 const shardTimeoutMsg = "The query was unable to complete in the allocated time."
@@ -139,7 +143,22 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		cappedAggregator.Add(amr.Key.Group, int32(amr.Count))
 	}
 
-	countingFunc, err := aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode)
+	if aggregationMode == types.REPO_METADATA_AGGREGATION_MODE && (args.RepoMetadataKey == nil || *args.RepoMetadataKey == "") {
+		return &searchAggregationResultResolver{
+			resolver: newSearchAggregationNotAvailableResolver(
+				notAvailableReason{reason: repoMetadataKeyMissingMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY},
+				aggregationMode),
+		}, nil
+	}
+	deps := aggregation.ModeDependencies{
+		Gitserver:    gitserver.NewClient(r.postgresDB),
+		RepoMetadata: r.postgresDB.RepoKVPs(),
+	}
+	if args.RepoMetadataKey != nil {
+		deps.RepoMetadataKey = *args.RepoMetadataKey
+	}
+
+	countingFunc, err := aggregation.GetCountFuncForMode(ctx, r.searchQuery, r.patternType, aggregationMode, deps)
 	if err != nil {
 		r.getLogger().Debug("no aggregation counting function for mode", log.String("mode", string(aggregationMode)), log.Error(err))
 		return &searchAggregationResultResolver{
@@ -173,7 +192,7 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		return &searchAggregationResultResolver{resolver: newSearchAggregationNotAvailableResolver(failureReason, aggregationMode)}, nil
 	}
 
-	results := buildResults(cappedAggregator, int(args.Limit), aggregationMode, r.searchQuery, r.patternType)
+	results := buildResults(cappedAggregator, int(args.Limit), aggregationMode, r.searchQuery, r.patternType, deps.RepoMetadataKey)
 
 	return &searchAggregationResultResolver{resolver: &searchAggregationModeResultResolver{
 		searchQuery:  r.searchQuery,
@@ -276,7 +295,7 @@ func (r *AggregationGroup) Query() (*string, error) {
 	return r.query, nil
 }
 
-func buildResults(aggregator aggregation.LimitedAggregator, limit int, mode types.SearchAggregationMode, originalQuery string, patternType string) aggregationResults {
+func buildResults(aggregator aggregation.LimitedAggregator, limit int, mode types.SearchAggregationMode, originalQuery string, patternType string, repoMetadataKey string) aggregationResults {
 	sorted := aggregator.SortAggregate()
 	groups := make([]graphqlbackend.AggregationGroup, 0, limit)
 	otherResults := aggregator.OtherCounts().ResultCount
@@ -286,7 +305,7 @@ func buildResults(aggregator aggregation.LimitedAggregator, limit int, mode type
 	for i := 0; i < len(sorted); i++ {
 		if i < limit {
 			label := sorted[i].Label
-			drilldownQuery, err := buildDrilldownQuery(mode, originalQuery, label, patternType)
+			drilldownQuery, err := buildDrilldownQuery(mode, originalQuery, label, patternType, repoMetadataKey)
 			if err != nil {
 				// for some reason we couldn't generate a new query, so fallback to the original
 				drilldownQuery = originalQuery
@@ -369,6 +388,9 @@ func getAggregateBy(mode types.SearchAggregationMode) canAggregateBy {
 		types.PATH_AGGREGATION_MODE:          canAggregateByPath,
 		types.AUTHOR_AGGREGATION_MODE:        canAggregateByAuthor,
 		types.CAPTURE_GROUP_AGGREGATION_MODE: canAggregateByCaptureGroup,
+		types.LANGUAGE_AGGREGATION_MODE:      canAggregateByLanguage,
+		types.OWNER_AGGREGATION_MODE:         canAggregateByOwner,
+		types.REPO_METADATA_AGGREGATION_MODE: canAggregateByRepoMetadata,
 	}
 	canAggregateByFunc, ok := checkByMode[mode]
 	if !ok {
@@ -394,6 +416,20 @@ func canAggregateByRepo(searchQuery, patternType string) (bool, *notAvailableRea
 }
 
 func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, fileUnsupportedFieldValueFmt)
+}
+
+func canAggregateByLanguage(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, langUnsupportedFieldValueFmt)
+}
+
+func canAggregateByOwner(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	return canAggregateByFile(searchQuery, patternType, ownerUnsupportedFieldValueFmt)
+}
+
+// canAggregateByFile checks that a query returns file results, which the modes grouping by properties of files
+// depend on.
+func canAggregateByFile(searchQuery, patternType, unsupportedFieldValueFmt string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
 		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
@@ -404,8 +440,7 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 	for _, parameter := range parameters {
 		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
 			if strings.EqualFold(parameter.Value, "commit") || strings.EqualFold(parameter.Value, "diff") || strings.EqualFold(parameter.Value, "repo") {
-				reason := fmt.Sprintf(fileUnsupportedFieldValueFmt,
-					parameter.Field, parameter.Value)
+				reason := fmt.Sprintf(unsupportedFieldValueFmt, parameter.Field, parameter.Value)
 				return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 			}
 		}
@@ -413,6 +448,12 @@ func canAggregateByPath(searchQuery, patternType string) (bool, *notAvailableRea
 	return true, nil, nil
 }
 
+func canAggregateByRepoMetadata(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	// Every result belongs to a repository, so this is available whenever grouping by repository is. The metadata
+	// key is only known once the aggregation is requested.
+	return canAggregateByRepo(searchQuery, patternType)
+}
+
 func canAggregateByAuthor(searchQuery, patternType string) (bool, *notAvailableReason, error) {
 	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
 	if err != nil {
@@ -577,7 +618,7 @@ func (r *searchAggregationModeResultResolver) Mode() (string, error) {
 	return string(r.mode), nil
 }
 
-func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string, drilldown string, patternType string) (string, error) {
+func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string, drilldown string, patternType string, repoMetadataKey string) (string, error) {
 	caseSensitive := false
 	var modifierFunc func(querybuilder.BasicQuery, string) (querybuilder.BasicQuery, error)
 	switch mode {
@@ -587,6 +628,14 @@ func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string,
 		modifierFunc = querybuilder.AddFileFilter
 	case types.AUTHOR_AGGREGATION_MODE:
 		modifierFunc = querybuilder.AddAuthorFilter
+	case types.LANGUAGE_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddLangFilter
+	case types.OWNER_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddOwnerFilter
+	case types.REPO_METADATA_AGGREGATION_MODE:
+		modifierFunc = func(basicQuery querybuilder.BasicQuery, s string) (querybuilder.BasicQuery, error) {
+			return querybuilder.AddRepoMetadataFilter(basicQuery, repoMetadataKey, s)
+		}
 	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		searchType, err := client.SearchTypeFromString(patternType)
 		if err != nil {
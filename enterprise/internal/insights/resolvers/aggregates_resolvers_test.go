@@ -413,6 +413,7 @@ func Test_buildDrilldownQuery(t *testing.T) {
 		drilldown   string
 		patternType string
 		mode        types.SearchAggregationMode
+		key         string
 	}{
 		{
 			want:        autogold.Want("author_no_whitespace", "type:commit author:^Drilldown$ findme"),
@@ -470,10 +471,32 @@ func Test_buildDrilldownQuery(t *testing.T) {
 			patternType: "standard",
 			mode:        types.CAPTURE_GROUP_AGGREGATION_MODE,
 		},
+		{
+			want:        autogold.Want("language_with_whitespace", `lang:"Visual Basic .NET" findme`),
+			query:       "findme",
+			drilldown:   "Visual Basic .NET",
+			patternType: "standard",
+			mode:        types.LANGUAGE_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("owner", "file:has.owner(@sourcegraph/search) findme"),
+			query:       "findme",
+			drilldown:   "@sourcegraph/search",
+			patternType: "standard",
+			mode:        types.OWNER_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Want("repo_metadata", "repo:has(team:search) findme"),
+			query:       "findme",
+			drilldown:   "search",
+			patternType: "standard",
+			mode:        types.REPO_METADATA_AGGREGATION_MODE,
+			key:         "team",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.want.Name(), func(t *testing.T) {
-			got, err := buildDrilldownQuery(test.mode, test.query, test.drilldown, test.patternType)
+			got, err := buildDrilldownQuery(test.mode, test.query, test.drilldown, test.patternType, test.key)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -0,0 +1,99 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightSeriesResolver = &derivedInsightSeriesResolver{}
+
+// derivedInsightSeriesResolver is a series resolver for a series that is calculated from the other series of the
+// same insight.
+type derivedInsightSeriesResolver struct {
+	series types.InsightViewSeries
+	points []timeseries.Point
+	status graphqlbackend.InsightStatusResolver
+}
+
+// derivedSeries evaluates the expression of a derived series over the resolved series it references, which are
+// keyed by label. Referenced series that no longer exist are treated as if they had no points.
+func derivedSeries(ctx context.Context, definition types.InsightViewSeries, sources map[string]graphqlbackend.InsightSeriesResolver) (graphqlbackend.InsightSeriesResolver, error) {
+	expr, err := timeseries.ParseExpression(definition.Query)
+	if err != nil {
+		return nil, errors.Wrap(err, "ParseExpression")
+	}
+
+	var status insightStatusResolver
+	values := make(map[string][]timeseries.Point)
+	for _, v := range expr.Variables() {
+		source, ok := sources[v]
+		if !ok {
+			continue
+		}
+		points, err := source.Points(ctx, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Points for series %q", v)
+		}
+		for _, p := range points {
+			values[v] = append(values[v], timeseries.Point{Time: p.DateTime().Time, Value: p.Value()})
+		}
+
+		// The derived series is only complete once all of the series it is calculated from are.
+		sourceStatus, err := source.Status(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Status for series %q", v)
+		}
+		status.totalPoints += sourceStatus.TotalPoints()
+		status.pendingJobs += sourceStatus.PendingJobs()
+		status.completedJobs += sourceStatus.CompletedJobs()
+		status.failedJobs += sourceStatus.FailedJobs()
+	}
+
+	interval := timeseries.TimeInterval{
+		Unit:  types.IntervalUnit(definition.SampleIntervalUnit),
+		Value: definition.SampleIntervalValue,
+	}
+	if !interval.IsValid() {
+		interval = timeseries.DefaultInterval
+	}
+
+	return &derivedInsightSeriesResolver{
+		series: definition,
+		points: timeseries.EvaluateExpression(expr, values, interval, time.Now()),
+		status: status,
+	}, nil
+}
+
+func (d *derivedInsightSeriesResolver) SeriesId() string {
+	return d.series.SeriesID
+}
+
+func (d *derivedInsightSeriesResolver) Label() string {
+	return d.series.Label
+}
+
+func (d *derivedInsightSeriesResolver) Points(ctx context.Context, _ *graphqlbackend.InsightsPointsArgs) ([]graphqlbackend.InsightsDataPointResolver, error) {
+	resolvers := make([]graphqlbackend.InsightsDataPointResolver, 0, len(d.points))
+	for _, point := range d.points {
+		resolvers = append(resolvers, insightsDataPointResolver{store.SeriesPoint{
+			SeriesID: d.series.SeriesID,
+			Time:     point.Time,
+			Value:    point.Value,
+		}})
+	}
+	return resolvers, nil
+}
+
+func (d *derivedInsightSeriesResolver) Status(ctx context.Context) (graphqlbackend.InsightStatusResolver, error) {
+	return d.status, nil
+}
+
+func (d *derivedInsightSeriesResolver) DirtyMetadata(ctx context.Context) ([]graphqlbackend.InsightDirtyQueryResolver, error) {
+	return nil, nil
+}
@@ -370,6 +370,17 @@ func getRecordedSeriesPointOpts(ctx context.Context, db database.DB, definition
 	return opts, nil
 }
 
+// AllRecordedSeriesPointOpts returns the options to query all the points recorded for the series with the given
+// filters applied, without the time range the series resolvers use by default.
+func AllRecordedSeriesPointOpts(ctx context.Context, db database.DB, definition types.InsightViewSeries, filters types.InsightViewFilters) (*store.SeriesPointsOpts, error) {
+	opts, err := getRecordedSeriesPointOpts(ctx, db, definition, filters)
+	if err != nil {
+		return nil, err
+	}
+	opts.From = nil
+	return opts, nil
+}
+
 var loadingStrategyRED = metrics.NewREDMetrics(prometheus.DefaultRegisterer, "src_insights_loading_strategy", metrics.WithLabels("in_mem", "capture"))
 
 func fetchSeries(ctx context.Context, definition types.InsightViewSeries, filters types.InsightViewFilters, r *baseInsightResolver) (points []store.SeriesPoint, err error) {
@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -208,14 +209,32 @@ func (i *insightViewResolver) computeDataSeries(ctx context.Context) ([]graphqlb
 			seriesOptions = i.view.SeriesOptions
 		}
 
+		// Derived series are calculated from the other series of the insight, so they are resolved last.
+		var derived []types.InsightViewSeries
+		sources := make(map[string]graphqlbackend.InsightSeriesResolver)
 		for _, current := range i.view.Series {
+			if current.GenerationMethod == types.Derived {
+				derived = append(derived, current)
+				continue
+			}
 			seriesResolvers, err := i.dataSeriesGenerator.Generate(ctx, current, i.baseInsightResolver, *filters)
 			if err != nil {
 				i.seriesErr = errors.Wrapf(err, "generate for seriesID: %s", current.SeriesID)
 				return
 			}
+			if !current.GeneratedFromCaptureGroups && len(seriesResolvers) == 1 {
+				sources[current.Label] = seriesResolvers[0]
+			}
 			resolvers = append(resolvers, seriesResolvers...)
 		}
+		for _, current := range derived {
+			seriesResolver, err := derivedSeries(ctx, current, sources)
+			if err != nil {
+				i.seriesErr = errors.Wrapf(err, "derive seriesID: %s", current.SeriesID)
+				return
+			}
+			resolvers = append(resolvers, seriesResolver)
+		}
 		i.totalSeries = len(resolvers)
 
 		sortedAndLimitedResovlers, err := sortSeriesResolvers(ctx, seriesOptions, resolvers)
@@ -363,7 +382,10 @@ type searchInsightDataSeriesDefinitionResolver struct {
 }
 
 func (s *searchInsightDataSeriesDefinitionResolver) IsCalculated() (bool, error) {
-	if s.series.GeneratedFromCaptureGroups {
+	if s.series.GenerationMethod == types.Derived {
+		// derived series are calculated from the other series when they are read.
+		return true, nil
+	} else if s.series.GeneratedFromCaptureGroups {
 		// capture groups series are always pre-calculated!
 		return true, nil
 	} else {
@@ -396,6 +418,10 @@ func (s *searchInsightDataSeriesDefinitionResolver) GeneratedFromCaptureGroups()
 	return s.series.GeneratedFromCaptureGroups, nil
 }
 
+func (s *searchInsightDataSeriesDefinitionResolver) IsDerived() (bool, error) {
+	return s.series.GenerationMethod == types.Derived, nil
+}
+
 func (s *searchInsightDataSeriesDefinitionResolver) GroupBy() (*string, error) {
 	if s.series.GroupBy != nil {
 		groupBy := strings.ToUpper(*s.series.GroupBy)
@@ -463,6 +489,9 @@ func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graph
 	if len(args.Input.DataSeries) == 0 {
 		return nil, errors.New("At least one data series is required to create an insight view")
 	}
+	if err := validateDerivedSeries(args.Input.DataSeries); err != nil {
+		return nil, err
+	}
 
 	uid := actor.FromContext(ctx).UID
 	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
@@ -541,6 +570,9 @@ func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graph
 	if len(args.Input.DataSeries) == 0 {
 		return nil, errors.New("At least one data series is required to update an insight view")
 	}
+	if err := validateDerivedSeries(args.Input.DataSeries); err != nil {
+		return nil, err
+	}
 
 	tx, err := r.insightStore.Transact(ctx)
 	if err != nil {
@@ -997,8 +1029,13 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, scopedBa
 	var foundSeries bool
 	var err error
 	var dynamic bool
+	derived := isDerived(series)
 	// Validate the query before creating anything; we don't want faulty insights running pointlessly.
-	if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
+	if derived {
+		if _, err := timeseries.ParseExpression(series.Query); err != nil {
+			return nil, errors.Wrap(err, "expression validation")
+		}
+	} else if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
 		if _, err := querybuilder.ParseComputeQuery(series.Query); err != nil {
 			return nil, errors.Wrap(err, "query validation")
 		}
@@ -1013,7 +1050,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, scopedBa
 	}
 
 	groupBy := lowercaseGroupBy(series.GroupBy)
-	var nextRecordingAfter time.Time
+	var nextRecordingAfter, nextSnapshotAfter time.Time
 	var oldestHistoricalAt time.Time
 	if series.GroupBy != nil {
 		// We want to disable interval recording for compute types.
@@ -1021,9 +1058,17 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, scopedBa
 		nextRecordingAfter = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
 		oldestHistoricalAt = time.Now()
 	}
+	if derived {
+		// Derived series are calculated at read time from the other series of the insight, so they are never
+		// recorded themselves.
+		nextRecordingAfter = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+		nextSnapshotAfter = nextRecordingAfter
+		series.RepositoryScope.Repositories = nil
+	}
 
-	// Don't try to match on non-global series, since they are always replaced
-	if len(series.RepositoryScope.Repositories) == 0 {
+	// Don't try to match on non-global series, since they are always replaced. Derived series are only meaningful
+	// together with the series of their insight, so they aren't shared either.
+	if len(series.RepositoryScope.Repositories) == 0 && !derived {
 		matchingSeries, foundSeries, err = tx.FindMatchingSeries(ctx, store.MatchSeriesArgs{
 			Query:                     series.Query,
 			StepIntervalUnit:          series.TimeScope.StepInterval.Unit,
@@ -1053,12 +1098,19 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, scopedBa
 			GenerationMethod:           searchGenerationMethod(series),
 			GroupBy:                    groupBy,
 			NextRecordingAfter:         nextRecordingAfter,
+			NextSnapshotAfter:          nextSnapshotAfter,
 			OldestHistoricalAt:         oldestHistoricalAt,
 		})
 		if err != nil {
 			return nil, errors.Wrap(err, "CreateSeries")
 		}
-		if groupBy != nil {
+		if derived {
+			// Stamp the backfill so that no backfill is queued for the series.
+			_, err = tx.StampBackfill(ctx, seriesToAdd)
+			if err != nil {
+				return nil, errors.Wrap(err, "Derived.StampBackfill")
+			}
+		} else if groupBy != nil {
 			if err := insightEnqueuer.EnqueueSingle(ctx, seriesToAdd, store.SnapshotMode, tx.StampSnapshot); err != nil {
 				return nil, errors.Wrap(err, "GroupBy.EnqueueSingle")
 			}
@@ -1097,6 +1149,9 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, scopedBa
 }
 
 func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+	if isDerived(series) {
+		return types.Derived
+	}
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
 			return types.MappingCompute
@@ -1106,6 +1161,38 @@ func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeri
 	return types.Search
 }
 
+func isDerived(series graphqlbackend.LineChartSearchInsightDataSeriesInput) bool {
+	return series.Derived != nil && *series.Derived
+}
+
+// validateDerivedSeries validates that the expressions of the derived series only reference other series of the
+// insight by their label. Derived series can't reference series generated from capture groups, nor other derived
+// series.
+func validateDerivedSeries(dataSeries []graphqlbackend.LineChartSearchInsightDataSeriesInput) error {
+	labels := make(map[string]struct{}, len(dataSeries))
+	for _, series := range dataSeries {
+		if !isDerived(series) && (series.GeneratedFromCaptureGroups == nil || !*series.GeneratedFromCaptureGroups) {
+			labels[emptyIfNil(series.Options.Label)] = struct{}{}
+		}
+	}
+
+	for _, series := range dataSeries {
+		if !isDerived(series) {
+			continue
+		}
+		expr, err := timeseries.ParseExpression(series.Query)
+		if err != nil {
+			return errors.Wrapf(err, "invalid expression of series %q", emptyIfNil(series.Options.Label))
+		}
+		for _, v := range expr.Variables() {
+			if _, ok := labels[v]; !ok {
+				return errors.Newf("series %q references unknown series %q", emptyIfNil(series.Options.Label), v)
+			}
+		}
+	}
+	return nil
+}
+
 func seriesFound(existingSeries types.InsightViewSeries, inputSeries []graphqlbackend.LineChartSearchInsightDataSeriesInput) bool {
 	for i := range inputSeries {
 		if inputSeries[i].SeriesId == nil {
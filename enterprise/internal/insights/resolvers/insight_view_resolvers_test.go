@@ -546,3 +546,75 @@ func TestRemoveClosePoints(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDerivedSeries(t *testing.T) {
+	truePtr := func() *bool { b := true; return &b }
+	series := func(label, query string, derived bool) graphqlbackend.LineChartSearchInsightDataSeriesInput {
+		s := graphqlbackend.LineChartSearchInsightDataSeriesInput{
+			Query:   query,
+			Options: graphqlbackend.LineChartDataSeriesOptionsInput{Label: addrStr(label)},
+		}
+		if derived {
+			s.Derived = truePtr()
+		}
+		return s
+	}
+	captureGroups := series("versions", "go\\s(\\d\\.\\d+)", false)
+	captureGroups.GeneratedFromCaptureGroups = truePtr()
+
+	tests := []struct {
+		name    string
+		series  []graphqlbackend.LineChartSearchInsightDataSeriesInput
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			series: []graphqlbackend.LineChartSearchInsightDataSeriesInput{
+				series("new_api", "newapi.Call", false),
+				series("Old API", "oldapi.Call", false),
+				series("ratio", `new_api / (new_api + "Old API")`, true),
+			},
+		},
+		{
+			name: "unknown series",
+			series: []graphqlbackend.LineChartSearchInsightDataSeriesInput{
+				series("new_api", "newapi.Call", false),
+				series("ratio", "new_api / (new_api + old_api)", true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "references derived series",
+			series: []graphqlbackend.LineChartSearchInsightDataSeriesInput{
+				series("new_api", "newapi.Call", false),
+				series("double", "new_api * 2", true),
+				series("quadruple", "double * 2", true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "references capture groups series",
+			series: []graphqlbackend.LineChartSearchInsightDataSeriesInput{
+				captureGroups,
+				series("double", "versions * 2", true),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid expression",
+			series: []graphqlbackend.LineChartSearchInsightDataSeriesInput{
+				series("new_api", "newapi.Call", false),
+				series("ratio", "new_api / ", true),
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateDerivedSeries(test.series)
+			if (err != nil) != test.wantErr {
+				t.Errorf("unexpected error. wantErr=%t, have=%v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, errors.New("Unable to create an alert without being signed in.")
+	}
+
+	var viewId string
+	if err := relay.UnmarshalSpec(args.Input.InsightViewId, &viewId); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight view id")
+	}
+	permissionsValidator := PermissionsValidatorFromBase(&r.baseInsightResolver)
+	if err := permissionsValidator.validateUserAccessForView(ctx, viewId); err != nil {
+		return nil, err
+	}
+
+	insights, err := r.insightStore.GetMapped(ctx, store.InsightQueryArgs{WithoutAuthorization: true, UniqueID: viewId})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetMapped")
+	}
+	if len(insights) != 1 {
+		return nil, errors.New("Insight not found.")
+	}
+	if err := validateSeriesAlert(insights[0], args.Input); err != nil {
+		return nil, err
+	}
+
+	series, err := r.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: args.Input.SeriesId})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDataSeries")
+	}
+	if len(series) != 1 {
+		return nil, errors.New("Series not found.")
+	}
+
+	alert, err := r.alertStore.CreateAlert(ctx, types.InsightSeriesAlert{
+		InsightViewID:   insights[0].ViewID,
+		InsightSeriesID: series[0].ID,
+		CreatorID:       uid,
+		Condition:       types.InsightSeriesAlertCondition(args.Input.Condition),
+		Threshold:       args.Input.Threshold,
+		NotifyEmail:     args.Input.NotifyEmail != nil && *args.Input.NotifyEmail,
+		SlackWebhookURL: nonEmpty(args.Input.SlackWebhookURL),
+		WebhookURL:      nonEmpty(args.Input.WebhookURL),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateAlert")
+	}
+	return &insightSeriesAlertResolver{alert: alert}, nil
+}
+
+// validateSeriesAlert validates an alert on a series of the given insight. Alerts are evaluated on the points
+// recorded for a single series, so they can't be created on derived series, which don't record points, nor on
+// series that are split in one series per capture group.
+func validateSeriesAlert(insight types.Insight, input graphqlbackend.CreateInsightSeriesAlertInput) error {
+	var series *types.InsightViewSeries
+	for i := range insight.Series {
+		if insight.Series[i].SeriesID == input.SeriesId {
+			series = &insight.Series[i]
+			break
+		}
+	}
+	if series == nil {
+		return errors.Newf("series %q is not part of the insight", input.SeriesId)
+	}
+	if series.GenerationMethod == types.Derived {
+		return errors.New("alerts can't be created on derived series")
+	}
+	if series.GeneratedFromCaptureGroups || series.GroupBy != nil {
+		return errors.New("alerts can't be created on series generated from capture groups")
+	}
+
+	switch types.InsightSeriesAlertCondition(input.Condition) {
+	case types.ValueAbove, types.ValueBelow, types.WeekOverWeekIncrease:
+	default:
+		return errors.Newf("invalid alert condition %q", input.Condition)
+	}
+
+	notifyEmail := input.NotifyEmail != nil && *input.NotifyEmail
+	slackWebhookURL, webhookURL := nonEmpty(input.SlackWebhookURL), nonEmpty(input.WebhookURL)
+	if !notifyEmail && slackWebhookURL == nil && webhookURL == nil {
+		return errors.New("at least one of notifyEmail, slackWebhookURL and webhookURL must be set")
+	}
+	for _, u := range []*string{slackWebhookURL, webhookURL} {
+		if u == nil {
+			continue
+		}
+		if parsed, err := url.Parse(*u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return errors.Newf("invalid webhook URL %q", *u)
+		}
+	}
+	return nil
+}
+
+func nonEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the alert id")
+	}
+
+	alerts, err := r.alertStore.GetAlerts(ctx, store.AlertQueryArgs{ID: id})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlerts")
+	}
+	if len(alerts) != 1 {
+		return nil, errors.New("Alert not found.")
+	}
+
+	// 🚨 SECURITY: Only the creator of the alert and site admins can delete it.
+	if uid := actor.FromContext(ctx).UID; uid == 0 || uid != alerts[0].CreatorID {
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.postgresDB); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.alertStore.DeleteAlert(ctx, id); err != nil {
+		return nil, errors.Wrap(err, "DeleteAlert")
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (i *insightViewResolver) Alerts(ctx context.Context) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	uid := actor.FromContext(ctx).UID
+	if uid == 0 {
+		return nil, nil
+	}
+
+	alerts, err := i.alertStore.GetAlerts(ctx, store.AlertQueryArgs{InsightViewID: i.view.ViewID, CreatorID: uid})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetAlerts")
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alert: alert})
+	}
+	return resolvers, nil
+}
+
+type insightSeriesAlertResolver struct {
+	alert types.InsightSeriesAlert
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, r.alert.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesId() string {
+	return r.alert.SeriesID
+}
+
+func (r *insightSeriesAlertResolver) Condition() string {
+	return string(r.alert.Condition)
+}
+
+func (r *insightSeriesAlertResolver) Threshold() float64 {
+	return r.alert.Threshold
+}
+
+func (r *insightSeriesAlertResolver) NotifyEmail() bool {
+	return r.alert.NotifyEmail
+}
+
+func (r *insightSeriesAlertResolver) SlackWebhookURL() *string {
+	return r.alert.SlackWebhookURL
+}
+
+func (r *insightSeriesAlertResolver) WebhookURL() *string {
+	return r.alert.WebhookURL
+}
+
+func (r *insightSeriesAlertResolver) Firing() bool {
+	return r.alert.Firing
+}
+
+func (r *insightSeriesAlertResolver) LastAlertedAt() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.alert.LastAlertedAt)
+}
+
+func (r *insightSeriesAlertResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.alert.CreatedAt}
+}
@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestValidateSeriesAlert(t *testing.T) {
+	truePtr := func() *bool { b := true; return &b }
+	groupBy := "repo"
+	insight := types.Insight{Series: []types.InsightViewSeries{
+		{SeriesID: "search", GenerationMethod: types.Search},
+		{SeriesID: "derived", GenerationMethod: types.Derived},
+		{SeriesID: "capture", GenerationMethod: types.SearchCompute, GeneratedFromCaptureGroups: true},
+		{SeriesID: "compute", GenerationMethod: types.MappingCompute, GroupBy: &groupBy},
+	}}
+	input := func(seriesID string, modify func(*graphqlbackend.CreateInsightSeriesAlertInput)) graphqlbackend.CreateInsightSeriesAlertInput {
+		in := graphqlbackend.CreateInsightSeriesAlertInput{
+			SeriesId:    seriesID,
+			Condition:   string(types.ValueAbove),
+			Threshold:   10,
+			NotifyEmail: truePtr(),
+		}
+		if modify != nil {
+			modify(&in)
+		}
+		return in
+	}
+
+	tests := []struct {
+		name    string
+		input   graphqlbackend.CreateInsightSeriesAlertInput
+		wantErr bool
+	}{
+		{name: "valid", input: input("search", nil)},
+		{
+			name: "valid webhooks only",
+			input: input("search", func(in *graphqlbackend.CreateInsightSeriesAlertInput) {
+				in.NotifyEmail = nil
+				in.SlackWebhookURL = addrStr("https://hooks.slack.com/services/x")
+				in.WebhookURL = addrStr("http://example.com/alerts")
+			}),
+		},
+		{name: "unknown series", input: input("other", nil), wantErr: true},
+		{name: "derived series", input: input("derived", nil), wantErr: true},
+		{name: "capture groups series", input: input("capture", nil), wantErr: true},
+		{name: "compute series", input: input("compute", nil), wantErr: true},
+		{
+			name:    "invalid condition",
+			input:   input("search", func(in *graphqlbackend.CreateInsightSeriesAlertInput) { in.Condition = "CHANGED" }),
+			wantErr: true,
+		},
+		{
+			name: "no channel",
+			input: input("search", func(in *graphqlbackend.CreateInsightSeriesAlertInput) {
+				in.NotifyEmail = nil
+				in.WebhookURL = addrStr("")
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "invalid webhook URL",
+			input:   input("search", func(in *graphqlbackend.CreateInsightSeriesAlertInput) { in.WebhookURL = addrStr("ftp://example.com") }),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSeriesAlert(insight, tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("wrong error. wantErr=%t, have=%v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+type AlertStore struct {
+	*basestore.Store
+	Now func() time.Time
+}
+
+// NewAlertStore returns a new AlertStore backed by the given Postgres db.
+func NewAlertStore(db edb.InsightsDB) *AlertStore {
+	return &AlertStore{Store: basestore.NewWithHandle(db.Handle()), Now: time.Now}
+}
+
+// With creates a new AlertStore with the given basestore.Shareable store as the underlying basestore.Store.
+// Needed to implement the basestore.Store interface
+func (s *AlertStore) With(other basestore.ShareableStore) *AlertStore {
+	return &AlertStore{Store: s.Store.With(other), Now: s.Now}
+}
+
+func (s *AlertStore) Transact(ctx context.Context) (*AlertStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AlertStore{Store: txBase, Now: s.Now}, err
+}
+
+type AlertQueryArgs struct {
+	ID            int
+	InsightViewID int
+	CreatorID     int32
+	SeriesID      string
+
+	// ExcludeDeletedSeries excludes the alerts on series that have been deleted, which won't record any new points.
+	ExcludeDeletedSeries bool
+}
+
+// GetAlerts returns the alerts matching the given arguments, ordered by ID.
+func (s *AlertStore) GetAlerts(ctx context.Context, args AlertQueryArgs) ([]types.InsightSeriesAlert, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if args.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("a.id = %s", args.ID))
+	}
+	if args.InsightViewID != 0 {
+		preds = append(preds, sqlf.Sprintf("a.insight_view_id = %s", args.InsightViewID))
+	}
+	if args.CreatorID != 0 {
+		preds = append(preds, sqlf.Sprintf("a.creator_user_id = %s", args.CreatorID))
+	}
+	if args.SeriesID != "" {
+		preds = append(preds, sqlf.Sprintf("s.series_id = %s", args.SeriesID))
+	}
+	if args.ExcludeDeletedSeries {
+		preds = append(preds, sqlf.Sprintf("s.deleted_at IS NULL"))
+	}
+
+	q := sqlf.Sprintf(getAlertsSql, sqlf.Join(preds, "\n AND "))
+	return scanAlerts(s.Query(ctx, q))
+}
+
+const getAlertsSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:GetAlerts
+SELECT a.id, a.insight_view_id, a.insight_series_id, a.creator_user_id, a.condition, a.threshold, a.notify_email,
+       a.slack_webhook_url, a.webhook_url, a.firing, a.last_evaluated_at, a.last_alerted_at, a.created_at,
+       a.sent_channels, s.series_id, COALESCE(ivs.label, ''), v.unique_id, COALESCE(v.title, '')
+FROM insight_series_alerts a
+JOIN insight_series s ON s.id = a.insight_series_id
+JOIN insight_view v ON v.id = a.insight_view_id
+LEFT JOIN insight_view_series ivs ON ivs.insight_view_id = a.insight_view_id AND ivs.insight_series_id = a.insight_series_id
+WHERE %s
+ORDER BY a.id
+`
+
+// CreateAlert creates the given alert and returns it with the fields of the series and view it belongs to.
+func (s *AlertStore) CreateAlert(ctx context.Context, alert types.InsightSeriesAlert) (_ types.InsightSeriesAlert, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	id, _, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(createAlertSql,
+		alert.InsightViewID,
+		alert.InsightSeriesID,
+		alert.CreatorID,
+		alert.Condition,
+		alert.Threshold,
+		alert.NotifyEmail,
+		alert.SlackWebhookURL,
+		alert.WebhookURL,
+		s.Now(),
+	)))
+	if err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+
+	alerts, err := tx.GetAlerts(ctx, AlertQueryArgs{ID: id})
+	if err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	if len(alerts) == 0 {
+		return types.InsightSeriesAlert{}, sql.ErrNoRows
+	}
+	return alerts[0], nil
+}
+
+const createAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:CreateAlert
+INSERT INTO insight_series_alerts (insight_view_id, insight_series_id, creator_user_id, condition, threshold, notify_email,
+                                   slack_webhook_url, webhook_url, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING id;
+`
+
+// DeleteAlert deletes the alert with the given ID.
+func (s *AlertStore) DeleteAlert(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteAlertSql, id))
+}
+
+const deleteAlertSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:DeleteAlert
+DELETE FROM insight_series_alerts WHERE id = %s;
+`
+
+// UpdateAlertState records that the alert was evaluated for the series point at the given time, whether its
+// condition was met, and when an alert was sent if one was. The channels recorded by UpdateAlertSentChannels are
+// cleared.
+func (s *AlertStore) UpdateAlertState(ctx context.Context, id int, evaluatedAt time.Time, firing bool, alertedAt *time.Time) error {
+	return s.Exec(ctx, sqlf.Sprintf(updateAlertStateSql, evaluatedAt, firing, alertedAt, id))
+}
+
+const updateAlertStateSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:UpdateAlertState
+UPDATE insight_series_alerts
+SET last_evaluated_at = %s, firing = %s, last_alerted_at = COALESCE(%s, last_alerted_at), sent_channels = '{}'
+WHERE id = %s;
+`
+
+// UpdateAlertSentChannels records the channels an alert has been sent through when sending it through the
+// others failed, so that they're skipped when it's retried.
+func (s *AlertStore) UpdateAlertSentChannels(ctx context.Context, id int, channels []string) error {
+	if channels == nil {
+		channels = []string{}
+	}
+	return s.Exec(ctx, sqlf.Sprintf(updateAlertSentChannelsSql, pq.Array(channels), id))
+}
+
+const updateAlertSentChannelsSql = `
+-- source: enterprise/internal/insights/store/alert_store.go:UpdateAlertSentChannels
+UPDATE insight_series_alerts
+SET sent_channels = %s
+WHERE id = %s;
+`
+
+func scanAlerts(rows *sql.Rows, queryErr error) (_ []types.InsightSeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	results := make([]types.InsightSeriesAlert, 0)
+	for rows.Next() {
+		var temp types.InsightSeriesAlert
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.InsightViewID,
+			&temp.InsightSeriesID,
+			&temp.CreatorID,
+			&temp.Condition,
+			&temp.Threshold,
+			&temp.NotifyEmail,
+			&temp.SlackWebhookURL,
+			&temp.WebhookURL,
+			&temp.Firing,
+			&temp.LastEvaluatedAt,
+			&temp.LastAlertedAt,
+			&temp.CreatedAt,
+			pq.Array(&temp.SentChannels),
+			&temp.SeriesID,
+			&temp.SeriesLabel,
+			&temp.ViewUniqueID,
+			&temp.ViewTitle,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, temp)
+	}
+	return results, nil
+}
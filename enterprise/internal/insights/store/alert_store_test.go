@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestAlertStore(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	now := time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	_, err := insightsDB.ExecContext(ctx, `INSERT INTO insight_view (id, title, description, unique_id)
+									VALUES (1, 'crypto usage', '', 'unique-1')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = insightsDB.ExecContext(ctx, `INSERT INTO insight_series (id, series_id, query, created_at, oldest_historical_at, last_recorded_at,
+		next_recording_after, last_snapshot_at, next_snapshot_after, deleted_at, generation_method, group_by)
+		VALUES  (1, 'series-id-1', 'query-1', $1, $1, $1, $1, $1, $1, null, 'search', null),
+				(2, 'series-id-2', 'query-2', $1, $1, $1, $1, $1, $1, $1, 'search', null)`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = insightsDB.ExecContext(ctx, `INSERT INTO insight_view_series (insight_view_id, insight_series_id, label, stroke)
+	VALUES  (1, 1, 'md5', 'color'),
+			(1, 2, 'sha1', 'color')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewAlertStore(insightsDB)
+	store.Now = func() time.Time { return now }
+
+	webhook := "https://example.com/webhook"
+	alert, err := store.CreateAlert(ctx, types.InsightSeriesAlert{
+		InsightViewID:   1,
+		InsightSeriesID: 1,
+		CreatorID:       42,
+		Condition:       types.ValueAbove,
+		Threshold:       10,
+		NotifyEmail:     true,
+		WebhookURL:      &webhook,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alert.SeriesID != "series-id-1" || alert.SeriesLabel != "md5" || alert.ViewUniqueID != "unique-1" || alert.ViewTitle != "crypto usage" {
+		t.Errorf("wrong series and view fields: %+v", alert)
+	}
+	if alert.WebhookURL == nil || *alert.WebhookURL != webhook || alert.SlackWebhookURL != nil {
+		t.Errorf("wrong webhooks: %+v", alert)
+	}
+	if _, err := store.CreateAlert(ctx, types.InsightSeriesAlert{
+		InsightViewID:   1,
+		InsightSeriesID: 2,
+		CreatorID:       43,
+		Condition:       types.WeekOverWeekIncrease,
+		Threshold:       50,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("get", func(t *testing.T) {
+		for _, tc := range []struct {
+			args AlertQueryArgs
+			want int
+		}{
+			{AlertQueryArgs{}, 2},
+			{AlertQueryArgs{InsightViewID: 1}, 2},
+			{AlertQueryArgs{CreatorID: 42}, 1},
+			{AlertQueryArgs{ID: alert.ID}, 1},
+			{AlertQueryArgs{SeriesID: "series-id-2"}, 1},
+			{AlertQueryArgs{ExcludeDeletedSeries: true}, 1},
+		} {
+			alerts, err := store.GetAlerts(ctx, tc.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(alerts) != tc.want {
+				t.Errorf("wrong number of alerts for %+v. want=%d, have=%d", tc.args, tc.want, len(alerts))
+			}
+		}
+	})
+
+	t.Run("update state", func(t *testing.T) {
+		if err := store.UpdateAlertSentChannels(ctx, alert.ID, []string{"webhook"}); err != nil {
+			t.Fatal(err)
+		}
+		alerts, err := store.GetAlerts(ctx, AlertQueryArgs{ID: alert.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have := alerts[0].SentChannels; len(have) != 1 || have[0] != "webhook" {
+			t.Errorf("wrong sent channels: %v", have)
+		}
+
+		evaluatedAt := now.Add(-time.Hour)
+		if err := store.UpdateAlertState(ctx, alert.ID, evaluatedAt, true, &now); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.UpdateAlertState(ctx, alert.ID, now, true, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		alerts, err = store.GetAlerts(ctx, AlertQueryArgs{ID: alert.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		have := alerts[0]
+		if len(have.SentChannels) != 0 {
+			t.Errorf("sent channels not cleared: %v", have.SentChannels)
+		}
+		if !have.Firing || have.LastEvaluatedAt == nil || !have.LastEvaluatedAt.Equal(now) || have.LastAlertedAt == nil || !have.LastAlertedAt.Equal(now) {
+			t.Errorf("wrong state: %+v", have)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := store.DeleteAlert(ctx, alert.ID); err != nil {
+			t.Fatal(err)
+		}
+		alerts, err := store.GetAlerts(ctx, AlertQueryArgs{ID: alert.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(alerts) != 0 {
+			t.Errorf("alert not deleted: %+v", alerts)
+		}
+	})
+}
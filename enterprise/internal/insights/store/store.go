@@ -110,6 +110,13 @@ type SeriesPointsOpts struct {
 
 	// Limit is the number of data points to query, if non-zero.
 	Limit int
+
+	// ExcludeSnapshots excludes the snapshot points, which are replaced each time a new snapshot is taken, and
+	// only returns the recorded points.
+	ExcludeSnapshots bool
+
+	// LatestOnly only returns the points recorded at the latest time of the series, if SeriesID is non-nil.
+	LatestOnly bool
 }
 
 // SeriesPoints queries data points over time for a specific insights' series.
@@ -132,7 +139,11 @@ func (s *Store) SeriesPoints(ctx context.Context, opts SeriesPointsOpts) ([]Seri
 	}
 	opts.Excluded = append(opts.Excluded, denylist...)
 
-	q := seriesPointsQuery(fullVectorSeriesAggregation, opts)
+	baseQuery := fullVectorSeriesAggregation
+	if opts.ExcludeSnapshots {
+		baseQuery = recordedVectorSeriesAggregation
+	}
+	q := seriesPointsQuery(baseQuery, opts)
 	err = s.query(ctx, q, func(sc scanner) error {
 		var point SeriesPoint
 		err := sc.Scan(
@@ -288,6 +299,20 @@ GROUP BY sub.series_id, sub.interval_time, sub.capture
 ORDER BY sub.series_id, sub.interval_time ASC
 `
 
+const recordedVectorSeriesAggregation = `
+-- source: enterprise/internal/insights/store/store.go:SeriesPoints
+SELECT sub.series_id, sub.interval_time, SUM(sub.value) as value, sub.capture FROM (
+	SELECT sp.repo_name_id, sp.series_id, date_trunc('seconds', sp.time) AS interval_time, MAX(value) as value, capture
+	FROM series_points AS sp
+	%s
+	WHERE %s
+	GROUP BY sp.series_id, interval_time, sp.repo_name_id, capture
+	ORDER BY sp.series_id, interval_time, sp.repo_name_id
+) sub
+GROUP BY sub.series_id, sub.interval_time, sub.capture
+ORDER BY sub.series_id, sub.interval_time ASC
+`
+
 // Note that the series_points table may contain duplicate points, or points recorded at irregular
 // intervals. In specific:
 //
@@ -342,6 +367,14 @@ func seriesPointsPredicates(opts SeriesPointsOpts) []*sqlf.Query {
 	if opts.To != nil {
 		preds = append(preds, sqlf.Sprintf("time <= %s", *opts.To))
 	}
+	if opts.LatestOnly && opts.SeriesID != nil {
+		// Points are grouped by second, so the points of the latest second are returned.
+		latestTime := sqlf.Sprintf(latestSeriesPointTime, *opts.SeriesID, *opts.SeriesID)
+		if opts.ExcludeSnapshots {
+			latestTime = sqlf.Sprintf(latestRecordedSeriesPointTime, *opts.SeriesID)
+		}
+		preds = append(preds, sqlf.Sprintf("time >= date_trunc('seconds', (%s))", latestTime))
+	}
 
 	if len(opts.Included) > 0 {
 		s := fmt.Sprintf("repo_id = any(%v)", values(opts.Included))
@@ -373,6 +406,18 @@ func seriesPointsPredicates(opts SeriesPointsOpts) []*sqlf.Query {
 	return preds
 }
 
+const latestSeriesPointTime = `
+SELECT MAX(latest.time) FROM (
+	SELECT MAX(time) AS time FROM series_points WHERE series_id = %s
+	UNION ALL
+	SELECT MAX(time) AS time FROM series_points_snapshots WHERE series_id = %s
+) latest
+`
+
+const latestRecordedSeriesPointTime = `
+SELECT MAX(time) FROM series_points WHERE series_id = %s
+`
+
 // values constructs a SQL values statement out of an array of repository ids
 func values(ids []api.RepoID) string {
 	if len(ids) == 0 {
@@ -548,6 +593,43 @@ func (s *Store) RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointA
 	return nil
 }
 
+// ReplaceRecordedSeriesPoints records the given points, replacing all the points previously recorded for the same
+// series at the same times. It is used to import historical data, which should override the data recorded for
+// those times rather than add up to it.
+func (s *Store) ReplaceRecordedSeriesPoints(ctx context.Context, pts []RecordSeriesPointArgs) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	type seriesTime struct {
+		seriesID string
+		time     time.Time
+	}
+	seen := make(map[seriesTime]struct{}, len(pts))
+	for _, pt := range pts {
+		if pt.PersistMode != RecordMode {
+			return errors.Newf("unsupported insights series point persist mode for replacement: %v", pt.PersistMode)
+		}
+		key := seriesTime{seriesID: pt.SeriesID, time: pt.Point.Time.UTC()}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		if err := tx.Exec(ctx, sqlf.Sprintf(deleteRecordedPointsAtTimeSql, key.seriesID, key.time)); err != nil {
+			return errors.Wrap(err, "deleting recorded points")
+		}
+	}
+
+	return tx.RecordSeriesPoints(ctx, pts)
+}
+
+const deleteRecordedPointsAtTimeSql = `
+-- source: enterprise/internal/insights/store/store.go:ReplaceRecordedSeriesPoints
+DELETE FROM series_points WHERE series_id = %s AND time = %s;
+`
+
 const upsertRepoNameFmtStr = `
 -- source: enterprise/internal/insights/store/store.go:RecordSeriesPoint
 WITH e AS(
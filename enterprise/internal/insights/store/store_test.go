@@ -320,6 +320,107 @@ func TestRecordSeriesPoints(t *testing.T) {
 	}
 }
 
+func TestReplaceRecordedSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := NewWithClock(insightsDB, NewInsightPermissionStore(postgres), timeutil.Now)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+	previous := current.Add(-time.Hour * 24 * 7)
+
+	if err := store.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 1}, RepoName: optionalString("repo1"), RepoID: optionalRepoID(3), PersistMode: RecordMode},
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 2}, RepoName: optionalString("repo2"), RepoID: optionalRepoID(4), PersistMode: RecordMode},
+		{SeriesID: "one", Point: SeriesPoint{Time: previous, Value: 5}, RepoName: optionalString("repo1"), RepoID: optionalRepoID(3), PersistMode: RecordMode},
+		{SeriesID: "two", Point: SeriesPoint{Time: current, Value: 7}, PersistMode: RecordMode},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.ReplaceRecordedSeriesPoints(ctx, []RecordSeriesPointArgs{
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 10}, PersistMode: RecordMode},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	points, err := store.SeriesPoints(ctx, SeriesPointsOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SeriesPoint{
+		{SeriesID: "one", Time: previous, Value: 5},
+		{SeriesID: "one", Time: current, Value: 10},
+		{SeriesID: "two", Time: current, Value: 7},
+	}
+	if diff := cmp.Diff(want, points); diff != "" {
+		t.Errorf("unexpected points (-want +got):\n%s", diff)
+	}
+
+	if err := store.ReplaceRecordedSeriesPoints(ctx, []RecordSeriesPointArgs{
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 10}, PersistMode: SnapshotMode},
+	}); err == nil {
+		t.Error("expected error replacing snapshot points")
+	}
+}
+
+func TestSeriesPointsLatestOnly(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := NewWithClock(insightsDB, NewInsightPermissionStore(postgres), timeutil.Now)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+	previous := current.Add(-time.Hour * 24 * 7)
+	snapshot := current.Add(time.Hour)
+
+	if err := store.RecordSeriesPoints(ctx, []RecordSeriesPointArgs{
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 1}, RepoName: optionalString("repo1"), RepoID: optionalRepoID(3), PersistMode: RecordMode},
+		{SeriesID: "one", Point: SeriesPoint{Time: current, Value: 2}, RepoName: optionalString("repo2"), RepoID: optionalRepoID(4), PersistMode: RecordMode},
+		{SeriesID: "one", Point: SeriesPoint{Time: previous, Value: 5}, RepoName: optionalString("repo1"), RepoID: optionalRepoID(3), PersistMode: RecordMode},
+		{SeriesID: "one", Point: SeriesPoint{Time: snapshot, Value: 4}, RepoName: optionalString("repo1"), RepoID: optionalRepoID(3), PersistMode: SnapshotMode},
+		{SeriesID: "two", Point: SeriesPoint{Time: snapshot.Add(time.Hour), Value: 7}, PersistMode: RecordMode},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	seriesID := "one"
+	for _, tc := range []struct {
+		name             string
+		excludeSnapshots bool
+		want             []SeriesPoint
+	}{
+		{name: "with snapshots", want: []SeriesPoint{{SeriesID: "one", Time: snapshot, Value: 4}}},
+		{name: "recorded only", excludeSnapshots: true, want: []SeriesPoint{{SeriesID: "one", Time: current, Value: 3}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			points, err := store.SeriesPoints(ctx, SeriesPointsOpts{SeriesID: &seriesID, ExcludeSnapshots: tc.excludeSnapshots, LatestOnly: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, points); diff != "" {
+				t.Errorf("unexpected points (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestRecordSeriesPointsSnapshotOnly(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
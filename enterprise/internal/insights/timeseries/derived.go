@@ -0,0 +1,76 @@
+package timeseries
+
+import (
+	"sort"
+	"time"
+)
+
+// Point is the value of a series at a point in time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// EvaluateExpression evaluates a derived series over the series it references,
+// which are keyed by their label. The points of the referenced series are
+// aligned in frames of the given interval ending at now, and the expression is
+// evaluated once per frame with the latest point of each series in that frame.
+// The resulting point is placed at the time of the latest point it was
+// calculated from. Frames in which a referenced series has no point, or in
+// which the expression divides by zero, are skipped.
+func EvaluateExpression(expr *Expression, series map[string][]Point, interval TimeInterval, now time.Time) []Point {
+	var oldest time.Time
+	for _, v := range expr.Variables() {
+		for _, p := range series[v] {
+			if oldest.IsZero() || p.Time.Before(oldest) {
+				oldest = p.Time
+			}
+		}
+	}
+	if oldest.IsZero() {
+		return nil
+	}
+
+	numPoints := 1
+	for current := now; current.After(oldest); current = interval.StepBackwards(current) {
+		numPoints++
+	}
+	frames := BuildFrames(numPoints, interval, now)
+
+	// latest holds the latest point of each referenced series per frame.
+	latest := make([]map[string]Point, len(frames))
+	for i := range latest {
+		latest[i] = make(map[string]Point)
+	}
+	for _, v := range expr.Variables() {
+		for _, p := range series[v] {
+			i := sort.Search(len(frames), func(i int) bool { return frames[i].From.After(p.Time) }) - 1
+			if i < 0 {
+				continue
+			}
+			if prev, ok := latest[i][v]; !ok || p.Time.After(prev.Time) {
+				latest[i][v] = p
+			}
+		}
+	}
+
+	var points []Point
+	for _, frame := range latest {
+		if len(frame) != len(expr.Variables()) {
+			continue
+		}
+
+		var at time.Time
+		values := make(map[string]float64, len(frame))
+		for v, p := range frame {
+			values[v] = p.Value
+			if p.Time.After(at) {
+				at = p.Time
+			}
+		}
+		if value, ok := expr.Eval(values); ok {
+			points = append(points, Point{Time: at, Value: value})
+		}
+	}
+	return points
+}
@@ -0,0 +1,59 @@
+package timeseries
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day int) time.Time { return time.Date(2022, month, day, 0, 0, 0, 0, time.UTC) }
+
+	expr, err := ParseExpression("new / (new + old)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	series := map[string][]Point{
+		"new": {
+			{Time: at(3, 1), Value: 1},
+			{Time: at(4, 2), Value: 2},
+			// Recorded twice in the same month, the latest point is used.
+			{Time: at(5, 1), Value: 2},
+			{Time: at(5, 3), Value: 3},
+		},
+		"old": {
+			// The series of new doesn't reach back this far.
+			{Time: at(2, 1), Value: 4},
+			{Time: at(3, 1), Value: 3},
+			{Time: at(4, 1), Value: 2},
+			{Time: at(5, 2), Value: 1},
+		},
+		"unrelated": {{Time: at(1, 1), Value: 1}},
+	}
+
+	have := EvaluateExpression(expr, series, TimeInterval{Unit: types.Month, Value: 1}, now)
+	want := []Point{
+		{Time: at(3, 1), Value: 0.25},
+		{Time: at(4, 2), Value: 0.5},
+		{Time: at(5, 3), Value: 0.75},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("wrong points (-want +got):\n%s", diff)
+	}
+
+	t.Run("undefined values are skipped", func(t *testing.T) {
+		series := map[string][]Point{
+			"new": {{Time: at(4, 1), Value: 0}, {Time: at(5, 1), Value: 1}},
+			"old": {{Time: at(4, 1), Value: 0}, {Time: at(5, 1), Value: 1}},
+		}
+		have := EvaluateExpression(expr, series, TimeInterval{Unit: types.Month, Value: 1}, now)
+		if diff := cmp.Diff([]Point{{Time: at(5, 1), Value: 0.5}}, have); diff != "" {
+			t.Errorf("wrong points (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -0,0 +1,246 @@
+package timeseries
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Expression is a parsed arithmetic expression over other series, for example
+// `new_api / (new_api + old_api)`. Series are referenced by their label: labels
+// that are valid identifiers can be used as is, all others have to be enclosed
+// in double quotes, for example `"New API" * 100`.
+type Expression struct {
+	root      node
+	variables []string
+}
+
+// ParseExpression parses an expression consisting of numbers, series
+// references, the operators +, -, * and /, and parentheses.
+func ParseExpression(input string) (*Expression, error) {
+	p := &parser{input: input}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos < len(p.input) {
+		return nil, errors.Newf("unexpected %q at position %d", p.input[p.pos], p.pos)
+	}
+
+	e := &Expression{root: root}
+	seen := map[string]struct{}{}
+	root.walk(func(n node) {
+		if v, ok := n.(variable); ok {
+			if _, ok := seen[string(v)]; !ok {
+				seen[string(v)] = struct{}{}
+				e.variables = append(e.variables, string(v))
+			}
+		}
+	})
+	if len(e.variables) == 0 {
+		return nil, errors.New("expression doesn't reference any series")
+	}
+	return e, nil
+}
+
+// Variables returns the labels of the series referenced by the expression, in
+// the order they first appear in.
+func (e *Expression) Variables() []string {
+	return e.variables
+}
+
+// Eval evaluates the expression with the given values of the referenced
+// series. It returns false if a value is missing or the expression divides by
+// zero.
+func (e *Expression) Eval(values map[string]float64) (float64, bool) {
+	return e.root.eval(values)
+}
+
+type node interface {
+	eval(values map[string]float64) (float64, bool)
+	walk(func(node))
+}
+
+type number float64
+
+func (n number) eval(map[string]float64) (float64, bool) { return float64(n), true }
+func (n number) walk(f func(node))                       { f(n) }
+
+type variable string
+
+func (v variable) eval(values map[string]float64) (float64, bool) {
+	value, ok := values[string(v)]
+	return value, ok
+}
+
+func (v variable) walk(f func(node)) { f(v) }
+
+type negation struct{ operand node }
+
+func (n negation) eval(values map[string]float64) (float64, bool) {
+	v, ok := n.operand.eval(values)
+	return -v, ok
+}
+
+func (n negation) walk(f func(node)) {
+	f(n)
+	n.operand.walk(f)
+}
+
+type binary struct {
+	op          byte
+	left, right node
+}
+
+func (b binary) eval(values map[string]float64) (float64, bool) {
+	l, ok := b.left.eval(values)
+	if !ok {
+		return 0, false
+	}
+	r, ok := b.right.eval(values)
+	if !ok {
+		return 0, false
+	}
+	switch b.op {
+	case '+':
+		return l + r, true
+	case '-':
+		return l - r, true
+	case '*':
+		return l * r, true
+	default:
+		if r == 0 {
+			return 0, false
+		}
+		return l / r, true
+	}
+}
+
+func (b binary) walk(f func(node)) {
+	f(b)
+	b.left.walk(f)
+	b.right.walk(f)
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space character, or 0 at the end of the input.
+func (p *parser) peek() byte {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) parseSum() (node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseProduct() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek() == '-' {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negation{operand: operand}, nil
+	}
+	return p.parseOperand()
+}
+
+func (p *parser) parseOperand() (node, error) {
+	c := p.peek()
+	start := p.pos
+	switch {
+	case c == 0:
+		return nil, errors.New("unexpected end of expression")
+
+	case c == '(':
+		p.pos++
+		n, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, errors.Newf("missing closing parenthesis for the one at position %d", start)
+		}
+		p.pos++
+		return n, nil
+
+	case c == '"':
+		end := strings.IndexByte(p.input[start+1:], '"')
+		if end < 0 {
+			return nil, errors.Newf("missing closing quote for the one at position %d", start)
+		}
+		p.pos = start + 1 + end + 1
+		label := p.input[start+1 : start+1+end]
+		if label == "" {
+			return nil, errors.Newf("empty series label at position %d", start)
+		}
+		return variable(label), nil
+
+	case isDigit(c) || c == '.':
+		for p.pos < len(p.input) && (isDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+			p.pos++
+		}
+		f, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+		if err != nil {
+			return nil, errors.Newf("invalid number %q at position %d", p.input[start:p.pos], start)
+		}
+		return number(f), nil
+
+	case isIdentifierStart(c):
+		for p.pos < len(p.input) && (isIdentifierStart(p.input[p.pos]) || isDigit(p.input[p.pos])) {
+			p.pos++
+		}
+		return variable(p.input[start:p.pos]), nil
+	}
+
+	return nil, errors.Newf("unexpected %q at position %d", c, start)
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
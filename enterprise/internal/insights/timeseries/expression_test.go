@@ -0,0 +1,60 @@
+package timeseries
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseExpression(t *testing.T) {
+	values := map[string]float64{"new_api": 3, "old_api": 1, "Old API": 5}
+
+	for _, tc := range []struct {
+		input     string
+		variables []string
+		want      float64
+		undefined bool
+	}{
+		{input: "new_api / (new_api + old_api)", variables: []string{"new_api", "old_api"}, want: 0.75},
+		{input: "100 * new_api / (new_api + old_api)", variables: []string{"new_api", "old_api"}, want: 75},
+		{input: "new_api - old_api * 2", variables: []string{"new_api", "old_api"}, want: 1},
+		{input: `-"Old API" + 0.5`, variables: []string{"Old API"}, want: -4.5},
+		{input: "new_api / (old_api - 1)", variables: []string{"new_api", "old_api"}, undefined: true},
+		{input: "missing + 1", variables: []string{"missing"}, undefined: true},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			expr, err := ParseExpression(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.variables, expr.Variables()); diff != "" {
+				t.Errorf("wrong variables (-want +got):\n%s", diff)
+			}
+
+			have, ok := expr.Eval(values)
+			if ok == tc.undefined {
+				t.Fatalf("wrong definedness. want=%t, have=%t", !tc.undefined, ok)
+			}
+			if ok && have != tc.want {
+				t.Errorf("wrong value. want=%f, have=%f", tc.want, have)
+			}
+		})
+	}
+
+	for _, input := range []string{
+		"",
+		"1 + 2",
+		"new_api +",
+		"(new_api",
+		`"new_api`,
+		`""`,
+		"new_api % 2",
+		"1.2.3 * new_api",
+	} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			if _, err := ParseExpression(input); err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+	}
+}
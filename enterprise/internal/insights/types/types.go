@@ -124,8 +124,50 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+	// Derived series are calculated at read time from the other series of the same insight. Their query holds the
+	// expression they are calculated with.
+	Derived GenerationMethod = "derived"
 )
 
+// InsightSeriesAlertCondition is the condition that triggers an insight series alert.
+type InsightSeriesAlertCondition string
+
+const (
+	// ValueAbove is met when the value of the series is above the threshold.
+	ValueAbove InsightSeriesAlertCondition = "VALUE_ABOVE"
+	// ValueBelow is met when the value of the series is below the threshold.
+	ValueBelow InsightSeriesAlertCondition = "VALUE_BELOW"
+	// WeekOverWeekIncrease is met when the value of the series increased by more than the threshold, in percent,
+	// compared to its value a week before.
+	WeekOverWeekIncrease InsightSeriesAlertCondition = "WEEK_OVER_WEEK_INCREASE"
+)
+
+// InsightSeriesAlert is an alert rule on a series of an insight view. It's evaluated for each new point recorded
+// for the series, and notifies its creator when its condition starts being met.
+type InsightSeriesAlert struct {
+	ID              int
+	InsightViewID   int
+	InsightSeriesID int
+	CreatorID       int32
+	Condition       InsightSeriesAlertCondition
+	Threshold       float64
+	NotifyEmail     bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+	Firing          bool
+	LastEvaluatedAt *time.Time
+	LastAlertedAt   *time.Time
+	CreatedAt       time.Time
+	// SentChannels are the channels the alert has already been sent through while its delivery is retried.
+	SentChannels []string
+
+	// The following fields are read from the series and view the alert belongs to.
+	SeriesID     string
+	SeriesLabel  string
+	ViewUniqueID string
+	ViewTitle    string
+}
+
 type DirtyQuery struct {
 	ID      int
 	Query   string
@@ -206,9 +248,12 @@ const (
 	PATH_AGGREGATION_MODE          SearchAggregationMode = "PATH"
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
+	LANGUAGE_AGGREGATION_MODE      SearchAggregationMode = "LANGUAGE"
+	OWNER_AGGREGATION_MODE         SearchAggregationMode = "OWNER"
+	REPO_METADATA_AGGREGATION_MODE SearchAggregationMode = "REPO_METADATA"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, LANGUAGE_AGGREGATION_MODE, OWNER_AGGREGATION_MODE, REPO_METADATA_AGGREGATION_MODE}
 
 type AggregationNotAvailableReasonType string
 
@@ -1,17 +1,21 @@
 package notebooks
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
 type NotebookBlockType string
 
 const (
-	NotebookQueryBlockType    NotebookBlockType = "query"
-	NotebookMarkdownBlockType NotebookBlockType = "md"
-	NotebookFileBlockType     NotebookBlockType = "file"
-	NotebookSymbolBlockType   NotebookBlockType = "symbol"
-	NotebookComputeBlockType  NotebookBlockType = "compute"
+	NotebookQueryBlockType       NotebookBlockType = "query"
+	NotebookMarkdownBlockType    NotebookBlockType = "md"
+	NotebookFileBlockType        NotebookBlockType = "file"
+	NotebookSymbolBlockType      NotebookBlockType = "symbol"
+	NotebookComputeBlockType     NotebookBlockType = "compute"
+	NotebookInsightBlockType     NotebookBlockType = "insight"
+	NotebookDiffPreviewBlockType NotebookBlockType = "diffPreview"
 )
 
 type NotebookQueryBlockInput struct {
@@ -51,14 +55,122 @@ type NotebookComputeBlockInput struct {
 	Value string `json:"value"`
 }
 
+// InsightTimeRange is a time range that ends now, e.g. the last 6 months.
+type InsightTimeRange struct {
+	// Unit is one of the insight interval units: HOUR, DAY, WEEK, MONTH or YEAR.
+	Unit string `json:"unit"`
+
+	// Value is the number of units that the range spans.
+	Value int32 `json:"value"`
+}
+
+// NotebookInsightBlockInput embeds a live insight series, either an existing
+// series or one computed from an inline search query.
+type NotebookInsightBlockInput struct {
+	// SeriesID is the ID of an existing insight series. SeriesID/Query are mutually exclusive.
+	SeriesID *string `json:"seriesID,omitempty"`
+	// Query is a search query whose results are counted over time. SeriesID/Query are mutually exclusive.
+	Query     *string           `json:"query,omitempty"`
+	TimeRange *InsightTimeRange `json:"timeRange,omitempty"`
+}
+
+type DiffPreviewPatternType string
+
+const (
+	DiffPreviewStructuralPatternType DiffPreviewPatternType = "structural"
+	DiffPreviewRegexpPatternType     DiffPreviewPatternType = "regexp"
+)
+
+// NotebookDiffPreviewBlockInput previews the changes of a rewrite across the
+// files matched by a search query, without applying them.
+type NotebookDiffPreviewBlockInput struct {
+	Query          string                 `json:"query"`
+	MatchPattern   string                 `json:"matchPattern"`
+	RewritePattern string                 `json:"rewritePattern"`
+	PatternType    DiffPreviewPatternType `json:"patternType"`
+}
+
+// ComputeQuery returns the compute query that previews the rewrite as diffs.
+// The patterns are escaped, so that they may contain unbalanced parentheses
+// or arrows.
+func (i NotebookDiffPreviewBlockInput) ComputeQuery() string {
+	command := "rewrite.structural"
+	matchPattern := escapeRewritePattern(i.MatchPattern)
+	if i.PatternType == DiffPreviewRegexpPatternType {
+		command = "rewrite.regexp"
+		matchPattern = escapeRegexpPattern(i.MatchPattern)
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s content:%s(%s -> %s)", i.Query, command, matchPattern, escapeRewritePattern(i.RewritePattern)))
+}
+
+// escapeRewritePattern escapes backslashes, parentheses and the '>' of arrows
+// in a structural pattern or rewrite template of a compute rewrite command,
+// which unescapes them again.
+func escapeRewritePattern(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' || c == '(' || c == ')':
+			b.WriteByte('\\')
+		case c == '>' && i > 0 && pattern[i-1] == '-':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(pattern[i])
+	}
+	return b.String()
+}
+
+// escapeRegexpPattern escapes the parentheses within character classes and
+// the '>' of arrows in a regular expression, so that it doesn't unbalance or
+// split the arguments of a compute rewrite command. Escaping them doesn't
+// change the meaning of the regular expression.
+func escapeRegexpPattern(pattern string) string {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '\\' && i+1 < len(pattern):
+			// Keep escape sequences as they are.
+			b.WriteByte(c)
+			i++
+			c = pattern[i]
+		case c == '[' && !inClass:
+			inClass = true
+			b.WriteByte(c)
+			// A ']' right after the opening bracket (or its negation) is
+			// part of the class.
+			if i+1 < len(pattern) && pattern[i+1] == '^' {
+				i++
+				b.WriteByte(pattern[i])
+			}
+			if i+1 < len(pattern) && pattern[i+1] == ']' {
+				i++
+				b.WriteByte(pattern[i])
+			}
+			continue
+		case c == ']' && inClass:
+			inClass = false
+		case (c == '(' || c == ')') && inClass:
+			b.WriteByte('\\')
+		case c == '>' && i > 0 && pattern[i-1] == '-':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
 type NotebookBlock struct {
-	ID            string                      `json:"id"`
-	Type          NotebookBlockType           `json:"type"`
-	QueryInput    *NotebookQueryBlockInput    `json:"queryInput,omitempty"`
-	MarkdownInput *NotebookMarkdownBlockInput `json:"markdownInput,omitempty"`
-	FileInput     *NotebookFileBlockInput     `json:"fileInput,omitempty"`
-	SymbolInput   *NotebookSymbolBlockInput   `json:"symbolInput,omitempty"`
-	ComputeInput  *NotebookComputeBlockInput  `json:"computeInput,omitempty"`
+	ID               string                         `json:"id"`
+	Type             NotebookBlockType              `json:"type"`
+	QueryInput       *NotebookQueryBlockInput       `json:"queryInput,omitempty"`
+	MarkdownInput    *NotebookMarkdownBlockInput    `json:"markdownInput,omitempty"`
+	FileInput        *NotebookFileBlockInput        `json:"fileInput,omitempty"`
+	SymbolInput      *NotebookSymbolBlockInput      `json:"symbolInput,omitempty"`
+	ComputeInput     *NotebookComputeBlockInput     `json:"computeInput,omitempty"`
+	InsightInput     *NotebookInsightBlockInput     `json:"insightInput,omitempty"`
+	DiffPreviewInput *NotebookDiffPreviewBlockInput `json:"diffPreviewInput,omitempty"`
 }
 
 type NotebookBlocks []NotebookBlock
@@ -73,3 +73,17 @@ func TestNotebookBlockUnmarshalling(t *testing.T) {
 		tt.want.Equal(t, block)
 	}
 }
+
+func TestNotebookDiffPreviewBlockInputComputeQuery(t *testing.T) {
+	structural := NotebookDiffPreviewBlockInput{Query: "repo:a lang:go", MatchPattern: "foo(:[x])", RewritePattern: "bar(:[x])", PatternType: DiffPreviewStructuralPatternType}
+	autogold.Want("structural", `repo:a lang:go content:rewrite.structural(foo\(:[x]\) -> bar\(:[x]\))`).Equal(t, structural.ComputeQuery())
+
+	regexp := NotebookDiffPreviewBlockInput{MatchPattern: `foo\((\w+)\)`, RewritePattern: "bar($1)", PatternType: DiffPreviewRegexpPatternType}
+	autogold.Want("regexp without query", `content:rewrite.regexp(foo\((\w+)\) -> bar\($1\))`).Equal(t, regexp.ComputeQuery())
+
+	unbalancedStructural := NotebookDiffPreviewBlockInput{MatchPattern: `a->b(`, RewritePattern: `"\n")`, PatternType: DiffPreviewStructuralPatternType}
+	autogold.Want("unbalanced structural", `content:rewrite.structural(a-\>b\( -> "\\n"\))`).Equal(t, unbalancedStructural.ComputeQuery())
+
+	unbalancedRegexp := NotebookDiffPreviewBlockInput{MatchPattern: `[(]x->[^])]`, RewritePattern: "y", PatternType: DiffPreviewRegexpPatternType}
+	autogold.Want("unbalanced regexp", `content:rewrite.regexp([\(]x-\>[^]\)] -> y)`).Equal(t, unbalancedRegexp.ComputeQuery())
+}
@@ -1,13 +1,19 @@
 package notebooks
 
-import "github.com/sourcegraph/sourcegraph/lib/errors"
+import (
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
 
 func validateNotebookBlock(block NotebookBlock) error {
 	if block.Type != NotebookQueryBlockType &&
 		block.Type != NotebookMarkdownBlockType &&
 		block.Type != NotebookFileBlockType &&
 		block.Type != NotebookSymbolBlockType &&
-		block.Type != NotebookComputeBlockType {
+		block.Type != NotebookComputeBlockType &&
+		block.Type != NotebookInsightBlockType &&
+		block.Type != NotebookDiffPreviewBlockType {
 		return errors.Errorf("invalid block type: %s", string(block.Type))
 	}
 
@@ -21,12 +27,69 @@ func validateNotebookBlock(block NotebookBlock) error {
 		return errors.Errorf("invalid symbol block with id: %s", block.ID)
 	} else if block.Type == NotebookComputeBlockType && block.ComputeInput == nil {
 		return errors.Errorf("invalid compute block with id: %s", block.ID)
+	} else if block.Type == NotebookInsightBlockType && block.InsightInput == nil {
+		return errors.Errorf("invalid insight block with id: %s", block.ID)
+	} else if block.Type == NotebookDiffPreviewBlockType && block.DiffPreviewInput == nil {
+		return errors.Errorf("invalid diff preview block with id: %s", block.ID)
 	}
 
 	if block.Type == NotebookSymbolBlockType && block.SymbolInput != nil && block.SymbolInput.LineContext < 0 {
 		return errors.Errorf("symbol block line context cannot be negative, block id: %s", block.ID)
 	}
 
+	if block.Type == NotebookInsightBlockType && block.InsightInput != nil {
+		if err := validateInsightBlockInput(block.ID, block.InsightInput); err != nil {
+			return err
+		}
+	}
+
+	if block.Type == NotebookDiffPreviewBlockType && block.DiffPreviewInput != nil {
+		if err := validateDiffPreviewBlockInput(block.ID, block.DiffPreviewInput); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var insightTimeRangeUnits = map[string]struct{}{
+	"HOUR":  {},
+	"DAY":   {},
+	"WEEK":  {},
+	"MONTH": {},
+	"YEAR":  {},
+}
+
+func validateInsightBlockInput(id string, input *NotebookInsightBlockInput) error {
+	hasSeriesID := input.SeriesID != nil && *input.SeriesID != ""
+	hasQuery := input.Query != nil && *input.Query != ""
+	if hasSeriesID == hasQuery {
+		return errors.Errorf("insight block must have either a series ID or a query, block id: %s", id)
+	}
+
+	if input.TimeRange != nil {
+		if _, ok := insightTimeRangeUnits[input.TimeRange.Unit]; !ok {
+			return errors.Errorf("invalid insight block time range unit %q, block id: %s", input.TimeRange.Unit, id)
+		}
+		if input.TimeRange.Value <= 0 {
+			return errors.Errorf("insight block time range must be positive, block id: %s", id)
+		}
+	}
+	return nil
+}
+
+func validateDiffPreviewBlockInput(id string, input *NotebookDiffPreviewBlockInput) error {
+	if input.PatternType != DiffPreviewStructuralPatternType && input.PatternType != DiffPreviewRegexpPatternType {
+		return errors.Errorf("invalid diff preview block pattern type %q, block id: %s", string(input.PatternType), id)
+	}
+	if input.MatchPattern == "" {
+		return errors.Errorf("diff preview block match pattern cannot be empty, block id: %s", id)
+	}
+	if input.PatternType == DiffPreviewRegexpPatternType {
+		if _, err := regexp.Compile(input.MatchPattern); err != nil {
+			return errors.Wrapf(err, "invalid diff preview block match pattern, block id: %s", id)
+		}
+	}
 	return nil
 }
 
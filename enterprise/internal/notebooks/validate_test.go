@@ -5,6 +5,8 @@ import (
 )
 
 func TestNotebookBlocksValidation(t *testing.T) {
+	seriesID := "series"
+	query := "repo:a b"
 	tests := []struct {
 		blocks  NotebookBlocks
 		wantErr string
@@ -23,6 +25,29 @@ func TestNotebookBlocksValidation(t *testing.T) {
 			{ID: "id1", SymbolInput: &NotebookSymbolBlockInput{LineContext: -10}, Type: NotebookSymbolBlockType},
 		}, wantErr: "symbol block line context cannot be negative, block id: id1"},
 		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookComputeBlockType}}, wantErr: "invalid compute block with id: id1"},
+		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookInsightBlockType}}, wantErr: "invalid insight block with id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{}},
+		}, wantErr: "insight block must have either a series ID or a query, block id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{SeriesID: &seriesID, Query: &query}},
+		}, wantErr: "insight block must have either a series ID or a query, block id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{Query: &query, TimeRange: &InsightTimeRange{Unit: "FORTNIGHT", Value: 1}}},
+		}, wantErr: `invalid insight block time range unit "FORTNIGHT", block id: id1`},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{SeriesID: &seriesID, TimeRange: &InsightTimeRange{Unit: "MONTH"}}},
+		}, wantErr: "insight block time range must be positive, block id: id1"},
+		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookDiffPreviewBlockType}}, wantErr: "invalid diff preview block with id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookDiffPreviewBlockType, DiffPreviewInput: &NotebookDiffPreviewBlockInput{MatchPattern: "a", PatternType: "literal"}},
+		}, wantErr: `invalid diff preview block pattern type "literal", block id: id1`},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookDiffPreviewBlockType, DiffPreviewInput: &NotebookDiffPreviewBlockInput{PatternType: DiffPreviewStructuralPatternType}},
+		}, wantErr: "diff preview block match pattern cannot be empty, block id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookDiffPreviewBlockType, DiffPreviewInput: &NotebookDiffPreviewBlockInput{MatchPattern: "foo(", PatternType: DiffPreviewRegexpPatternType}},
+		}, wantErr: "invalid diff preview block match pattern, block id: id1: error parsing regexp: missing closing ): `foo(`"},
 	}
 
 	for _, tt := range tests {
@@ -34,3 +59,16 @@ func TestNotebookBlocksValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestNotebookBlocksValidation_Valid(t *testing.T) {
+	seriesID := "series"
+	query := "repo:a b"
+	blocks := NotebookBlocks{
+		{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{SeriesID: &seriesID}},
+		{ID: "id2", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{Query: &query, TimeRange: &InsightTimeRange{Unit: "MONTH", Value: 6}}},
+		{ID: "id3", Type: NotebookDiffPreviewBlockType, DiffPreviewInput: &NotebookDiffPreviewBlockInput{Query: "repo:a", MatchPattern: "foo(:[x])", RewritePattern: "bar(:[x])", PatternType: DiffPreviewStructuralPatternType}},
+	}
+	if err := validateNotebookBlocks(blocks); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+}
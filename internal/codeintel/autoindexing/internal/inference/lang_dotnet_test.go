@@ -0,0 +1,102 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "solution files",
+			repositoryContents: map[string]string{
+				"App.sln":                 "",
+				"src/App/App.csproj":      "",
+				"src/Lib/Lib.csproj":      "",
+				"tools/Tool/Tool.csproj":  "",
+				"tools/Tool/bin/x.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "project files without solution",
+			repositoryContents: map[string]string{
+				"services/api/Api.csproj":             "",
+				"services/worker/Worker.csproj":       "",
+				"services/worker/Worker.Tests.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "services/api",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "services/worker",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "nested solutions and stray projects",
+			repositoryContents: map[string]string{
+				"a/A.sln":              "",
+				"a/src/A.csproj":       "",
+				"b/B.sln":              "",
+				"c/C.csproj":           "",
+				"c/nested/Nest.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "a",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "b",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "c",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "c/nested",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,59 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestKotlinGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "multi-project build",
+			repositoryContents: map[string]string{
+				"settings.gradle.kts":       "",
+				"build.gradle.kts":          "",
+				"app/build.gradle.kts":      "",
+				"core/build.gradle.kts":     "",
+				"buildSrc/build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "independent builds",
+			repositoryContents: map[string]string{
+				"server/settings.gradle.kts":   "",
+				"server/api/build.gradle.kts":  "",
+				"android/build.gradle.kts":     "",
+				"android/app/build.gradle.kts": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "android",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "server",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=gradle"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
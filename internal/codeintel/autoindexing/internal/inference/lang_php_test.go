@@ -0,0 +1,49 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "composer projects",
+			repositoryContents: map[string]string{
+				"composer.json":               "",
+				"packages/http/composer.json": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/scip-php:autoindex",
+							Commands: []string{"composer install --no-interaction --no-scripts"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-php:autoindex",
+					IndexerArgs: []string{"scip-php"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "packages/http",
+							Image:    "sourcegraph/scip-php:autoindex",
+							Commands: []string{"composer install --no-interaction --no-scripts"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "packages/http",
+					Indexer:     "sourcegraph/scip-php:autoindex",
+					IndexerArgs: []string{"scip-php"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,56 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRubyGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "bundler projects",
+			repositoryContents: map[string]string{
+				"Gemfile":               "",
+				"engines/admin/Gemfile": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/scip-ruby:autoindex",
+							Commands: []string{"bundle install || true"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-ruby:autoindex",
+					IndexerArgs: []string{"scip-ruby-autoindex"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "engines/admin",
+							Image:    "sourcegraph/scip-ruby:autoindex",
+							Commands: []string{"bundle install || true"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "engines/admin",
+					Indexer:     "sourcegraph/scip-ruby:autoindex",
+					IndexerArgs: []string{"scip-ruby-autoindex"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "no gemfile",
+			repositoryContents: map[string]string{
+				"lib/foo.rb": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
@@ -0,0 +1,67 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+local util = require "sg.autoindex.util"
+
+local indexer = "sourcegraph/scip-dotnet:latest"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "bin",
+  pattern.new_path_segment "obj",
+})
+
+local make_job = function(root)
+  return {
+    steps = {},
+    root = root,
+    indexer = indexer,
+    indexer_args = { "scip-dotnet", "index" },
+    outfile = outfile,
+  }
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when solution or C# project files exist. Solution files take
+  -- precedence: a project file is only indexed on its own when it is not
+  -- nested under a directory that also contains a solution file.
+  generate = function(_, paths)
+    local solution_dirs = {}
+    for i = 1, #paths do
+      if string.lower(path.basename(paths[i])):match "%.sln$" then
+        solution_dirs[path.dirname(paths[i])] = true
+      end
+    end
+
+    local jobs = {}
+    local visited = {}
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+
+      if visited[dir] == nil and solution_dirs[dir] then
+        table.insert(jobs, make_job(dir))
+        visited[dir] = true
+      end
+    end
+
+    for i = 1, #paths do
+      local dir = path.dirname(paths[i])
+
+      if visited[dir] == nil and not util.has_ancestor_in(dir, solution_dirs) then
+        table.insert(jobs, make_job(dir))
+        visited[dir] = true
+      end
+    end
+
+    return jobs
+  end,
+}
@@ -0,0 +1,52 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+local util = require "sg.autoindex.util"
+
+local indexer = "sourcegraph/scip-java"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "buildSrc",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "settings.gradle.kts",
+    pattern.new_path_basename "build.gradle.kts",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when Kotlin Gradle build scripts exist. Gradle indexes nested
+  -- subprojects as part of the enclosing build, so we only emit a job for
+  -- the outermost build directories.
+  generate = function(_, paths)
+    local build_dirs = {}
+    for i = 1, #paths do
+      build_dirs[path.dirname(paths[i])] = true
+    end
+
+    local jobs = {}
+    local visited = {}
+
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      if visited[root] == nil and not util.has_ancestor_in(root, build_dirs) then
+        table.insert(jobs, {
+          steps = {},
+          root = root,
+          indexer = indexer,
+          indexer_args = { "scip-java", "index", "--build-tool=gradle" },
+          outfile = outfile,
+        })
+
+        visited[root] = true
+      end
+    end
+
+    return jobs
+  end,
+}
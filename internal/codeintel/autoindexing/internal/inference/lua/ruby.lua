@@ -0,0 +1,44 @@
+local path = require "path"
+local pattern = require "sg.autoindex.patterns"
+local recognizer = require "sg.autoindex.recognizer"
+
+local shared = require "sg.autoindex.shared"
+
+local indexer = "sourcegraph/scip-ruby:autoindex"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "vendor",
+})
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "Gemfile",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when Gemfile files exist
+  generate = function(_, paths)
+    local jobs = {}
+    for i = 1, #paths do
+      local root = path.dirname(paths[i])
+
+      table.insert(jobs, {
+        steps = {
+          {
+            root = root,
+            image = indexer,
+            -- It's ok if bundle install fails, we can still do our best attempt.
+            commands = { "bundle install || true" },
+          },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-ruby-autoindex" },
+        outfile = outfile,
+      })
+    end
+
+    return jobs
+  end,
+}
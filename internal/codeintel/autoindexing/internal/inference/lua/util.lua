@@ -1,3 +1,5 @@
+local path = require "path"
+
 local contains = function(table, element)
   for i = 1, #table do
     if table[i] == element then
@@ -36,8 +38,26 @@ local with_new_head = function(slice, element)
   return new
 end
 
+-- Returns true if any strict ancestor directory of the given directory is
+-- a key of the given set. The repository root is represented by "".
+local has_ancestor_in = function(dir, set)
+  if dir == "" then
+    return false
+  end
+
+  local ancestors = path.ancestors(dir)
+  for i = 1, #ancestors do
+    if set[ancestors[i]] then
+      return true
+    end
+  end
+
+  return false
+end
+
 return {
   contains = contains,
+  has_ancestor_in = has_ancestor_in,
   contains_any = contains_any,
   reverse = reverse,
   with_new_head = with_new_head,
@@ -9,11 +9,17 @@ import (
 type config struct {
 	env.BaseConfig
 
-	Interval time.Duration
+	ExporterInterval      time.Duration
+	ExporterBatchSize     int
+	RankComputerInterval  time.Duration
+	RankComputerBatchSize int
 }
 
 var ConfigInst = &config{}
 
 func (c *config) Load() {
-	c.Interval = c.GetInterval("CODEINTEL_RANKING_INDEXER_INTERVAL", "10s", "The frequency with which to run periodic codeintel rank indexing tasks.")
+	c.ExporterInterval = c.GetInterval("CODEINTEL_RANKING_INDEXER_INTERVAL", "10s", "The frequency with which to export the reference graph of new uploads.")
+	c.ExporterBatchSize = c.GetInt("CODEINTEL_RANKING_INDEXER_BATCH_SIZE", "100", "The maximum number of uploads whose reference graph is exported at once.")
+	c.RankComputerInterval = c.GetInterval("CODEINTEL_RANKING_RANK_COMPUTER_INTERVAL", "1h", "The frequency with which to recompute document ranks from the reference graph.")
+	c.RankComputerBatchSize = c.GetInt("CODEINTEL_RANKING_RANK_COMPUTER_BATCH_SIZE", "100", "The maximum number of uploads whose references are read from the reference graph at once.")
 }
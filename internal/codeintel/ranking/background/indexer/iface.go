@@ -7,5 +7,6 @@ import (
 )
 
 type RankingService interface {
-	RepositoryIndexer(interval time.Duration) goroutine.BackgroundRoutine
+	GraphExporter(interval time.Duration, batchSize int) goroutine.BackgroundRoutine
+	RankComputer(interval time.Duration, batchSize int) goroutine.BackgroundRoutine
 }
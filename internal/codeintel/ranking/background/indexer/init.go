@@ -6,6 +6,7 @@ import (
 
 func NewIndexer(rankingSvc RankingService) []goroutine.BackgroundRoutine {
 	return []goroutine.BackgroundRoutine{
-		rankingSvc.RepositoryIndexer(ConfigInst.Interval),
+		rankingSvc.GraphExporter(ConfigInst.ExporterInterval, ConfigInst.ExporterBatchSize),
+		rankingSvc.RankComputer(ConfigInst.RankComputerInterval, ConfigInst.RankComputerBatchSize),
 	}
 }
@@ -2,30 +2,163 @@ package ranking
 
 import (
 	"context"
-	"errors"
 	"os"
 	"strconv"
 	"time"
 
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
-func (s *Service) RepositoryIndexer(interval time.Duration) goroutine.BackgroundRoutine {
+// GraphExporter periodically exports the symbols defined and referenced by each document of the
+// uploads visible at the tip of the default branch of their repository. This is the map step of
+// the document rank computation.
+func (s *Service) GraphExporter(interval time.Duration, batchSize int) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, goroutine.HandlerFunc(func(ctx context.Context) error {
+		return s.exportRankingGraph(ctx, batchSize)
+	}))
+}
+
+// RankComputer periodically computes the PageRank of each document over the exported reference
+// graph and stores the resulting ranks per repository. This is the reduce step of the document
+// rank computation. The reference graph is read from the database in batches of batchSize uploads.
+func (s *Service) RankComputer(interval time.Duration, batchSize int) goroutine.BackgroundRoutine {
 	return goroutine.NewPeriodicGoroutine(context.Background(), interval, goroutine.HandlerFunc(func(ctx context.Context) error {
-		return s.indexRepositories(ctx)
+		return s.computeDocumentRanks(ctx, batchSize)
 	}))
 }
 
 var rankingEnabled, _ = strconv.ParseBool(os.Getenv("ENABLE_EXPERIMENTAL_RANKING"))
 
-func (s *Service) indexRepositories(ctx context.Context) (err error) {
+func (s *Service) exportRankingGraph(ctx context.Context, batchSize int) (err error) {
+	if !rankingEnabled {
+		return nil
+	}
+
+	ctx, trace, endObservation := s.operations.exportRankingGraph.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	numDeleted, err := s.store.DeleteStaleRankingGraphs(ctx)
+	if err != nil {
+		return err
+	}
+	trace.Log(log.Int("numStaleUploads", numDeleted))
+
+	uploads, err := s.store.GetUploadsForRanking(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+	trace.Log(log.Int("numUploads", len(uploads)))
+
+	for _, upload := range uploads {
+		if err := s.exportUpload(ctx, upload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) exportUpload(ctx context.Context, upload shared.ExportableUpload) error {
+	// Paths in the code intelligence database are relative to the root of the upload
+	var definitions []shared.SymbolDefinition
+	if err := s.lsifstore.ScanDefinitions(ctx, upload.ID, func(symbolName, path string) error {
+		definitions = append(definitions, shared.SymbolDefinition{
+			SymbolName:   symbolName,
+			DocumentPath: upload.Root + path,
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var references []shared.SymbolReference
+	if err := s.lsifstore.ScanReferences(ctx, upload.ID, func(symbolName, path string, count int) error {
+		references = append(references, shared.SymbolReference{
+			SymbolName:   symbolName,
+			DocumentPath: upload.Root + path,
+			Count:        count,
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return s.store.InsertRankingGraph(ctx, upload.ID, definitions, references)
+}
+
+func (s *Service) computeDocumentRanks(ctx context.Context, batchSize int) (err error) {
 	if !rankingEnabled {
 		return nil
 	}
 
-	_, _, endObservation := s.operations.indexRepositories.With(ctx, &err, observation.Args{})
+	ctx, trace, endObservation := s.operations.computeDocumentRanks.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
-	return errors.New("codeintel.ranking.service.indexRepositories unimplemented")
+	edges := map[documentKey]map[documentKey]float64{}
+	addEdge := func(edge shared.DocumentEdge) error {
+		source := documentKey{edge.SourceRepositoryID, edge.SourcePath}
+		target := documentKey{edge.TargetRepositoryID, edge.TargetPath}
+
+		if _, ok := edges[source]; !ok {
+			edges[source] = map[documentKey]float64{}
+		}
+		edges[source][target] += float64(edge.Count)
+		return nil
+	}
+
+	for afterUploadID := 0; ; {
+		lastUploadID, err := s.store.ScanReferenceGraph(ctx, afterUploadID, batchSize, addEdge)
+		if err != nil {
+			return err
+		}
+		if lastUploadID == 0 {
+			break
+		}
+		afterUploadID = lastUploadID
+	}
+	trace.Log(log.Int("numDocuments", len(edges)))
+
+	// Group ranks by repository, normalizing each to the most highly ranked document of that
+	// repository so that the resulting values are comparable when ordering a single repository.
+	ranksByRepository := map[int]map[string]float64{}
+	maxRankByRepository := map[int]float64{}
+	for key, rank := range pageRank(edges) {
+		if _, ok := ranksByRepository[key.repositoryID]; !ok {
+			ranksByRepository[key.repositoryID] = map[string]float64{}
+		}
+		ranksByRepository[key.repositoryID][key.path] = rank
+
+		if rank > maxRankByRepository[key.repositoryID] {
+			maxRankByRepository[key.repositoryID] = rank
+		}
+	}
+	trace.Log(log.Int("numRepositories", len(ranksByRepository)))
+
+	repositoryIDs := make([]int, 0, len(ranksByRepository))
+	for repositoryID, ranks := range ranksByRepository {
+		repositoryIDs = append(repositoryIDs, repositoryID)
+
+		if max := maxRankByRepository[repositoryID]; max > 0 {
+			for path, rank := range ranks {
+				ranks[path] = rank / max
+			}
+		}
+
+		if err := s.store.SetDocumentRanks(ctx, repositoryID, ranks); err != nil {
+			return err
+		}
+	}
+
+	// Remove the ranks of repositories that no longer take part in the reference graph
+	numDeleted, err := s.store.DeleteDocumentRanksExcept(ctx, repositoryIDs)
+	if err != nil {
+		return err
+	}
+	trace.Log(log.Int("numStaleRepositories", numDeleted))
+
+	return nil
 }
@@ -0,0 +1,81 @@
+package ranking
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestComputeDocumentRanks(t *testing.T) {
+	rankingEnabled = true
+	t.Cleanup(func() { rankingEnabled = false })
+
+	ctx := context.Background()
+	mockStore := NewMockStore()
+	svc := newService(mockStore, NewMockLsifStore(), nil, NewMockGitserverClient(), siteConfigQuerier{}, &observation.TestContext)
+
+	batches := map[int][]shared.DocumentEdge{
+		0: {
+			{SourceRepositoryID: 50, SourcePath: "main.go", TargetRepositoryID: 51, TargetPath: "lib.go", Count: 3},
+		},
+		10: {
+			{SourceRepositoryID: 51, SourcePath: "lib.go", TargetRepositoryID: 51, TargetPath: "util.go", Count: 1},
+		},
+	}
+	mockStore.ScanReferenceGraphFunc.SetDefaultHook(func(ctx context.Context, afterUploadID, batchSize int, f func(edge shared.DocumentEdge) error) (int, error) {
+		edges, ok := batches[afterUploadID]
+		if !ok {
+			return 0, nil
+		}
+		for _, edge := range edges {
+			if err := f(edge); err != nil {
+				return 0, err
+			}
+		}
+
+		return afterUploadID + 10, nil
+	})
+
+	if err := svc.computeDocumentRanks(ctx, 10); err != nil {
+		t.Fatalf("unexpected error computing document ranks: %s", err)
+	}
+
+	var afterUploadIDs []int
+	for _, call := range mockStore.ScanReferenceGraphFunc.History() {
+		afterUploadIDs = append(afterUploadIDs, call.Arg1)
+	}
+	if diff := cmp.Diff([]int{0, 10, 20}, afterUploadIDs); diff != "" {
+		t.Errorf("unexpected batches (-want +got):\n%s", diff)
+	}
+
+	ranksByRepository := map[int][]string{}
+	for _, call := range mockStore.SetDocumentRanksFunc.History() {
+		for path := range call.Arg2 {
+			ranksByRepository[call.Arg1] = append(ranksByRepository[call.Arg1], path)
+		}
+		sort.Strings(ranksByRepository[call.Arg1])
+	}
+	expectedRanks := map[int][]string{
+		50: {"main.go"},
+		51: {"lib.go", "util.go"},
+	}
+	if diff := cmp.Diff(expectedRanks, ranksByRepository); diff != "" {
+		t.Errorf("unexpected ranked documents (-want +got):\n%s", diff)
+	}
+
+	if history := mockStore.DeleteDocumentRanksExceptFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of calls to DeleteDocumentRanksExcept. want=%d have=%d", 1, len(history))
+	} else {
+		repositoryIDs := history[0].Arg1
+		sort.Ints(repositoryIDs)
+
+		if diff := cmp.Diff([]int{50, 51}, repositoryIDs); diff != "" {
+			t.Errorf("unexpected retained repositories (-want +got):\n%s", diff)
+		}
+	}
+}
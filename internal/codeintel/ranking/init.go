@@ -1,7 +1,9 @@
 package ranking
 
 import (
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/memo"
@@ -12,11 +14,13 @@ import (
 // If the service is not yet initialized, it will use the provided dependencies.
 func GetService(
 	db database.DB,
+	codeIntelDB stores.CodeIntelDB,
 	uploadSvc *uploads.Service,
 	gitserverClient GitserverClient,
 ) *Service {
 	svc, _ := initServiceMemo.Init(serviceDependencies{
 		db,
+		codeIntelDB,
 		uploadSvc,
 		gitserverClient,
 	})
@@ -26,6 +30,7 @@ func GetService(
 
 type serviceDependencies struct {
 	db              database.DB
+	codeIntelDB     stores.CodeIntelDB
 	uploadsService  *uploads.Service
 	gitserverClient GitserverClient
 }
@@ -33,6 +38,7 @@ type serviceDependencies struct {
 var initServiceMemo = memo.NewMemoizedConstructorWithArg(func(deps serviceDependencies) (*Service, error) {
 	return newService(
 		store.New(deps.db, scopedContext("store")),
+		lsifstore.New(deps.codeIntelDB, scopedContext("lsifstore")),
 		deps.uploadsService,
 		deps.gitserverClient,
 		siteConfigQuerier{},
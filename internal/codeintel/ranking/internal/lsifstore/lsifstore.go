@@ -0,0 +1,28 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type LsifStore interface {
+	ScanDefinitions(ctx context.Context, uploadID int, f func(symbolName, path string) error) error
+	ScanReferences(ctx context.Context, uploadID int, f func(symbolName, path string, count int) error) error
+}
+
+type store struct {
+	db         *basestore.Store
+	serializer *Serializer
+	operations *operations
+}
+
+func New(db stores.CodeIntelDB, observationContext *observation.Context) LsifStore {
+	return &store{
+		db:         basestore.NewWithHandle(db.Handle()),
+		serializer: NewSerializer(),
+		operations: newOperations(observationContext),
+	}
+}
@@ -0,0 +1,91 @@
+package lsifstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ScanDefinitions calls the given function once for each (symbol, document) pair such that the
+// symbol is exported from the given upload and is defined in that document. Symbol names are
+// formed from the moniker scheme and identifier.
+func (s *store) ScanDefinitions(ctx context.Context, uploadID int, f func(symbolName, path string) error) (err error) {
+	ctx, _, endObservation := s.operations.scanDefinitions.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.scanMonikerLocations(ctx, "definitions", uploadID, func(symbolName, path string, _ int) error {
+		return f(symbolName, path)
+	})
+}
+
+// ScanReferences calls the given function once for each (symbol, document) pair such that the
+// document of the given upload references the symbol, along with the number of references to
+// the symbol within that document. Symbol names are formed from the moniker scheme and identifier.
+func (s *store) ScanReferences(ctx context.Context, uploadID int, f func(symbolName, path string, count int) error) (err error) {
+	ctx, _, endObservation := s.operations.scanReferences.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.scanMonikerLocations(ctx, "references", uploadID, f)
+}
+
+// scanMonikerLocations reads the moniker locations of the given upload from the given table and
+// calls the given function once for each distinct (symbol, document) pair.
+func (s *store) scanMonikerLocations(ctx context.Context, tableName string, uploadID int, f func(symbolName, path string, count int) error) (err error) {
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(
+		scanMonikerLocationsQuery,
+		sqlf.Sprintf(fmt.Sprintf("lsif_data_%s", tableName)),
+		uploadID,
+	))
+	if err != nil {
+		return err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var scheme, identifier string
+		var rawData []byte
+		if err := rows.Scan(&scheme, &identifier, &rawData); err != nil {
+			return err
+		}
+
+		locations, err := s.serializer.UnmarshalLocations(rawData)
+		if err != nil {
+			return err
+		}
+
+		paths := []string{}
+		countsByPath := map[string]int{}
+		for _, location := range locations {
+			if _, ok := countsByPath[location.URI]; !ok {
+				paths = append(paths, location.URI)
+			}
+			countsByPath[location.URI]++
+		}
+
+		symbolName := scheme + ":" + identifier
+		for _, path := range paths {
+			if err := f(symbolName, path, countsByPath[path]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+const scanMonikerLocationsQuery = `
+-- source: internal/codeintel/ranking/internal/lsifstore/lsifstore_monikers.go:scanMonikerLocations
+SELECT scheme, identifier, data
+FROM %s
+WHERE dump_id = %s
+ORDER BY scheme, identifier
+`
@@ -0,0 +1,54 @@
+package lsifstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func init() {
+	gob.Register(&precise.LocationData{})
+}
+
+type Serializer struct {
+	readers sync.Pool
+}
+
+func NewSerializer() *Serializer {
+	return &Serializer{
+		readers: sync.Pool{New: func() any { return new(gzip.Reader) }},
+	}
+}
+
+// UnmarshalLocations unmarshals a slice of locations (the value in the `data` column of the
+// definitions and references tables).
+func (s *Serializer) UnmarshalLocations(data []byte) (locations []precise.LocationData, err error) {
+	err = s.decode(data, &locations)
+	return locations, err
+}
+
+// decode decompresses gob-decodes the given data and sets the given pointer. If the given data
+// is empty, the pointer will not be assigned.
+func (s *Serializer) decode(data []byte, target any) (err error) {
+	if len(data) == 0 {
+		return nil
+	}
+
+	r := s.readers.Get().(*gzip.Reader)
+	defer s.readers.Put(r)
+
+	if err := r.Reset(bytes.NewReader(data)); err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := r.Close(); closeErr != nil {
+			err = errors.Append(err, closeErr)
+		}
+	}()
+
+	return gob.NewDecoder(r).Decode(target)
+}
@@ -10,6 +10,13 @@ import (
 type operations struct {
 	getStaleSourcedCommits      *observation.Operation
 	insertDependencyIndexingJob *observation.Operation
+	getUploadsForRanking        *observation.Operation
+	insertRankingGraph          *observation.Operation
+	deleteStaleRankingGraphs    *observation.Operation
+	scanReferenceGraph          *observation.Operation
+	setDocumentRanks            *observation.Operation
+	getDocumentRanks            *observation.Operation
+	deleteDocumentRanksExcept   *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -29,6 +36,13 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		getStaleSourcedCommits: op("StaleSourcedCommits"),
+		getStaleSourcedCommits:    op("StaleSourcedCommits"),
+		getUploadsForRanking:      op("GetUploadsForRanking"),
+		insertRankingGraph:        op("InsertRankingGraph"),
+		deleteStaleRankingGraphs:  op("DeleteStaleRankingGraphs"),
+		scanReferenceGraph:        op("ScanReferenceGraph"),
+		setDocumentRanks:          op("SetDocumentRanks"),
+		getDocumentRanks:          op("GetDocumentRanks"),
+		deleteDocumentRanksExcept: op("DeleteDocumentRanksExcept"),
 	}
 }
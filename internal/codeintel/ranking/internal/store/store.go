@@ -7,6 +7,7 @@ import (
 	logger "github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -19,6 +20,15 @@ type Store interface {
 	Done(err error) error
 
 	GetStarRank(ctx context.Context, repoName api.RepoName) (float64, error)
+	GetDocumentRanks(ctx context.Context, repoName api.RepoName) (map[string]float64, bool, error)
+	SetDocumentRanks(ctx context.Context, repositoryID int, ranks map[string]float64) error
+	DeleteDocumentRanksExcept(ctx context.Context, repositoryIDs []int) (int, error)
+
+	// Reference graph
+	GetUploadsForRanking(ctx context.Context, limit int) ([]shared.ExportableUpload, error)
+	InsertRankingGraph(ctx context.Context, uploadID int, definitions []shared.SymbolDefinition, references []shared.SymbolReference) error
+	DeleteStaleRankingGraphs(ctx context.Context) (int, error)
+	ScanReferenceGraph(ctx context.Context, afterUploadID, batchSize int, f func(edge shared.DocumentEdge) error) (int, error)
 }
 
 // store manages the ranking store.
@@ -0,0 +1,363 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// GetUploadsForRanking returns uploads visible at the tip of the default branch of their repository
+// whose reference graph has not yet been exported.
+func (s *store) GetUploadsForRanking(ctx context.Context, limit int) (_ []shared.ExportableUpload, err error) {
+	ctx, _, endObservation := s.operations.getUploadsForRanking.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanExportableUploads(s.db.Query(ctx, sqlf.Sprintf(getUploadsForRankingQuery, limit)))
+}
+
+const getUploadsForRankingQuery = `
+-- source: internal/codeintel/ranking/internal/store/store_graph.go:GetUploadsForRanking
+SELECT u.id, u.repository_id, u.root
+FROM lsif_uploads u
+WHERE
+	u.state = 'completed' AND
+	EXISTS (
+		SELECT 1
+		FROM lsif_uploads_visible_at_tip vt
+		WHERE
+			vt.upload_id = u.id AND
+			vt.is_default_branch
+	) AND
+	NOT EXISTS (
+		SELECT 1
+		FROM codeintel_ranking_exports e
+		WHERE e.upload_id = u.id
+	)
+ORDER BY u.id
+LIMIT %s
+`
+
+var scanExportableUploads = basestore.NewSliceScanner(func(s dbutil.Scanner) (upload shared.ExportableUpload, err error) {
+	err = s.Scan(&upload.ID, &upload.RepositoryID, &upload.Root)
+	return upload, err
+})
+
+// InsertRankingGraph stores the symbols defined and referenced by the documents of the given upload
+// and marks the upload as exported.
+func (s *store) InsertRankingGraph(ctx context.Context, uploadID int, definitions []shared.SymbolDefinition, references []shared.SymbolReference) (err error) {
+	ctx, _, endObservation := s.operations.insertRankingGraph.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+		log.Int("numDefinitions", len(definitions)),
+		log.Int("numReferences", len(references)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := batch.WithInserter(
+		ctx,
+		tx.db.Handle(),
+		"codeintel_ranking_definitions",
+		batch.MaxNumPostgresParameters,
+		[]string{"upload_id", "symbol_name", "document_path"},
+		func(inserter *batch.Inserter) error {
+			for _, definition := range definitions {
+				if err := inserter.Insert(ctx, uploadID, definition.SymbolName, definition.DocumentPath); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	if err := batch.WithInserter(
+		ctx,
+		tx.db.Handle(),
+		"codeintel_ranking_references",
+		batch.MaxNumPostgresParameters,
+		[]string{"upload_id", "symbol_name", "document_path", "count"},
+		func(inserter *batch.Inserter) error {
+			for _, reference := range references {
+				if err := inserter.Insert(ctx, uploadID, reference.SymbolName, reference.DocumentPath, reference.Count); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	return tx.db.Exec(ctx, sqlf.Sprintf(insertRankingExportQuery, uploadID))
+}
+
+const insertRankingExportQuery = `
+-- source: internal/codeintel/ranking/internal/store/store_graph.go:InsertRankingGraph
+INSERT INTO codeintel_ranking_exports (upload_id) VALUES (%s)
+ON CONFLICT (upload_id) DO NOTHING
+`
+
+// DeleteStaleRankingGraphs removes the exported reference graph of uploads that are no longer visible
+// at the tip of the default branch of their repository. Rows belonging to deleted uploads are removed
+// by cascading deletes.
+func (s *store) DeleteStaleRankingGraphs(ctx context.Context) (_ int, err error) {
+	ctx, trace, endObservation := s.operations.deleteStaleRankingGraphs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(deleteStaleRankingGraphsQuery)))
+	if err != nil {
+		return 0, err
+	}
+	trace.Log(log.Int("numUploads", count))
+
+	return count, nil
+}
+
+const deleteStaleRankingGraphsQuery = `
+-- source: internal/codeintel/ranking/internal/store/store_graph.go:DeleteStaleRankingGraphs
+WITH
+stale_exports AS (
+	SELECT e.upload_id
+	FROM codeintel_ranking_exports e
+	WHERE NOT EXISTS (
+		SELECT 1
+		FROM lsif_uploads_visible_at_tip vt
+		WHERE
+			vt.upload_id = e.upload_id AND
+			vt.is_default_branch
+	)
+	ORDER BY e.upload_id
+	FOR UPDATE SKIP LOCKED
+),
+deleted_definitions AS (
+	DELETE FROM codeintel_ranking_definitions
+	WHERE upload_id IN (SELECT upload_id FROM stale_exports)
+),
+deleted_references AS (
+	DELETE FROM codeintel_ranking_references
+	WHERE upload_id IN (SELECT upload_id FROM stale_exports)
+),
+deleted_exports AS (
+	DELETE FROM codeintel_ranking_exports
+	WHERE upload_id IN (SELECT upload_id FROM stale_exports)
+	RETURNING 1
+)
+SELECT COUNT(*) FROM deleted_exports
+`
+
+// ScanReferenceGraph calls the given function once for each pair of documents such that the source
+// document references symbols defined in the target document. Only the references of the next batch
+// of uploads visible at the tip of the default branch with an identifier greater than afterUploadID
+// are considered. Symbols defined in more than maxDefinitionsPerSymbol documents are ignored, as such
+// symbols would add a large number of edges while telling little about the referenced documents. The
+// largest upload identifier of the batch is returned, or zero if no uploads remain.
+func (s *store) ScanReferenceGraph(ctx context.Context, afterUploadID, batchSize int, f func(edge shared.DocumentEdge) error) (_ int, err error) {
+	ctx, trace, endObservation := s.operations.scanReferenceGraph.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("afterUploadID", afterUploadID),
+		log.Int("batchSize", batchSize),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	uploadIDs, err := basestore.ScanInts(s.db.Query(ctx, sqlf.Sprintf(referenceGraphUploadsQuery, afterUploadID, batchSize)))
+	if err != nil {
+		return 0, err
+	}
+	trace.Log(log.Int("numUploads", len(uploadIDs)))
+
+	if len(uploadIDs) == 0 {
+		return 0, nil
+	}
+
+	if err := s.scanReferenceGraphEdges(ctx, uploadIDs, f); err != nil {
+		return 0, err
+	}
+
+	return uploadIDs[len(uploadIDs)-1], nil
+}
+
+const referenceGraphUploadsQuery = `
+-- source: internal/codeintel/ranking/internal/store/store_graph.go:ScanReferenceGraph
+SELECT DISTINCT vt.upload_id
+FROM lsif_uploads_visible_at_tip vt
+WHERE
+	vt.is_default_branch AND
+	vt.upload_id > %s
+ORDER BY vt.upload_id
+LIMIT %s
+`
+
+// maxDefinitionsPerSymbol is the maximum number of documents defining a symbol for which references
+// to that symbol are added to the reference graph.
+const maxDefinitionsPerSymbol = 100
+
+func (s *store) scanReferenceGraphEdges(ctx context.Context, uploadIDs []int, f func(edge shared.DocumentEdge) error) (err error) {
+	rows, err := s.db.Query(ctx, sqlf.Sprintf(
+		scanReferenceGraphQuery,
+		maxDefinitionsPerSymbol+1,
+		maxDefinitionsPerSymbol,
+		pq.Array(uploadIDs),
+	))
+	if err != nil {
+		return err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var edge shared.DocumentEdge
+		if err := rows.Scan(
+			&edge.SourceRepositoryID,
+			&edge.SourcePath,
+			&edge.TargetRepositoryID,
+			&edge.TargetPath,
+			&edge.Count,
+		); err != nil {
+			return err
+		}
+
+		if err := f(edge); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const scanReferenceGraphQuery = `
+-- source: internal/codeintel/ranking/internal/store/store_graph.go:scanReferenceGraphEdges
+SELECT
+	ru.repository_id,
+	r.document_path,
+	d.repository_id,
+	d.document_path,
+	SUM(r.count)
+FROM codeintel_ranking_references r
+JOIN lsif_uploads ru ON ru.id = r.upload_id
+JOIN LATERAL (
+	SELECT
+		sd.repository_id,
+		sd.document_path,
+		COUNT(*) OVER () AS num_definitions
+	FROM (
+		SELECT du.repository_id, d.document_path
+		FROM codeintel_ranking_definitions d
+		JOIN lsif_uploads du ON du.id = d.upload_id
+		WHERE
+			d.symbol_name = r.symbol_name AND
+			EXISTS (
+				SELECT 1
+				FROM lsif_uploads_visible_at_tip vt
+				WHERE
+					vt.upload_id = d.upload_id AND
+					vt.is_default_branch
+			)
+		LIMIT %s
+	) sd
+) d ON d.num_definitions <= %s
+WHERE r.upload_id = ANY(%s)
+GROUP BY ru.repository_id, r.document_path, d.repository_id, d.document_path
+`
+
+// GetDocumentRanks returns a map from paths within the given repository to their rank computed from
+// the reference graph. The returned flag is false if ranks have not been computed for the repository.
+func (s *store) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (_ map[string]float64, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getDocumentRanks.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repoName", string(repoName)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	serialized, ok, err := basestore.ScanFirstString(s.db.Query(ctx, sqlf.Sprintf(getDocumentRanksQuery, repoName)))
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	ranks := map[string]float64{}
+	if err := json.Unmarshal([]byte(serialized), &ranks); err != nil {
+		return nil, false, err
+	}
+
+	return ranks, true, nil
+}
+
+const getDocumentRanksQuery = `
+-- source: internal/codeintel/ranking/internal/store/store_graph.go:GetDocumentRanks
+SELECT pr.payload
+FROM codeintel_path_ranks pr
+JOIN repo r ON r.id = pr.repository_id
+WHERE
+	r.name = %s AND
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL
+`
+
+// SetDocumentRanks replaces the document ranks of the given repository.
+func (s *store) SetDocumentRanks(ctx context.Context, repositoryID int, ranks map[string]float64) (err error) {
+	ctx, _, endObservation := s.operations.setDocumentRanks.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Int("numRanks", len(ranks)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	serialized, err := json.Marshal(ranks)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Exec(ctx, sqlf.Sprintf(setDocumentRanksQuery, repositoryID, string(serialized)))
+}
+
+const setDocumentRanksQuery = `
+-- source: internal/codeintel/ranking/internal/store/store_graph.go:SetDocumentRanks
+INSERT INTO codeintel_path_ranks AS pr (repository_id, payload)
+VALUES (%s, %s)
+ON CONFLICT (repository_id) DO
+UPDATE
+	SET
+		payload = EXCLUDED.payload,
+		updated_at = NOW()
+`
+
+// DeleteDocumentRanksExcept removes the document ranks of all repositories other than the given ones.
+func (s *store) DeleteDocumentRanksExcept(ctx context.Context, repositoryIDs []int) (_ int, err error) {
+	ctx, trace, endObservation := s.operations.deleteDocumentRanksExcept.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numRepositoryIDs", len(repositoryIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	count, _, err := basestore.ScanFirstInt(s.db.Query(ctx, sqlf.Sprintf(deleteDocumentRanksExceptQuery, pq.Array(repositoryIDs))))
+	if err != nil {
+		return 0, err
+	}
+	trace.Log(log.Int("numDeleted", count))
+
+	return count, nil
+}
+
+const deleteDocumentRanksExceptQuery = `
+-- source: internal/codeintel/ranking/internal/store/store_graph.go:DeleteDocumentRanksExcept
+WITH deleted AS (
+	DELETE FROM codeintel_path_ranks
+	WHERE repository_id != ALL(%s)
+	RETURNING 1
+)
+SELECT COUNT(*) FROM deleted
+`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -58,3 +59,66 @@ func TestGetStarRank(t *testing.T) {
 		}
 	}
 }
+
+func TestDocumentRanks(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db, &observation.TestContext)
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO repo (id, name) VALUES (50, 'foo'), (51, 'bar')`); err != nil {
+		t.Fatalf("failed to insert repos: %s", err)
+	}
+
+	if _, ok, err := store.GetDocumentRanks(ctx, api.RepoName("foo")); err != nil {
+		t.Fatalf("unexpected error getting document ranks: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected document ranks before they were set")
+	}
+
+	for _, expected := range []map[string]float64{
+		{"cmd/main.go": 0.25, "internal/util.go": 1},
+		{"cmd/main.go": 0.5, "internal/server.go": 1},
+	} {
+		if err := store.SetDocumentRanks(ctx, 50, expected); err != nil {
+			t.Fatalf("unexpected error setting document ranks: %s", err)
+		}
+
+		ranks, ok, err := store.GetDocumentRanks(ctx, api.RepoName("foo"))
+		if err != nil {
+			t.Fatalf("unexpected error getting document ranks: %s", err)
+		}
+		if !ok {
+			t.Fatalf("expected document ranks")
+		}
+		if diff := cmp.Diff(expected, ranks); diff != "" {
+			t.Errorf("unexpected document ranks (-want +got):\n%s", diff)
+		}
+	}
+
+	if err := store.SetDocumentRanks(ctx, 51, map[string]float64{"main.go": 1}); err != nil {
+		t.Fatalf("unexpected error setting document ranks: %s", err)
+	}
+
+	// Delete ranks of repositories other than foo
+	if numDeleted, err := store.DeleteDocumentRanksExcept(ctx, []int{50}); err != nil {
+		t.Fatalf("unexpected error deleting document ranks: %s", err)
+	} else if numDeleted != 1 {
+		t.Errorf("unexpected number of deleted ranks. want=%d have=%d", 1, numDeleted)
+	}
+
+	if _, ok, err := store.GetDocumentRanks(ctx, api.RepoName("foo")); err != nil {
+		t.Fatalf("unexpected error getting document ranks: %s", err)
+	} else if !ok {
+		t.Errorf("expected document ranks of retained repository")
+	}
+	if _, ok, err := store.GetDocumentRanks(ctx, api.RepoName("bar")); err != nil {
+		t.Fatalf("unexpected error getting document ranks: %s", err)
+	} else if ok {
+		t.Errorf("unexpected document ranks of deleted repository")
+	}
+}
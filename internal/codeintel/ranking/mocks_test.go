@@ -12,7 +12,9 @@ import (
 
 	regexp "github.com/grafana/regexp"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
+	lsifstore "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore"
 	store "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store"
+	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/shared"
 	conftypes "github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	schema "github.com/sourcegraph/sourcegraph/schema"
 )
@@ -22,12 +24,33 @@ import (
 // github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store)
 // used for unit testing.
 type MockStore struct {
+	// DeleteDocumentRanksExceptFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteDocumentRanksExcept.
+	DeleteDocumentRanksExceptFunc *StoreDeleteDocumentRanksExceptFunc
+	// DeleteStaleRankingGraphsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteStaleRankingGraphs.
+	DeleteStaleRankingGraphsFunc *StoreDeleteStaleRankingGraphsFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *StoreDoneFunc
+	// GetDocumentRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDocumentRanks.
+	GetDocumentRanksFunc *StoreGetDocumentRanksFunc
 	// GetStarRankFunc is an instance of a mock function object controlling
 	// the behavior of the method GetStarRank.
 	GetStarRankFunc *StoreGetStarRankFunc
+	// GetUploadsForRankingFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadsForRanking.
+	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
+	// InsertRankingGraphFunc is an instance of a mock function object
+	// controlling the behavior of the method InsertRankingGraph.
+	InsertRankingGraphFunc *StoreInsertRankingGraphFunc
+	// ScanReferenceGraphFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanReferenceGraph.
+	ScanReferenceGraphFunc *StoreScanReferenceGraphFunc
+	// SetDocumentRanksFunc is an instance of a mock function object
+	// controlling the behavior of the method SetDocumentRanks.
+	SetDocumentRanksFunc *StoreSetDocumentRanksFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *StoreTransactFunc
@@ -37,16 +60,51 @@ type MockStore struct {
 // return zero values for all results, unless overwritten.
 func NewMockStore() *MockStore {
 	return &MockStore{
+		DeleteDocumentRanksExceptFunc: &StoreDeleteDocumentRanksExceptFunc{
+			defaultHook: func(context.Context, []int) (r0 int, r1 error) {
+				return
+			},
+		},
+		DeleteStaleRankingGraphsFunc: &StoreDeleteStaleRankingGraphsFunc{
+			defaultHook: func(context.Context) (r0 int, r1 error) {
+				return
+			},
+		},
 		DoneFunc: &StoreDoneFunc{
 			defaultHook: func(error) (r0 error) {
 				return
 			},
 		},
+		GetDocumentRanksFunc: &StoreGetDocumentRanksFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 map[string]float64, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetStarRankFunc: &StoreGetStarRankFunc{
 			defaultHook: func(context.Context, api.RepoName) (r0 float64, r1 error) {
 				return
 			},
 		},
+		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
+			defaultHook: func(context.Context, int) (r0 []shared.ExportableUpload, r1 error) {
+				return
+			},
+		},
+		InsertRankingGraphFunc: &StoreInsertRankingGraphFunc{
+			defaultHook: func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) (r0 error) {
+				return
+			},
+		},
+		ScanReferenceGraphFunc: &StoreScanReferenceGraphFunc{
+			defaultHook: func(context.Context, int, int, func(edge shared.DocumentEdge) error) (r0 int, r1 error) {
+				return
+			},
+		},
+		SetDocumentRanksFunc: &StoreSetDocumentRanksFunc{
+			defaultHook: func(context.Context, int, map[string]float64) (r0 error) {
+				return
+			},
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: func(context.Context) (r0 store.Store, r1 error) {
 				return
@@ -59,16 +117,51 @@ func NewMockStore() *MockStore {
 // panic on invocation, unless overwritten.
 func NewStrictMockStore() *MockStore {
 	return &MockStore{
+		DeleteDocumentRanksExceptFunc: &StoreDeleteDocumentRanksExceptFunc{
+			defaultHook: func(context.Context, []int) (int, error) {
+				panic("unexpected invocation of MockStore.DeleteDocumentRanksExcept")
+			},
+		},
+		DeleteStaleRankingGraphsFunc: &StoreDeleteStaleRankingGraphsFunc{
+			defaultHook: func(context.Context) (int, error) {
+				panic("unexpected invocation of MockStore.DeleteStaleRankingGraphs")
+			},
+		},
 		DoneFunc: &StoreDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockStore.Done")
 			},
 		},
+		GetDocumentRanksFunc: &StoreGetDocumentRanksFunc{
+			defaultHook: func(context.Context, api.RepoName) (map[string]float64, bool, error) {
+				panic("unexpected invocation of MockStore.GetDocumentRanks")
+			},
+		},
 		GetStarRankFunc: &StoreGetStarRankFunc{
 			defaultHook: func(context.Context, api.RepoName) (float64, error) {
 				panic("unexpected invocation of MockStore.GetStarRank")
 			},
 		},
+		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
+			defaultHook: func(context.Context, int) ([]shared.ExportableUpload, error) {
+				panic("unexpected invocation of MockStore.GetUploadsForRanking")
+			},
+		},
+		InsertRankingGraphFunc: &StoreInsertRankingGraphFunc{
+			defaultHook: func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) error {
+				panic("unexpected invocation of MockStore.InsertRankingGraph")
+			},
+		},
+		ScanReferenceGraphFunc: &StoreScanReferenceGraphFunc{
+			defaultHook: func(context.Context, int, int, func(edge shared.DocumentEdge) error) (int, error) {
+				panic("unexpected invocation of MockStore.ScanReferenceGraph")
+			},
+		},
+		SetDocumentRanksFunc: &StoreSetDocumentRanksFunc{
+			defaultHook: func(context.Context, int, map[string]float64) error {
+				panic("unexpected invocation of MockStore.SetDocumentRanks")
+			},
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: func(context.Context) (store.Store, error) {
 				panic("unexpected invocation of MockStore.Transact")
@@ -81,46 +174,69 @@ func NewStrictMockStore() *MockStore {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockStoreFrom(i store.Store) *MockStore {
 	return &MockStore{
+		DeleteDocumentRanksExceptFunc: &StoreDeleteDocumentRanksExceptFunc{
+			defaultHook: i.DeleteDocumentRanksExcept,
+		},
+		DeleteStaleRankingGraphsFunc: &StoreDeleteStaleRankingGraphsFunc{
+			defaultHook: i.DeleteStaleRankingGraphs,
+		},
 		DoneFunc: &StoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetDocumentRanksFunc: &StoreGetDocumentRanksFunc{
+			defaultHook: i.GetDocumentRanks,
+		},
 		GetStarRankFunc: &StoreGetStarRankFunc{
 			defaultHook: i.GetStarRank,
 		},
+		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
+			defaultHook: i.GetUploadsForRanking,
+		},
+		InsertRankingGraphFunc: &StoreInsertRankingGraphFunc{
+			defaultHook: i.InsertRankingGraph,
+		},
+		ScanReferenceGraphFunc: &StoreScanReferenceGraphFunc{
+			defaultHook: i.ScanReferenceGraph,
+		},
+		SetDocumentRanksFunc: &StoreSetDocumentRanksFunc{
+			defaultHook: i.SetDocumentRanks,
+		},
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: i.Transact,
 		},
 	}
 }
 
-// StoreDoneFunc describes the behavior when the Done method of the parent
-// MockStore instance is invoked.
-type StoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []StoreDoneFuncCall
+// StoreDeleteDocumentRanksExceptFunc describes the behavior when the
+// DeleteDocumentRanksExcept method of the parent MockStore instance is
+// invoked.
+type StoreDeleteDocumentRanksExceptFunc struct {
+	defaultHook func(context.Context, []int) (int, error)
+	hooks       []func(context.Context, []int) (int, error)
+	history     []StoreDeleteDocumentRanksExceptFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(StoreDoneFuncCall{v0, r0})
-	return r0
+// DeleteDocumentRanksExcept delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) DeleteDocumentRanksExcept(v0 context.Context, v1 []int) (int, error) {
+	r0, r1 := m.DeleteDocumentRanksExceptFunc.nextHook()(v0, v1)
+	m.DeleteDocumentRanksExceptFunc.appendCall(StoreDeleteDocumentRanksExceptFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the
+// DeleteDocumentRanksExcept method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreDeleteDocumentRanksExceptFunc) SetDefaultHook(hook func(context.Context, []int) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *StoreDoneFunc) PushHook(hook func(error) error) {
+// DeleteDocumentRanksExcept method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreDeleteDocumentRanksExceptFunc) PushHook(hook func(context.Context, []int) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -128,20 +244,20 @@ func (f *StoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
-		return r0
+func (f *StoreDeleteDocumentRanksExceptFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int) (int, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
-		return r0
+func (f *StoreDeleteDocumentRanksExceptFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, []int) (int, error) {
+		return r0, r1
 	})
 }
 
-func (f *StoreDoneFunc) nextHook() func(error) error {
+func (f *StoreDeleteDocumentRanksExceptFunc) nextHook() func(context.Context, []int) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -154,74 +270,83 @@ func (f *StoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *StoreDoneFunc) appendCall(r0 StoreDoneFuncCall) {
+func (f *StoreDeleteDocumentRanksExceptFunc) appendCall(r0 StoreDeleteDocumentRanksExceptFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreDoneFuncCall objects describing the
-// invocations of this function.
-func (f *StoreDoneFunc) History() []StoreDoneFuncCall {
+// History returns a sequence of StoreDeleteDocumentRanksExceptFuncCall
+// objects describing the invocations of this function.
+func (f *StoreDeleteDocumentRanksExceptFunc) History() []StoreDeleteDocumentRanksExceptFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreDoneFuncCall, len(f.history))
+	history := make([]StoreDeleteDocumentRanksExceptFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreDoneFuncCall is an object that describes an invocation of method
-// Done on an instance of MockStore.
-type StoreDoneFuncCall struct {
+// StoreDeleteDocumentRanksExceptFuncCall is an object that describes an
+// invocation of method DeleteDocumentRanksExcept on an instance of
+// MockStore.
+type StoreDeleteDocumentRanksExceptFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 error
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreDoneFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0}
+func (c StoreDeleteDocumentRanksExceptFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreDoneFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c StoreDeleteDocumentRanksExceptFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetStarRankFunc describes the behavior when the GetStarRank method
-// of the parent MockStore instance is invoked.
-type StoreGetStarRankFunc struct {
-	defaultHook func(context.Context, api.RepoName) (float64, error)
-	hooks       []func(context.Context, api.RepoName) (float64, error)
-	history     []StoreGetStarRankFuncCall
+// StoreDeleteStaleRankingGraphsFunc describes the behavior when the
+// DeleteStaleRankingGraphs method of the parent MockStore instance is
+// invoked.
+type StoreDeleteStaleRankingGraphsFunc struct {
+	defaultHook func(context.Context) (int, error)
+	hooks       []func(context.Context) (int, error)
+	history     []StoreDeleteStaleRankingGraphsFuncCall
 	mutex       sync.Mutex
 }
 
-// GetStarRank delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockStore) GetStarRank(v0 context.Context, v1 api.RepoName) (float64, error) {
-	r0, r1 := m.GetStarRankFunc.nextHook()(v0, v1)
-	m.GetStarRankFunc.appendCall(StoreGetStarRankFuncCall{v0, v1, r0, r1})
+// DeleteStaleRankingGraphs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) DeleteStaleRankingGraphs(v0 context.Context) (int, error) {
+	r0, r1 := m.DeleteStaleRankingGraphsFunc.nextHook()(v0)
+	m.DeleteStaleRankingGraphsFunc.appendCall(StoreDeleteStaleRankingGraphsFuncCall{v0, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetStarRank method
-// of the parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreGetStarRankFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (float64, error)) {
+// SetDefaultHook sets function that is called when the
+// DeleteStaleRankingGraphs method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreDeleteStaleRankingGraphsFunc) SetDefaultHook(hook func(context.Context) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetStarRank method of the parent MockStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *StoreGetStarRankFunc) PushHook(hook func(context.Context, api.RepoName) (float64, error)) {
+// DeleteStaleRankingGraphs method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreDeleteStaleRankingGraphsFunc) PushHook(hook func(context.Context) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -229,20 +354,20 @@ func (f *StoreGetStarRankFunc) PushHook(hook func(context.Context, api.RepoName)
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreGetStarRankFunc) SetDefaultReturn(r0 float64, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoName) (float64, error) {
+func (f *StoreDeleteStaleRankingGraphsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context) (int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetStarRankFunc) PushReturn(r0 float64, r1 error) {
-	f.PushHook(func(context.Context, api.RepoName) (float64, error) {
+func (f *StoreDeleteStaleRankingGraphsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context) (int, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreGetStarRankFunc) nextHook() func(context.Context, api.RepoName) (float64, error) {
+func (f *StoreDeleteStaleRankingGraphsFunc) nextHook() func(context.Context) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -255,35 +380,33 @@ func (f *StoreGetStarRankFunc) nextHook() func(context.Context, api.RepoName) (f
 	return hook
 }
 
-func (f *StoreGetStarRankFunc) appendCall(r0 StoreGetStarRankFuncCall) {
+func (f *StoreDeleteStaleRankingGraphsFunc) appendCall(r0 StoreDeleteStaleRankingGraphsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreGetStarRankFuncCall objects describing
-// the invocations of this function.
-func (f *StoreGetStarRankFunc) History() []StoreGetStarRankFuncCall {
+// History returns a sequence of StoreDeleteStaleRankingGraphsFuncCall
+// objects describing the invocations of this function.
+func (f *StoreDeleteStaleRankingGraphsFunc) History() []StoreDeleteStaleRankingGraphsFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreGetStarRankFuncCall, len(f.history))
+	history := make([]StoreDeleteStaleRankingGraphsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreGetStarRankFuncCall is an object that describes an invocation of
-// method GetStarRank on an instance of MockStore.
-type StoreGetStarRankFuncCall struct {
+// StoreDeleteStaleRankingGraphsFuncCall is an object that describes an
+// invocation of method DeleteStaleRankingGraphs on an instance of
+// MockStore.
+type StoreDeleteStaleRankingGraphsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 api.RepoName
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 float64
+	Result0 int
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -291,44 +414,44 @@ type StoreGetStarRankFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreGetStarRankFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c StoreDeleteStaleRankingGraphsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreGetStarRankFuncCall) Results() []interface{} {
+func (c StoreDeleteStaleRankingGraphsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreTransactFunc describes the behavior when the Transact method of the
-// parent MockStore instance is invoked.
-type StoreTransactFunc struct {
-	defaultHook func(context.Context) (store.Store, error)
-	hooks       []func(context.Context) (store.Store, error)
-	history     []StoreTransactFuncCall
+// StoreDoneFunc describes the behavior when the Done method of the parent
+// MockStore instance is invoked.
+type StoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []StoreDoneFuncCall
 	mutex       sync.Mutex
 }
 
-// Transact delegates to the next hook function in the queue and stores the
+// Done delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockStore) Transact(v0 context.Context) (store.Store, error) {
-	r0, r1 := m.TransactFunc.nextHook()(v0)
-	m.TransactFunc.appendCall(StoreTransactFuncCall{v0, r0, r1})
-	return r0, r1
+func (m *MockStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(StoreDoneFuncCall{v0, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the Transact method of
-// the parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreTransactFunc) SetDefaultHook(hook func(context.Context) (store.Store, error)) {
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreDoneFunc) SetDefaultHook(hook func(error) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Transact method of the parent MockStore instance invokes the hook at the
+// Done method of the parent MockStore instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *StoreTransactFunc) PushHook(hook func(context.Context) (store.Store, error)) {
+func (f *StoreDoneFunc) PushHook(hook func(error) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -336,20 +459,20 @@ func (f *StoreTransactFunc) PushHook(hook func(context.Context) (store.Store, er
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *StoreTransactFunc) SetDefaultReturn(r0 store.Store, r1 error) {
-	f.SetDefaultHook(func(context.Context) (store.Store, error) {
-		return r0, r1
+func (f *StoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreTransactFunc) PushReturn(r0 store.Store, r1 error) {
-	f.PushHook(func(context.Context) (store.Store, error) {
-		return r0, r1
+func (f *StoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
 	})
 }
 
-func (f *StoreTransactFunc) nextHook() func(context.Context) (store.Store, error) {
+func (f *StoreDoneFunc) nextHook() func(error) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -362,47 +485,1083 @@ func (f *StoreTransactFunc) nextHook() func(context.Context) (store.Store, error
 	return hook
 }
 
-func (f *StoreTransactFunc) appendCall(r0 StoreTransactFuncCall) {
+func (f *StoreDoneFunc) appendCall(r0 StoreDoneFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreTransactFuncCall objects describing
-// the invocations of this function.
-func (f *StoreTransactFunc) History() []StoreTransactFuncCall {
+// History returns a sequence of StoreDoneFuncCall objects describing the
+// invocations of this function.
+func (f *StoreDoneFunc) History() []StoreDoneFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreTransactFuncCall, len(f.history))
+	history := make([]StoreDoneFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreTransactFuncCall is an object that describes an invocation of method
-// Transact on an instance of MockStore.
-type StoreTransactFuncCall struct {
+// StoreDoneFuncCall is an object that describes an invocation of method
+// Done on an instance of MockStore.
+type StoreDoneFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 context.Context
+	Arg0 error
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 store.Store
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
+	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreTransactFuncCall) Args() []interface{} {
+func (c StoreDoneFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreTransactFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+func (c StoreDoneFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreGetDocumentRanksFunc describes the behavior when the
+// GetDocumentRanks method of the parent MockStore instance is invoked.
+type StoreGetDocumentRanksFunc struct {
+	defaultHook func(context.Context, api.RepoName) (map[string]float64, bool, error)
+	hooks       []func(context.Context, api.RepoName) (map[string]float64, bool, error)
+	history     []StoreGetDocumentRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDocumentRanks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetDocumentRanks(v0 context.Context, v1 api.RepoName) (map[string]float64, bool, error) {
+	r0, r1, r2 := m.GetDocumentRanksFunc.nextHook()(v0, v1)
+	m.GetDocumentRanksFunc.appendCall(StoreGetDocumentRanksFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetDocumentRanks
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetDocumentRanksFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (map[string]float64, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDocumentRanks method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetDocumentRanksFunc) PushHook(hook func(context.Context, api.RepoName) (map[string]float64, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetDocumentRanksFunc) SetDefaultReturn(r0 map[string]float64, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (map[string]float64, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetDocumentRanksFunc) PushReturn(r0 map[string]float64, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, api.RepoName) (map[string]float64, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetDocumentRanksFunc) nextHook() func(context.Context, api.RepoName) (map[string]float64, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetDocumentRanksFunc) appendCall(r0 StoreGetDocumentRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetDocumentRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetDocumentRanksFunc) History() []StoreGetDocumentRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetDocumentRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetDocumentRanksFuncCall is an object that describes an invocation
+// of method GetDocumentRanks on an instance of MockStore.
+type StoreGetDocumentRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]float64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetDocumentRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetDocumentRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreGetStarRankFunc describes the behavior when the GetStarRank method
+// of the parent MockStore instance is invoked.
+type StoreGetStarRankFunc struct {
+	defaultHook func(context.Context, api.RepoName) (float64, error)
+	hooks       []func(context.Context, api.RepoName) (float64, error)
+	history     []StoreGetStarRankFuncCall
+	mutex       sync.Mutex
+}
+
+// GetStarRank delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) GetStarRank(v0 context.Context, v1 api.RepoName) (float64, error) {
+	r0, r1 := m.GetStarRankFunc.nextHook()(v0, v1)
+	m.GetStarRankFunc.appendCall(StoreGetStarRankFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetStarRank method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreGetStarRankFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (float64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetStarRank method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetStarRankFunc) PushHook(hook func(context.Context, api.RepoName) (float64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetStarRankFunc) SetDefaultReturn(r0 float64, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (float64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetStarRankFunc) PushReturn(r0 float64, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (float64, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetStarRankFunc) nextHook() func(context.Context, api.RepoName) (float64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetStarRankFunc) appendCall(r0 StoreGetStarRankFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetStarRankFuncCall objects describing
+// the invocations of this function.
+func (f *StoreGetStarRankFunc) History() []StoreGetStarRankFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetStarRankFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetStarRankFuncCall is an object that describes an invocation of
+// method GetStarRank on an instance of MockStore.
+type StoreGetStarRankFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 float64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetStarRankFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetStarRankFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetUploadsForRankingFunc describes the behavior when the
+// GetUploadsForRanking method of the parent MockStore instance is invoked.
+type StoreGetUploadsForRankingFunc struct {
+	defaultHook func(context.Context, int) ([]shared.ExportableUpload, error)
+	hooks       []func(context.Context, int) ([]shared.ExportableUpload, error)
+	history     []StoreGetUploadsForRankingFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadsForRanking delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadsForRanking(v0 context.Context, v1 int) ([]shared.ExportableUpload, error) {
+	r0, r1 := m.GetUploadsForRankingFunc.nextHook()(v0, v1)
+	m.GetUploadsForRankingFunc.appendCall(StoreGetUploadsForRankingFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUploadsForRanking
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetUploadsForRankingFunc) SetDefaultHook(hook func(context.Context, int) ([]shared.ExportableUpload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadsForRanking method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetUploadsForRankingFunc) PushHook(hook func(context.Context, int) ([]shared.ExportableUpload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadsForRankingFunc) SetDefaultReturn(r0 []shared.ExportableUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]shared.ExportableUpload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadsForRankingFunc) PushReturn(r0 []shared.ExportableUpload, r1 error) {
+	f.PushHook(func(context.Context, int) ([]shared.ExportableUpload, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUploadsForRankingFunc) nextHook() func(context.Context, int) ([]shared.ExportableUpload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadsForRankingFunc) appendCall(r0 StoreGetUploadsForRankingFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadsForRankingFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetUploadsForRankingFunc) History() []StoreGetUploadsForRankingFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadsForRankingFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadsForRankingFuncCall is an object that describes an
+// invocation of method GetUploadsForRanking on an instance of MockStore.
+type StoreGetUploadsForRankingFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.ExportableUpload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadsForRankingFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadsForRankingFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreInsertRankingGraphFunc describes the behavior when the
+// InsertRankingGraph method of the parent MockStore instance is invoked.
+type StoreInsertRankingGraphFunc struct {
+	defaultHook func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) error
+	hooks       []func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) error
+	history     []StoreInsertRankingGraphFuncCall
+	mutex       sync.Mutex
+}
+
+// InsertRankingGraph delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) InsertRankingGraph(v0 context.Context, v1 int, v2 []shared.SymbolDefinition, v3 []shared.SymbolReference) error {
+	r0 := m.InsertRankingGraphFunc.nextHook()(v0, v1, v2, v3)
+	m.InsertRankingGraphFunc.appendCall(StoreInsertRankingGraphFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the InsertRankingGraph
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreInsertRankingGraphFunc) SetDefaultHook(hook func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// InsertRankingGraph method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreInsertRankingGraphFunc) PushHook(hook func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreInsertRankingGraphFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreInsertRankingGraphFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) error {
+		return r0
+	})
+}
+
+func (f *StoreInsertRankingGraphFunc) nextHook() func(context.Context, int, []shared.SymbolDefinition, []shared.SymbolReference) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreInsertRankingGraphFunc) appendCall(r0 StoreInsertRankingGraphFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreInsertRankingGraphFuncCall objects
+// describing the invocations of this function.
+func (f *StoreInsertRankingGraphFunc) History() []StoreInsertRankingGraphFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreInsertRankingGraphFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreInsertRankingGraphFuncCall is an object that describes an invocation
+// of method InsertRankingGraph on an instance of MockStore.
+type StoreInsertRankingGraphFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []shared.SymbolDefinition
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []shared.SymbolReference
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreInsertRankingGraphFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreInsertRankingGraphFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreScanReferenceGraphFunc describes the behavior when the
+// ScanReferenceGraph method of the parent MockStore instance is invoked.
+type StoreScanReferenceGraphFunc struct {
+	defaultHook func(context.Context, int, int, func(edge shared.DocumentEdge) error) (int, error)
+	hooks       []func(context.Context, int, int, func(edge shared.DocumentEdge) error) (int, error)
+	history     []StoreScanReferenceGraphFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanReferenceGraph delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) ScanReferenceGraph(v0 context.Context, v1 int, v2 int, v3 func(edge shared.DocumentEdge) error) (int, error) {
+	r0, r1 := m.ScanReferenceGraphFunc.nextHook()(v0, v1, v2, v3)
+	m.ScanReferenceGraphFunc.appendCall(StoreScanReferenceGraphFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ScanReferenceGraph
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreScanReferenceGraphFunc) SetDefaultHook(hook func(context.Context, int, int, func(edge shared.DocumentEdge) error) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanReferenceGraph method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreScanReferenceGraphFunc) PushHook(hook func(context.Context, int, int, func(edge shared.DocumentEdge) error) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreScanReferenceGraphFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int, func(edge shared.DocumentEdge) error) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreScanReferenceGraphFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int, int, func(edge shared.DocumentEdge) error) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreScanReferenceGraphFunc) nextHook() func(context.Context, int, int, func(edge shared.DocumentEdge) error) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreScanReferenceGraphFunc) appendCall(r0 StoreScanReferenceGraphFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreScanReferenceGraphFuncCall objects
+// describing the invocations of this function.
+func (f *StoreScanReferenceGraphFunc) History() []StoreScanReferenceGraphFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreScanReferenceGraphFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreScanReferenceGraphFuncCall is an object that describes an invocation
+// of method ScanReferenceGraph on an instance of MockStore.
+type StoreScanReferenceGraphFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 func(edge shared.DocumentEdge) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreScanReferenceGraphFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreScanReferenceGraphFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreSetDocumentRanksFunc describes the behavior when the
+// SetDocumentRanks method of the parent MockStore instance is invoked.
+type StoreSetDocumentRanksFunc struct {
+	defaultHook func(context.Context, int, map[string]float64) error
+	hooks       []func(context.Context, int, map[string]float64) error
+	history     []StoreSetDocumentRanksFuncCall
+	mutex       sync.Mutex
+}
+
+// SetDocumentRanks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) SetDocumentRanks(v0 context.Context, v1 int, v2 map[string]float64) error {
+	r0 := m.SetDocumentRanksFunc.nextHook()(v0, v1, v2)
+	m.SetDocumentRanksFunc.appendCall(StoreSetDocumentRanksFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetDocumentRanks
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreSetDocumentRanksFunc) SetDefaultHook(hook func(context.Context, int, map[string]float64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetDocumentRanks method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreSetDocumentRanksFunc) PushHook(hook func(context.Context, int, map[string]float64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreSetDocumentRanksFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, map[string]float64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreSetDocumentRanksFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, map[string]float64) error {
+		return r0
+	})
+}
+
+func (f *StoreSetDocumentRanksFunc) nextHook() func(context.Context, int, map[string]float64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreSetDocumentRanksFunc) appendCall(r0 StoreSetDocumentRanksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreSetDocumentRanksFuncCall objects
+// describing the invocations of this function.
+func (f *StoreSetDocumentRanksFunc) History() []StoreSetDocumentRanksFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreSetDocumentRanksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreSetDocumentRanksFuncCall is an object that describes an invocation
+// of method SetDocumentRanks on an instance of MockStore.
+type StoreSetDocumentRanksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 map[string]float64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreSetDocumentRanksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreSetDocumentRanksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// StoreTransactFunc describes the behavior when the Transact method of the
+// parent MockStore instance is invoked.
+type StoreTransactFunc struct {
+	defaultHook func(context.Context) (store.Store, error)
+	hooks       []func(context.Context) (store.Store, error)
+	history     []StoreTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// Transact delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Transact(v0 context.Context) (store.Store, error) {
+	r0, r1 := m.TransactFunc.nextHook()(v0)
+	m.TransactFunc.appendCall(StoreTransactFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Transact method of
+// the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreTransactFunc) SetDefaultHook(hook func(context.Context) (store.Store, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Transact method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreTransactFunc) PushHook(hook func(context.Context) (store.Store, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreTransactFunc) SetDefaultReturn(r0 store.Store, r1 error) {
+	f.SetDefaultHook(func(context.Context) (store.Store, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreTransactFunc) PushReturn(r0 store.Store, r1 error) {
+	f.PushHook(func(context.Context) (store.Store, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreTransactFunc) nextHook() func(context.Context) (store.Store, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreTransactFunc) appendCall(r0 StoreTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreTransactFuncCall objects describing
+// the invocations of this function.
+func (f *StoreTransactFunc) History() []StoreTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreTransactFuncCall is an object that describes an invocation of method
+// Transact on an instance of MockStore.
+type StoreTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 store.Store
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockLsifStore is a mock implementation of the LsifStore interface (from
+// the package
+// github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore)
+// used for unit testing.
+type MockLsifStore struct {
+	// ScanDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanDefinitions.
+	ScanDefinitionsFunc *LsifStoreScanDefinitionsFunc
+	// ScanReferencesFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanReferences.
+	ScanReferencesFunc *LsifStoreScanReferencesFunc
+}
+
+// NewMockLsifStore creates a new mock of the LsifStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockLsifStore() *MockLsifStore {
+	return &MockLsifStore{
+		ScanDefinitionsFunc: &LsifStoreScanDefinitionsFunc{
+			defaultHook: func(context.Context, int, func(symbolName string, path string) error) (r0 error) {
+				return
+			},
+		},
+		ScanReferencesFunc: &LsifStoreScanReferencesFunc{
+			defaultHook: func(context.Context, int, func(symbolName string, path string, count int) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockLsifStore creates a new mock of the LsifStore interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockLsifStore() *MockLsifStore {
+	return &MockLsifStore{
+		ScanDefinitionsFunc: &LsifStoreScanDefinitionsFunc{
+			defaultHook: func(context.Context, int, func(symbolName string, path string) error) error {
+				panic("unexpected invocation of MockLsifStore.ScanDefinitions")
+			},
+		},
+		ScanReferencesFunc: &LsifStoreScanReferencesFunc{
+			defaultHook: func(context.Context, int, func(symbolName string, path string, count int) error) error {
+				panic("unexpected invocation of MockLsifStore.ScanReferences")
+			},
+		},
+	}
+}
+
+// NewMockLsifStoreFrom creates a new mock of the MockLsifStore interface.
+// All methods delegate to the given implementation, unless overwritten.
+func NewMockLsifStoreFrom(i lsifstore.LsifStore) *MockLsifStore {
+	return &MockLsifStore{
+		ScanDefinitionsFunc: &LsifStoreScanDefinitionsFunc{
+			defaultHook: i.ScanDefinitions,
+		},
+		ScanReferencesFunc: &LsifStoreScanReferencesFunc{
+			defaultHook: i.ScanReferences,
+		},
+	}
+}
+
+// LsifStoreScanDefinitionsFunc describes the behavior when the
+// ScanDefinitions method of the parent MockLsifStore instance is invoked.
+type LsifStoreScanDefinitionsFunc struct {
+	defaultHook func(context.Context, int, func(symbolName string, path string) error) error
+	hooks       []func(context.Context, int, func(symbolName string, path string) error) error
+	history     []LsifStoreScanDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) ScanDefinitions(v0 context.Context, v1 int, v2 func(symbolName string, path string) error) error {
+	r0 := m.ScanDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.ScanDefinitionsFunc.appendCall(LsifStoreScanDefinitionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanDefinitions
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreScanDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, func(symbolName string, path string) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanDefinitions method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreScanDefinitionsFunc) PushHook(hook func(context.Context, int, func(symbolName string, path string) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreScanDefinitionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(symbolName string, path string) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreScanDefinitionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(symbolName string, path string) error) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreScanDefinitionsFunc) nextHook() func(context.Context, int, func(symbolName string, path string) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreScanDefinitionsFunc) appendCall(r0 LsifStoreScanDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreScanDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreScanDefinitionsFunc) History() []LsifStoreScanDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreScanDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreScanDefinitionsFuncCall is an object that describes an
+// invocation of method ScanDefinitions on an instance of MockLsifStore.
+type LsifStoreScanDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(symbolName string, path string) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreScanDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreScanDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LsifStoreScanReferencesFunc describes the behavior when the
+// ScanReferences method of the parent MockLsifStore instance is invoked.
+type LsifStoreScanReferencesFunc struct {
+	defaultHook func(context.Context, int, func(symbolName string, path string, count int) error) error
+	hooks       []func(context.Context, int, func(symbolName string, path string, count int) error) error
+	history     []LsifStoreScanReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanReferences delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLsifStore) ScanReferences(v0 context.Context, v1 int, v2 func(symbolName string, path string, count int) error) error {
+	r0 := m.ScanReferencesFunc.nextHook()(v0, v1, v2)
+	m.ScanReferencesFunc.appendCall(LsifStoreScanReferencesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanReferences
+// method of the parent MockLsifStore instance is invoked and the hook queue
+// is empty.
+func (f *LsifStoreScanReferencesFunc) SetDefaultHook(hook func(context.Context, int, func(symbolName string, path string, count int) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanReferences method of the parent MockLsifStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LsifStoreScanReferencesFunc) PushHook(hook func(context.Context, int, func(symbolName string, path string, count int) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LsifStoreScanReferencesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(symbolName string, path string, count int) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LsifStoreScanReferencesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(symbolName string, path string, count int) error) error {
+		return r0
+	})
+}
+
+func (f *LsifStoreScanReferencesFunc) nextHook() func(context.Context, int, func(symbolName string, path string, count int) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LsifStoreScanReferencesFunc) appendCall(r0 LsifStoreScanReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LsifStoreScanReferencesFuncCall objects
+// describing the invocations of this function.
+func (f *LsifStoreScanReferencesFunc) History() []LsifStoreScanReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]LsifStoreScanReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LsifStoreScanReferencesFuncCall is an object that describes an invocation
+// of method ScanReferences on an instance of MockLsifStore.
+type LsifStoreScanReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(symbolName string, path string, count int) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LsifStoreScanReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LsifStoreScanReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
 }
 
 // MockGitserverClient is a mock implementation of the GitserverClient
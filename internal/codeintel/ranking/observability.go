@@ -8,9 +8,10 @@ import (
 )
 
 type operations struct {
-	getRepoRank       *observation.Operation
-	getDocumentRanks  *observation.Operation
-	indexRepositories *observation.Operation
+	getRepoRank          *observation.Operation
+	getDocumentRanks     *observation.Operation
+	exportRankingGraph   *observation.Operation
+	computeDocumentRanks *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -30,8 +31,9 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		getRepoRank:       op("GetRepoRank"),
-		getDocumentRanks:  op("GetDocumentRanks"),
-		indexRepositories: op("IndexRepositories"),
+		getRepoRank:          op("GetRepoRank"),
+		getDocumentRanks:     op("GetDocumentRanks"),
+		exportRankingGraph:   op("ExportRankingGraph"),
+		computeDocumentRanks: op("ComputeDocumentRanks"),
 	}
 }
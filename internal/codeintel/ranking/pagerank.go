@@ -0,0 +1,117 @@
+package ranking
+
+import "math"
+
+const (
+	// pageRankDampingFactor is the probability that the random surfer follows a reference
+	// rather than jumping to an arbitrary document.
+	pageRankDampingFactor = 0.85
+
+	// pageRankMaxIterations bounds the number of power iterations performed.
+	pageRankMaxIterations = 100
+
+	// pageRankTolerance is the L1 distance between successive iterations below which
+	// the computation is considered to have converged.
+	pageRankTolerance = 1e-6
+)
+
+// documentKey identifies a document within a repository.
+type documentKey struct {
+	repositoryID int
+	path         string
+}
+
+// pageRank computes the PageRank of each document in the given weighted graph, where
+// edges[a][b] is the number of references from document a to symbols defined in document
+// b. The resulting ranks sum to one. Documents without outgoing edges distribute their
+// rank evenly over all documents.
+func pageRank(edges map[documentKey]map[documentKey]float64) map[documentKey]float64 {
+	// Assign each document a dense index
+	indexes := map[documentKey]int{}
+	keys := []documentKey{}
+	addKey := func(key documentKey) {
+		if _, ok := indexes[key]; !ok {
+			indexes[key] = len(keys)
+			keys = append(keys, key)
+		}
+	}
+	for source, targets := range edges {
+		addKey(source)
+		for target := range targets {
+			addKey(target)
+		}
+	}
+
+	n := len(keys)
+	if n == 0 {
+		return map[documentKey]float64{}
+	}
+
+	type weightedEdge struct {
+		target int
+		weight float64
+	}
+
+	// Normalize outgoing weights so that each document distributes all of its rank
+	outgoing := make([][]weightedEdge, n)
+	for source, targets := range edges {
+		total := 0.0
+		for target, weight := range targets {
+			if target != source {
+				total += weight
+			}
+		}
+		if total == 0 {
+			continue
+		}
+
+		i := indexes[source]
+		for target, weight := range targets {
+			if target != source && weight > 0 {
+				outgoing[i] = append(outgoing[i], weightedEdge{target: indexes[target], weight: weight / total})
+			}
+		}
+	}
+
+	ranks := make([]float64, n)
+	for i := range ranks {
+		ranks[i] = 1 / float64(n)
+	}
+
+	next := make([]float64, n)
+	for iteration := 0; iteration < pageRankMaxIterations; iteration++ {
+		dangling := 0.0
+		for i, rank := range ranks {
+			if len(outgoing[i]) == 0 {
+				dangling += rank
+			}
+		}
+
+		base := (1-pageRankDampingFactor)/float64(n) + pageRankDampingFactor*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i, rank := range ranks {
+			for _, edge := range outgoing[i] {
+				next[edge.target] += pageRankDampingFactor * rank * edge.weight
+			}
+		}
+
+		delta := 0.0
+		for i := range ranks {
+			delta += math.Abs(next[i] - ranks[i])
+		}
+		ranks, next = next, ranks
+
+		if delta < pageRankTolerance {
+			break
+		}
+	}
+
+	ranksByKey := make(map[documentKey]float64, n)
+	for i, key := range keys {
+		ranksByKey[key] = ranks[i]
+	}
+
+	return ranksByKey
+}
@@ -0,0 +1,46 @@
+package ranking
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPageRank(t *testing.T) {
+	var (
+		main   = documentKey{1, "cmd/main.go"}
+		server = documentKey{1, "internal/server.go"}
+		util   = documentKey{1, "internal/util.go"}
+		lib    = documentKey{2, "lib/lib.go"}
+	)
+
+	ranks := pageRank(map[documentKey]map[documentKey]float64{
+		main:   {server: 3, util: 1, lib: 1},
+		server: {util: 5, lib: 5, server: 10},
+		util:   {lib: 2},
+	})
+
+	sum := 0.0
+	for _, rank := range ranks {
+		sum += rank
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Errorf("unexpected sum of ranks. want=%.6f have=%.6f", 1.0, sum)
+	}
+
+	if len(ranks) != 4 {
+		t.Fatalf("unexpected number of ranks. want=%d have=%d", 4, len(ranks))
+	}
+
+	// Each document references only documents later in this list
+	for i, pair := range [][2]documentKey{{main, server}, {server, util}, {util, lib}} {
+		if ranks[pair[0]] >= ranks[pair[1]] {
+			t.Errorf("unexpected order %d. want rank(%s) < rank(%s), have %.6f >= %.6f", i, pair[0].path, pair[1].path, ranks[pair[0]], ranks[pair[1]])
+		}
+	}
+}
+
+func TestPageRankEmpty(t *testing.T) {
+	if ranks := pageRank(nil); len(ranks) != 0 {
+		t.Errorf("unexpected ranks. want=%v have=%v", map[documentKey]float64{}, ranks)
+	}
+}
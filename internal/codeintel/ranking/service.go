@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/ranking/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -18,6 +19,7 @@ import (
 
 type Service struct {
 	store           store.Store
+	lsifstore       lsifstore.LsifStore
 	uploadSvc       *uploads.Service
 	gitserverClient GitserverClient
 	getConf         conftypes.SiteConfigQuerier
@@ -27,6 +29,7 @@ type Service struct {
 
 func newService(
 	store store.Store,
+	lsifstore lsifstore.LsifStore,
 	uploadSvc *uploads.Service,
 	gitserverClient GitserverClient,
 	getConf conftypes.SiteConfigQuerier,
@@ -34,6 +37,7 @@ func newService(
 ) *Service {
 	return &Service{
 		store:           store,
+		lsifstore:       lsifstore,
 		uploadSvc:       uploadSvc,
 		gitserverClient: gitserverClient,
 		getConf:         getConf,
@@ -86,8 +90,9 @@ var allPathsPattern = lazyregexp.New(".*")
 
 // GetDocumentRank returns a map from paths within the given repo to their score vectors. Paths are
 // assumed to be ordered by each pairwise component of the resulting vector, lower scores coming
-// earlier. We currently rank documents by path name length and lexicographic order, while performing
-// a few heuristics to sink generated, test, and vendor files lower in the ranking.
+// earlier. We currently rank documents by how frequently they are referenced according to precise code
+// intelligence, then by path name length and lexicographic order, while performing a few heuristics to
+// sink generated, test, and vendor files lower in the ranking.
 func (s *Service) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (_ map[string][]float64, err error) {
 	_, _, endObservation := s.operations.getDocumentRanks.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -98,10 +103,15 @@ func (s *Service) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (
 	}
 	sort.Strings(paths)
 
+	referenceRanks, _, err := s.store.GetDocumentRanks(ctx, repoName)
+	if err != nil {
+		return nil, err
+	}
+
 	n := float64(len(paths))
 	ranks := make(map[string][]float64, len(paths))
 	for i, path := range paths {
-		ranks[path] = rank(path, float64(i)/n)
+		ranks[path] = rank(path, referenceRanks[path], float64(i)/n)
 	}
 
 	return ranks, nil
@@ -111,7 +121,7 @@ var testPattern = lazyregexp.New("test")
 
 // copy pasta + modified
 // https://github.com/sourcegraph/zoekt/blob/f89a534103a224663d23b4579959854dd7816942/build/builder.go#L872-L918
-func rank(name string, nameRank float64) []float64 {
+func rank(name string, referenceRank, nameRank float64) []float64 {
 	generated := 0.0
 	if strings.HasSuffix(name, "min.js") || strings.HasSuffix(name, "js.map") {
 		generated = 1.0
@@ -138,6 +148,9 @@ func rank(name string, nameRank float64) []float64 {
 		// Prefer docs that are not tests
 		test,
 
+		// Prefer docs that are frequently referenced (unreferenced docs have a zero rank)
+		1.0 - referenceRank,
+
 		// With short names
 		squashRange(float64(len(name))),
 
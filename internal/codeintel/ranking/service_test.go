@@ -16,7 +16,7 @@ func TestGetRepoRank(t *testing.T) {
 	ctx := context.Background()
 	mockStore := NewMockStore()
 	gitserverClient := NewMockGitserverClient()
-	svc := newService(mockStore, nil, gitserverClient, siteConfigQuerier{}, &observation.TestContext)
+	svc := newService(mockStore, NewMockLsifStore(), nil, gitserverClient, siteConfigQuerier{}, &observation.TestContext)
 
 	mockStore.GetStarRankFunc.SetDefaultReturn(0.6, nil)
 
@@ -38,7 +38,7 @@ func TestGetRepoRankWithUserBoostedScores(t *testing.T) {
 	mockStore := NewMockStore()
 	gitserverClient := NewMockGitserverClient()
 	mockConfigQuerier := NewMockSiteConfigQuerier()
-	svc := newService(mockStore, nil, gitserverClient, mockConfigQuerier, &observation.TestContext)
+	svc := newService(mockStore, NewMockLsifStore(), nil, gitserverClient, mockConfigQuerier, &observation.TestContext)
 
 	mockStore.GetStarRankFunc.SetDefaultReturn(0.6, nil)
 	mockConfigQuerier.SiteConfigFunc.SetDefaultReturn(schema.SiteConfiguration{
@@ -70,7 +70,7 @@ func TestGetDocumentRanks(t *testing.T) {
 	ctx := context.Background()
 	mockStore := NewMockStore()
 	gitserverClient := NewMockGitserverClient()
-	svc := newService(mockStore, nil, gitserverClient, siteConfigQuerier{}, &observation.TestContext)
+	svc := newService(mockStore, NewMockLsifStore(), nil, gitserverClient, siteConfigQuerier{}, &observation.TestContext)
 
 	gitserverClient.ListFilesForRepoFunc.SetDefaultReturn([]string{
 		"main.go",
@@ -87,6 +87,11 @@ func TestGetDocumentRanks(t *testing.T) {
 		"node_modules/bar.js", // vendor
 		"node_modules/baz.js", // vendor
 	}, nil)
+	mockStore.GetDocumentRanksFunc.SetDefaultReturn(map[string]float64{
+		"code/b.go": 1.0,
+		"main.go":   0.5,
+		"test/a.go": 0.25,
+	}, true, nil)
 
 	ranks, err := svc.GetDocumentRanks(ctx, api.RepoName("foo"))
 	if err != nil {
@@ -94,19 +99,19 @@ func TestGetDocumentRanks(t *testing.T) {
 	}
 
 	expected := map[string][]float64{
-		"code/a.go":           {0, 0, 0, 0.900, 0.00 / 13.0},
-		"code/b.go":           {0, 0, 0, 0.900, 1.00 / 13.0},
-		"code/c.go":           {0, 0, 0, 0.900, 2.00 / 13.0},
-		"code/d.go":           {0, 0, 0, 0.900, 3.00 / 13.0},
-		"main.go":             {0, 0, 0, 0.875, 4.00 / 13.0},
-		"node_modules/bar.js": {0, 1, 0, 0.950, 5.00 / 13.0},
-		"node_modules/baz.js": {0, 1, 0, 0.950, 6.00 / 13.0},
-		"node_modules/foo.js": {0, 1, 0, 0.950, 7.00 / 13.0},
-		"rendered/web/min.js": {1, 0, 0, 0.950, 8.00 / 13.0},
-		"test/a.go":           {0, 0, 1, 0.900, 9.00 / 13.0},
-		"test/b.go":           {0, 0, 1, 0.900, 10.0 / 13.0},
-		"test/c.go":           {0, 0, 1, 0.900, 11.0 / 13.0},
-		"test/d.go":           {0, 0, 1, 0.900, 12.0 / 13.0},
+		"code/a.go":           {0, 0, 0, 1.00, 0.900, 0.00 / 13.0},
+		"code/b.go":           {0, 0, 0, 0.00, 0.900, 1.00 / 13.0},
+		"code/c.go":           {0, 0, 0, 1.00, 0.900, 2.00 / 13.0},
+		"code/d.go":           {0, 0, 0, 1.00, 0.900, 3.00 / 13.0},
+		"main.go":             {0, 0, 0, 0.50, 0.875, 4.00 / 13.0},
+		"node_modules/bar.js": {0, 1, 0, 1.00, 0.950, 5.00 / 13.0},
+		"node_modules/baz.js": {0, 1, 0, 1.00, 0.950, 6.00 / 13.0},
+		"node_modules/foo.js": {0, 1, 0, 1.00, 0.950, 7.00 / 13.0},
+		"rendered/web/min.js": {1, 0, 0, 1.00, 0.950, 8.00 / 13.0},
+		"test/a.go":           {0, 0, 1, 0.75, 0.900, 9.00 / 13.0},
+		"test/b.go":           {0, 0, 1, 1.00, 0.900, 10.0 / 13.0},
+		"test/c.go":           {0, 0, 1, 1.00, 0.900, 11.0 / 13.0},
+		"test/d.go":           {0, 0, 1, 1.00, 0.900, 12.0 / 13.0},
 	}
 
 	if diff := cmp.Diff(expected, ranks); diff != "" {
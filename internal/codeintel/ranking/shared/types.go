@@ -0,0 +1,32 @@
+package shared
+
+// ExportableUpload is an upload visible at the tip of the default branch of its repository
+// whose reference graph has not yet been exported for ranking.
+type ExportableUpload struct {
+	ID           int
+	RepositoryID int
+	Root         string
+}
+
+// SymbolDefinition associates a symbol exported from an upload with the document defining it.
+type SymbolDefinition struct {
+	SymbolName   string
+	DocumentPath string
+}
+
+// SymbolReference associates a symbol with a document of an upload that references it.
+type SymbolReference struct {
+	SymbolName   string
+	DocumentPath string
+	Count        int
+}
+
+// DocumentEdge is a weighted edge of the reference graph. The source document references
+// symbols defined in the target document Count times.
+type DocumentEdge struct {
+	SourceRepositoryID int
+	SourcePath         string
+	TargetRepositoryID int
+	TargetPath         string
+	Count              int
+}
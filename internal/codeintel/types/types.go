@@ -89,6 +89,7 @@ type Upload struct {
 	UploadedParts     []int
 	UploadSize        *int64
 	UncompressedSize  *int64
+	ContentHash       *string
 	Rank              *int
 	AssociatedIndexID *int
 }
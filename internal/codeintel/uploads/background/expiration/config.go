@@ -19,6 +19,8 @@ type config struct {
 
 	ReferenceCountUpdaterInterval  time.Duration
 	ReferenceCountUpdaterBatchSize int
+
+	StorageQuotaExpirerInterval time.Duration
 }
 
 var ConfigInst = &config{}
@@ -40,4 +42,5 @@ func (c *config) Load() {
 	c.UploadProcessDelay = c.GetInterval(uploadProcessDelay, "24h", "The minimum frequency that the same upload record can be considered for expiration.")
 	c.ReferenceCountUpdaterInterval = c.GetInterval("CODEINTEL_UPLOAD_REFERENCE_COUNT_UPDATER_INTERVAL", "1m", "How frequently to run the reference count updater routine.")
 	c.ReferenceCountUpdaterBatchSize = c.GetInt("CODEINTEL_UPLOAD_REFERENCE_COUNT_UPDATER_BATCH_SIZE", "100", "How many upload reference counts to backfill per batch.")
+	c.StorageQuotaExpirerInterval = c.GetInterval("CODEINTEL_UPLOAD_STORAGE_QUOTA_EXPIRER_INTERVAL", "1h", "How frequently to run the storage quota expirer routine.")
 }
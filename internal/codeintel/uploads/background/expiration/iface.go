@@ -21,4 +21,8 @@ type UploadService interface {
 		interval time.Duration,
 		batchSize int,
 	) goroutine.BackgroundRoutine
+
+	NewStorageQuotaExpirer(
+		interval time.Duration,
+	) goroutine.BackgroundRoutine
 }
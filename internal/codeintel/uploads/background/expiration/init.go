@@ -19,5 +19,8 @@ func NewExpirationTasks(uploadSvc UploadService) []goroutine.BackgroundRoutine {
 			ConfigInst.ReferenceCountUpdaterInterval,
 			ConfigInst.ReferenceCountUpdaterBatchSize,
 		),
+		uploadSvc.NewStorageQuotaExpirer(
+			ConfigInst.StorageQuotaExpirerInterval,
+		),
 	}
 }
@@ -179,7 +179,23 @@ func (e *Service) handleUploads(
 		expiredUploadIDs   = make([]int, 0, len(uploads))
 	)
 
+	// The data of an upload reused for another commit is as recent as its last reuse, so retention
+	// durations are counted from then. Otherwise, commits reusing an old upload would lose their code
+	// intelligence as soon as the original upload expires.
+	ids := make([]int, 0, len(uploads))
 	for _, upload := range uploads {
+		ids = append(ids, upload.ID)
+	}
+	lastReusedAt, err := e.store.GetUploadsLastReusedAt(ctx, ids...)
+	if err != nil {
+		return errors.Wrap(err, "uploadSvc.GetUploadsLastReusedAt")
+	}
+
+	for _, upload := range uploads {
+		if reusedAt, ok := lastReusedAt[upload.ID]; ok && reusedAt.After(upload.UploadedAt) {
+			upload.UploadedAt = reusedAt
+		}
+
 		protected, checkErr := e.isUploadProtectedByPolicy(ctx, commitMap, upload, cfg, now)
 		if checkErr != nil {
 			if err == nil {
@@ -0,0 +1,252 @@
+package uploads
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/opentracing/opentracing-go/log"
+	logger "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewStorageQuotaExpirer returns a background routine that expires the least valuable uploads
+// of repositories (or of the entire instance) whose storage usage exceeds the storage quotas
+// configured in the site configuration.
+func (s *Service) NewStorageQuotaExpirer(interval time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, goroutine.HandlerFunc(func(ctx context.Context) error {
+		return s.handleStorageQuotas(ctx, conf.Get().CodeIntelStorageQuota)
+	}))
+}
+
+// handleStorageQuotas marks the uploads that must be evicted to satisfy the given storage quotas
+// as expired. Expired records with no dependents will be removed by the expiredUploadDeleter.
+func (s *Service) handleStorageQuotas(ctx context.Context, quotaConfig *schema.CodeIntelStorageQuota) error {
+	quotas, ok := newStorageQuotas(quotaConfig)
+	if !ok {
+		return nil
+	}
+
+	evictions, err := s.getStorageQuotaEvictions(ctx, quotas, 0)
+	if err != nil {
+		return err
+	}
+
+	expiredUploadIDs := make([]int, 0, len(evictions))
+	for _, eviction := range evictions {
+		if eviction.WouldBeEvicted {
+			expiredUploadIDs = append(expiredUploadIDs, eviction.UploadID)
+		}
+	}
+	if len(expiredUploadIDs) == 0 {
+		return nil
+	}
+
+	if err := s.store.UpdateUploadRetention(ctx, nil, expiredUploadIDs); err != nil {
+		return errors.Wrap(err, "uploadSvc.UpdateUploadRetention")
+	}
+
+	s.logger.Info("Expiring codeintel uploads exceeding storage quota", logger.Int("count", len(expiredUploadIDs)))
+	s.expirationMetrics.numUploadsExpired.Add(float64(len(expiredUploadIDs)))
+
+	return nil
+}
+
+// GetStorageQuotaEvictionPreview returns the outcome of enforcing the storage quotas currently
+// configured in the site configuration for each upload of the given repository without expiring
+// any upload. A nil slice is returned if no storage quota is configured.
+func (s *Service) GetStorageQuotaEvictionPreview(ctx context.Context, repositoryID int) (_ []shared.StorageQuotaEviction, err error) {
+	ctx, _, endObservation := s.operations.getStorageQuotaEvictionPreview.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	quotas, ok := newStorageQuotas(conf.Get().CodeIntelStorageQuota)
+	if !ok {
+		return nil, nil
+	}
+
+	// The global quota depends on the uploads of all repositories
+	scope := repositoryID
+	if quotas.global > 0 {
+		scope = 0
+	}
+
+	evictions, err := s.getStorageQuotaEvictions(ctx, quotas, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var preview []shared.StorageQuotaEviction
+	for _, eviction := range evictions {
+		if eviction.repositoryID == repositoryID {
+			preview = append(preview, eviction.StorageQuotaEviction)
+		}
+	}
+
+	return preview, nil
+}
+
+// storageQuotas are the storage quotas (in bytes) configured in the site configuration.
+type storageQuotas struct {
+	global              int64
+	repository          int64
+	repositoryOverrides map[string]int64
+}
+
+// newStorageQuotas converts the given site configuration. The returned flag is false if no quota
+// is configured.
+func newStorageQuotas(quotaConfig *schema.CodeIntelStorageQuota) (storageQuotas, bool) {
+	if quotaConfig == nil {
+		return storageQuotas{}, false
+	}
+
+	quotas := storageQuotas{
+		global:              int64(quotaConfig.Global),
+		repository:          int64(quotaConfig.Repository),
+		repositoryOverrides: make(map[string]int64, len(quotaConfig.RepositoryOverrides)),
+	}
+	for repoName, quota := range quotaConfig.RepositoryOverrides {
+		quotas.repositoryOverrides[repoName] = int64(quota)
+	}
+
+	return quotas, quotas.global > 0 || quotas.repository > 0 || len(quotas.repositoryOverrides) > 0
+}
+
+// repositoryQuota returns the storage quota of the given repository. The returned flag is false if
+// the repository has no quota.
+func (q storageQuotas) repositoryQuota(repoName string) (int64, bool) {
+	if quota, ok := q.repositoryOverrides[repoName]; ok {
+		return quota, true
+	}
+
+	return q.repository, q.repository > 0
+}
+
+// lessValuable returns true if the first upload should be evicted before the second one. Old
+// uploads are evicted before recent ones, then uploads referenced by fewer other uploads, then
+// uploads farther from the branches of their repository.
+func lessValuable(a, b shared.StorageQuotaCandidate) bool {
+	if ageA, ageB := uploadAge(a), uploadAge(b); !ageA.Equal(ageB) {
+		return ageA.Before(ageB)
+	}
+	if a.ReferenceCount != b.ReferenceCount {
+		return a.ReferenceCount < b.ReferenceCount
+	}
+	if (a.BranchDistance == nil) != (b.BranchDistance == nil) {
+		return a.BranchDistance == nil
+	}
+	if a.BranchDistance != nil && *a.BranchDistance != *b.BranchDistance {
+		return *a.BranchDistance > *b.BranchDistance
+	}
+	return a.UploadID < b.UploadID
+}
+
+// uploadAge returns the date of the commit of the given upload, or when it was uploaded if the
+// commit date is unknown.
+func uploadAge(candidate shared.StorageQuotaCandidate) time.Time {
+	if candidate.CommittedAt != nil {
+		return *candidate.CommittedAt
+	}
+	return candidate.UploadedAt
+}
+
+type storageQuotaEviction struct {
+	shared.StorageQuotaEviction
+	repositoryID int
+}
+
+// getStorageQuotaEvictions determines which uploads must be evicted so that the storage usage of
+// each repository, then of the entire instance, falls under its quota. If a repository identifier
+// is supplied (is non-zero), then only the uploads of that repository are considered.
+func (s *Service) getStorageQuotaEvictions(ctx context.Context, quotas storageQuotas, repositoryID int) ([]*storageQuotaEviction, error) {
+	candidates, err := s.store.GetStorageQuotaCandidates(ctx, repositoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "uploadSvc.GetStorageQuotaCandidates")
+	}
+
+	// Candidates are ordered by repository
+	var repositoryIDs []int
+	candidatesByRepository := map[int][]shared.StorageQuotaCandidate{}
+	var totalUsage int64
+	for _, candidate := range candidates {
+		if _, ok := candidatesByRepository[candidate.RepositoryID]; !ok {
+			repositoryIDs = append(repositoryIDs, candidate.RepositoryID)
+		}
+		candidatesByRepository[candidate.RepositoryID] = append(candidatesByRepository[candidate.RepositoryID], candidate)
+		totalUsage += candidate.Size
+	}
+
+	var (
+		evictions     = make([]*storageQuotaEviction, 0, len(candidates))
+		evictionsByID = make(map[int]*storageQuotaEviction, len(candidates))
+		evictable     []shared.StorageQuotaCandidate
+	)
+
+	for _, id := range repositoryIDs {
+		repositoryCandidates := candidatesByRepository[id]
+
+		var usage int64
+		for _, candidate := range repositoryCandidates {
+			usage += candidate.Size
+		}
+		quota, hasQuota := quotas.repositoryQuota(repositoryCandidates[0].RepositoryName)
+		repositoryExceeded := hasQuota && usage > quota
+
+		unprotected := make([]shared.StorageQuotaCandidate, 0, len(repositoryCandidates))
+		for _, candidate := range repositoryCandidates {
+			// Referenced uploads are not protected, but are ranked after unreferenced uploads of
+			// the same age since their storage is only freed once nothing depends on them
+			protected := candidate.VisibleAtTip
+
+			eviction := &storageQuotaEviction{
+				StorageQuotaEviction: shared.StorageQuotaEviction{
+					UploadID:  candidate.UploadID,
+					Protected: protected,
+				},
+				repositoryID: id,
+			}
+			evictions = append(evictions, eviction)
+			evictionsByID[candidate.UploadID] = eviction
+
+			if !protected {
+				unprotected = append(unprotected, candidate)
+			}
+		}
+		sort.Slice(unprotected, func(i, j int) bool { return lessValuable(unprotected[i], unprotected[j]) })
+
+		for i, candidate := range unprotected {
+			eviction := evictionsByID[candidate.UploadID]
+			eviction.Rank = i + 1
+
+			if repositoryExceeded && usage > quota {
+				eviction.WouldBeEvicted = true
+				usage -= candidate.Size
+				totalUsage -= candidate.Size
+			} else {
+				evictable = append(evictable, candidate)
+			}
+		}
+	}
+
+	if quotas.global > 0 && totalUsage > quotas.global {
+		sort.Slice(evictable, func(i, j int) bool { return lessValuable(evictable[i], evictable[j]) })
+
+		for _, candidate := range evictable {
+			if totalUsage <= quotas.global {
+				break
+			}
+
+			evictionsByID[candidate.UploadID].WouldBeEvicted = true
+			totalUsage -= candidate.Size
+		}
+	}
+
+	return evictions, nil
+}
@@ -0,0 +1,145 @@
+package uploads
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestStorageQuotaExpirer(t *testing.T) {
+	mockStore := setupMockStorageQuotaStore(timeutil.Now())
+	svc := &Service{
+		store:             mockStore,
+		expirationMetrics: newExpirationMetrics(&observation.TestContext),
+		logger:            logtest.Scoped(t),
+		operations:        newOperations(&observation.TestContext),
+	}
+
+	if err := svc.handleStorageQuotas(context.Background(), &schema.CodeIntelStorageQuota{
+		Global:              160,
+		Repository:          1000,
+		RepositoryOverrides: map[string]int{"r1": 100},
+	}); err != nil {
+		t.Fatalf("unexpected error from handle: %s", err)
+	}
+
+	var protectedIDs, expiredIDs []int
+	for _, call := range mockStore.UpdateUploadRetentionFunc.History() {
+		protectedIDs = append(protectedIDs, call.Arg1...)
+		expiredIDs = append(expiredIDs, call.Arg2...)
+	}
+	sort.Ints(expiredIDs)
+
+	if len(protectedIDs) != 0 {
+		t.Errorf("unexpected protected upload identifiers: %v", protectedIDs)
+	}
+
+	// Upload 3 is evicted to satisfy the quota of r1, then upload 5 to satisfy the global quota
+	expectedExpiredIDs := []int{3, 5}
+	if diff := cmp.Diff(expectedExpiredIDs, expiredIDs); diff != "" {
+		t.Errorf("unexpected expired upload identifiers (-want +got):\n%s", diff)
+	}
+}
+
+func TestStorageQuotaExpirerNoQuota(t *testing.T) {
+	mockStore := setupMockStorageQuotaStore(timeutil.Now())
+	svc := &Service{
+		store:             mockStore,
+		expirationMetrics: newExpirationMetrics(&observation.TestContext),
+		logger:            logtest.Scoped(t),
+		operations:        newOperations(&observation.TestContext),
+	}
+
+	for _, quotaConfig := range []*schema.CodeIntelStorageQuota{nil, {}, {Global: 1000}} {
+		if err := svc.handleStorageQuotas(context.Background(), quotaConfig); err != nil {
+			t.Fatalf("unexpected error from handle: %s", err)
+		}
+	}
+
+	if calls := mockStore.UpdateUploadRetentionFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of calls to UpdateUploadRetention. want=%d have=%d", 0, len(calls))
+	}
+}
+
+func TestGetStorageQuotaEvictionPreview(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			CodeIntelStorageQuota: &schema.CodeIntelStorageQuota{
+				RepositoryOverrides: map[string]int{"r1": 100},
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	mockStore := setupMockStorageQuotaStore(timeutil.Now())
+	svc := &Service{
+		store:      mockStore,
+		logger:     logtest.Scoped(t),
+		operations: newOperations(&observation.TestContext),
+	}
+
+	preview, err := svc.GetStorageQuotaEvictionPreview(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting preview: %s", err)
+	}
+
+	expected := []shared.StorageQuotaEviction{
+		{UploadID: 1, Protected: true},
+		{UploadID: 2, Rank: 3},
+		{UploadID: 3, Rank: 1, WouldBeEvicted: true},
+		{UploadID: 4, Rank: 2},
+	}
+	if diff := cmp.Diff(expected, preview); diff != "" {
+		t.Errorf("unexpected preview (-want +got):\n%s", diff)
+	}
+
+	// Without a global quota only the target repository is considered
+	if calls := mockStore.GetStorageQuotaCandidatesFunc.History(); len(calls) != 1 || calls[0].Arg1 != 1 {
+		t.Errorf("unexpected calls to GetStorageQuotaCandidates: %v", calls)
+	}
+	if calls := mockStore.UpdateUploadRetentionFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of calls to UpdateUploadRetention. want=%d have=%d", 0, len(calls))
+	}
+}
+
+func setupMockStorageQuotaStore(now time.Time) *MockStore {
+	candidates := []shared.StorageQuotaCandidate{
+		// r1: uploads visible at tip are protected, the others are ordered by age, then reference
+		// count, then branch distance
+		{UploadID: 1, RepositoryID: 1, RepositoryName: "r1", Size: 40, UploadedAt: daysAgo(now, 9), CommittedAt: timePtr(daysAgo(now, 9)), VisibleAtTip: true, BranchDistance: intPtr(0)},
+		{UploadID: 2, RepositoryID: 1, RepositoryName: "r1", Size: 30, UploadedAt: daysAgo(now, 2), CommittedAt: timePtr(daysAgo(now, 20)), ReferenceCount: 2, BranchDistance: intPtr(1)},
+		{UploadID: 3, RepositoryID: 1, RepositoryName: "r1", Size: 30, UploadedAt: daysAgo(now, 20), BranchDistance: intPtr(0)},
+		{UploadID: 4, RepositoryID: 1, RepositoryName: "r1", Size: 30, UploadedAt: daysAgo(now, 5), CommittedAt: timePtr(daysAgo(now, 20)), ReferenceCount: 2},
+
+		// r2: under its quota, but contributes to the global quota
+		{UploadID: 5, RepositoryID: 2, RepositoryName: "r2", Size: 50, UploadedAt: daysAgo(now, 1), CommittedAt: timePtr(daysAgo(now, 30)), BranchDistance: intPtr(1)},
+		{UploadID: 6, RepositoryID: 2, RepositoryName: "r2", Size: 50, UploadedAt: daysAgo(now, 10), CommittedAt: timePtr(daysAgo(now, 1)), BranchDistance: intPtr(0)},
+	}
+
+	mockStore := NewMockStore()
+	mockStore.GetStorageQuotaCandidatesFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) ([]shared.StorageQuotaCandidate, error) {
+		var filtered []shared.StorageQuotaCandidate
+		for _, candidate := range candidates {
+			if repositoryID == 0 || candidate.RepositoryID == repositoryID {
+				filtered = append(filtered, candidate)
+			}
+		}
+		return filtered, nil
+	})
+
+	return mockStore
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -57,12 +57,12 @@ func TestUploadExpirer(t *testing.T) {
 	}
 	sort.Ints(expiredIDs)
 
-	expectedProtectedIDs := []int{12, 16, 18, 20, 25, 26, 27, 28}
+	expectedProtectedIDs := []int{12, 13, 16, 18, 20, 25, 26, 27, 28}
 	if diff := cmp.Diff(expectedProtectedIDs, protectedIDs); diff != "" {
 		t.Errorf("unexpected protected upload identifiers (-want +got):\n%s", diff)
 	}
 
-	expectedExpiredIDs := []int{11, 13, 14, 15, 17, 19, 21, 22, 23, 24, 29, 30}
+	expectedExpiredIDs := []int{11, 14, 15, 17, 19, 21, 22, 23, 24, 29, 30}
 	if diff := cmp.Diff(expectedExpiredIDs, expiredIDs); diff != "" {
 		t.Errorf("unexpected expired upload identifiers (-want +got):\n%s", diff)
 	}
@@ -210,8 +210,23 @@ func setupMockUploadService(now time.Time) *MockStore {
 		return nil, nil, nil
 	}
 
+	// Upload 13 is older than its policy allows, but it was reused for another commit since
+	lastReusedAt := map[int]time.Time{13: daysAgo(now, 1)}
+
+	getUploadsLastReusedAt := func(ctx context.Context, ids ...int) (map[int]time.Time, error) {
+		reusedAt := map[int]time.Time{}
+		for _, id := range ids {
+			if t, ok := lastReusedAt[id]; ok {
+				reusedAt[id] = t
+			}
+		}
+
+		return reusedAt, nil
+	}
+
 	uploadSvc := NewMockStore()
 	uploadSvc.SetRepositoriesForRetentionScanFunc.SetDefaultHook(setRepositoriesForRetentionScanFunc)
+	uploadSvc.GetUploadsLastReusedAtFunc.SetDefaultHook(getUploadsLastReusedAt)
 	uploadSvc.GetUploadsFunc.SetDefaultHook(getUploads)
 	uploadSvc.UpdateUploadRetentionFunc.SetDefaultHook(updateUploadRetention)
 	uploadSvc.GetCommitsVisibleToUploadFunc.SetDefaultHook(getCommitsVisibleToUpload)
@@ -188,6 +188,13 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload codeinte
 		return requeued, err
 	}
 
+	// If the uploader told us the inputs of this index are identical to an upload we have already
+	// processed for the same root and indexer, attach this commit to the existing data rather than
+	// storing a duplicate copy of it.
+	if reused, err := h.reuseExistingUpload(ctx, logger, upload, trace); err != nil || reused {
+		return false, err
+	}
+
 	// Determine if the upload is for the default Git branch.
 	isDefaultBranch, err := h.gitserverClient.DefaultBranchContains(ctx, upload.RepositoryID, upload.Commit)
 	if err != nil {
@@ -295,6 +302,58 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload codeinte
 	})
 }
 
+// reuseExistingUpload determines if a completed upload with the same repository, root, indexer, and
+// content hash as the given upload exists at a different commit. If so, the existing upload is made
+// visible from the given upload's commit, the given upload is marked as deleted, and this function
+// returns a true valued flag. The raw payload of the given upload is never read.
+func (h *handler) reuseExistingUpload(ctx context.Context, logger log.Logger, upload codeinteltypes.Upload, trace observation.TraceLogger) (bool, error) {
+	if upload.ContentHash == nil || *upload.ContentHash == "" {
+		return false, nil
+	}
+
+	existing, ok, err := h.dbStore.GetCompletedUploadByContentHash(ctx, upload.RepositoryID, upload.Root, upload.Indexer, upload.IndexerVersion, *upload.ContentHash)
+	if err != nil {
+		return false, errors.Wrap(err, "store.GetCompletedUploadByContentHash")
+	}
+	if !ok || existing.Commit == upload.Commit {
+		// Re-uploads for the same commit are processed normally and replace the existing data
+		return false, nil
+	}
+	trace.Log(otlog.Int("reusedUploadID", existing.ID))
+
+	if err := inTransaction(ctx, h.dbStore, func(tx store.Store) error {
+		// Any other data for this commit, root, and indexer would shadow the data we're reusing
+		if err := tx.DeleteOverlappingDumps(ctx, upload.RepositoryID, upload.Commit, upload.Root, upload.Indexer); err != nil {
+			return errors.Wrap(err, "store.DeleteOverlappingDumps")
+		}
+
+		if err := tx.ReuseUpload(ctx, upload.ID, existing.ID, upload.Commit); err != nil {
+			return errors.Wrap(err, "store.ReuseUpload")
+		}
+
+		// Recalculate the commit graph so the reused upload becomes visible from the new commit
+		if err := tx.SetRepositoryAsDirty(ctx, upload.RepositoryID); err != nil {
+			return errors.Wrap(err, "store.MarkRepositoryAsDirty")
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	uploadFilename := fmt.Sprintf("upload-%d.lsif.gz", upload.ID)
+	if err := h.uploadStore.Delete(ctx, uploadFilename); err != nil {
+		logger.Warn("Failed to delete upload file",
+			log.NamedError("err", err),
+			log.String("filename", uploadFilename))
+	}
+
+	logger.Info("Reused data of existing upload with identical content hash",
+		log.Int("id", upload.ID),
+		log.Int("reusedUploadID", existing.ID))
+	return true, nil
+}
+
 func inTransaction(ctx context.Context, dbStore store.Store, fn func(tx store.Store) error) (err error) {
 	tx, err := dbStore.Transact(ctx)
 	if err != nil {
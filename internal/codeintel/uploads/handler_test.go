@@ -221,6 +221,81 @@ func TestHandleError(t *testing.T) {
 	}
 }
 
+func TestHandleReusedContentHash(t *testing.T) {
+	setupRepoMocks(t)
+
+	contentHash := "cafebabe"
+	upload := codeinteltypes.Upload{
+		ID:           42,
+		Root:         "root/",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		ContentHash:  &contentHash,
+	}
+
+	mockWorkerStore := NewMockWorkerStore()
+	mockDBStore := NewMockStore()
+	mockRepoStore := NewMockRepoStore()
+	mockLSIFStore := NewMockLsifStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := NewMockGitserverClient()
+
+	// Set default transaction behavior
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+
+	// Return a completed upload with identical inputs at an older commit
+	mockDBStore.GetCompletedUploadByContentHashFunc.SetDefaultReturn(codeinteltypes.Upload{ID: 24, Commit: "cafebabe"}, true, nil)
+
+	handler := &handler{
+		dbStore:         mockDBStore,
+		repoStore:       mockRepoStore,
+		workerStore:     mockWorkerStore,
+		lsifStore:       mockLSIFStore,
+		uploadStore:     mockUploadStore,
+		gitserverClient: gitserverClient,
+	}
+
+	requeued, err := handler.handle(context.Background(), logtest.Scoped(t), upload, observation.TestTraceLogger(logtest.Scoped(t)))
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if requeued {
+		t.Errorf("unexpected requeue")
+	}
+
+	if calls := mockDBStore.GetCompletedUploadByContentHashFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of GetCompletedUploadByContentHash calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg5 != contentHash {
+		t.Errorf("unexpected content hash. want=%s have=%s", contentHash, calls[0].Arg5)
+	}
+
+	if calls := mockDBStore.ReuseUploadFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of ReuseUpload calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != 42 || calls[0].Arg2 != 24 || calls[0].Arg3 != "deadbeef" {
+		t.Errorf("unexpected ReuseUpload args. want=(%d, %d, %s) have=(%d, %d, %s)", 42, 24, "deadbeef", calls[0].Arg1, calls[0].Arg2, calls[0].Arg3)
+	}
+
+	if len(mockDBStore.SetRepositoryAsDirtyFunc.History()) != 1 {
+		t.Errorf("unexpected number of MarkRepositoryAsDirty calls. want=%d have=%d", 1, len(mockDBStore.SetRepositoryAsDirtyFunc.History()))
+	}
+
+	// Payload is never read or written to the codeintel database
+	if len(mockUploadStore.GetFunc.History()) != 0 {
+		t.Errorf("unexpected number of Get calls. want=%d have=%d", 0, len(mockUploadStore.GetFunc.History()))
+	}
+	if len(mockLSIFStore.TransactFunc.History()) != 0 {
+		t.Errorf("unexpected number of Transact calls. want=%d have=%d", 0, len(mockLSIFStore.TransactFunc.History()))
+	}
+	if len(mockDBStore.UpdatePackagesFunc.History()) != 0 {
+		t.Errorf("unexpected number of UpdatePackages calls. want=%d have=%d", 0, len(mockDBStore.UpdatePackagesFunc.History()))
+	}
+
+	if len(mockUploadStore.DeleteFunc.History()) != 1 {
+		t.Errorf("unexpected number of Delete calls. want=%d have=%d", 1, len(mockUploadStore.DeleteFunc.History()))
+	}
+}
+
 func TestHandleCloneInProgress(t *testing.T) {
 	upload := codeinteltypes.Upload{
 		ID:           42,
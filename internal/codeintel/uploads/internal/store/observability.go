@@ -36,6 +36,8 @@ type operations struct {
 	getUploads                        *observation.Operation
 	getUploadByID                     *observation.Operation
 	getUploadsByIDs                   *observation.Operation
+	getCompletedUploadByContentHash   *observation.Operation
+	getUploadsLastReusedAt            *observation.Operation
 	getVisibleUploadsMatchingMonikers *observation.Operation
 	updateUploadsVisibleToCommits     *observation.Operation
 	writeVisibleUploads               *observation.Operation
@@ -44,6 +46,7 @@ type operations struct {
 	persistUploadsVisibleAtTip        *observation.Operation
 	updateUploadRetention             *observation.Operation
 	backfillReferenceCountBatch       *observation.Operation
+	getStorageQuotaCandidates         *observation.Operation
 	updateCommittedAt                 *observation.Operation
 	sourcedCommitsWithoutCommittedAt  *observation.Operation
 	updateUploadsReferenceCounts      *observation.Operation
@@ -56,6 +59,7 @@ type operations struct {
 	addUploadPart                     *observation.Operation
 	markQueued                        *observation.Operation
 	markFailed                        *observation.Operation
+	reuseUpload                       *observation.Operation
 	deleteUploads                     *observation.Operation
 
 	// Dumps
@@ -124,10 +128,13 @@ func newOperations(observationContext *observation.Context) *operations {
 		getUploads:                        op("GetUploads"),
 		getUploadByID:                     op("GetUploadByID"),
 		getUploadsByIDs:                   op("GetUploadsByIDs"),
+		getCompletedUploadByContentHash:   op("GetCompletedUploadByContentHash"),
+		getUploadsLastReusedAt:            op("GetUploadsLastReusedAt"),
 		getVisibleUploadsMatchingMonikers: op("GetVisibleUploadsMatchingMonikers"),
 		updateUploadsVisibleToCommits:     op("UpdateUploadsVisibleToCommits"),
 		updateUploadRetention:             op("UpdateUploadRetention"),
 		backfillReferenceCountBatch:       op("BackfillReferenceCountBatch"),
+		getStorageQuotaCandidates:         op("GetStorageQuotaCandidates"),
 		updateCommittedAt:                 op("UpdateCommittedAt"),
 		sourcedCommitsWithoutCommittedAt:  op("SourcedCommitsWithoutCommittedAt"),
 		updateUploadsReferenceCounts:      op("UpdateUploadsReferenceCounts"),
@@ -140,6 +147,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		addUploadPart:                     op("AddUploadPart"),
 		markQueued:                        op("MarkQueued"),
 		markFailed:                        op("MarkFailed"),
+		reuseUpload:                       op("ReuseUpload"),
 		deleteUploads:                     op("DeleteUploads"),
 
 		writeVisibleUploads:        op("writeVisibleUploads"),
@@ -3,6 +3,7 @@ package store
 import (
 	"database/sql"
 	"sort"
+	"time"
 
 	"github.com/jackc/pgtype"
 	"github.com/lib/pq"
@@ -39,6 +40,7 @@ func scanCompleteUpload(s dbutil.Scanner) (upload types.Upload, _ error) {
 		&upload.AssociatedIndexID,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.ContentHash,
 	); err != nil {
 		return upload, err
 	}
@@ -83,6 +85,7 @@ func scanUploadWithCount(s dbutil.Scanner) (upload types.Upload, count int, err
 		&upload.AssociatedIndexID,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.ContentHash,
 		&count,
 	); err != nil {
 		return upload, 0, err
@@ -142,6 +145,23 @@ func scanDump(s dbutil.Scanner) (dump types.Dump, err error) {
 
 var scanDumps = basestore.NewSliceScanner(scanDump)
 
+// scanStorageQuotaCandidates scans a slice of storage quota candidates from the return value of
+// `*Store.query`.
+var scanStorageQuotaCandidates = basestore.NewSliceScanner(func(s dbutil.Scanner) (candidate shared.StorageQuotaCandidate, err error) {
+	err = s.Scan(
+		&candidate.UploadID,
+		&candidate.RepositoryID,
+		&candidate.RepositoryName,
+		&candidate.Size,
+		&candidate.UploadedAt,
+		&candidate.CommittedAt,
+		&candidate.ReferenceCount,
+		&candidate.VisibleAtTip,
+		&candidate.BranchDistance,
+	)
+	return candidate, err
+})
+
 // scanSourcedCommits scans triples of repository ids/repository names/commits from the
 // return value of `*Store.query`. The output of this function is ordered by repository
 // identifier, then by commit.
@@ -275,6 +295,29 @@ func scanRepoNames(rows *sql.Rows, queryErr error) (_ map[int]string, err error)
 	return names, nil
 }
 
+func scanUploadTimes(rows *sql.Rows, queryErr error) (_ map[int]time.Time, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	times := map[int]time.Time{}
+
+	for rows.Next() {
+		var (
+			id int
+			t  time.Time
+		)
+		if err := rows.Scan(&id, &t); err != nil {
+			return nil, err
+		}
+
+		times[id] = t
+	}
+
+	return times, nil
+}
+
 func scanUploadAuditLog(s dbutil.Scanner) (log types.UploadLog, _ error) {
 	hstores := pgtype.HstoreArray{}
 	err := s.Scan(
@@ -92,6 +92,7 @@ SELECT
 	u.associated_index_id,
 	s.rank,
 	u.uncompressed_size,
+	u.content_hash,
 	COUNT(*) OVER() AS count
 FROM %s
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -131,7 +132,8 @@ SELECT
 	NULL::integer[] as uploaded_parts,
 	au.upload_size, au.associated_index_id,
 	COALESCE((snapshot->'expired')::boolean, false) AS expired,
-	NULL::bigint AS uncompressed_size
+	NULL::bigint AS uncompressed_size,
+	NULL::text AS content_hash
 FROM (
 	SELECT upload_id, snapshot_transition_columns(transition_columns ORDER BY sequence ASC) AS snapshot
 	FROM lsif_uploads_audit_logs
@@ -236,7 +238,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.content_hash
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -244,6 +247,84 @@ JOIN repo ON repo.id = u.repository_id
 WHERE repo.deleted_at IS NULL AND u.state != 'deleted' AND u.id = %s AND %s
 `
 
+// GetCompletedUploadByContentHash returns the most recently processed completed upload for the given
+// repository, root, and indexer whose inputs were recorded with the given content hash, along with a
+// boolean flag indicating its existence. Expired uploads are ignored, as they are about to be deleted.
+func (s *store) GetCompletedUploadByContentHash(ctx context.Context, repositoryID int, root, indexer, indexerVersion, contentHash string) (_ types.Upload, _ bool, err error) {
+	ctx, _, endObservation := s.operations.getCompletedUploadByContentHash.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("root", root),
+		log.String("indexer", indexer),
+		log.String("indexerVersion", indexerVersion),
+		log.String("contentHash", contentHash),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanFirstUpload(s.db.Query(ctx, sqlf.Sprintf(getCompletedUploadByContentHashQuery, repositoryID, root, indexer, indexerVersion, contentHash)))
+}
+
+const getCompletedUploadByContentHashQuery = `
+-- source: internal/codeintel/uploads/internal/store/store_uploads.go:GetCompletedUploadByContentHash
+SELECT
+	u.id,
+	u.commit,
+	u.root,
+	EXISTS (` + visibleAtTipSubselectQuery + `) AS visible_at_tip,
+	u.uploaded_at,
+	u.state,
+	u.failure_message,
+	u.started_at,
+	u.finished_at,
+	u.process_after,
+	u.num_resets,
+	u.num_failures,
+	u.repository_id,
+	u.repository_name,
+	u.indexer,
+	u.indexer_version,
+	u.num_parts,
+	u.uploaded_parts,
+	u.upload_size,
+	u.associated_index_id,
+	NULL,
+	u.uncompressed_size,
+	u.content_hash
+FROM lsif_uploads_with_repository_name u
+WHERE
+	u.state = 'completed' AND
+	NOT u.expired AND
+	u.repository_id = %s AND
+	u.root = %s AND
+	u.indexer = %s AND
+	COALESCE(u.indexer_version, '') = %s AND
+	u.content_hash = %s
+ORDER BY u.finished_at DESC, u.id DESC
+LIMIT 1
+`
+
+// GetUploadsLastReusedAt returns, for each of the given uploads whose data was reused for another commit,
+// the time it was last reused.
+func (s *store) GetUploadsLastReusedAt(ctx context.Context, ids ...int) (_ map[int]time.Time, err error) {
+	ctx, _, endObservation := s.operations.getUploadsLastReusedAt.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("ids", intsToString(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return scanUploadTimes(s.db.Query(ctx, sqlf.Sprintf(getUploadsLastReusedAtQuery, pq.Array(ids))))
+}
+
+const getUploadsLastReusedAtQuery = `
+-- source: internal/codeintel/uploads/internal/store/store_uploads.go:GetUploadsLastReusedAt
+SELECT upload_id, MAX(created_at)
+FROM lsif_uploads_reused_commits
+WHERE upload_id = ANY(%s)
+GROUP BY upload_id
+`
+
 // GetUploadsByIDs returns an upload for each of the given identifiers. Not all given ids will necessarily
 // have a corresponding element in the returned list.
 func (s *store) GetUploadsByIDs(ctx context.Context, ids ...int) (_ []types.Upload, err error) {
@@ -293,7 +374,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.content_hash
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -396,7 +478,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.content_hash
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -792,6 +875,74 @@ const updateUploadRetentionQuery = `
 UPDATE lsif_uploads SET %s WHERE id IN (%s)
 `
 
+// GetStorageQuotaCandidates returns the completed, unexpired uploads that count towards the storage
+// quotas, along with their distance from the branches of their repository according to the commit
+// graph stored for it. If a repository identifier is supplied (is non-zero), then only uploads of that
+// repository are returned. Uploads are ordered by repository and identifier.
+func (s *store) GetStorageQuotaCandidates(ctx context.Context, repositoryID int) (_ []shared.StorageQuotaCandidate, err error) {
+	ctx, trace, endObservation := s.operations.getStorageQuotaCandidates.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	conds := []*sqlf.Query{
+		sqlf.Sprintf("u.state = 'completed'"),
+		sqlf.Sprintf("NOT u.expired"),
+		sqlf.Sprintf("r.deleted_at IS NULL"),
+	}
+	if repositoryID != 0 {
+		conds = append(conds, sqlf.Sprintf("u.repository_id = %s", repositoryID))
+	}
+
+	candidates, err := scanStorageQuotaCandidates(s.db.Query(ctx, sqlf.Sprintf(getStorageQuotaCandidatesQuery, sqlf.Join(conds, " AND "))))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numCandidates", len(candidates)))
+
+	return candidates, nil
+}
+
+const getStorageQuotaCandidatesQuery = `
+-- source: internal/codeintel/uploads/internal/store/store_uploads.go:GetStorageQuotaCandidates
+SELECT
+	u.id,
+	u.repository_id,
+	r.name,
+	COALESCE(u.uncompressed_size, u.upload_size, 0),
+	u.uploaded_at,
+	u.committed_at,
+	COALESCE(u.reference_count, 0),
+	EXISTS (
+		SELECT 1
+		FROM lsif_uploads_visible_at_tip vt
+		WHERE
+			vt.repository_id = u.repository_id AND
+			vt.upload_id = u.id AND
+			vt.is_default_branch
+	),
+	CASE
+		WHEN EXISTS (
+			SELECT 1
+			FROM lsif_uploads_visible_at_tip vt
+			WHERE
+				vt.repository_id = u.repository_id AND
+				vt.upload_id = u.id
+		) THEN 0
+		WHEN EXISTS (
+			SELECT 1
+			FROM lsif_nearest_uploads nu
+			WHERE
+				nu.repository_id = u.repository_id AND
+				nu.uploads ? u.id::text
+		) THEN 1
+	END
+FROM lsif_uploads u
+JOIN repo r ON r.id = u.repository_id
+WHERE %s
+ORDER BY u.repository_id, u.id
+`
+
 // BackfillReferenceCountBatch calculates the reference count for a batch of upload records that do not
 // have a set value. This method is used to backfill old upload records prior to reference counting-based
 // expiration, or records that have been re-set to NULL and re-calculated (e.g., emergency resets).
@@ -1159,7 +1310,7 @@ func (s *store) UpdateUploadsVisibleToCommits(
 
 	// Pull all queryable upload metadata known to this repository so we can correlate
 	// it with the current  commit graph.
-	commitGraphView, err := scanCommitGraphView(tx.Query(ctx, sqlf.Sprintf(calculateVisibleUploadsCommitGraphQuery, repositoryID)))
+	commitGraphView, err := scanCommitGraphView(tx.Query(ctx, sqlf.Sprintf(calculateVisibleUploadsCommitGraphQuery, repositoryID, repositoryID)))
 	if err != nil {
 		return err
 	}
@@ -1230,6 +1381,24 @@ func (s *store) UpdateUploadsVisibleToCommits(
 const calculateVisibleUploadsCommitGraphQuery = `
 -- source: internal/codeintel/uploads/internal/store/store_uploads.go:UpdateUploadsVisibleToCommits
 SELECT id, commit, md5(root || ':' || indexer) as token, 0 as distance FROM lsif_uploads WHERE state = 'completed' AND repository_id = %s
+UNION ALL
+SELECT u.id, rc.commit, md5(u.root || ':' || u.indexer) as token, 0 as distance
+FROM lsif_uploads_reused_commits rc
+JOIN lsif_uploads u ON u.id = rc.upload_id
+WHERE
+	u.state = 'completed' AND
+	u.repository_id = %s AND
+	-- An upload processed directly at the reused commit takes precedence
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_uploads u2
+		WHERE
+			u2.state = 'completed' AND
+			u2.repository_id = u.repository_id AND
+			u2.commit = rc.commit AND
+			u2.root = u.root AND
+			u2.indexer = u.indexer
+	)
 `
 
 const calculateVisibleUploadsDirtyRepositoryQuery = `
@@ -1881,6 +2050,7 @@ func (s *store) InsertUpload(ctx context.Context, upload types.Upload) (id int,
 			upload.UploadSize,
 			upload.AssociatedIndexID,
 			upload.UncompressedSize,
+			upload.ContentHash,
 		),
 	))
 
@@ -1900,11 +2070,39 @@ INSERT INTO lsif_uploads (
 	uploaded_parts,
 	upload_size,
 	associated_index_id,
-	uncompressed_size
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	uncompressed_size,
+	content_hash
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
+// ReuseUpload makes the data of the completed upload with the given reused identifier visible from the
+// given commit and marks the given (duplicate) upload as deleted without processing its payload.
+func (s *store) ReuseUpload(ctx context.Context, uploadID, reusedUploadID int, commit string) (err error) {
+	ctx, _, endObservation := s.operations.reuseUpload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+		log.Int("reusedUploadID", reusedUploadID),
+		log.String("commit", commit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	unset, _ := s.db.SetLocal(ctx, "codeintel.lsif_uploads_audit.reason", "upload deduplicated into an existing upload with an identical content hash")
+	defer unset(ctx)
+
+	return s.db.Exec(ctx, sqlf.Sprintf(reuseUploadQuery, reusedUploadID, commit, uploadID))
+}
+
+const reuseUploadQuery = `
+-- source: internal/codeintel/uploads/internal/store/store_uploads.go:ReuseUpload
+WITH
+inserted AS (
+	INSERT INTO lsif_uploads_reused_commits (upload_id, commit)
+	VALUES (%s, %s)
+	ON CONFLICT DO NOTHING
+)
+UPDATE lsif_uploads SET state = 'deleted' WHERE id = %s
+`
+
 // AddUploadPart adds the part index to the given upload's uploaded parts array. This method is idempotent
 // (the resulting array is deduplicated on update).
 func (s *store) AddUploadPart(ctx context.Context, uploadID, partIndex int) (err error) {
@@ -2082,7 +2280,8 @@ func buildGetConditionsAndCte(opts types.GetUploadsOptions) (*sqlf.Query, []*sql
 				upload_size,
 				associated_index_id,
 				expired,
-				uncompressed_size
+				uncompressed_size,
+				content_hash
 			FROM lsif_uploads
 			UNION ALL
 			SELECT *
@@ -356,6 +356,95 @@ func TestGetUploadByIDDeleted(t *testing.T) {
 	}
 }
 
+func TestGetCompletedUploadByContentHash(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db, &observation.TestContext)
+
+	hashA := "hash-a"
+	hashB := "hash-b"
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Minute)
+	t3 := t2.Add(time.Minute)
+
+	insertUploads(t, db,
+		types.Upload{ID: 1, Root: "sub/", FinishedAt: &t1, ContentHash: &hashA},
+		types.Upload{ID: 2, Root: "sub/", FinishedAt: &t2, ContentHash: &hashA},
+		types.Upload{ID: 3, Root: "sub/", FinishedAt: &t2, ContentHash: &hashA, State: "errored"},
+		types.Upload{ID: 4, Root: "sub/", FinishedAt: &t2, ContentHash: &hashB},
+		types.Upload{ID: 5, Root: "other/", FinishedAt: &t2, ContentHash: &hashA},
+		types.Upload{ID: 6, Root: "sub/", FinishedAt: &t2, ContentHash: &hashA, Indexer: "scip-go"},
+		types.Upload{ID: 7, Root: "sub/", FinishedAt: &t3, ContentHash: &hashA},
+	)
+
+	// Expired uploads are about to be deleted and must not be reused
+	if _, err := db.ExecContext(ctx, `UPDATE lsif_uploads SET expired = true WHERE id = 7`); err != nil {
+		t.Fatalf("unexpected error while expiring upload: %s", err)
+	}
+
+	testCases := []struct {
+		root           string
+		indexer        string
+		indexerVersion string
+		contentHash    string
+		expectedID     int
+	}{
+		{"sub/", "lsif-go", "latest", hashA, 2},
+		{"sub/", "lsif-go", "latest", hashB, 4},
+		{"other/", "lsif-go", "latest", hashA, 5},
+		{"sub/", "scip-go", "latest", hashA, 6},
+		{"sub/", "lsif-go", "1.2.3", hashA, 0},
+		{"sub/", "lsif-go", "latest", "hash-c", 0},
+	}
+
+	for _, testCase := range testCases {
+		name := fmt.Sprintf("root=%q indexer=%q indexerVersion=%q contentHash=%q", testCase.root, testCase.indexer, testCase.indexerVersion, testCase.contentHash)
+
+		t.Run(name, func(t *testing.T) {
+			upload, exists, err := store.GetCompletedUploadByContentHash(ctx, 50, testCase.root, testCase.indexer, testCase.indexerVersion, testCase.contentHash)
+			if err != nil {
+				t.Fatalf("unexpected error getting upload: %s", err)
+			}
+
+			if testCase.expectedID == 0 {
+				if exists {
+					t.Fatalf("unexpected upload %d", upload.ID)
+				}
+			} else if !exists {
+				t.Fatal("expected record to exist")
+			} else if upload.ID != testCase.expectedID {
+				t.Errorf("unexpected upload. want=%d have=%d", testCase.expectedID, upload.ID)
+			}
+		})
+	}
+}
+
+func TestGetUploadsLastReusedAt(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db, &observation.TestContext)
+
+	insertUploads(t, db,
+		types.Upload{ID: 1, Commit: makeCommit(1)},
+		types.Upload{ID: 2, Commit: makeCommit(2)},
+		types.Upload{ID: 3, Commit: makeCommit(3), State: "processing"},
+	)
+
+	if err := store.ReuseUpload(ctx, 3, 1, makeCommit(3)); err != nil {
+		t.Fatalf("unexpected error reusing upload: %s", err)
+	}
+
+	lastReusedAt, err := store.GetUploadsLastReusedAt(ctx, 1, 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error getting last reuse times: %s", err)
+	}
+	if _, ok := lastReusedAt[1]; !ok || len(lastReusedAt) != 1 {
+		t.Errorf("unexpected last reuse times: %v", lastReusedAt)
+	}
+}
+
 func TestGetQueuedUploadRank(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
@@ -730,6 +819,56 @@ func TestHardDeleteUploadsByIDs(t *testing.T) {
 	})
 }
 
+func TestGetStorageQuotaCandidates(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db, &observation.TestContext)
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Hour)
+	size := func(v int64) *int64 { return &v }
+
+	insertUploads(t, db,
+		types.Upload{ID: 1, RepositoryID: 50, UploadedAt: t1, UploadSize: size(100)},
+		types.Upload{ID: 2, RepositoryID: 50, UploadedAt: t2, UploadSize: size(100)},
+		types.Upload{ID: 3, RepositoryID: 50, UploadedAt: t2, State: "queued"},
+		types.Upload{ID: 4, RepositoryID: 51, UploadedAt: t1},
+		types.Upload{ID: 5, RepositoryID: 52, RepositoryName: "DELETED-foo", UploadedAt: t1},
+	)
+	insertVisibleAtTip(t, db, 50, 2)
+	insertNearestUploads(t, db, 50, map[string][]commitgraph.UploadMeta{makeCommit(1): {{UploadID: 1, Distance: 0}}})
+
+	if _, err := db.ExecContext(context.Background(), `UPDATE lsif_uploads SET uncompressed_size = 300 WHERE id = 2`); err != nil {
+		t.Fatalf("unexpected error updating upload: %s", err)
+	}
+	if _, err := db.ExecContext(context.Background(), `UPDATE lsif_uploads SET committed_at = $1 WHERE id = 1`, t1); err != nil {
+		t.Fatalf("unexpected error updating upload: %s", err)
+	}
+
+	candidates, err := store.GetStorageQuotaCandidates(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error getting storage quota candidates: %s", err)
+	}
+
+	onTip, behindTip := 0, 1
+	expected := []shared.StorageQuotaCandidate{
+		{UploadID: 1, RepositoryID: 50, RepositoryName: "n-50", Size: 100, UploadedAt: t1, CommittedAt: &t1, BranchDistance: &behindTip},
+		{UploadID: 2, RepositoryID: 50, RepositoryName: "n-50", Size: 300, UploadedAt: t2, VisibleAtTip: true, BranchDistance: &onTip},
+		{UploadID: 4, RepositoryID: 51, RepositoryName: "n-51", Size: 0, UploadedAt: t1},
+	}
+	if diff := cmp.Diff(expected, candidates); diff != "" {
+		t.Errorf("unexpected candidates (-want +got):\n%s", diff)
+	}
+
+	candidates, err = store.GetStorageQuotaCandidates(context.Background(), 51)
+	if err != nil {
+		t.Fatalf("unexpected error getting storage quota candidates: %s", err)
+	}
+	if diff := cmp.Diff(expected[2:], candidates); diff != "" {
+		t.Errorf("unexpected candidates (-want +got):\n%s", diff)
+	}
+}
+
 func TestBackfillReferenceCountBatch(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
@@ -1076,6 +1215,71 @@ func TestUpdateUploadsVisibleToCommits(t *testing.T) {
 	}
 }
 
+func TestUpdateUploadsVisibleToCommitsReusedUpload(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(db, &observation.TestContext)
+
+	// This database has the following commit graph:
+	//
+	// [1] --+-- 2 -- [3] -- 4
+	//       |
+	//       +-- [5] -- 6
+	//
+	// Upload 1 is reused at commit 5 and upload 2 is processed at commit 3.
+
+	uploads := []types.Upload{
+		{ID: 1, Commit: makeCommit(1)},
+		{ID: 2, Commit: makeCommit(3)},
+		{ID: 3, Commit: makeCommit(5), State: "processing"},
+	}
+	insertUploads(t, db, uploads...)
+
+	if err := store.ReuseUpload(context.Background(), 3, 1, makeCommit(5)); err != nil {
+		t.Fatalf("unexpected error reusing upload: %s", err)
+	}
+
+	graph := gitdomain.ParseCommitGraph([]string{
+		strings.Join([]string{makeCommit(6), makeCommit(5)}, " "),
+		strings.Join([]string{makeCommit(5), makeCommit(1)}, " "),
+		strings.Join([]string{makeCommit(4), makeCommit(3)}, " "),
+		strings.Join([]string{makeCommit(3), makeCommit(2)}, " "),
+		strings.Join([]string{makeCommit(2), makeCommit(1)}, " "),
+		strings.Join([]string{makeCommit(1)}, " "),
+	})
+
+	refDescriptions := map[string][]gitdomain.RefDescription{
+		makeCommit(6): {{IsDefaultBranch: true}},
+	}
+
+	if err := store.UpdateUploadsVisibleToCommits(context.Background(), 50, graph, refDescriptions, time.Hour, time.Hour, 0, time.Now()); err != nil {
+		t.Fatalf("unexpected error while calculating visible uploads: %s", err)
+	}
+
+	expectedVisibleUploads := map[string][]int{
+		makeCommit(1): {1},
+		makeCommit(2): {1},
+		makeCommit(3): {2},
+		makeCommit(4): {2},
+		makeCommit(5): {1},
+		makeCommit(6): {1},
+	}
+	if diff := cmp.Diff(expectedVisibleUploads, getVisibleUploads(t, db, 50, keysOf(expectedVisibleUploads))); diff != "" {
+		t.Errorf("unexpected visible uploads (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]int{1}, getUploadsVisibleAtTip(t, db, 50)); diff != "" {
+		t.Errorf("unexpected uploads visible at tip (-want +got):\n%s", diff)
+	}
+
+	// Duplicate upload record is removed
+	if _, exists, err := store.GetUploadByID(context.Background(), 3); err != nil {
+		t.Fatalf("unexpected error getting upload: %s", err)
+	} else if exists {
+		t.Fatal("unexpected record")
+	}
+}
+
 func TestUpdateUploadsVisibleToCommitsAlternateCommitGraph(t *testing.T) {
 	logger := logtest.Scoped(t)
 	db := database.NewDB(logger, dbtest.NewDB(logger, t))
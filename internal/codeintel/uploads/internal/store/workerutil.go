@@ -43,6 +43,7 @@ var uploadColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.associated_index_id"),
 	sqlf.Sprintf("NULL"),
 	sqlf.Sprintf("u.uncompressed_size"),
+	sqlf.Sprintf("u.content_hash"),
 }
 
 var uploadWorkerStoreOptions = dbworkerstore.Options{
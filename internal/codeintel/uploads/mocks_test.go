@@ -85,6 +85,10 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetCommitsVisibleToUpload.
 	GetCommitsVisibleToUploadFunc *StoreGetCommitsVisibleToUploadFunc
+	// GetCompletedUploadByContentHashFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetCompletedUploadByContentHash.
+	GetCompletedUploadByContentHashFunc *StoreGetCompletedUploadByContentHashFunc
 	// GetDirtyRepositoriesFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDirtyRepositories.
 	GetDirtyRepositoriesFunc *StoreGetDirtyRepositoriesFunc
@@ -116,6 +120,10 @@ type MockStore struct {
 	// GetStaleSourcedCommitsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetStaleSourcedCommits.
 	GetStaleSourcedCommitsFunc *StoreGetStaleSourcedCommitsFunc
+	// GetStorageQuotaCandidatesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetStorageQuotaCandidates.
+	GetStorageQuotaCandidatesFunc *StoreGetStorageQuotaCandidatesFunc
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *StoreGetUploadByIDFunc
@@ -129,6 +137,9 @@ type MockStore struct {
 	// GetUploadsByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsByIDs.
 	GetUploadsByIDsFunc *StoreGetUploadsByIDsFunc
+	// GetUploadsLastReusedAtFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadsLastReusedAt.
+	GetUploadsLastReusedAtFunc *StoreGetUploadsLastReusedAtFunc
 	// GetVisibleUploadsMatchingMonikersFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// GetVisibleUploadsMatchingMonikers.
@@ -164,6 +175,9 @@ type MockStore struct {
 	// RepoNamesFunc is an instance of a mock function object controlling
 	// the behavior of the method RepoNames.
 	RepoNamesFunc *StoreRepoNamesFunc
+	// ReuseUploadFunc is an instance of a mock function object controlling
+	// the behavior of the method ReuseUpload.
+	ReuseUploadFunc *StoreReuseUploadFunc
 	// SetRepositoriesForRetentionScanFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// SetRepositoriesForRetentionScan.
@@ -292,6 +306,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetCompletedUploadByContentHashFunc: &StoreGetCompletedUploadByContentHashFunc{
+			defaultHook: func(context.Context, int, string, string, string, string) (r0 types.Upload, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (r0 map[int]int, r1 error) {
 				return
@@ -337,6 +356,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetStorageQuotaCandidatesFunc: &StoreGetStorageQuotaCandidatesFunc{
+			defaultHook: func(context.Context, int) (r0 []shared.StorageQuotaCandidate, r1 error) {
+				return
+			},
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (r0 types.Upload, r1 bool, r2 error) {
 				return
@@ -357,6 +381,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUploadsLastReusedAtFunc: &StoreGetUploadsLastReusedAtFunc{
+			defaultHook: func(context.Context, ...int) (r0 map[int]time.Time, r1 error) {
+				return
+			},
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (r0 shared.PackageReferenceScanner, r1 int, r2 error) {
 				return
@@ -412,6 +441,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		ReuseUploadFunc: &StoreReuseUploadFunc{
+			defaultHook: func(context.Context, int, int, string) (r0 error) {
+				return
+			},
+		},
 		SetRepositoriesForRetentionScanFunc: &StoreSetRepositoriesForRetentionScanFunc{
 			defaultHook: func(context.Context, time.Duration, int) (r0 []int, r1 error) {
 				return
@@ -564,6 +598,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetCommitsVisibleToUpload")
 			},
 		},
+		GetCompletedUploadByContentHashFunc: &StoreGetCompletedUploadByContentHashFunc{
+			defaultHook: func(context.Context, int, string, string, string, string) (types.Upload, bool, error) {
+				panic("unexpected invocation of MockStore.GetCompletedUploadByContentHash")
+			},
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (map[int]int, error) {
 				panic("unexpected invocation of MockStore.GetDirtyRepositories")
@@ -609,6 +648,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetStaleSourcedCommits")
 			},
 		},
+		GetStorageQuotaCandidatesFunc: &StoreGetStorageQuotaCandidatesFunc{
+			defaultHook: func(context.Context, int) ([]shared.StorageQuotaCandidate, error) {
+				panic("unexpected invocation of MockStore.GetStorageQuotaCandidates")
+			},
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: func(context.Context, int) (types.Upload, bool, error) {
 				panic("unexpected invocation of MockStore.GetUploadByID")
@@ -629,6 +673,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUploadsByIDs")
 			},
 		},
+		GetUploadsLastReusedAtFunc: &StoreGetUploadsLastReusedAtFunc{
+			defaultHook: func(context.Context, ...int) (map[int]time.Time, error) {
+				panic("unexpected invocation of MockStore.GetUploadsLastReusedAt")
+			},
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared.PackageReferenceScanner, int, error) {
 				panic("unexpected invocation of MockStore.GetVisibleUploadsMatchingMonikers")
@@ -684,6 +733,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.RepoNames")
 			},
 		},
+		ReuseUploadFunc: &StoreReuseUploadFunc{
+			defaultHook: func(context.Context, int, int, string) error {
+				panic("unexpected invocation of MockStore.ReuseUpload")
+			},
+		},
 		SetRepositoriesForRetentionScanFunc: &StoreSetRepositoriesForRetentionScanFunc{
 			defaultHook: func(context.Context, time.Duration, int) ([]int, error) {
 				panic("unexpected invocation of MockStore.SetRepositoriesForRetentionScan")
@@ -806,6 +860,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetCommitsVisibleToUploadFunc: &StoreGetCommitsVisibleToUploadFunc{
 			defaultHook: i.GetCommitsVisibleToUpload,
 		},
+		GetCompletedUploadByContentHashFunc: &StoreGetCompletedUploadByContentHashFunc{
+			defaultHook: i.GetCompletedUploadByContentHash,
+		},
 		GetDirtyRepositoriesFunc: &StoreGetDirtyRepositoriesFunc{
 			defaultHook: i.GetDirtyRepositories,
 		},
@@ -833,6 +890,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetStaleSourcedCommitsFunc: &StoreGetStaleSourcedCommitsFunc{
 			defaultHook: i.GetStaleSourcedCommits,
 		},
+		GetStorageQuotaCandidatesFunc: &StoreGetStorageQuotaCandidatesFunc{
+			defaultHook: i.GetStorageQuotaCandidates,
+		},
 		GetUploadByIDFunc: &StoreGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
@@ -845,6 +905,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetUploadsByIDsFunc: &StoreGetUploadsByIDsFunc{
 			defaultHook: i.GetUploadsByIDs,
 		},
+		GetUploadsLastReusedAtFunc: &StoreGetUploadsLastReusedAtFunc{
+			defaultHook: i.GetUploadsLastReusedAt,
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: i.GetVisibleUploadsMatchingMonikers,
 		},
@@ -878,6 +941,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		RepoNamesFunc: &StoreRepoNamesFunc{
 			defaultHook: i.RepoNames,
 		},
+		ReuseUploadFunc: &StoreReuseUploadFunc{
+			defaultHook: i.ReuseUpload,
+		},
 		SetRepositoriesForRetentionScanFunc: &StoreSetRepositoriesForRetentionScanFunc{
 			defaultHook: i.SetRepositoriesForRetentionScan,
 		},
@@ -2605,6 +2671,133 @@ func (c StoreGetCommitsVisibleToUploadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreGetCompletedUploadByContentHashFunc describes the behavior when the
+// GetCompletedUploadByContentHash method of the parent MockStore instance
+// is invoked.
+type StoreGetCompletedUploadByContentHashFunc struct {
+	defaultHook func(context.Context, int, string, string, string, string) (types.Upload, bool, error)
+	hooks       []func(context.Context, int, string, string, string, string) (types.Upload, bool, error)
+	history     []StoreGetCompletedUploadByContentHashFuncCall
+	mutex       sync.Mutex
+}
+
+// GetCompletedUploadByContentHash delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetCompletedUploadByContentHash(v0 context.Context, v1 int, v2 string, v3 string, v4 string, v5 string) (types.Upload, bool, error) {
+	r0, r1, r2 := m.GetCompletedUploadByContentHashFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.GetCompletedUploadByContentHashFunc.appendCall(StoreGetCompletedUploadByContentHashFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetCompletedUploadByContentHash method of the parent MockStore instance
+// is invoked and the hook queue is empty.
+func (f *StoreGetCompletedUploadByContentHashFunc) SetDefaultHook(hook func(context.Context, int, string, string, string, string) (types.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetCompletedUploadByContentHash method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetCompletedUploadByContentHashFunc) PushHook(hook func(context.Context, int, string, string, string, string) (types.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetCompletedUploadByContentHashFunc) SetDefaultReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string, string, string) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetCompletedUploadByContentHashFunc) PushReturn(r0 types.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int, string, string, string, string) (types.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetCompletedUploadByContentHashFunc) nextHook() func(context.Context, int, string, string, string, string) (types.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetCompletedUploadByContentHashFunc) appendCall(r0 StoreGetCompletedUploadByContentHashFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreGetCompletedUploadByContentHashFuncCall objects describing the
+// invocations of this function.
+func (f *StoreGetCompletedUploadByContentHashFunc) History() []StoreGetCompletedUploadByContentHashFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetCompletedUploadByContentHashFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetCompletedUploadByContentHashFuncCall is an object that describes
+// an invocation of method GetCompletedUploadByContentHash on an instance of
+// MockStore.
+type StoreGetCompletedUploadByContentHashFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 types.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetCompletedUploadByContentHashFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetCompletedUploadByContentHashFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreGetDirtyRepositoriesFunc describes the behavior when the
 // GetDirtyRepositories method of the parent MockStore instance is invoked.
 type StoreGetDirtyRepositoriesFunc struct {
@@ -3614,6 +3807,116 @@ func (c StoreGetStaleSourcedCommitsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetStorageQuotaCandidatesFunc describes the behavior when the
+// GetStorageQuotaCandidates method of the parent MockStore instance is
+// invoked.
+type StoreGetStorageQuotaCandidatesFunc struct {
+	defaultHook func(context.Context, int) ([]shared.StorageQuotaCandidate, error)
+	hooks       []func(context.Context, int) ([]shared.StorageQuotaCandidate, error)
+	history     []StoreGetStorageQuotaCandidatesFuncCall
+	mutex       sync.Mutex
+}
+
+// GetStorageQuotaCandidates delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetStorageQuotaCandidates(v0 context.Context, v1 int) ([]shared.StorageQuotaCandidate, error) {
+	r0, r1 := m.GetStorageQuotaCandidatesFunc.nextHook()(v0, v1)
+	m.GetStorageQuotaCandidatesFunc.appendCall(StoreGetStorageQuotaCandidatesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetStorageQuotaCandidates method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetStorageQuotaCandidatesFunc) SetDefaultHook(hook func(context.Context, int) ([]shared.StorageQuotaCandidate, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetStorageQuotaCandidates method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreGetStorageQuotaCandidatesFunc) PushHook(hook func(context.Context, int) ([]shared.StorageQuotaCandidate, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetStorageQuotaCandidatesFunc) SetDefaultReturn(r0 []shared.StorageQuotaCandidate, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]shared.StorageQuotaCandidate, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetStorageQuotaCandidatesFunc) PushReturn(r0 []shared.StorageQuotaCandidate, r1 error) {
+	f.PushHook(func(context.Context, int) ([]shared.StorageQuotaCandidate, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetStorageQuotaCandidatesFunc) nextHook() func(context.Context, int) ([]shared.StorageQuotaCandidate, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetStorageQuotaCandidatesFunc) appendCall(r0 StoreGetStorageQuotaCandidatesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetStorageQuotaCandidatesFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetStorageQuotaCandidatesFunc) History() []StoreGetStorageQuotaCandidatesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetStorageQuotaCandidatesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetStorageQuotaCandidatesFuncCall is an object that describes an
+// invocation of method GetStorageQuotaCandidates on an instance of
+// MockStore.
+type StoreGetStorageQuotaCandidatesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.StorageQuotaCandidate
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetStorageQuotaCandidatesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetStorageQuotaCandidatesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetUploadByIDFunc describes the behavior when the GetUploadByID
 // method of the parent MockStore instance is invoked.
 type StoreGetUploadByIDFunc struct {
@@ -4084,6 +4387,121 @@ func (c StoreGetUploadsByIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetUploadsLastReusedAtFunc describes the behavior when the GetUploadsLastReusedAt
+// method of the parent MockStore instance is invoked.
+type StoreGetUploadsLastReusedAtFunc struct {
+	defaultHook func(context.Context, ...int) (map[int]time.Time, error)
+	hooks       []func(context.Context, ...int) (map[int]time.Time, error)
+	history     []StoreGetUploadsLastReusedAtFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadsLastReusedAt delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadsLastReusedAt(v0 context.Context, v1 ...int) (map[int]time.Time, error) {
+	r0, r1 := m.GetUploadsLastReusedAtFunc.nextHook()(v0, v1...)
+	m.GetUploadsLastReusedAtFunc.appendCall(StoreGetUploadsLastReusedAtFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUploadsLastReusedAt
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetUploadsLastReusedAtFunc) SetDefaultHook(hook func(context.Context, ...int) (map[int]time.Time, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadsLastReusedAt method of the parent MockStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetUploadsLastReusedAtFunc) PushHook(hook func(context.Context, ...int) (map[int]time.Time, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadsLastReusedAtFunc) SetDefaultReturn(r0 map[int]time.Time, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...int) (map[int]time.Time, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadsLastReusedAtFunc) PushReturn(r0 map[int]time.Time, r1 error) {
+	f.PushHook(func(context.Context, ...int) (map[int]time.Time, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUploadsLastReusedAtFunc) nextHook() func(context.Context, ...int) (map[int]time.Time, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadsLastReusedAtFunc) appendCall(r0 StoreGetUploadsLastReusedAtFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadsLastReusedAtFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetUploadsLastReusedAtFunc) History() []StoreGetUploadsLastReusedAtFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadsLastReusedAtFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadsLastReusedAtFuncCall is an object that describes an invocation of
+// method GetUploadsLastReusedAt on an instance of MockStore.
+type StoreGetUploadsLastReusedAtFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[int]time.Time
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c StoreGetUploadsLastReusedAtFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg1 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadsLastReusedAtFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetVisibleUploadsMatchingMonikersFunc describes the behavior when
 // the GetVisibleUploadsMatchingMonikers method of the parent MockStore
 // instance is invoked.
@@ -5302,6 +5720,116 @@ func (c StoreRepoNamesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreReuseUploadFunc describes the behavior when the ReuseUpload method
+// of the parent MockStore instance is invoked.
+type StoreReuseUploadFunc struct {
+	defaultHook func(context.Context, int, int, string) error
+	hooks       []func(context.Context, int, int, string) error
+	history     []StoreReuseUploadFuncCall
+	mutex       sync.Mutex
+}
+
+// ReuseUpload delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) ReuseUpload(v0 context.Context, v1 int, v2 int, v3 string) error {
+	r0 := m.ReuseUploadFunc.nextHook()(v0, v1, v2, v3)
+	m.ReuseUploadFunc.appendCall(StoreReuseUploadFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ReuseUpload method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreReuseUploadFunc) SetDefaultHook(hook func(context.Context, int, int, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReuseUpload method of the parent MockStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreReuseUploadFunc) PushHook(hook func(context.Context, int, int, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreReuseUploadFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreReuseUploadFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int, string) error {
+		return r0
+	})
+}
+
+func (f *StoreReuseUploadFunc) nextHook() func(context.Context, int, int, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreReuseUploadFunc) appendCall(r0 StoreReuseUploadFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreReuseUploadFuncCall objects describing
+// the invocations of this function.
+func (f *StoreReuseUploadFunc) History() []StoreReuseUploadFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreReuseUploadFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreReuseUploadFuncCall is an object that describes an invocation of
+// method ReuseUpload on an instance of MockStore.
+type StoreReuseUploadFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreReuseUploadFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreReuseUploadFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreSetRepositoriesForRetentionScanFunc describes the behavior when the
 // SetRepositoriesForRetentionScan method of the parent MockStore instance
 // is invoked.
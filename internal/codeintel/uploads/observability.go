@@ -45,6 +45,9 @@ type operations struct {
 	// Tags
 	getListTags *observation.Operation
 
+	// Storage quotas
+	getStorageQuotaEvictionPreview *observation.Operation
+
 	// Worker metrics
 	uploadProcessor *observation.Operation
 	uploadSizeGuage prometheus.Gauge
@@ -116,6 +119,9 @@ func newOperations(observationContext *observation.Context) *operations {
 		// Tags
 		getListTags: op("GetListTags"),
 
+		// Storage quotas
+		getStorageQuotaEvictionPreview: op("GetStorageQuotaEvictionPreview"),
+
 		// Worker metrics
 		uploadProcessor: uploadProcessor,
 		uploadSizeGuage: uploadSizeGuage,
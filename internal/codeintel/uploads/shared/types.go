@@ -175,6 +175,40 @@ type UploadsWithRepositoryNamespace struct {
 	Uploads []types.Upload
 }
 
+// StorageQuotaCandidate is a completed upload whose size counts towards the storage quotas.
+type StorageQuotaCandidate struct {
+	UploadID       int
+	RepositoryID   int
+	RepositoryName string
+	Size           int64
+	UploadedAt     time.Time
+	CommittedAt    *time.Time
+	ReferenceCount int
+	VisibleAtTip   bool
+
+	// BranchDistance is how far the upload is from the branches of its repository according to
+	// the commit graph stored for it: 0 if the upload is visible at the tip of a branch or tag, 1
+	// if it is only visible from commits behind the tips, and nil if it isn't visible from any
+	// commit of the graph anymore.
+	BranchDistance *int
+}
+
+// StorageQuotaEviction describes the outcome of enforcing the storage quotas for a single upload.
+type StorageQuotaEviction struct {
+	UploadID int
+
+	// Protected is true if the upload is visible at the tip of the default branch of its
+	// repository. Protected uploads are never evicted to satisfy a storage quota.
+	Protected bool
+
+	// Rank is the 1-based position of the upload in the eviction order of its repository, where
+	// the least valuable upload is evicted first. Zero for protected uploads.
+	Rank int
+
+	// WouldBeEvicted is true if the upload is evicted to bring the storage usage under quota.
+	WouldBeEvicted bool
+}
+
 type UploadLog struct {
 	LogTimestamp      time.Time
 	RecordDeletedAt   *time.Time
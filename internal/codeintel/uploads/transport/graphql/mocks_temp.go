@@ -13,6 +13,7 @@ import (
 
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	types "github.com/sourcegraph/sourcegraph/internal/codeintel/types"
+	shared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
 	database "github.com/sourcegraph/sourcegraph/internal/database"
 	gitdomain "github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 )
@@ -890,6 +891,10 @@ type MockUploadService struct {
 	// GetListTagsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetListTags.
 	GetListTagsFunc *UploadServiceGetListTagsFunc
+	// GetStorageQuotaEvictionPreviewFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetStorageQuotaEvictionPreview.
+	GetStorageQuotaEvictionPreviewFunc *UploadServiceGetStorageQuotaEvictionPreviewFunc
 	// GetUploadDocumentsForPathFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetUploadDocumentsForPath.
@@ -931,6 +936,11 @@ func NewMockUploadService() *MockUploadService {
 				return
 			},
 		},
+		GetStorageQuotaEvictionPreviewFunc: &UploadServiceGetStorageQuotaEvictionPreviewFunc{
+			defaultHook: func(context.Context, int) (r0 []shared.StorageQuotaEviction, r1 error) {
+				return
+			},
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) (r0 []string, r1 int, r2 error) {
 				return
@@ -978,6 +988,11 @@ func NewStrictMockUploadService() *MockUploadService {
 				panic("unexpected invocation of MockUploadService.GetListTags")
 			},
 		},
+		GetStorageQuotaEvictionPreviewFunc: &UploadServiceGetStorageQuotaEvictionPreviewFunc{
+			defaultHook: func(context.Context, int) ([]shared.StorageQuotaEviction, error) {
+				panic("unexpected invocation of MockUploadService.GetStorageQuotaEvictionPreview")
+			},
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: func(context.Context, int, string) ([]string, int, error) {
 				panic("unexpected invocation of MockUploadService.GetUploadDocumentsForPath")
@@ -1016,6 +1031,9 @@ func NewMockUploadServiceFrom(i UploadService) *MockUploadService {
 		GetListTagsFunc: &UploadServiceGetListTagsFunc{
 			defaultHook: i.GetListTags,
 		},
+		GetStorageQuotaEvictionPreviewFunc: &UploadServiceGetStorageQuotaEvictionPreviewFunc{
+			defaultHook: i.GetStorageQuotaEvictionPreview,
+		},
 		GetUploadDocumentsForPathFunc: &UploadServiceGetUploadDocumentsForPathFunc{
 			defaultHook: i.GetUploadDocumentsForPath,
 		},
@@ -1586,6 +1604,118 @@ func (c UploadServiceGetListTagsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// UploadServiceGetStorageQuotaEvictionPreviewFunc describes the behavior
+// when the GetStorageQuotaEvictionPreview method of the parent
+// MockUploadService instance is invoked.
+type UploadServiceGetStorageQuotaEvictionPreviewFunc struct {
+	defaultHook func(context.Context, int) ([]shared.StorageQuotaEviction, error)
+	hooks       []func(context.Context, int) ([]shared.StorageQuotaEviction, error)
+	history     []UploadServiceGetStorageQuotaEvictionPreviewFuncCall
+	mutex       sync.Mutex
+}
+
+// GetStorageQuotaEvictionPreview delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockUploadService) GetStorageQuotaEvictionPreview(v0 context.Context, v1 int) ([]shared.StorageQuotaEviction, error) {
+	r0, r1 := m.GetStorageQuotaEvictionPreviewFunc.nextHook()(v0, v1)
+	m.GetStorageQuotaEvictionPreviewFunc.appendCall(UploadServiceGetStorageQuotaEvictionPreviewFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetStorageQuotaEvictionPreview method of the parent MockUploadService
+// instance is invoked and the hook queue is empty.
+func (f *UploadServiceGetStorageQuotaEvictionPreviewFunc) SetDefaultHook(hook func(context.Context, int) ([]shared.StorageQuotaEviction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetStorageQuotaEvictionPreview method of the parent MockUploadService
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *UploadServiceGetStorageQuotaEvictionPreviewFunc) PushHook(hook func(context.Context, int) ([]shared.StorageQuotaEviction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadServiceGetStorageQuotaEvictionPreviewFunc) SetDefaultReturn(r0 []shared.StorageQuotaEviction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]shared.StorageQuotaEviction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadServiceGetStorageQuotaEvictionPreviewFunc) PushReturn(r0 []shared.StorageQuotaEviction, r1 error) {
+	f.PushHook(func(context.Context, int) ([]shared.StorageQuotaEviction, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadServiceGetStorageQuotaEvictionPreviewFunc) nextHook() func(context.Context, int) ([]shared.StorageQuotaEviction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadServiceGetStorageQuotaEvictionPreviewFunc) appendCall(r0 UploadServiceGetStorageQuotaEvictionPreviewFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// UploadServiceGetStorageQuotaEvictionPreviewFuncCall objects describing
+// the invocations of this function.
+func (f *UploadServiceGetStorageQuotaEvictionPreviewFunc) History() []UploadServiceGetStorageQuotaEvictionPreviewFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadServiceGetStorageQuotaEvictionPreviewFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadServiceGetStorageQuotaEvictionPreviewFuncCall is an object that
+// describes an invocation of method GetStorageQuotaEvictionPreview on an
+// instance of MockUploadService.
+type UploadServiceGetStorageQuotaEvictionPreviewFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.StorageQuotaEviction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadServiceGetStorageQuotaEvictionPreviewFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadServiceGetStorageQuotaEvictionPreviewFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // UploadServiceGetUploadDocumentsForPathFunc describes the behavior when
 // the GetUploadDocumentsForPath method of the parent MockUploadService
 // instance is invoked.
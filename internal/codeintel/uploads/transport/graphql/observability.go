@@ -16,6 +16,9 @@ type operations struct {
 
 	// Commit Graph
 	commitGraph *observation.Operation
+
+	// Storage Quotas
+	storageQuotaEvictionPreview *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -43,5 +46,8 @@ func newOperations(observationContext *observation.Context) *operations {
 
 		// Commit Graph
 		commitGraph: op("CommitGraph"),
+
+		// Storage Quotas
+		storageQuotaEvictionPreview: op("StorageQuotaEvictionPreview"),
 	}
 }
@@ -14,6 +14,7 @@ import (
 
 type RootResolver interface {
 	CommitGraph(ctx context.Context, id graphql.ID) (CodeIntelligenceCommitGraphResolver, error)
+	StorageQuotaEvictionPreview(ctx context.Context, id graphql.ID) (*[]LSIFUploadStorageQuotaEvictionResolver, error)
 	LSIFUploadByID(ctx context.Context, id graphql.ID) (sharedresolvers.LSIFUploadResolver, error)
 	LSIFUploads(ctx context.Context, args *LSIFUploadsQueryArgs) (sharedresolvers.LSIFUploadConnectionResolver, error)
 	LSIFUploadsByRepo(ctx context.Context, args *LSIFRepositoryUploadsQueryArgs) (sharedresolvers.LSIFUploadConnectionResolver, error)
@@ -57,6 +58,35 @@ func (r *rootResolver) CommitGraph(ctx context.Context, id graphql.ID) (_ CodeIn
 	return NewCommitGraphResolver(stale, updatedAt), nil
 }
 
+// 🚨 SECURITY: Only entrypoint is within the repository resolver so the user is already authenticated
+func (r *rootResolver) StorageQuotaEvictionPreview(ctx context.Context, id graphql.ID) (_ *[]LSIFUploadStorageQuotaEvictionResolver, err error) {
+	ctx, traceErrs, endObservation := r.operations.storageQuotaEvictionPreview.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repoID", string(id)),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	repositoryID, err := unmarshalRepositoryID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := r.uploadSvc.GetStorageQuotaEvictionPreview(ctx, int(repositoryID))
+	if err != nil || preview == nil {
+		return nil, err
+	}
+
+	// Create a new prefetcher here as we only want to cache upload and index records in
+	// the same graphQL request, not across different request.
+	prefetcher := sharedresolvers.NewPrefetcher(r.autoindexSvc, r.uploadSvc)
+
+	resolvers := make([]LSIFUploadStorageQuotaEvictionResolver, 0, len(preview))
+	for _, eviction := range preview {
+		resolvers = append(resolvers, NewStorageQuotaEvictionResolver(r.uploadSvc, r.autoindexSvc, r.policySvc, eviction, prefetcher, traceErrs))
+	}
+
+	return &resolvers, nil
+}
+
 // 🚨 SECURITY: dbstore layer handles authz for GetUploadByID
 func (r *rootResolver) LSIFUploadByID(ctx context.Context, id graphql.ID) (_ sharedresolvers.LSIFUploadResolver, err error) {
 	ctx, traceErrs, endObservation := r.operations.lsifUploadByID.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
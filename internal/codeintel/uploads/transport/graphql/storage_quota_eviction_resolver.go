@@ -0,0 +1,60 @@
+package graphql
+
+import (
+	"context"
+
+	sharedresolvers "github.com/sourcegraph/sourcegraph/internal/codeintel/shared/resolvers"
+	uploadsShared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type LSIFUploadStorageQuotaEvictionResolver interface {
+	Upload(ctx context.Context) (sharedresolvers.LSIFUploadResolver, error)
+	Protected() bool
+	Rank() *int32
+	WouldBeEvicted() bool
+}
+
+type storageQuotaEvictionResolver struct {
+	uploadSvc    UploadService
+	autoindexSvc AutoIndexingService
+	policySvc    PolicyService
+	eviction     uploadsShared.StorageQuotaEviction
+	prefetcher   *sharedresolvers.Prefetcher
+	traceErrs    *observation.ErrCollector
+}
+
+func NewStorageQuotaEvictionResolver(uploadSvc UploadService, autoindexSvc AutoIndexingService, policySvc PolicyService, eviction uploadsShared.StorageQuotaEviction, prefetcher *sharedresolvers.Prefetcher, traceErrs *observation.ErrCollector) LSIFUploadStorageQuotaEvictionResolver {
+	// Request the next batch of upload fetches to contain the record's upload. This
+	// allows the prefetcher.GetUploadByID invocation in the Upload method to batch
+	// its work with sibling resolvers, which share the same prefetcher instance.
+	prefetcher.MarkUpload(eviction.UploadID)
+
+	return &storageQuotaEvictionResolver{
+		uploadSvc:    uploadSvc,
+		autoindexSvc: autoindexSvc,
+		policySvc:    policySvc,
+		eviction:     eviction,
+		prefetcher:   prefetcher,
+		traceErrs:    traceErrs,
+	}
+}
+
+func (r *storageQuotaEvictionResolver) Upload(ctx context.Context) (sharedresolvers.LSIFUploadResolver, error) {
+	upload, exists, err := r.prefetcher.GetUploadByID(ctx, r.eviction.UploadID)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return sharedresolvers.NewUploadResolver(r.uploadSvc, r.autoindexSvc, r.policySvc, upload, r.prefetcher, r.traceErrs), nil
+}
+
+func (r *storageQuotaEvictionResolver) Protected() bool      { return r.eviction.Protected }
+func (r *storageQuotaEvictionResolver) WouldBeEvicted() bool { return r.eviction.WouldBeEvicted }
+
+func (r *storageQuotaEvictionResolver) Rank() *int32 {
+	if r.eviction.Protected {
+		return nil
+	}
+	return intPtr(int32(r.eviction.Rank))
+}
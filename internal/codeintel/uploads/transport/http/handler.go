@@ -48,6 +48,7 @@ func newHandler(
 			Indexer:           getQuery(r, "indexerName"),
 			IndexerVersion:    getQuery(r, "indexerVersion"),
 			AssociatedIndexID: getQueryInt(r, "associatedIndexId"),
+			ContentHash:       getQuery(r, "contentHash"),
 		}, 0, nil
 	}
 
@@ -15,6 +15,7 @@ type UploadMetadata struct {
 	Indexer           string
 	IndexerVersion    string
 	AssociatedIndexID int
+	ContentHash       string
 }
 
 type uploadHandlerShim struct {
@@ -40,6 +41,11 @@ func (s *uploadHandlerShim) InsertUpload(ctx context.Context, upload uploadhandl
 		associatedIndexID = &upload.Metadata.AssociatedIndexID
 	}
 
+	var contentHash *string
+	if upload.Metadata.ContentHash != "" {
+		contentHash = &upload.Metadata.ContentHash
+	}
+
 	return s.Store.InsertUpload(ctx, types.Upload{
 		ID:                upload.ID,
 		State:             upload.State,
@@ -53,6 +59,7 @@ func (s *uploadHandlerShim) InsertUpload(ctx context.Context, upload uploadhandl
 		Indexer:           upload.Metadata.Indexer,
 		IndexerVersion:    upload.Metadata.IndexerVersion,
 		AssociatedIndexID: associatedIndexID,
+		ContentHash:       contentHash,
 	})
 }
 
@@ -84,6 +91,9 @@ func (s *uploadHandlerShim) GetUploadByID(ctx context.Context, uploadID int) (up
 	if upload.AssociatedIndexID != nil {
 		u.Metadata.AssociatedIndexID = *upload.AssociatedIndexID
 	}
+	if upload.ContentHash != nil {
+		u.Metadata.ContentHash = *upload.ContentHash
+	}
 
 	return u, true, nil
 }
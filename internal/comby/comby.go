@@ -105,6 +105,15 @@ func toFileReplacement(b []byte) Result {
 	return r
 }
 
+func toFileDiff(b []byte) Result {
+	var d *FileDiff
+	if err := json.Unmarshal(b, &d); err != nil {
+		log15.Warn("toFileDiff() comby error: skipping unmarshaling error", "err", err.Error())
+		return nil
+	}
+	return d
+}
+
 func toOutput(b []byte) Result {
 	return &Output{Value: b}
 }
@@ -252,6 +261,24 @@ func Replacements(ctx context.Context, args Args) ([]*FileReplacement, error) {
 	return matches, nil
 }
 
+// Diffs performs in-place replacement for match and rewrite template and
+// returns the unified diffs of the changes.
+func Diffs(ctx context.Context, args Args) ([]*FileDiff, error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Comby.Diffs")
+	defer span.Finish()
+
+	args.ResultKind = Diff
+	results, err := Run(ctx, args, toFileDiff)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []*FileDiff
+	for _, r := range results {
+		diffs = append(diffs, r.(*FileDiff))
+	}
+	return diffs, nil
+}
+
 // Outputs performs substitution of all variables captured in a match
 // pattern in a rewrite template and outputs the result, newline-sparated.
 func Outputs(ctx context.Context, args Args) (string, error) {
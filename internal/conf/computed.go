@@ -153,6 +153,12 @@ func ExecutorsEnabled() bool {
 	return Get().ExecutorsAccessToken != ""
 }
 
+// SCIMEnabled returns whether identity providers can provision users and
+// groups through the SCIM API.
+func SCIMEnabled() bool {
+	return Get().ScimAuthToken != ""
+}
+
 func ExecutorsFrontendURL() string {
 	current := Get()
 	if current.ExecutorsFrontendURL != "" {
@@ -240,6 +240,23 @@ func TestRedactSecrets_AuthProvidersSectionNotAdded(t *testing.T) {
 	assert.Equal(t, want, redacted.Site)
 }
 
+func TestRedactSecrets_SCIMAuthToken(t *testing.T) {
+	const cfg = `{
+  "scim.authToken": "%s"
+}`
+	redacted, err := RedactSecrets(
+		conftypes.RawUnified{
+			Site: fmt.Sprintf(cfg, "scim-token-that-is-long-enough"),
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(cfg, "REDACTED"), redacted.Site)
+
+	unredacted, err := UnredactSecrets(redacted.Site, conftypes.RawUnified{Site: fmt.Sprintf(cfg, "scim-token-that-is-long-enough")})
+	require.NoError(t, err)
+	assert.Contains(t, unredacted, `"scim.authToken": "scim-token-that-is-long-enough"`)
+}
+
 func TestUnredactSecrets(t *testing.T) {
 	previousSite := getTestSiteWithSecrets(
 		executorsAccessToken,
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	codecommittypes "github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/aws/smithy-go"
 
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
@@ -44,13 +45,28 @@ func (c *Client) cacheKeyPrefix(ctx context.Context) (string, error) {
 	return hex.EncodeToString(key[:]), nil
 }
 
+// GetCallerARN returns the ARN of the IAM identity whose credentials are used
+// by the client.
+func (c *Client) GetCallerARN(ctx context.Context) (string, error) {
+	result, err := sts.NewFromConfig(c.aws).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", &wrappedError{err: err}
+	}
+	if result.Arn == nil {
+		return "", errors.New("AWS STS returned no caller ARN")
+	}
+	return *result.Arn, nil
+}
+
 // ErrNotFound is when the requested AWS CodeCommit repository is not found.
 var ErrNotFound = errors.New("AWS CodeCommit repository not found")
 
 // IsNotFound reports whether err is a AWS CodeCommit API not-found error or the
 // equivalent cached response error.
 func IsNotFound(err error) bool {
-	return errors.Is(err, ErrNotFound) || errors.HasType(err, &codecommittypes.RepositoryDoesNotExistException{})
+	return errors.Is(err, ErrNotFound) ||
+		errors.HasType(err, &codecommittypes.RepositoryDoesNotExistException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestDoesNotExistException{})
 }
 
 // IsUnauthorized reports whether err is a AWS CodeCommit API unauthorized error.
@@ -0,0 +1,285 @@
+package awscodecommit
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	codecommittypes "github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+)
+
+// PullRequestStatus is the status of an AWS CodeCommit pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusOpen   PullRequestStatus = "OPEN"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED"
+)
+
+// PullRequest is an AWS CodeCommit pull request.
+type PullRequest struct {
+	ID               string              // the system-generated ID of the pull request
+	Title            string              // the title of the pull request
+	Description      string              // the description of the pull request
+	Status           PullRequestStatus   // the status of the pull request
+	AuthorARN        string              // the ARN of the user who created the pull request
+	RevisionID       string              // the system-generated revision ID of the pull request
+	CreationDate     time.Time           // the date the pull request was created
+	LastActivityDate time.Time           // the date of the most recent activity on the pull request
+	Targets          []PullRequestTarget // the source and destination of the pull request
+}
+
+// Target returns the first target of the pull request. Pull requests can have
+// multiple targets through the API, but the console and Sourcegraph only ever
+// create pull requests with a single target.
+func (pr *PullRequest) Target() PullRequestTarget {
+	if len(pr.Targets) == 0 {
+		return PullRequestTarget{}
+	}
+	return pr.Targets[0]
+}
+
+// PullRequestTarget is the source and destination of a pull request.
+type PullRequestTarget struct {
+	RepositoryName       string // the name of the repository
+	SourceReference      string // the branch the changes are merged from
+	SourceCommit         string // the tip of the source branch
+	DestinationReference string // the branch the changes are merged into
+	DestinationCommit    string // the tip of the destination branch
+	MergeBase            string // the merge base of the source and destination commits
+	IsMerged             bool   // whether the pull request has been merged
+	MergedBy             string // the ARN of the user who merged the pull request
+}
+
+// ApprovalState is the state of an approval of a pull request.
+type ApprovalState string
+
+const (
+	ApprovalStateApprove ApprovalState = "APPROVE"
+	ApprovalStateRevoke  ApprovalState = "REVOKE"
+)
+
+// Approval is the approval of a pull request revision by a single user.
+type Approval struct {
+	UserARN string        // the ARN of the user
+	State   ApprovalState // the state of the approval
+}
+
+// CreatePullRequestInput describes a pull request to be created.
+type CreatePullRequestInput struct {
+	RepositoryName       string
+	Title                string
+	Description          string
+	SourceReference      string
+	DestinationReference string
+}
+
+// CreatePullRequest calls the CreatePullRequest API method of AWS CodeCommit.
+func (c *Client) CreatePullRequest(ctx context.Context, input CreatePullRequestInput) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.CreatePullRequest(ctx, &codecommit.CreatePullRequestInput{
+		Title:       &input.Title,
+		Description: &input.Description,
+		Targets: []codecommittypes.Target{{
+			RepositoryName:       &input.RepositoryName,
+			SourceReference:      &input.SourceReference,
+			DestinationReference: &input.DestinationReference,
+		}},
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// GetPullRequest calls the GetPullRequest API method of AWS CodeCommit.
+func (c *Client) GetPullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: &id})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// ListOpenPullRequestIDs returns the IDs of the open pull requests in the given
+// repository that were created by the user with the given ARN.
+func (c *Client) ListOpenPullRequestIDs(ctx context.Context, repositoryName, authorARN string) ([]string, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+
+	var ids []string
+	input := codecommit.ListPullRequestsInput{
+		RepositoryName:    &repositoryName,
+		AuthorArn:         &authorARN,
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumOpen,
+	}
+	for {
+		result, err := svc.ListPullRequests(ctx, &input)
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		ids = append(ids, result.PullRequestIds...)
+
+		if result.NextToken == nil || *result.NextToken == "" {
+			return ids, nil
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// UpdatePullRequestTitle calls the UpdatePullRequestTitle API method of AWS
+// CodeCommit.
+func (c *Client) UpdatePullRequestTitle(ctx context.Context, id, title string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.UpdatePullRequestTitle(ctx, &codecommit.UpdatePullRequestTitleInput{
+		PullRequestId: &id,
+		Title:         &title,
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// UpdatePullRequestDescription calls the UpdatePullRequestDescription API
+// method of AWS CodeCommit.
+func (c *Client) UpdatePullRequestDescription(ctx context.Context, id, description string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.UpdatePullRequestDescription(ctx, &codecommit.UpdatePullRequestDescriptionInput{
+		PullRequestId: &id,
+		Description:   &description,
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// ClosePullRequest closes the given pull request. Note that AWS CodeCommit
+// doesn't allow closed pull requests to be reopened.
+func (c *Client) ClosePullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.UpdatePullRequestStatus(ctx, &codecommit.UpdatePullRequestStatusInput{
+		PullRequestId:     &id,
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumClosed,
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return fromPullRequest(result.PullRequest), nil
+}
+
+// MergePullRequest merges the given pull request, either by squashing the
+// changes into a single commit or with a three-way merge.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash bool) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	target := pr.Target()
+
+	var merged *codecommittypes.PullRequest
+	if squash {
+		result, err := svc.MergePullRequestBySquash(ctx, &codecommit.MergePullRequestBySquashInput{
+			PullRequestId:  &pr.ID,
+			RepositoryName: &target.RepositoryName,
+			SourceCommitId: &target.SourceCommit,
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		merged = result.PullRequest
+	} else {
+		result, err := svc.MergePullRequestByThreeWay(ctx, &codecommit.MergePullRequestByThreeWayInput{
+			PullRequestId:  &pr.ID,
+			RepositoryName: &target.RepositoryName,
+			SourceCommitId: &target.SourceCommit,
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		merged = result.PullRequest
+	}
+
+	return fromPullRequest(merged), nil
+}
+
+// CreatePullRequestComment posts a general comment on the given pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, content string) error {
+	svc := codecommit.NewFromConfig(c.aws)
+	target := pr.Target()
+
+	_, err := svc.PostCommentForPullRequest(ctx, &codecommit.PostCommentForPullRequestInput{
+		PullRequestId:  &pr.ID,
+		RepositoryName: &target.RepositoryName,
+		BeforeCommitId: &target.DestinationCommit,
+		AfterCommitId:  &target.SourceCommit,
+		Content:        &content,
+	})
+	if err != nil {
+		return &wrappedError{err: err}
+	}
+	return nil
+}
+
+// GetPullRequestApprovals returns the approvals of the given revision of a pull
+// request.
+func (c *Client) GetPullRequestApprovals(ctx context.Context, id, revisionID string) ([]*Approval, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	result, err := svc.GetPullRequestApprovalStates(ctx, &codecommit.GetPullRequestApprovalStatesInput{
+		PullRequestId: &id,
+		RevisionId:    &revisionID,
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+
+	approvals := make([]*Approval, 0, len(result.Approvals))
+	for _, a := range result.Approvals {
+		approvals = append(approvals, &Approval{
+			UserARN: stringValue(a.UserArn),
+			State:   ApprovalState(a.ApprovalState),
+		})
+	}
+	return approvals, nil
+}
+
+func fromPullRequest(pr *codecommittypes.PullRequest) *PullRequest {
+	result := PullRequest{
+		ID:          stringValue(pr.PullRequestId),
+		Title:       stringValue(pr.Title),
+		Description: stringValue(pr.Description),
+		Status:      PullRequestStatus(pr.PullRequestStatus),
+		AuthorARN:   stringValue(pr.AuthorArn),
+		RevisionID:  stringValue(pr.RevisionId),
+		Targets:     make([]PullRequestTarget, 0, len(pr.PullRequestTargets)),
+	}
+	if pr.CreationDate != nil {
+		result.CreationDate = *pr.CreationDate
+	}
+	if pr.LastActivityDate != nil {
+		result.LastActivityDate = *pr.LastActivityDate
+	}
+
+	for _, t := range pr.PullRequestTargets {
+		target := PullRequestTarget{
+			RepositoryName:       stringValue(t.RepositoryName),
+			SourceReference:      stringValue(t.SourceReference),
+			SourceCommit:         stringValue(t.SourceCommit),
+			DestinationReference: stringValue(t.DestinationReference),
+			DestinationCommit:    stringValue(t.DestinationCommit),
+			MergeBase:            stringValue(t.MergeBase),
+		}
+		if t.MergeMetadata != nil {
+			target.IsMerged = t.MergeMetadata.IsMerged
+			target.MergedBy = stringValue(t.MergeMetadata.MergedBy)
+		}
+		result.Targets = append(result.Targets, target)
+	}
+
+	return &result
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
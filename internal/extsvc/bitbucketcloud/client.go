@@ -45,6 +45,10 @@ type Client interface {
 	ForkRepository(ctx context.Context, upstream *Repo, input ForkInput) (*Repo, error)
 
 	CurrentUser(ctx context.Context) (*User, error)
+
+	WorkspaceMember(ctx context.Context, workspace, nickname string) (*Account, error)
+	RepositoryPermissions(ctx context.Context, workspace, slug string) ([]*RepositoryPermission, error)
+	UserRepositoryPermissions(ctx context.Context, workspace, userUUID string) ([]*RepositoryPermission, error)
 }
 
 // client access a Bitbucket Cloud via the REST API 2.0.
@@ -0,0 +1,74 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// RepositoryPermission is the effective permission of a user on a repository,
+// taking into account the permissions the user is granted through groups.
+type RepositoryPermission struct {
+	Permission string   `json:"permission"`
+	User       *Account `json:"user"`
+	Repository *Repo    `json:"repository"`
+}
+
+type workspaceMembership struct {
+	User *Account `json:"user"`
+}
+
+// WorkspaceMember returns the account of the member of the given workspace
+// with the given nickname, or nil if there is no such member. The
+// authenticated user must be an administrator of the workspace.
+func (c *client) WorkspaceMember(ctx context.Context, workspace, nickname string) (*Account, error) {
+	qry := url.Values{"q": []string{"user.nickname=" + strconv.Quote(nickname)}}
+
+	var memberships []*workspaceMembership
+	if _, err := c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/permissions", workspace), qry, &PageToken{Pagelen: 1}, &memberships); err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		// The filter is applied by Bitbucket Cloud, so we double check it.
+		if m.User != nil && m.User.Nickname == nickname {
+			return m.User, nil
+		}
+	}
+	return nil, nil
+}
+
+// RepositoryPermissions returns the effective permissions of all users that
+// have access to the given repository. The authenticated user must be an
+// administrator of the workspace.
+func (c *client) RepositoryPermissions(ctx context.Context, workspace, slug string) ([]*RepositoryPermission, error) {
+	return allPages[*RepositoryPermission](ctx, c, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", workspace, slug), nil)
+}
+
+// UserRepositoryPermissions returns the effective permissions of the user with
+// the given UUID on the repositories of the workspace. The authenticated user
+// must be an administrator of the workspace.
+func (c *client) UserRepositoryPermissions(ctx context.Context, workspace, userUUID string) ([]*RepositoryPermission, error) {
+	qry := url.Values{"q": []string{"user.uuid=" + strconv.Quote(userUUID)}}
+	return allPages[*RepositoryPermission](ctx, c, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories", workspace), qry)
+}
+
+// allPages requests all pages of the paginated resource at path and returns
+// the values of all pages.
+func allPages[T any](ctx context.Context, c *client, path string, qry url.Values) ([]T, error) {
+	var all []T
+
+	var values []T
+	next, err := c.page(ctx, path, qry, &PageToken{Pagelen: 100}, &values)
+	for {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, values...)
+		if !next.HasMore() {
+			return all, nil
+		}
+		values = nil
+		next, err = c.reqPage(ctx, next.Next, &values)
+	}
+}
@@ -0,0 +1,80 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_Permissions(t *testing.T) {
+	ctx := context.Background()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/workspaces/sourcegraph/permissions":
+			if r.URL.Query().Get("q") == `user.nickname="alice"` {
+				fmt.Fprint(w, `{"values": [{"permission": "member", "user": {"uuid": "{1}", "nickname": "alice"}}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"values": []}`)
+
+		case "/2.0/workspaces/sourcegraph/permissions/repositories/private":
+			fmt.Fprint(w, `{"values": [{"permission": "read", "user": {"uuid": "{1}"}, "repository": {"uuid": "{r1}", "full_name": "sourcegraph/private"}}]}`)
+
+		case "/2.0/workspaces/sourcegraph/permissions/repositories":
+			assert.Equal(t, `user.uuid="{1}"`, r.URL.Query().Get("q"))
+			fmt.Fprint(w, `{"values": [{"permission": "admin", "user": {"uuid": "{1}"}, "repository": {"uuid": "{r1}"}}, {"permission": "write", "user": {"uuid": "{1}"}, "repository": {"uuid": "{r2}"}}]}`)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := newClient("urn", &schema.BitbucketCloudConnection{
+		ApiURL:      srv.URL,
+		Username:    "admin",
+		AppPassword: "secret",
+	}, srv.Client())
+	require.NoError(t, err)
+
+	t.Run("WorkspaceMember", func(t *testing.T) {
+		member, err := c.WorkspaceMember(ctx, "sourcegraph", "alice")
+		require.NoError(t, err)
+		require.NotNil(t, member)
+		assert.Equal(t, "{1}", member.UUID)
+
+		member, err = c.WorkspaceMember(ctx, "sourcegraph", "mallory")
+		require.NoError(t, err)
+		assert.Nil(t, member)
+	})
+
+	t.Run("RepositoryPermissions", func(t *testing.T) {
+		perms, err := c.RepositoryPermissions(ctx, "sourcegraph", "private")
+		require.NoError(t, err)
+		require.Len(t, perms, 1)
+		assert.Equal(t, "{1}", perms[0].User.UUID)
+		assert.Equal(t, "read", perms[0].Permission)
+	})
+
+	t.Run("UserRepositoryPermissions", func(t *testing.T) {
+		perms, err := c.UserRepositoryPermissions(ctx, "sourcegraph", "{1}")
+		require.NoError(t, err)
+		require.Len(t, perms, 2)
+		assert.Equal(t, "{r1}", perms[0].Repository.UUID)
+		assert.Equal(t, "{r2}", perms[1].Repository.UUID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := c.RepositoryPermissions(ctx, "other", "repo")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,211 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ChangeStatus is the status of a Gerrit change.
+type ChangeStatus string
+
+const (
+	ChangeStatusNew       ChangeStatus = "NEW"
+	ChangeStatusMerged    ChangeStatus = "MERGED"
+	ChangeStatusAbandoned ChangeStatus = "ABANDONED"
+)
+
+// Change is a Gerrit change, as returned by the changes endpoints of the REST
+// API. See https://gerrit-review.googlesource.com/Documentation/rest-api-changes.html#change-info.
+type Change struct {
+	// ID is the project~branch~Change-Id triplet identifying the change.
+	ID       string       `json:"id"`
+	Project  string       `json:"project"`
+	Branch   string       `json:"branch"`
+	Topic    string       `json:"topic,omitempty"`
+	ChangeID string       `json:"change_id"`
+	Subject  string       `json:"subject"`
+	Status   ChangeStatus `json:"status"`
+	Created  Timestamp    `json:"created"`
+	Updated  Timestamp    `json:"updated"`
+	// Number is the legacy numeric ID of the change, which is what is shown
+	// in the Gerrit UI.
+	Number          int                    `json:"_number"`
+	Owner           Account                `json:"owner"`
+	WorkInProgress  bool                   `json:"work_in_progress,omitempty"`
+	Submittable     bool                   `json:"submittable,omitempty"`
+	Labels          map[string]ChangeLabel `json:"labels,omitempty"`
+	CurrentRevision string                 `json:"current_revision,omitempty"`
+	Revisions       map[string]Revision    `json:"revisions,omitempty"`
+}
+
+// CurrentCommit returns the commit of the current revision of the change, or
+// nil if the change was requested without revision details.
+func (c *Change) CurrentCommit() *Commit {
+	if rev, ok := c.Revisions[c.CurrentRevision]; ok {
+		return rev.Commit
+	}
+	return nil
+}
+
+// ChangeLabel is the state of a review label (e.g. Code-Review) on a change.
+type ChangeLabel struct {
+	Approved    *Account   `json:"approved,omitempty"`
+	Rejected    *Account   `json:"rejected,omitempty"`
+	Recommended *Account   `json:"recommended,omitempty"`
+	Disliked    *Account   `json:"disliked,omitempty"`
+	All         []Approval `json:"all,omitempty"`
+}
+
+// Approval is a single vote on a label.
+type Approval struct {
+	Account
+	Value int        `json:"value"`
+	Date  *Timestamp `json:"date,omitempty"`
+}
+
+// Revision is a patch set of a change.
+type Revision struct {
+	Number int     `json:"_number"`
+	Ref    string  `json:"ref"`
+	Commit *Commit `json:"commit,omitempty"`
+}
+
+// Commit is the commit of a revision.
+type Commit struct {
+	Parents []CommitParent `json:"parents"`
+	Subject string         `json:"subject"`
+	Message string         `json:"message"`
+}
+
+// CommitParent is a parent of a commit.
+type CommitParent struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// timestampLayout is the format used by Gerrit for timestamps. Timestamps are
+// always in UTC.
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// Timestamp is a point in time as formatted by the Gerrit REST API.
+type Timestamp struct {
+	time.Time
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Time.UTC().Format(timestampLayout))
+}
+
+// changeOptions are the additional fields requested for changes. They contain
+// everything needed to derive the state, review state and check state of a
+// change.
+var changeOptions = []string{
+	"CURRENT_REVISION",
+	"CURRENT_COMMIT",
+	"DETAILED_LABELS",
+	"DETAILED_ACCOUNTS",
+	"SUBMITTABLE",
+}
+
+// GetChange returns the change with the given identifier, which can either be
+// the change number or a project~branch~Change-Id triplet.
+func (c *Client) GetChange(ctx context.Context, changeID string) (*Change, error) {
+	qs := url.Values{"o": changeOptions}
+	req, err := http.NewRequest("GET", changePath(changeID, "")+"?"+qs.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// AbandonChange abandons the given change.
+func (c *Client) AbandonChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.postChangeAction(ctx, changeID, "/abandon", struct{}{})
+}
+
+// RestoreChange restores the given abandoned change.
+func (c *Client) RestoreChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.postChangeAction(ctx, changeID, "/restore", struct{}{})
+}
+
+// SubmitChange submits (merges) the given change.
+func (c *Client) SubmitChange(ctx context.Context, changeID string) (*Change, error) {
+	return c.postChangeAction(ctx, changeID, "/submit", struct{}{})
+}
+
+// MoveChange moves the given change to the given destination branch.
+func (c *Client) MoveChange(ctx context.Context, changeID, destinationBranch string) (*Change, error) {
+	return c.postChangeAction(ctx, changeID, "/move", struct {
+		DestinationBranch string `json:"destination_branch"`
+	}{DestinationBranch: destinationBranch})
+}
+
+// SetCommitMessage updates the commit message of the given change. This
+// creates a new patch set.
+func (c *Client) SetCommitMessage(ctx context.Context, changeID, message string) error {
+	req, err := newJSONRequest("PUT", changePath(changeID, "/message"), struct {
+		Message string `json:"message"`
+	}{Message: message})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// CreateChangeComment posts a review message on the current revision of the
+// given change.
+func (c *Client) CreateChangeComment(ctx context.Context, changeID, message string) error {
+	req, err := newJSONRequest("POST", changePath(changeID, "/revisions/current/review"), struct {
+		Message string `json:"message"`
+	}{Message: message})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+func (c *Client) postChangeAction(ctx context.Context, changeID, action string, payload any) (*Change, error) {
+	req, err := newJSONRequest("POST", changePath(changeID, action), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var change Change
+	if _, err = c.do(ctx, req, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// changePath returns the relative path to the given endpoint of a change. The
+// change ID is expected to be escaped already, as Gerrit encodes slashes in
+// project names within change IDs.
+func changePath(changeID, endpoint string) string {
+	return "a/changes/" + changeID + endpoint
+}
@@ -1010,6 +1010,51 @@ func (c *V4Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash
 	return nil
 }
 
+const refStatusCheckRollupQuery = `
+query RefStatusCheckRollup($owner: String!, $name: String!, $ref: String!) {
+  repository(owner: $owner, name: $name) {
+    ref(qualifiedName: $ref) {
+      target {
+        ... on Commit {
+          statusCheckRollup { state }
+        }
+      }
+    }
+  }
+}
+`
+
+// GetRefStatusCheckRollupState returns the combined state of all commit
+// statuses and check runs of the commit the given ref points to. It is one of
+// "ERROR", "EXPECTED", "FAILURE", "PENDING" and "SUCCESS", or empty if the
+// commit has no statuses or check runs.
+func (c *V4Client) GetRefStatusCheckRollupState(ctx context.Context, owner, name, ref string) (string, error) {
+	var result struct {
+		Repository struct {
+			Ref *struct {
+				Target struct {
+					StatusCheckRollup *struct {
+						State string
+					}
+				}
+			}
+		}
+	}
+
+	vars := map[string]any{"owner": owner, "name": name, "ref": ref}
+	if err := c.requestGraphQL(ctx, refStatusCheckRollupQuery, vars, &result); err != nil {
+		return "", err
+	}
+
+	if result.Repository.Ref == nil {
+		return "", errors.Errorf("ref %q not found", ref)
+	}
+	if result.Repository.Ref.Target.StatusCheckRollup == nil {
+		return "", nil
+	}
+	return result.Repository.Ref.Target.StatusCheckRollup.State, nil
+}
+
 func (c *V4Client) loadRemainingTimelineItems(ctx context.Context, prID string, pageInfo PageInfo) (items []TimelineItem, err error) {
 	version := c.determineGitHubVersion(ctx)
 	timelineItemTypes, err := timelineItemTypes(version)
@@ -731,6 +731,93 @@ func (c *V3Client) Fork(ctx context.Context, owner, repo string, org *string) (*
 	return convertRestRepo(restRepo), nil
 }
 
+// RequestReviewers requests reviews on the given pull request from the given
+// users and teams. Teams are identified by their slug within the owning
+// organization.
+//
+// API docs: https://docs.github.com/en/rest/pulls/review-requests#request-reviewers-for-a-pull-request
+func (c *V3Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, users, teams []string) error {
+	payload := struct {
+		Reviewers     []string `json:"reviewers,omitempty"`
+		TeamReviewers []string `json:"team_reviewers,omitempty"`
+	}{Reviewers: users, TeamReviewers: teams}
+
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
+// SearchUserLoginsByEmail returns the logins of the users whose public email
+// address matches the given one.
+//
+// API docs: https://docs.github.com/en/rest/search#search-users
+func (c *V3Client) SearchUserLoginsByEmail(ctx context.Context, email string) ([]string, error) {
+	var result struct {
+		Items []struct {
+			Login string `json:"login"`
+		} `json:"items"`
+	}
+
+	qry := url.Values{"q": []string{email + " in:email"}}
+	if _, err := c.get(ctx, "search/users?"+qry.Encode(), &result); err != nil {
+		return nil, err
+	}
+
+	logins := make([]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		logins = append(logins, item.Login)
+	}
+	return logins, nil
+}
+
+// Issue is a GitHub issue, as returned by the REST API.
+type Issue struct {
+	Number  int64  `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CreateIssue opens an issue with the given title, body and labels on the
+// given repository.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#create-an-issue
+func (c *V3Client) CreateIssue(ctx context.Context, owner, repo, title, body string, labels []string) (*Issue, error) {
+	payload := struct {
+		Title  string   `json:"title"`
+		Body   string   `json:"body,omitempty"`
+		Labels []string `json:"labels,omitempty"`
+	}{Title: title, Body: body, Labels: labels}
+
+	var issue Issue
+	if _, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues", owner, repo), payload, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// GetIssue gets the issue with the given number.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#get-an-issue
+func (c *V3Client) GetIssue(ctx context.Context, owner, repo string, number int64) (*Issue, error) {
+	var issue Issue
+	if _, err := c.get(ctx, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, number), &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// CreateIssueComment comments on the issue with the given number.
+//
+// API docs: https://docs.github.com/en/rest/issues/comments#create-an-issue-comment
+func (c *V3Client) CreateIssueComment(ctx context.Context, owner, repo string, number int64, body string) error {
+	payload := struct {
+		Body string `json:"body"`
+	}{Body: body}
+
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, number), payload, &struct{}{})
+	return err
+}
+
 // GetAppInstallation gets information of a GitHub App installation.
 //
 // API docs: https://docs.github.com/en/rest/reference/apps#get-an-installation-for-the-authenticated-app
@@ -942,3 +942,67 @@ func TestSyncWebhook_webhookURLBuilderWithID(t *testing.T) {
 		})
 	}
 }
+
+func TestV3Client_Issues(t *testing.T) {
+	rcache.SetupForTest(t)
+
+	var comments []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/sourcegraph/sourcegraph/issues":
+			var payload struct {
+				Title  string   `json:"title"`
+				Labels []string `json:"labels"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatal(err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"number": 7, "title": %q, "state": "open", "html_url": "https://github.com/sourcegraph/sourcegraph/issues/7"}`, payload.Title+" "+strings.Join(payload.Labels, ","))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/sourcegraph/sourcegraph/issues/7":
+			fmt.Fprint(w, `{"number": 7, "title": "Findings", "state": "closed", "html_url": "https://github.com/sourcegraph/sourcegraph/issues/7"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/sourcegraph/sourcegraph/issues/7/comments":
+			var payload struct {
+				Body string `json:"body"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatal(err)
+			}
+			comments = append(comments, payload.Body)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	uri, _ := url.Parse(testServer.URL)
+	cli := NewV3Client(logtest.Scoped(t), "Test", uri, nil, testServer.Client())
+	ctx := context.Background()
+
+	issue, err := cli.CreateIssue(ctx, "sourcegraph", "sourcegraph", "Findings", "body", []string{"security"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Issue{Number: 7, Title: "Findings security", State: "open", HTMLURL: "https://github.com/sourcegraph/sourcegraph/issues/7"}
+	if diff := cmp.Diff(want, issue); diff != "" {
+		t.Errorf("unexpected created issue (-want +got):\n%s", diff)
+	}
+
+	issue, err = cli.GetIssue(ctx, "sourcegraph", "sourcegraph", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.State != "closed" {
+		t.Errorf("unexpected state %q", issue.State)
+	}
+
+	if err := cli.CreateIssueComment(ctx, "sourcegraph", "sourcegraph", 7, "more findings"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"more findings"}, comments); diff != "" {
+		t.Errorf("unexpected comments (-want +got):\n%s", diff)
+	}
+}
@@ -603,6 +603,22 @@ func (c *V4Client) Fork(ctx context.Context, owner, repo string, org *string) (*
 	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).Fork(ctx, owner, repo, org)
 }
 
+// RequestReviewers requests reviews on the given pull request from the given
+// users and teams.
+func (c *V4Client) RequestReviewers(ctx context.Context, owner, repo string, number int64, users, teams []string) error {
+	// The GraphQL requestReviews mutation requires node IDs for every user and
+	// team, so we fall back to the REST API, which accepts logins and slugs.
+	logger := c.log.Scoped("RequestReviewers", "temporary client for requesting reviewers on a GitHub pull request")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).RequestReviewers(ctx, owner, repo, number, users, teams)
+}
+
+// SearchUserLoginsByEmail returns the logins of the users whose public email
+// address matches the given one.
+func (c *V4Client) SearchUserLoginsByEmail(ctx context.Context, email string) ([]string, error) {
+	logger := c.log.Scoped("SearchUserLoginsByEmail", "temporary client for searching GitHub users by email")
+	return NewV3Client(logger, c.urn, c.apiURL, c.auth, c.httpClient).SearchUserLoginsByEmail(ctx, email)
+}
+
 type RecentCommittersParams struct {
 	// Repository name
 	Name string
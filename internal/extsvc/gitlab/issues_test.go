@@ -0,0 +1,73 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestCreateIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 1}}
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			statusCode:   http.StatusCreated,
+			responseBody: `{"id": 10, "iid": 2, "project_id": 1, "title": "Findings", "state": "opened", "web_url": "https://example.com/a/b/-/issues/2"}`,
+		}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "Findings"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &Issue{ID: 10, IID: 2, ProjectID: 1, Title: "Findings", State: IssueStateOpened, WebURL: "https://example.com/a/b/-/issues/2"}
+		if diff := cmp.Diff(want, issue); diff != "" {
+			t.Errorf("unexpected issue (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusInternalServerError}
+
+		if issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{}); err == nil {
+			t.Errorf("unexpected nil error, issue: %+v", issue)
+		}
+	})
+}
+
+func TestGetIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 1}}
+
+	t.Run("not found", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			statusCode:   http.StatusNotFound,
+			responseBody: `{"message": "404 Not found"}`,
+		}
+
+		_, err := client.GetIssue(ctx, project, 2)
+		if !errors.Is(err, ErrIssueNotFound) {
+			t.Errorf("unexpected error: have %+v; want %+v", err, ErrIssueNotFound)
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{responseBody: `{"iid": 2, "state": "closed"}`}
+
+		issue, err := client.GetIssue(ctx, project, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if issue.State != IssueStateClosed {
+			t.Errorf("unexpected state: %q", issue.State)
+		}
+	})
+}
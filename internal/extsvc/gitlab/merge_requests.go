@@ -47,6 +47,7 @@ type MergeRequest struct {
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Draft                  bool              `json:"draft"`
 	Author                 User              `json:"author"`
+	Reviewers              []User            `json:"reviewers"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -221,6 +222,7 @@ type UpdateMergeRequestOpts struct {
 	Title        string                       `json:"title,omitempty"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+	ReviewerIDs  []int32                      `json:"reviewer_ids,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -0,0 +1,24 @@
+package gitolite
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// AccountData stores information of a Gitolite user.
+type AccountData struct {
+	Username string `json:"username"`
+}
+
+// GetExternalAccountData extracts account data for the external account.
+func GetExternalAccountData(ctx context.Context, data *extsvc.AccountData) (*AccountData, error) {
+	if data.Data == nil {
+		return nil, nil
+	}
+
+	var d AccountData
+	err := encryption.DecryptJSON(ctx, data.Data, &d)
+	return &d, err
+}
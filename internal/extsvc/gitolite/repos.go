@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Repo is the repository metadata returned by the Gitolite API.
@@ -43,6 +46,24 @@ func (c *Client) ListRepos(ctx context.Context) ([]*Repo, error) {
 	return decodeRepos(c.Host, string(out)), nil
 }
 
+// validUsername matches the user names accepted by Gitolite.
+var validUsername = lazyregexp.New(`^[0-9a-zA-Z][0-9a-zA-Z._@+-]*$`)
+
+// ListUserRepos lists the repositories the given Gitolite user has read access to. It runs the
+// `info` command on behalf of the user, which requires the `sudo` command to be enabled on the
+// Gitolite server and the client's SSH key to belong to a Gitolite administrator.
+func (c *Client) ListUserRepos(ctx context.Context, user string) ([]*Repo, error) {
+	if !validUsername.MatchString(user) {
+		return nil, errors.Errorf("invalid gitolite user name %q", user)
+	}
+	out, err := exec.CommandContext(ctx, "ssh", c.Host, "sudo", user, "info").Output()
+	if err != nil {
+		log15.Error("listing gitolite user repos failed", "error", err, "user", user, "out", string(out))
+		return nil, maybeUnauthorized(err)
+	}
+	return decodeRepos(c.Host, string(out)), nil
+}
+
 func decodeRepos(host, gitoliteInfo string) []*Repo {
 	lines := strings.Split(gitoliteInfo, "\n")
 	var repos []*Repo
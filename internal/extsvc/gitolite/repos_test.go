@@ -1,6 +1,7 @@
 package gitolite
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -75,3 +76,12 @@ func TestMaybeUnauthorized(t *testing.T) {
 		t.Errorf("Should be unauthorized")
 	}
 }
+
+func TestListUserRepos_InvalidUser(t *testing.T) {
+	c := NewClient("git@gitolite.example.com")
+	for _, user := range []string{"", "-oProxyCommand=evil", "alice; rm -rf /", "alice bob"} {
+		if _, err := c.ListUserRepos(context.Background(), user); err == nil {
+			t.Errorf("expected error for user name %q", user)
+		}
+	}
+}
@@ -26,6 +26,16 @@ func NewGitoliteLister(cli httpcli.Doer) *GitoliteLister {
 }
 
 func (c *GitoliteLister) ListRepos(ctx context.Context, gitoliteHost string) (list []*gitolite.Repo, err error) {
+	return c.list(ctx, gitoliteHost, url.Values{"gitolite": {gitoliteHost}})
+}
+
+// ListUserRepos lists the repositories of the Gitolite server the given Gitolite user has read
+// access to.
+func (c *GitoliteLister) ListUserRepos(ctx context.Context, gitoliteHost, user string) (list []*gitolite.Repo, err error) {
+	return c.list(ctx, gitoliteHost, url.Values{"gitolite": {gitoliteHost}, "user": {user}})
+}
+
+func (c *GitoliteLister) list(ctx context.Context, gitoliteHost string, qry url.Values) (list []*gitolite.Repo, err error) {
 	addrs := c.addrs()
 	if len(addrs) == 0 {
 		panic("unexpected state: no gitserver addresses")
@@ -34,7 +44,7 @@ func (c *GitoliteLister) ListRepos(ctx context.Context, gitoliteHost string) (li
 	// we need to only call a single gitserver (or else we'd get duplicate results).
 	addr := addrForKey(gitoliteHost, addrs)
 
-	req, err := http.NewRequest("GET", "http://"+addr+"/list-gitolite?gitolite="+url.QueryEscape(gitoliteHost), nil)
+	req, err := http.NewRequest("GET", "http://"+addr+"/list-gitolite?"+qry.Encode(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +109,9 @@ func (s GitoliteSource) makeRepo(repo *gitolite.Repo) *types.Repo {
 		Name:         api.RepoName(name),
 		URI:          name,
 		ExternalRepo: gitolite.ExternalRepoSpec(repo, gitolite.ServiceID(s.conn.Host)),
+		// Repositories are only private when Gitolite permissions are enforced,
+		// otherwise every user is allowed to see them.
+		Private: s.conn.Authorization != nil,
 		Sources: map[string]*types.SourceInfo{
 			urn: {
 				ID:       urn,
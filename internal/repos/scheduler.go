@@ -110,6 +110,41 @@ type UpdateScheduler struct {
 	updateQueue *updateQueue
 	schedule    *schedule
 	logger      log.Logger
+
+	onRepoChangedMu sync.RWMutex
+	onRepoChanged   []RepoChangedFunc
+}
+
+// RepoChangedFunc is called after an update fetched new commits for a
+// repository.
+type RepoChangedFunc func(ctx context.Context, id api.RepoID, name api.RepoName)
+
+// OnRepoChanged registers f to be called each time an update fetches new
+// commits for a repository. It is called from the update loop, after gitserver
+// responded, so it must return quickly and hand off any slow work.
+func (s *UpdateScheduler) OnRepoChanged(f RepoChangedFunc) {
+	s.onRepoChangedMu.Lock()
+	defer s.onRepoChangedMu.Unlock()
+	s.onRepoChanged = append(s.onRepoChanged, f)
+}
+
+func (s *UpdateScheduler) notifyRepoChanged(ctx context.Context, repo configuredRepo) {
+	s.onRepoChangedMu.RLock()
+	defer s.onRepoChangedMu.RUnlock()
+	for _, f := range s.onRepoChanged {
+		f(ctx, repo.ID, repo.Name)
+	}
+}
+
+// repoChanged returns whether the update requested at start fetched new
+// commits. gitserver debounces updates and then responds with the times of an
+// earlier fetch, so the repository only changed if it was fetched after start.
+// gitserver only updates the last changed time when the refs of the repository
+// changed after a fetch, so it is never before the last fetched time when they
+// did.
+func repoChanged(resp *gitserverprotocol.RepoUpdateResponse, start time.Time) bool {
+	return resp != nil && resp.Error == "" && resp.LastFetched != nil && resp.LastChanged != nil &&
+		!resp.LastFetched.Before(start) && !resp.LastChanged.Before(*resp.LastFetched)
 }
 
 // A configuredRepo represents the configuration data for a given repo from
@@ -213,6 +248,7 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 				// if it doesn't exist or update it if it does. The timeout of this request depends
 				// on the value of conf.GitLongCommandTimeout() or if the passed context has a set
 				// deadline shorter than the value of this config.
+				start := time.Now()
 				resp, err := requestRepoUpdate(ctx, s.db, repo, 1*time.Second)
 				if err != nil {
 					schedError.WithLabelValues("requestRepoUpdate").Inc()
@@ -224,6 +260,8 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 					if !strings.Contains(resp.Error, ratelimit.ErrBlockAll.Error()) {
 						subLogger.Error("error updating repo", log.String("err", resp.Error), log.String("uri", string(repo.Name)))
 					}
+				} else if repoChanged(resp, start) {
+					s.notifyRepoChanged(ctx, repo)
 				}
 
 				if interval := getCustomInterval(subLogger, conf.Get(), string(repo.Name)); interval > 0 {
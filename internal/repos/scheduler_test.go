@@ -1615,3 +1615,28 @@ func TestGetCustomInterval(t *testing.T) {
 		})
 	}
 }
+
+func TestRepoChanged(t *testing.T) {
+	start := defaultTime
+	fetched := defaultTime.Add(time.Second)
+	before := defaultTime.Add(-time.Hour)
+
+	for _, tc := range []struct {
+		name string
+		resp *gitserverprotocol.RepoUpdateResponse
+		want bool
+	}{
+		{name: "no response"},
+		{name: "no times", resp: &gitserverprotocol.RepoUpdateResponse{}},
+		{name: "changed", resp: &gitserverprotocol.RepoUpdateResponse{LastFetched: &fetched, LastChanged: &fetched}, want: true},
+		{name: "unchanged", resp: &gitserverprotocol.RepoUpdateResponse{LastFetched: &fetched, LastChanged: &before}},
+		{name: "debounced", resp: &gitserverprotocol.RepoUpdateResponse{LastFetched: &before, LastChanged: &before}},
+		{name: "error", resp: &gitserverprotocol.RepoUpdateResponse{LastFetched: &fetched, LastChanged: &fetched, Error: "boom"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := repoChanged(tc.resp, start); have != tc.want {
+				t.Errorf("repoChanged: have %t, want %t", have, tc.want)
+			}
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
-	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 )
 
 type Ruleset struct {
@@ -37,7 +36,12 @@ func (r *Ruleset) Match(path string) (Owners, error) {
 	return rule.Owners, nil
 }
 
-func NewRuleset(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) (Ruleset, error) {
+// FileReader is the subset of gitserver.Client needed to load a Ruleset.
+type FileReader interface {
+	ReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, name string, checker authz.SubRepoPermissionChecker) ([]byte, error)
+}
+
+func NewRuleset(ctx context.Context, gitserver FileReader, repoName api.RepoName, commitID api.CommitID) (Ruleset, error) {
 	ruleset := Ruleset{}
 
 	content, err := loadOwnershipFile(ctx, gitserver, repoName, commitID)
@@ -58,7 +62,7 @@ func NewRuleset(ctx context.Context, gitserver gitserver.Client, repoName api.Re
 	return ruleset, nil
 }
 
-func loadOwnershipFile(ctx context.Context, gitserver gitserver.Client, repoName api.RepoName, commitID api.CommitID) ([]byte, error) {
+func loadOwnershipFile(ctx context.Context, gitserver FileReader, repoName api.RepoName, commitID api.CommitID) ([]byte, error) {
 	for _, path := range []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", "docs/CODEOWNERS"} {
 		content, err := gitserver.ReadFile(
 			ctx,
@@ -33,3 +33,15 @@ type GerritConnection struct {
 	URN string
 	*schema.GerritConnection
 }
+
+type BitbucketCloudConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.BitbucketCloudConnection
+}
+
+type GitoliteConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.GitoliteConnection
+}
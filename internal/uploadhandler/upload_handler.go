@@ -54,7 +54,7 @@ var errUnprocessableRequest = errors.New("unprocessable request: missing expecte
 //   - POST `/upload?{metadata}`
 //
 // where `{metadata}` contains the keys `repositoryId`, `commit`, `root`, `indexerName`, `indexerVersion`,
-// and `associatedIndexId`.
+// `associatedIndexId`, and `contentHash`.
 //
 // For larger uploads, the requests are broken up into a setup request, a serires of upload requests,
 // and a finalization request:
@@ -37,14 +37,38 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	Rollout           *Rollout                 `json:"rollout,omitempty" yaml:"rollout"`
+}
+
+// Rollout describes how the changesets of a batch change are published in
+// stages.
+type Rollout struct {
+	// Stages is the number of changesets published in each stage. The
+	// remaining changesets are published in an implicit final stage.
+	Stages []int `json:"stages,omitempty" yaml:"stages"`
+	// MaxFailureRatio is the fraction of published changesets that may fail
+	// before the rollout stops. It's required, so that stopping at the first
+	// failure is a deliberate choice.
+	MaxFailureRatio float64 `json:"maxFailureRatio" yaml:"maxFailureRatio"`
 }
 
 type ChangesetTemplate struct {
-	Title     string                       `json:"title,omitempty" yaml:"title"`
-	Body      string                       `json:"body,omitempty" yaml:"body"`
-	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
-	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
-	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Title                   string                        `json:"title,omitempty" yaml:"title"`
+	Body                    string                        `json:"body,omitempty" yaml:"body"`
+	Branch                  string                        `json:"branch,omitempty" yaml:"branch"`
+	Commit                  ExpandedGitCommitDescription  `json:"commit,omitempty" yaml:"commit"`
+	Published               *overridable.BoolOrString     `json:"published" yaml:"published"`
+	DependsOn               []ChangesetDependencyTemplate `json:"dependsOn,omitempty" yaml:"dependsOn"`
+	RequestCodeOwnerReviews bool                          `json:"requestCodeOwnerReviews,omitempty" yaml:"requestCodeOwnerReviews"`
+}
+
+// ChangesetDependencyTemplate describes a changeset that the changesets created
+// from a changeset template depend on.
+type ChangesetDependencyTemplate struct {
+	// Repository is the name of the repository of the changeset. If empty, the
+	// repository of the dependent changeset is used.
+	Repository string `json:"repository,omitempty" yaml:"repository"`
+	Branch     string `json:"branch" yaml:"branch"`
 }
 
 type GitCommitAuthor struct {
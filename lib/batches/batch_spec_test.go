@@ -209,6 +209,71 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("rollout", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Test Rollout
+  body: Test a rollout
+  branch: test
+  commit:
+    message: Test
+rollout:
+  stages: [5, 50]
+  maxFailureRatio: 0.1
+`
+		s, err := ParseBatchSpec([]byte(spec))
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		assert.Equal(t, &Rollout{Stages: []int{5, 50}, MaxFailureRatio: 0.1}, s.Rollout)
+	})
+
+	t.Run("rollout with empty stage", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Test Rollout
+  body: Test a rollout
+  branch: test
+  commit:
+    message: Test
+rollout:
+  stages: [0]
+  maxFailureRatio: 0
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Error(t, err)
+	})
+
+	t.Run("rollout without maxFailureRatio", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Test Rollout
+  body: Test a rollout
+  branch: test
+  commit:
+    message: Test
+rollout:
+  stages: [5, 50]
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		assert.Error(t, err)
+	})
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
@@ -80,6 +80,18 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	DependsOn []ChangesetDependency `json:"dependsOn,omitempty"`
+
+	RequestCodeOwnerReviews bool `json:"requestCodeOwnerReviews,omitempty"`
+}
+
+// ChangesetDependency identifies a changeset in the same batch change that
+// must be merged before the dependent changeset is published.
+type ChangesetDependency struct {
+	// Repository is the name of the repository of the changeset.
+	Repository string `json:"repository"`
+	HeadRef    string `json:"headRef"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -102,6 +114,9 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Body           string                 `json:"body,omitempty"`
 		Commits        []GitCommitDescription `json:"commits,omitempty"`
 		Published      *PublishedValue        `json:"published,omitempty"`
+		DependsOn      []ChangesetDependency  `json:"dependsOn,omitempty"`
+
+		RequestCodeOwnerReviews bool `json:"requestCodeOwnerReviews,omitempty"`
 	}{
 		BaseRepository: c.BaseRepository,
 		ExternalID:     c.ExternalID,
@@ -112,6 +127,9 @@ func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 		Title:          c.Title,
 		Body:           c.Body,
 		Commits:        c.Commits,
+		DependsOn:      c.DependsOn,
+
+		RequestCodeOwnerReviews: c.RequestCodeOwnerReviews,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
@@ -94,19 +94,40 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 		return nil, err
 	}
 
+	dependencies, err := renderChangesetDependencies(input.Template.DependsOn, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	newSpec := func(branch, diff string) (*ChangesetSpec, error) {
 		var published any = nil
 		if input.Template.Published != nil {
 			published = input.Template.Published.ValueWithSuffix(input.Repository.Name, branch)
 		}
 
+		headRef := git.EnsureRefPrefix(branch)
+
+		// A changeset can't depend on itself, which happens naturally when
+		// the dependency is part of the same batch change: the changeset in
+		// the dependency's repository doesn't depend on anything.
+		var dependsOn []ChangesetDependency
+		for _, dep := range dependencies {
+			if dep.Repository == "" {
+				dep.Repository = input.Repository.Name
+			}
+			if dep.Repository == input.Repository.Name && dep.HeadRef == headRef {
+				continue
+			}
+			dependsOn = append(dependsOn, dep)
+		}
+
 		return &ChangesetSpec{
 			BaseRepository: input.Repository.ID,
 			HeadRepository: input.Repository.ID,
 			BaseRef:        input.Repository.BaseRef,
 			BaseRev:        input.Repository.BaseRev,
 
-			HeadRef: git.EnsureRefPrefix(branch),
+			HeadRef: headRef,
 			Title:   title,
 			Body:    body,
 			Commits: []GitCommitDescription{
@@ -118,6 +139,9 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 				},
 			},
 			Published: PublishedValue{Val: published},
+			DependsOn: dependsOn,
+
+			RequestCodeOwnerReviews: input.Template.RequestCodeOwnerReviews,
 		}, nil
 	}
 
@@ -154,6 +178,28 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 	return specs, nil
 }
 
+// renderChangesetDependencies renders the branches of the given dependency
+// templates. The repository of a dependency is left empty if it refers to the
+// repository of the changeset itself.
+func renderChangesetDependencies(deps []ChangesetDependencyTemplate, tmplCtx *template.ChangesetTemplateContext) ([]ChangesetDependency, error) {
+	rendered := make([]ChangesetDependency, 0, len(deps))
+	for _, dep := range deps {
+		branch, err := template.RenderChangesetTemplateField("dependsOn.branch", dep.Branch, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+		if branch == "" {
+			return nil, NewValidationError(errors.New("changesetTemplate.dependsOn entries must have a branch"))
+		}
+
+		rendered = append(rendered, ChangesetDependency{
+			Repository: dep.Repository,
+			HeadRef:    git.EnsureRefPrefix(branch),
+		})
+	}
+	return rendered, nil
+}
+
 type RepoFetcher func(context.Context, []string) (map[string]string, error)
 
 func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportChangeset, repoFetcher RepoFetcher) (specs []*ChangesetSpec, errs error) {
@@ -144,6 +144,61 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "depends on",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.DependsOn = []ChangesetDependencyTemplate{
+					{Repository: "github.com/sourcegraph/lib", Branch: "bump-${{ repository.branch }}"},
+					{Branch: "base-branch"},
+				}
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.DependsOn = []ChangesetDependency{
+						{Repository: "github.com/sourcegraph/lib", HeadRef: "refs/heads/bump-my-cool-base-ref"},
+						{Repository: "github.com/sourcegraph/src-cli", HeadRef: "refs/heads/base-branch"},
+					}
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "depends on itself",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.DependsOn = []ChangesetDependencyTemplate{
+					{Repository: "github.com/sourcegraph/src-cli", Branch: "my-branch"},
+				}
+			}),
+			want: []*ChangesetSpec{
+				defaultChangesetSpec,
+			},
+			wantErr: "",
+		},
+		{
+			name: "request code owner reviews",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.RequestCodeOwnerReviews = true
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.RequestCodeOwnerReviews = true
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "depends on empty branch",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.DependsOn = []ChangesetDependencyTemplate{
+					{Repository: "github.com/sourcegraph/lib"},
+				}
+			}),
+			wantErr: "changesetTemplate.dependsOn entries must have a branch",
+		},
 	}
 
 	for _, tt := range tests {
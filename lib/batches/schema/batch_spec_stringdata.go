@@ -355,6 +355,32 @@ const BatchSpecJSON = `{
             }
           }
         },
+        "dependsOn": {
+          "type": "array",
+          "description": "The changesets that must be merged before the changesets created by this batch change are published. Until then, dependent changesets are kept unpublished, or published as drafts if the code host supports it, and they are rebased onto the latest base branch once all of their dependencies have been merged.",
+          "items": {
+            "title": "ChangesetDependencyTemplate",
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["branch"],
+            "properties": {
+              "repository": {
+                "type": "string",
+                "description": "The name of the repository of the changeset to depend on. If omitted, the changeset in the same repository is used, which allows stacking changesets created with transformChanges.",
+                "examples": ["github.com/sourcegraph/sourcegraph"]
+              },
+              "branch": {
+                "type": "string",
+                "description": "The branch of the changeset to depend on. Supports templating."
+              }
+            }
+          }
+        },
+        "requestCodeOwnerReviews": {
+          "type": "boolean",
+          "description": "Whether to request reviews from the code owners of the changed files, as defined by the CODEOWNERS file of the repository, when the changeset is published. Supported on GitHub, GitLab, and Bitbucket Server.",
+          "default": false
+        },
         "published": {
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.",
           "oneOf": [
@@ -397,6 +423,31 @@ const BatchSpecJSON = `{
           ]
         }
       }
+    },
+    "rollout": {
+      "type": "object",
+      "description": "Publish the changesets of the batch change in stages, starting with a small canary stage. A stage is only published once all changesets of the previous stages have been merged or have failed. The rollout stops if too many changesets fail.",
+      "additionalProperties": false,
+      "required": ["stages", "maxFailureRatio"],
+      "properties": {
+        "stages": {
+          "type": "array",
+          "description": "The number of changesets to publish in each stage. The remaining changesets are published in a final stage once all listed stages have been completed.",
+          "items": {
+            "type": "integer",
+            "minimum": 1
+          },
+          "minItems": 1,
+          "examples": [[5, 50]]
+        },
+        "maxFailureRatio": {
+          "type": "number",
+          "description": "The fraction of published changesets that may fail before the rollout stops. A changeset fails if it is closed without being merged, if its checks fail before it is merged, or if it can't be published. Use 0 to stop the rollout at the first failure.",
+          "minimum": 0,
+          "maximum": 1,
+          "examples": [0.1]
+        }
+      }
     }
   }
 }
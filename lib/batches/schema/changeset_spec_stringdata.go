@@ -95,6 +95,33 @@ const ChangesetSpecJSON = `{
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+        },
+        "dependsOn": {
+          "type": "array",
+          "description": "The changesets in the same batch change that must be merged before this changeset is published. Until then, the changeset is kept unpublished, or published as a draft if the code host supports it.",
+          "items": {
+            "title": "ChangesetDependency",
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["repository", "headRef"],
+            "properties": {
+              "repository": {
+                "type": "string",
+                "description": "The name of the repository of the changeset this changeset depends on.",
+                "examples": ["github.com/sourcegraph/sourcegraph"]
+              },
+              "headRef": {
+                "type": "string",
+                "description": "The full name of the head ref of the changeset this changeset depends on.",
+                "pattern": "^refs\\/heads\\/\\S+$",
+                "examples": ["refs/heads/bump-library"]
+              }
+            }
+          }
+        },
+        "requestCodeOwnerReviews": {
+          "type": "boolean",
+          "description": "Whether to request reviews from the code owners of the changed files, as defined by the CODEOWNERS file of the base repository, when the changeset is published."
         }
       },
       "required": ["baseRepository", "baseRef", "baseRev", "headRepository", "headRef", "title", "body", "commits"],
@@ -169,6 +169,9 @@ func makeUploadURL(opts uploadRequestOptions) (*url.URL, error) {
 	if opts.UploadRecordOptions.AssociatedIndexID != nil {
 		qs.Add("associatedIndexId", formatInt(*opts.UploadRecordOptions.AssociatedIndexID))
 	}
+	if opts.UploadRecordOptions.ContentHash != "" {
+		qs.Add("contentHash", opts.UploadRecordOptions.ContentHash)
+	}
 	if opts.MultiPart {
 		qs.Add("multiPart", "true")
 	}
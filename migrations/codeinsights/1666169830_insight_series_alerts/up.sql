@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS insight_series_alerts (
+    id                  SERIAL PRIMARY KEY,
+    insight_view_id     INT NOT NULL,
+    insight_series_id   INT NOT NULL,
+    creator_user_id     INT NOT NULL,
+    condition           TEXT NOT NULL,
+    threshold           DOUBLE PRECISION NOT NULL,
+    notify_email        BOOLEAN NOT NULL DEFAULT FALSE,
+    slack_webhook_url   TEXT,
+    webhook_url         TEXT,
+    firing              BOOLEAN NOT NULL DEFAULT FALSE,
+    last_evaluated_at   TIMESTAMP,
+    last_alerted_at     TIMESTAMP,
+    created_at          TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_channels       TEXT[] NOT NULL DEFAULT '{}',
+    CONSTRAINT insight_series_alerts_insight_view_id_fk FOREIGN KEY (insight_view_id) REFERENCES insight_view(id) ON DELETE CASCADE,
+    CONSTRAINT insight_series_alerts_insight_series_id_fk FOREIGN KEY (insight_series_id) REFERENCES insight_series(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alerts_insight_view_id_idx ON insight_series_alerts(insight_view_id);
+
+COMMENT ON TABLE insight_series_alerts IS 'Alert rules on insight series. Each rule is evaluated once for every new point recorded for its series.';
+COMMENT ON COLUMN insight_series_alerts.condition IS 'The condition that triggers the alert: VALUE_ABOVE, VALUE_BELOW or WEEK_OVER_WEEK_INCREASE.';
+COMMENT ON COLUMN insight_series_alerts.threshold IS 'The value the series is compared to, or the percentage of increase for WEEK_OVER_WEEK_INCREASE.';
+COMMENT ON COLUMN insight_series_alerts.firing IS 'Whether the condition was met by the last evaluated point. An alert is only sent when this changes to true.';
+COMMENT ON COLUMN insight_series_alerts.last_evaluated_at IS 'The time of the last series point the rule was evaluated for.';
+COMMENT ON COLUMN insight_series_alerts.sent_channels IS 'The channels the alert has already been sent through while its delivery is being retried. Cleared once the alert has been sent through all of its channels.';
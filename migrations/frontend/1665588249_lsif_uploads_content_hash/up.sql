@@ -0,0 +1,48 @@
+ALTER TABLE lsif_uploads
+ADD COLUMN IF NOT EXISTS content_hash text;
+
+COMMENT ON COLUMN lsif_uploads.content_hash IS 'An opaque hash of the inputs of the index supplied by the uploader. Completed uploads sharing a repository, root, indexer, and content hash are considered interchangeable.';
+
+CREATE INDEX IF NOT EXISTS lsif_uploads_repository_id_root_indexer_content_hash ON lsif_uploads(repository_id, root, indexer, content_hash) WHERE state = 'completed' AND content_hash IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS lsif_uploads_reused_commits (
+    upload_id integer NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    commit text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    PRIMARY KEY (upload_id, commit)
+);
+
+COMMENT ON TABLE lsif_uploads_reused_commits IS 'Additional commits at which the data of a completed upload is defined. Rows are inserted instead of processing a duplicate upload with an identical content hash.';
+COMMENT ON COLUMN lsif_uploads_reused_commits.upload_id IS 'The identifier of the upload whose data is reused.';
+COMMENT ON COLUMN lsif_uploads_reused_commits.commit IS 'A 40-char revhash of the commit of the deduplicated upload.';
+
+DROP VIEW IF EXISTS lsif_uploads_with_repository_name;
+
+CREATE VIEW lsif_uploads_with_repository_name AS
+SELECT u.id,
+    u.commit,
+    u.root,
+    u.queued_at,
+    u.uploaded_at,
+    u.state,
+    u.failure_message,
+    u.started_at,
+    u.finished_at,
+    u.repository_id,
+    u.indexer,
+    u.indexer_version,
+    u.num_parts,
+    u.uploaded_parts,
+    u.process_after,
+    u.num_resets,
+    u.upload_size,
+    u.num_failures,
+    u.associated_index_id,
+    u.expired,
+    u.last_retention_scan_at,
+    r.name AS repository_name,
+    u.uncompressed_size,
+    u.content_hash
+FROM lsif_uploads u
+JOIN repo r ON r.id = u.repository_id
+WHERE r.deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS codeintel_path_ranks;
+DROP TABLE IF EXISTS codeintel_ranking_references;
+DROP TABLE IF EXISTS codeintel_ranking_definitions;
+DROP TABLE IF EXISTS codeintel_ranking_exports;
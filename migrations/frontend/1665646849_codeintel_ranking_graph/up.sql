@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS codeintel_ranking_exports (
+    upload_id integer NOT NULL PRIMARY KEY REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE codeintel_ranking_exports IS 'Tracks the uploads whose reference graph has been exported into codeintel_ranking_definitions and codeintel_ranking_references.';
+
+CREATE TABLE IF NOT EXISTS codeintel_ranking_definitions (
+    id bigserial PRIMARY KEY,
+    upload_id integer NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    symbol_name text NOT NULL,
+    document_path text NOT NULL
+);
+
+COMMENT ON TABLE codeintel_ranking_definitions IS 'The documents defining each exported symbol of an upload.';
+COMMENT ON COLUMN codeintel_ranking_definitions.symbol_name IS 'The moniker scheme and identifier of the symbol.';
+
+CREATE INDEX IF NOT EXISTS codeintel_ranking_definitions_upload_id ON codeintel_ranking_definitions(upload_id);
+CREATE INDEX IF NOT EXISTS codeintel_ranking_definitions_symbol_name ON codeintel_ranking_definitions(symbol_name);
+
+CREATE TABLE IF NOT EXISTS codeintel_ranking_references (
+    id bigserial PRIMARY KEY,
+    upload_id integer NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    symbol_name text NOT NULL,
+    document_path text NOT NULL,
+    count integer NOT NULL
+);
+
+COMMENT ON TABLE codeintel_ranking_references IS 'The number of references to each imported or exported symbol from each document of an upload.';
+COMMENT ON COLUMN codeintel_ranking_references.symbol_name IS 'The moniker scheme and identifier of the symbol.';
+COMMENT ON COLUMN codeintel_ranking_references.count IS 'The number of references to the symbol within the document.';
+
+CREATE INDEX IF NOT EXISTS codeintel_ranking_references_upload_id ON codeintel_ranking_references(upload_id);
+
+CREATE TABLE IF NOT EXISTS codeintel_path_ranks (
+    repository_id integer NOT NULL PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    payload jsonb NOT NULL DEFAULT '{}'::jsonb,
+    updated_at timestamp with time zone NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE codeintel_path_ranks IS 'The rank of each document of a repository computed from the precise reference graph.';
+COMMENT ON COLUMN codeintel_path_ranks.payload IS 'A map from document path to its rank. Higher ranks indicate more frequently referenced documents.';
@@ -0,0 +1,4 @@
+ALTER TABLE changeset_specs
+    ADD COLUMN IF NOT EXISTS depends_on jsonb DEFAULT '[]'::jsonb NOT NULL;
+
+COMMENT ON COLUMN changeset_specs.depends_on IS 'The changesets in the same batch change, identified by repository name and head ref, that must be merged before this changeset is published.';
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS batch_change_templates (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    namespace_user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    namespace_org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    raw_template text NOT NULL,
+    input_schema text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT batch_change_templates_has_1_namespace CHECK ((namespace_user_id IS NULL) <> (namespace_org_id IS NULL)),
+    CONSTRAINT batch_change_templates_name_not_blank CHECK (name <> '')
+);
+
+COMMENT ON COLUMN batch_change_templates.raw_template IS 'The batch spec YAML, in which ${{ inputs.NAME }} placeholders are replaced with the values of the inputs when rendering the template.';
+COMMENT ON COLUMN batch_change_templates.input_schema IS 'The JSON schema the inputs of the template are validated against.';
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_templates_unique_user_id ON batch_change_templates (name, namespace_user_id) WHERE namespace_user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_templates_unique_org_id ON batch_change_templates (name, namespace_org_id) WHERE namespace_org_id IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE changeset_specs
+    DROP COLUMN IF EXISTS code_owner_reviews;
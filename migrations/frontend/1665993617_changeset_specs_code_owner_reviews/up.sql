@@ -0,0 +1,4 @@
+ALTER TABLE changeset_specs
+    ADD COLUMN IF NOT EXISTS code_owner_reviews boolean DEFAULT false NOT NULL;
+
+COMMENT ON COLUMN changeset_specs.code_owner_reviews IS 'Whether reviews are requested from the code owners of the changed files when the changeset is published.';
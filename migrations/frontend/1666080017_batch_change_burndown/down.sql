@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS batch_change_slas;
+DROP TABLE IF EXISTS batch_change_snapshots;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS batch_change_snapshots (
+    id bigserial PRIMARY KEY,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    snapshot_date date NOT NULL,
+    total_count integer NOT NULL DEFAULT 0,
+    open_count integer NOT NULL DEFAULT 0,
+    draft_count integer NOT NULL DEFAULT 0,
+    merged_count integer NOT NULL DEFAULT 0,
+    closed_count integer NOT NULL DEFAULT 0,
+    review_latency_seconds bigint,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON COLUMN batch_change_snapshots.review_latency_seconds IS 'The average time between a changeset being opened and its first review, for all changesets that have been reviewed. NULL if no changeset has been reviewed yet.';
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_snapshots_batch_change_id_snapshot_date ON batch_change_snapshots (batch_change_id, snapshot_date);
+
+CREATE TABLE IF NOT EXISTS batch_change_slas (
+    id bigserial PRIMARY KEY,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    target_date timestamp with time zone NOT NULL,
+    notify_email boolean NOT NULL DEFAULT false,
+    slack_webhook_url text,
+    webhook_url text,
+    alerted_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON COLUMN batch_change_slas.notify_email IS 'Whether the creator of the SLA is notified by email when the batch change falls behind schedule.';
+COMMENT ON COLUMN batch_change_slas.alerted_at IS 'When the last alert was sent. Reset once the batch change is back on schedule, so that an alert is only sent once each time it falls behind.';
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_slas_batch_change_id ON batch_change_slas (batch_change_id);
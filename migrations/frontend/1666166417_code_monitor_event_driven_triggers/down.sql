@@ -0,0 +1 @@
+ALTER TABLE cm_queries DROP COLUMN IF EXISTS event_driven;
@@ -0,0 +1,3 @@
+ALTER TABLE cm_queries ADD COLUMN IF NOT EXISTS event_driven boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN cm_queries.event_driven IS 'Whether the query is run when new commits are fetched for a repository, rather than only on a schedule.';
@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS cm_issues (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    labels text[] NOT NULL DEFAULT '{}'::text[],
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE cm_issues IS 'Issue actions configured on code monitors, which open an issue on the code host of each repository with new results';
+COMMENT ON COLUMN cm_issues.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_issues.labels IS 'The labels added to the issues opened by the action';
+
+CREATE INDEX IF NOT EXISTS cm_issues_monitor ON cm_issues (monitor);
+
+CREATE TABLE IF NOT EXISTS cm_issue_filings (
+    id bigserial PRIMARY KEY,
+    issue_action bigint NOT NULL REFERENCES cm_issues(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    number bigint NOT NULL,
+    url text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_action_job integer
+);
+
+COMMENT ON TABLE cm_issue_filings IS 'The issue opened by an issue action in each repository, so that new results are added to it while it is open instead of opening another issue';
+COMMENT ON COLUMN cm_issue_filings.number IS 'The number of the issue on the code host. For GitLab, this is the IID of the issue within its project';
+COMMENT ON COLUMN cm_issue_filings.last_action_job IS 'The last action job that filed results in the issue, so that a retried job skips the repositories it already filed results for';
+
+CREATE UNIQUE INDEX IF NOT EXISTS cm_issue_filings_issue_action_repo_id ON cm_issue_filings (issue_action, repo_id);
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS issue bigint;
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_issue_fkey;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_issue_fkey FOREIGN KEY (issue) REFERENCES cm_issues(id) ON DELETE CASCADE;
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN issue IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON COLUMN cm_action_jobs.issue IS 'The ID of the cm_issues action to execute if this is an issue job. Mutually exclusive with email, webhook and slack_webhook';
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
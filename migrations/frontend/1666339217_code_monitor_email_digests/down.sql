@@ -0,0 +1,7 @@
+ALTER TABLE cm_action_jobs DROP COLUMN IF EXISTS digest_at;
+
+ALTER TABLE cm_emails
+    DROP COLUMN IF EXISTS digest_interval,
+    DROP COLUMN IF EXISTS digest_max_results;
+
+DROP TYPE IF EXISTS cm_email_digest_interval;
@@ -0,0 +1,18 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'cm_email_digest_interval') THEN
+        CREATE TYPE cm_email_digest_interval AS ENUM ('HOURLY', 'DAILY');
+    END IF;
+END
+$$;
+
+ALTER TABLE cm_emails
+    ADD COLUMN IF NOT EXISTS digest_interval cm_email_digest_interval,
+    ADD COLUMN IF NOT EXISTS digest_max_results integer NOT NULL DEFAULT 50;
+
+COMMENT ON COLUMN cm_emails.digest_interval IS 'If set, new results are collected and sent in one summary per interval rather than after each run of the trigger';
+COMMENT ON COLUMN cm_emails.digest_max_results IS 'The maximum number of results included in a digest';
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS digest_at timestamp with time zone;
+
+COMMENT ON COLUMN cm_action_jobs.digest_at IS 'The end of the digest window that the results of this job are sent in. Jobs of the same email action with the same digest_at are sent as one digest';
@@ -0,0 +1,3 @@
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS rollout_stage integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN batch_changes.rollout_stage IS 'The stage the rollout defined in the current batch spec has reached. Kept up to date when changesets are synced, so that the reconciler doesn''t have to look at all changesets of the batch change to decide whether one of them may be published.';
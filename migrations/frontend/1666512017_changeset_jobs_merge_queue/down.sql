@@ -0,0 +1,3 @@
+ALTER TABLE changeset_jobs
+    DROP COLUMN IF EXISTS merged_at,
+    DROP COLUMN IF EXISTS base_branch_checked;
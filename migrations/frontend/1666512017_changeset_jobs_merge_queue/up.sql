@@ -0,0 +1,7 @@
+ALTER TABLE changeset_jobs
+    ADD COLUMN IF NOT EXISTS merged_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS base_branch_checked boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN changeset_jobs.merged_at IS 'When a "merge when ready" job merged its changeset. Set before the changeset is merged, while holding a lock on the code host, to reserve the merge against the rate limit of the code host.';
+
+COMMENT ON COLUMN changeset_jobs.base_branch_checked IS 'Whether the checks on the base branch have been found to pass after a "merge when ready" job merged its changeset. Until then, the other jobs of the bulk operation don''t merge their changesets.';
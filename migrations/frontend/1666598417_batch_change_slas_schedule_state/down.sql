@@ -0,0 +1,6 @@
+ALTER TABLE batch_change_slas
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS on_track,
+    DROP COLUMN IF EXISTS sent_alert_channels;
+
+COMMENT ON COLUMN batch_change_slas.alerted_at IS 'When the last alert was sent. Reset once the batch change is back on schedule, so that an alert is only sent once each time it falls behind.';
@@ -0,0 +1,9 @@
+ALTER TABLE batch_change_slas
+    ADD COLUMN IF NOT EXISTS started_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS on_track boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS sent_alert_channels text[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN batch_change_slas.started_at IS 'When the schedule started, which is when the first snapshot with published changesets was taken after the SLA was set. NULL until then.';
+COMMENT ON COLUMN batch_change_slas.on_track IS 'Whether the batch change has been seen on schedule since it last fell behind. Alerts are only sent when a batch change that was on track falls behind.';
+COMMENT ON COLUMN batch_change_slas.sent_alert_channels IS 'The channels the current alert has already been sent through, so that a retry after a failed delivery skips them. Cleared once the alert has been sent through all channels.';
+COMMENT ON COLUMN batch_change_slas.alerted_at IS 'When the last alert was sent.';
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS scim_groups (
+    org_id integer PRIMARY KEY REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE scim_groups IS 'The organizations created through SCIM. Only these organizations can be read or changed through SCIM.';
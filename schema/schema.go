@@ -203,6 +203,8 @@ type BatchSpec struct {
 	Name string `json:"name"`
 	// On description: The set of repositories (and branches) to run the batch change on, specified as a list of search queries (that match repositories) and/or specific repositories.
 	On []interface{} `json:"on,omitempty"`
+	// Rollout description: Publish the changesets of the batch change in stages, starting with a small canary stage. A stage is only published once all changesets of the previous stages have been merged or have failed. The rollout stops if too many changesets fail.
+	Rollout *Rollout `json:"rollout,omitempty"`
 	// Steps description: The sequence of commands to run (for each repository branch matched in the `on` property) to produce the workspace changes that will be included in the batch change.
 	Steps []*Step `json:"steps,omitempty"`
 	// TransformChanges description: Optional transformations to apply to the changes produced in each repository.
@@ -211,12 +213,20 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. The configured user must be an administrator of its own workspace and of the workspaces listed in "teams".
+type BitbucketCloudAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph matches a user to the member of the configured workspaces whose Bitbucket Cloud nickname equals the Sourcegraph username. Nicknames can be changed by their owners and are not unique across Bitbucket Cloud, so whoever controls a matching nickname gets the repository permissions of the Sourcegraph user with that username, and vice versa. `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider BitbucketCloudIdentityProvider `json:"identityProvider"`
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. The configured user must be an administrator of its own workspace and of the workspaces listed in "teams".
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -247,6 +257,31 @@ type BitbucketCloudConnection struct {
 	WebhookSecret string `json:"webhookSecret,omitempty"`
 }
 
+// BitbucketCloudIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph matches a user to the member of the configured workspaces whose Bitbucket Cloud nickname equals the Sourcegraph username. Nicknames can be changed by their owners and are not unique across Bitbucket Cloud, so whoever controls a matching nickname gets the repository permissions of the Sourcegraph user with that username, and vice versa. `auth.enableUsernameChanges` must be set to false for security reasons.
+type BitbucketCloudIdentityProvider struct {
+	Username *BitbucketCloudUsernameIdentity
+}
+
+func (v BitbucketCloudIdentityProvider) MarshalJSON() ([]byte, error) {
+	if v.Username != nil {
+		return json.Marshal(v.Username)
+	}
+	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
+}
+func (v *BitbucketCloudIdentityProvider) UnmarshalJSON(data []byte) error {
+	var d struct {
+		DiscriminantProperty string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	switch d.DiscriminantProperty {
+	case "username":
+		return json.Unmarshal(data, &v.Username)
+	}
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"username"})
+}
+
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 type BitbucketCloudRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -255,6 +290,10 @@ type BitbucketCloudRateLimit struct {
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
 
+type BitbucketCloudUsernameIdentity struct {
+	Type string `json:"type"`
+}
+
 // BitbucketServerAuthorization description: If non-null, enforces Bitbucket Server / Bitbucket Data Center repository permissions.
 type BitbucketServerAuthorization struct {
 	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Server / Bitbucket Data Center identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Server / Bitbucket Data Center accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
@@ -388,12 +427,16 @@ type BranchChangesetSpec struct {
 	Body string `json:"body"`
 	// Commits description: The Git commits with the proposed changes. These commits are pushed to the head ref.
 	Commits []*GitCommitDescription `json:"commits"`
+	// DependsOn description: The changesets in the same batch change that must be merged before this changeset is published. Until then, the changeset is kept unpublished, or published as a draft if the code host supports it.
+	DependsOn []*ChangesetDependency `json:"dependsOn,omitempty"`
 	// HeadRef description: The full name of the Git ref that holds the changes proposed by this changeset. This ref will be created or updated with the commits.
 	HeadRef string `json:"headRef"`
 	// HeadRepository description: The GraphQL ID of the repository that contains the branch with this changeset's changes. Fork repositories and cross-repository changesets are not yet supported. Therefore, headRepository must be equal to baseRepository.
 	HeadRepository string `json:"headRepository"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
 	Published interface{} `json:"published,omitempty"`
+	// RequestCodeOwnerReviews description: Whether to request reviews from the code owners of the changed files, as defined by the CODEOWNERS file of the base repository, when the changeset is published.
+	RequestCodeOwnerReviews bool `json:"requestCodeOwnerReviews,omitempty"`
 	// Title description: The title of the changeset on the code host.
 	Title string `json:"title"`
 }
@@ -426,6 +469,18 @@ type BuiltinAuthProvider struct {
 	AllowSignup bool   `json:"allowSignup,omitempty"`
 	Type        string `json:"type"`
 }
+type ChangesetDependency struct {
+	// HeadRef description: The full name of the head ref of the changeset this changeset depends on.
+	HeadRef string `json:"headRef"`
+	// Repository description: The name of the repository of the changeset this changeset depends on.
+	Repository string `json:"repository"`
+}
+type ChangesetDependencyTemplate struct {
+	// Branch description: The branch of the changeset to depend on. Supports templating.
+	Branch string `json:"branch"`
+	// Repository description: The name of the repository of the changeset to depend on. If omitted, the changeset in the same repository is used, which allows stacking changesets created with transformChanges.
+	Repository string `json:"repository,omitempty"`
+}
 
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
@@ -435,8 +490,12 @@ type ChangesetTemplate struct {
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
+	// DependsOn description: The changesets that must be merged before the changesets created by this batch change are published. Until then, dependent changesets are kept unpublished, or published as drafts if the code host supports it, and they are rebased onto the latest base branch once all of their dependencies have been merged.
+	DependsOn []*ChangesetDependencyTemplate `json:"dependsOn,omitempty"`
 	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.
 	Published interface{} `json:"published,omitempty"`
+	// RequestCodeOwnerReviews description: Whether to request reviews from the code owners of the changed files, as defined by the CODEOWNERS file of the repository, when the changeset is published. Supported on GitHub, GitLab, and Bitbucket Server.
+	RequestCodeOwnerReviews bool `json:"requestCodeOwnerReviews,omitempty"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
@@ -462,6 +521,16 @@ type CodeIntelRepositoryBadge struct {
 	ForNerds *bool `json:"forNerds,omitempty"`
 }
 
+// CodeIntelStorageQuota description: Storage quotas for precise code intelligence uploads. Once a quota is exceeded, the upload expirer evicts the least valuable uploads that are not visible from the tip of the default branch, preferring old uploads, then uploads referenced by fewer other uploads, then uploads farther from the branches of the repository.
+type CodeIntelStorageQuota struct {
+	// Global description: The maximum total size (in bytes) of the uploads of all repositories.
+	Global int `json:"global,omitempty"`
+	// Repository description: The maximum total size (in bytes) of the uploads of a single repository.
+	Repository int `json:"repository,omitempty"`
+	// RepositoryOverrides description: A map from repository name to the maximum total size (in bytes) of the uploads of that repository. Overrides the repository quota.
+	RepositoryOverrides map[string]int `json:"repositoryOverrides,omitempty"`
+}
+
 // CustomGitFetchMapping description: Mapping from Git clone URl domain/path to git fetch command. The `domainPath` field contains the Git clone URL domain/path part. The `fetch` field contains the custom git fetch command.
 type CustomGitFetchMapping struct {
 	// DomainPath description: Git clone URL domain/path
@@ -991,8 +1060,16 @@ type Github struct {
 	WebhookSecret string `json:"webhookSecret"`
 }
 
+// GitoliteAuthorization description: If non-null, enforces Gitolite repository permissions. Permissions are read from the `info` command run on behalf of each user with the `sudo` command, so the `sudo` command must be enabled in the Gitolite rc file and the SSH key used by Sourcegraph must belong to a Gitolite administrator.
+type GitoliteAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Gitolite identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Gitolite, so any Sourcegraph user whose username matches a Gitolite user name gets the repository permissions of that Gitolite user. `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider GitoliteIdentityProvider `json:"identityProvider"`
+}
+
 // GitoliteConnection description: Configuration for a connection to Gitolite.
 type GitoliteConnection struct {
+	// Authorization description: If non-null, enforces Gitolite repository permissions. Permissions are read from the `info` command run on behalf of each user with the `sudo` command, so the `sudo` command must be enabled in the Gitolite rc file and the SSH key used by Sourcegraph must belong to a Gitolite administrator.
+	Authorization *GitoliteAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from this Gitolite instance. Supports excluding by exact name ({"name": "foo"}).
 	Exclude []*ExcludedGitoliteRepo `json:"exclude,omitempty"`
 	// Host description: Gitolite host that stores the repositories (e.g., git@gitolite.example.com, ssh://git@gitolite.example.com:2222/).
@@ -1007,6 +1084,35 @@ type GitoliteConnection struct {
 	Prefix string `json:"prefix"`
 }
 
+// GitoliteIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Gitolite identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Gitolite, so any Sourcegraph user whose username matches a Gitolite user name gets the repository permissions of that Gitolite user. `auth.enableUsernameChanges` must be set to false for security reasons.
+type GitoliteIdentityProvider struct {
+	Username *GitoliteUsernameIdentity
+}
+
+func (v GitoliteIdentityProvider) MarshalJSON() ([]byte, error) {
+	if v.Username != nil {
+		return json.Marshal(v.Username)
+	}
+	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
+}
+func (v *GitoliteIdentityProvider) UnmarshalJSON(data []byte) error {
+	var d struct {
+		DiscriminantProperty string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	switch d.DiscriminantProperty {
+	case "username":
+		return json.Unmarshal(data, &v.Username)
+	}
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"username"})
+}
+
+type GitoliteUsernameIdentity struct {
+	Type string `json:"type"`
+}
+
 // GoModulesConnection description: Configuration for a connection to Go module proxies
 type GoModulesConnection struct {
 	// Dependencies description: An array of strings specifying Go modules to mirror in Sourcegraph.
@@ -1585,6 +1691,14 @@ type Responders struct {
 	Username string `json:"username,omitempty"`
 }
 
+// Rollout description: Publish the changesets of the batch change in stages, starting with a small canary stage. A stage is only published once all changesets of the previous stages have been merged or have failed. The rollout stops if too many changesets fail.
+type Rollout struct {
+	// MaxFailureRatio description: The fraction of published changesets that may fail before the rollout stops. A changeset fails if it is closed without being merged, if its checks fail before it is merged, or if it can't be published. Use 0 to stop the rollout at the first failure.
+	MaxFailureRatio float64 `json:"maxFailureRatio"`
+	// Stages description: The number of changesets to publish in each stage. The remaining changesets are published in a final stage once all listed stages have been completed.
+	Stages []int `json:"stages"`
+}
+
 // RustPackagesConnection description: Configuration for a connection to Rust packages
 type RustPackagesConnection struct {
 	// Dependencies description: An array of strings specifying Rust packages to mirror in Sourcegraph.
@@ -1984,6 +2098,8 @@ type SiteConfiguration struct {
 	AuthzEnforceForSiteAdmins bool `json:"authz.enforceForSiteAdmins,omitempty"`
 	// AuthzRefreshInterval description: Time interval (in seconds) of how often each component picks up authorization changes in external services.
 	AuthzRefreshInterval int `json:"authz.refreshInterval,omitempty"`
+	// BatchChangesAutoRebase description: When enabled, open changesets are automatically rebased onto the latest commit of their base branch when it changes. Changesets whose diff no longer applies cleanly are marked as conflicting instead.
+	BatchChangesAutoRebase bool `json:"batchChanges.autoRebase,omitempty"`
 	// BatchChangesChangesetsRetention description: How long changesets will be retained after they have been detached from a batch change.
 	BatchChangesChangesetsRetention string `json:"batchChanges.changesetsRetention,omitempty"`
 	// BatchChangesDisableWebhooksWarning description: Hides Batch Changes warnings about webhooks not being configured.
@@ -1992,6 +2108,8 @@ type SiteConfiguration struct {
 	BatchChangesEnabled *bool `json:"batchChanges.enabled,omitempty"`
 	// BatchChangesEnforceForks description: When enabled, all branches created by batch changes will be pushed to forks of the original repository.
 	BatchChangesEnforceForks bool `json:"batchChanges.enforceForks,omitempty"`
+	// BatchChangesMergeQueueMaxMergesPerHour description: The maximum number of changesets that are merged per hour and code host by "merge when ready" bulk operations. 0 means unlimited. On GitHub, merges are also paused while the checks on the base branch of a merged changeset are failing.
+	BatchChangesMergeQueueMaxMergesPerHour *int `json:"batchChanges.mergeQueueMaxMergesPerHour,omitempty"`
 	// BatchChangesRestrictToAdmins description: When enabled, only site admins can create and apply batch changes.
 	BatchChangesRestrictToAdmins *bool `json:"batchChanges.restrictToAdmins,omitempty"`
 	// BatchChangesRolloutWindows description: Specifies specific windows, which can have associated rate limits, to be used when publishing changesets. All days and times are handled in UTC.
@@ -2014,6 +2132,8 @@ type SiteConfiguration struct {
 	CodeIntelAutoIndexingPolicyRepositoryMatchLimit *int `json:"codeIntelAutoIndexing.policyRepositoryMatchLimit,omitempty"`
 	// CodeIntelLockfileIndexingEnabled description: DEPRECATED: Enables/disables the code intel lockfile-indexing feature. Currently experimental.
 	CodeIntelLockfileIndexingEnabled *bool `json:"codeIntelLockfileIndexing.enabled,omitempty"`
+	// CodeIntelStorageQuota description: Storage quotas for precise code intelligence uploads. Once a quota is exceeded, the upload expirer evicts the least valuable uploads that are not visible from the tip of the default branch, preferring old uploads, then uploads referenced by fewer other uploads, then uploads farther from the branches of the repository.
+	CodeIntelStorageQuota *CodeIntelStorageQuota `json:"codeIntelStorageQuota,omitempty"`
 	// CorsOrigin description: Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.
 	CorsOrigin string `json:"corsOrigin,omitempty"`
 	// DebugSearchSymbolsParallelism description: (debug) controls the amount of symbol search parallelism. Defaults to 20. It is not recommended to change this outside of debugging scenarios. This option will be removed in a future version.
@@ -2147,6 +2267,8 @@ type SiteConfiguration struct {
 	RepoConcurrentExternalServiceSyncers int `json:"repoConcurrentExternalServiceSyncers,omitempty"`
 	// RepoListUpdateInterval description: Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
+	// ScimAuthToken description: The bearer token that identity providers (such as Okta or Azure AD) use to provision users and groups through the SCIM 2.0 API at /.api/scim/v2. SCIM provisioning is disabled if not set.
+	ScimAuthToken string `json:"scim.authToken,omitempty"`
 	// SearchIndexEnabled description: Whether indexed search is enabled. If : unset Sourcegraph detects the environment to decide if indexed search is enabled. Indexed search is RAM heavy, and is disabled by default in the single docker image. All other environments will have it enabled by default. The size of all your repository working copies is the amount of additional RAM required.
 	SearchIndexEnabled *bool `json:"search.index.enabled,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.